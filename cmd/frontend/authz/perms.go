@@ -172,6 +172,31 @@ func (p *RepoPermissions) TracingFields() []otlog.Field {
 	return fs
 }
 
+// SubRepoPermissions restricts which paths of a repository a user can read,
+// on top of the user's permissions for the repository as a whole. Paths are
+// relative to the repository root and matched against glob patterns, e.g.
+// "src/secret/**".
+type SubRepoPermissions struct {
+	UserID int32 // The internal database ID of a user
+	RepoID int32 // The internal database ID of a repository
+	// The globs of paths the user can read. If empty, the user can read all
+	// paths that are not excluded.
+	PathIncludes []string
+	// The globs of paths the user cannot read, even if they are included.
+	PathExcludes []string
+	UpdatedAt    time.Time // The last updated time
+}
+
+// TracingFields returns tracing fields for the opentracing log.
+func (p *SubRepoPermissions) TracingFields() []otlog.Field {
+	return []otlog.Field{
+		otlog.Int32("SubRepoPermissions.UserID", p.UserID),
+		otlog.Int32("SubRepoPermissions.RepoID", p.RepoID),
+		otlog.Int("SubRepoPermissions.PathIncludes.Count", len(p.PathIncludes)),
+		otlog.Int("SubRepoPermissions.PathExcludes.Count", len(p.PathExcludes)),
+	}
+}
+
 // UserPendingPermissions defines permissions that a not-yet-created user has to
 // perform on a given set of object IDs. Not-yet-created users may exist on the
 // code host but not yet in Sourcegraph. "ServiceType", "ServiceID" and "BindID"
@@ -0,0 +1,151 @@
+package authz
+
+import (
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+)
+
+// PathMatcher decides which paths of a repository a user can read according
+// to the user's SubRepoPermissions. A nil *PathMatcher allows all paths.
+type PathMatcher struct {
+	includes []glob.Glob
+	excludes []glob.Glob
+
+	// includePrefixes are the literal prefixes of the include globs. A
+	// directory which shares a prefix with one of them may contain paths the
+	// user can read.
+	includePrefixes []string
+}
+
+// NewPathMatcher compiles the globs of p into a PathMatcher. It returns nil if
+// p is nil or does not restrict any paths.
+func NewPathMatcher(p *SubRepoPermissions) (*PathMatcher, error) {
+	if p == nil || (len(p.PathIncludes) == 0 && len(p.PathExcludes) == 0) {
+		return nil, nil
+	}
+
+	m := &PathMatcher{}
+	for _, pattern := range p.PathIncludes {
+		g, err := compilePathGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.includes = append(m.includes, g)
+		m.includePrefixes = append(m.includePrefixes, literalPrefix(normalizePath(pattern)))
+	}
+	for _, pattern := range p.PathExcludes {
+		g, err := compilePathGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.excludes = append(m.excludes, g)
+	}
+	return m, nil
+}
+
+// ValidatePathGlobs returns an error if any of the patterns is not a valid
+// glob.
+func ValidatePathGlobs(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := compilePathGlob(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanReadPath reports whether the user can read the file at path.
+func (m *PathMatcher) CanReadPath(path string) bool {
+	if m == nil {
+		return true
+	}
+
+	path = normalizePath(path)
+	for _, g := range m.excludes {
+		if g.Match(path) {
+			return false
+		}
+	}
+	if len(m.includes) == 0 {
+		return true
+	}
+	for _, g := range m.includes {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReadDir reports whether the user can see the directory at path. The
+// user can see a directory unless it is excluded as a whole (e.g. by
+// "secret/**"), or none of the included paths can be inside of it.
+func (m *PathMatcher) CanReadDir(path string) bool {
+	if m == nil {
+		return true
+	}
+
+	path = normalizePath(path)
+	if path == "" || path == "." {
+		// Everyone can see the root directory.
+		return true
+	}
+	dir := path + "/"
+	for _, g := range m.excludes {
+		if g.Match(dir) {
+			return false
+		}
+	}
+	if len(m.includes) == 0 {
+		return true
+	}
+	for i, g := range m.includes {
+		prefix := m.includePrefixes[i]
+		if g.Match(dir) || strings.HasPrefix(prefix, dir) || strings.HasPrefix(dir, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterPaths returns the paths the user can read, preserving their order.
+//
+// NOTE: The paths slice is filtered in place and returned. Do not use it after
+// calling this function.
+func (m *PathMatcher) FilterPaths(paths []string) []string {
+	if m == nil {
+		return paths
+	}
+	filtered := paths[:0]
+	for _, p := range paths {
+		if m.CanReadPath(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+func compilePathGlob(pattern string) (glob.Glob, error) {
+	g, err := glob.Compile(normalizePath(pattern), '/')
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid path glob %q", pattern)
+	}
+	return g, nil
+}
+
+// normalizePath strips the leading slash of path, since globs and paths are
+// both relative to the repository root.
+func normalizePath(path string) string {
+	return strings.TrimPrefix(path, "/")
+}
+
+// literalPrefix returns the part of pattern before its first special
+// character.
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, `*?[{\`); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
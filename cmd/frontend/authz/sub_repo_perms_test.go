@@ -0,0 +1,93 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPathMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		perms     *SubRepoPermissions
+		readable  []string
+		forbidden []string
+		dirs      []string
+		noDirs    []string
+	}{
+		{
+			name:     "nil",
+			perms:    nil,
+			readable: []string{"a.go", "secret/key"},
+			dirs:     []string{"secret"},
+		},
+		{
+			name:      "excludes",
+			perms:     &SubRepoPermissions{PathExcludes: []string{"secret/**", "**/*.pem"}},
+			readable:  []string{"a.go", "/b/c.go", "secrets.go"},
+			forbidden: []string{"secret/key", "/secret/a/b", "a/cert.pem"},
+			dirs:      []string{"", "/", ".", "a", "secrets"},
+			noDirs:    []string{"secret", "/secret"},
+		},
+		{
+			name:      "includes",
+			perms:     &SubRepoPermissions{PathIncludes: []string{"src/public/**", "README.md"}},
+			readable:  []string{"README.md", "src/public/a.go", "/src/public/b/c.go"},
+			forbidden: []string{"main.go", "src/private/a.go", "src/a.go"},
+			dirs:      []string{".", "src", "src/public", "src/public/b"},
+			noDirs:    []string{"docs", "src/private"},
+		},
+		{
+			name: "excludes win over includes",
+			perms: &SubRepoPermissions{
+				PathIncludes: []string{"src/**"},
+				PathExcludes: []string{"src/internal/**"},
+			},
+			readable:  []string{"src/a.go"},
+			forbidden: []string{"src/internal/a.go", "b.go"},
+			dirs:      []string{"src"},
+			noDirs:    []string{"src/internal", "docs"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewPathMatcher(tc.perms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range tc.readable {
+				if !m.CanReadPath(p) {
+					t.Errorf("want %q to be readable", p)
+				}
+			}
+			for _, p := range tc.forbidden {
+				if m.CanReadPath(p) {
+					t.Errorf("want %q to be forbidden", p)
+				}
+			}
+			for _, p := range tc.dirs {
+				if !m.CanReadDir(p) {
+					t.Errorf("want directory %q to be visible", p)
+				}
+			}
+			for _, p := range tc.noDirs {
+				if m.CanReadDir(p) {
+					t.Errorf("want directory %q to be hidden", p)
+				}
+			}
+
+			paths := append(append([]string{}, tc.readable...), tc.forbidden...)
+			if diff := cmp.Diff(tc.readable, m.FilterPaths(paths)); diff != "" {
+				t.Errorf("FilterPaths: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePathGlobs(t *testing.T) {
+	if err := ValidatePathGlobs([]string{"a/**", "*.go"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidatePathGlobs([]string{"a/[b"}); err == nil {
+		t.Fatal("want error for invalid glob")
+	}
+}
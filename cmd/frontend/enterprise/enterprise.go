@@ -11,7 +11,9 @@ import (
 // enterprise frontend setup hook.
 type Services struct {
 	GithubWebhook             http.Handler
+	GitLabWebhook             http.Handler
 	BitbucketServerWebhook    http.Handler
+	CampaignsExport           http.Handler
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
 	AuthzResolver             graphqlbackend.AuthzResolver
 	CampaignsResolver         graphqlbackend.CampaignsResolver
@@ -26,7 +28,9 @@ type NewCodeIntelUploadHandler func(internal bool) http.Handler
 func DefaultServices() Services {
 	return Services{
 		GithubWebhook:             makeNotFoundHandler("github webhook"),
+		GitLabWebhook:             makeNotFoundHandler("gitlab webhook"),
 		BitbucketServerWebhook:    makeNotFoundHandler("bitbucket server webhook"),
+		CampaignsExport:           makeNotFoundHandler("campaigns export"),
 		NewCodeIntelUploadHandler: func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		AuthzResolver:             graphqlbackend.DefaultAuthzResolver,
 		CampaignsResolver:         graphqlbackend.DefaultCampaignsResolver,
@@ -44,6 +44,24 @@ type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type UndraftChangesetArgs struct {
+	Changeset graphql.ID
+}
+
+type RebaseChangesetArgs struct {
+	Changeset graphql.ID
+}
+
+type CreateChangesetBulkOperationArgs struct {
+	Campaign      graphql.ID
+	Operation     campaigns.ChangesetBulkOperationType
+	Comment       *string
+	ExternalState *campaigns.ChangesetExternalState
+	ReviewState   *campaigns.ChangesetReviewState
+	CheckState    *campaigns.ChangesetCheckState
+	Repositories  *[]graphql.ID
+}
+
 type CreateChangesetSpecArgs struct {
 	ChangesetSpec string
 }
@@ -55,6 +73,21 @@ type CreateCampaignSpecArgs struct {
 	ChangesetSpecs []graphql.ID
 }
 
+type ExecuteCampaignSpecArgs struct {
+	Namespace graphql.ID
+
+	CampaignSpec string
+}
+
+type RetryCampaignSpecJobArgs struct {
+	CampaignSpecJob graphql.ID
+}
+
+type CampaignSpecJobsConnectionArgs struct {
+	First *int32
+	After *string
+}
+
 type ChangesetSpecsConnectionArgs struct {
 	First *int32
 	After *string
@@ -69,7 +102,12 @@ type CampaignsResolver interface {
 	DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error)
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	CreateCampaignSpec(ctx context.Context, args *CreateCampaignSpecArgs) (CampaignSpecResolver, error)
+	ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecResolver, error)
+	RetryCampaignSpecJob(ctx context.Context, args *RetryCampaignSpecJobArgs) (CampaignSpecJobResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
+	UndraftChangeset(ctx context.Context, args *UndraftChangesetArgs) (ExternalChangesetResolver, error)
+	RebaseChangeset(ctx context.Context, args *RebaseChangesetArgs) (ExternalChangesetResolver, error)
+	CreateChangesetBulkOperation(ctx context.Context, args *CreateChangesetBulkOperationArgs) (ChangesetBulkOperationResolver, error)
 
 	// Queries
 	Campaigns(ctx context.Context, args *ListCampaignArgs) (CampaignsConnectionResolver, error)
@@ -86,6 +124,7 @@ type CampaignSpecResolver interface {
 	OriginalInput() (string, error)
 	ParsedInput() (JSONValue, error)
 	ChangesetSpecs(ctx context.Context, args *ChangesetSpecsConnectionArgs) (ChangesetSpecConnectionResolver, error)
+	Jobs(ctx context.Context, args *CampaignSpecJobsConnectionArgs) (CampaignSpecJobConnectionResolver, error)
 
 	Description() CampaignDescriptionResolver
 
@@ -105,6 +144,24 @@ type CampaignDescriptionResolver interface {
 	Description() string
 }
 
+type CampaignSpecJobConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]CampaignSpecJobResolver, error)
+}
+
+type CampaignSpecJobResolver interface {
+	ID() graphql.ID
+	Repository() *RepositoryResolver
+	State() string
+	FailureMessage() *string
+	NumFailures() int32
+	Log() string
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+	ChangesetSpec(ctx context.Context) (ChangesetSpecResolver, error)
+}
+
 type ChangesetSpecConnectionResolver interface {
 	TotalCount(ctx context.Context) (int32, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
@@ -158,6 +215,7 @@ type GitBranchChangesetDescriptionResolver interface {
 	Commits() []GitCommitDescriptionResolver
 
 	Published() bool
+	Draft() bool
 }
 
 type GitCommitDescriptionResolver interface {
@@ -193,6 +251,47 @@ type CampaignResolver interface {
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *DateTime
 	DiffStat(ctx context.Context) (*DiffStat, error)
+	BulkOperations(ctx context.Context, args *ChangesetBulkOperationsConnectionArgs) (ChangesetBulkOperationConnectionResolver, error)
+}
+
+type ChangesetBulkOperationsConnectionArgs struct {
+	First *int32
+	After *string
+}
+
+type ChangesetBulkOperationConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]ChangesetBulkOperationResolver, error)
+}
+
+type ChangesetBulkOperationJobsConnectionArgs struct {
+	First *int32
+	After *string
+	State *string
+}
+
+type ChangesetBulkOperationResolver interface {
+	ID() graphql.ID
+	Operation() string
+	Comment() *string
+	Initiator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	Jobs(ctx context.Context, args *ChangesetBulkOperationJobsConnectionArgs) (ChangesetBulkOperationJobConnectionResolver, error)
+}
+
+type ChangesetBulkOperationJobConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]ChangesetBulkOperationJobResolver, error)
+}
+
+type ChangesetBulkOperationJobResolver interface {
+	ID() graphql.ID
+	Changeset() ChangesetResolver
+	State() string
+	FailureMessage() *string
+	FinishedAt() *DateTime
 }
 
 type CampaignsConnectionResolver interface {
@@ -259,6 +358,7 @@ type ExternalChangesetResolver interface {
 	ExternalURL() (*externallink.Resolver, error)
 	ReviewState(context.Context) *campaigns.ChangesetReviewState
 	CheckState() *campaigns.ChangesetCheckState
+	MergeableState() *campaigns.ChangesetMergeableState
 	Repository(ctx context.Context) (*RepositoryResolver, error)
 
 	Events(ctx context.Context, args *struct{ graphqlutil.ConnectionArgs }) (ChangesetEventsConnectionResolver, error)
@@ -317,6 +417,14 @@ func (defaultCampaignsResolver) CreateCampaignSpec(ctx context.Context, args *Cr
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RetryCampaignSpecJob(ctx context.Context, args *RetryCampaignSpecJobArgs) (CampaignSpecJobResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) MoveCampaign(ctx context.Context, args *MoveCampaignArgs) (CampaignResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -329,6 +437,18 @@ func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncCha
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) UndraftChangeset(ctx context.Context, args *UndraftChangesetArgs) (ExternalChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RebaseChangeset(ctx context.Context, args *RebaseChangesetArgs) (ExternalChangesetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreateChangesetBulkOperation(ctx context.Context, args *CreateChangesetBulkOperationArgs) (ChangesetBulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -19,7 +19,9 @@ type CodeIntelResolver interface {
 	LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	LSIFIndexesByRepo(ctx context.Context, args *LSIFRepositoryIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	DeleteLSIFIndex(ctx context.Context, id graphql.ID) (*EmptyResponse, error)
+	UpdateLSIFIndexerOverride(ctx context.Context, args *UpdateLSIFIndexerOverrideArgs) (*EmptyResponse, error)
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
+	GitBlobDocumentSymbols(ctx context.Context, args *GitBlobLSIFDataArgs) ([]DocumentSymbolResolver, error)
 }
 
 var codeIntelOnlyInEnterprise = errors.New("lsif uploads and queries are only available in enterprise")
@@ -60,10 +62,18 @@ func (defaultCodeIntelResolver) DeleteLSIFIndex(ctx context.Context, id graphql.
 	return nil, codeIntelOnlyInEnterprise
 }
 
+func (defaultCodeIntelResolver) UpdateLSIFIndexerOverride(ctx context.Context, args *UpdateLSIFIndexerOverrideArgs) (*EmptyResponse, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
 func (defaultCodeIntelResolver) GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error) {
 	return nil, codeIntelOnlyInEnterprise
 }
 
+func (defaultCodeIntelResolver) GitBlobDocumentSymbols(ctx context.Context, args *GitBlobLSIFDataArgs) ([]DocumentSymbolResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
 func (r *schemaResolver) LSIFUploads(ctx context.Context, args *LSIFUploadsQueryArgs) (LSIFUploadConnectionResolver, error) {
 	return r.CodeIntelResolver.LSIFUploads(ctx, args)
 }
@@ -80,6 +90,16 @@ func (r *schemaResolver) DeleteLSIFIndex(ctx context.Context, args *struct{ ID g
 	return r.CodeIntelResolver.DeleteLSIFIndex(ctx, args.ID)
 }
 
+func (r *schemaResolver) UpdateLSIFIndexerOverride(ctx context.Context, args *UpdateLSIFIndexerOverrideArgs) (*EmptyResponse, error) {
+	return r.CodeIntelResolver.UpdateLSIFIndexerOverride(ctx, args)
+}
+
+type UpdateLSIFIndexerOverrideArgs struct {
+	Repository  graphql.ID
+	Indexer     *string
+	IndexerArgs *[]string
+}
+
 type LSIFUploadsQueryArgs struct {
 	graphqlutil.ConnectionArgs
 	Query           *string
@@ -156,7 +176,10 @@ type GitBlobLSIFDataResolver interface {
 	Ranges(ctx context.Context, args *LSIFRangesArgs) (CodeIntelligenceRangeConnectionResolver, error)
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
+	DocumentSymbols(ctx context.Context) ([]DocumentSymbolResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -208,6 +231,15 @@ type HoverResolver interface {
 	Range() RangeResolver
 }
 
+type DocumentSymbolResolver interface {
+	Name() string
+	Detail() *string
+	Kind() string
+	Range() RangeResolver
+	SelectionRange() RangeResolver
+	Children() []DocumentSymbolResolver
+}
+
 type DiagnosticConnectionResolver interface {
 	Nodes(ctx context.Context) ([]DiagnosticResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
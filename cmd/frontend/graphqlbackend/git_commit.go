@@ -197,6 +197,10 @@ func (r *GitCommitResolver) Tree(ctx context.Context, args *struct {
 	if !stat.Mode().IsDir() {
 		return nil, fmt.Errorf("not a directory: %q", args.Path)
 	}
+	// 🚨 SECURITY: Hide directories the user cannot read.
+	if err := checkPathPerms(ctx, r.repoResolver.repo.ID, args.Path, true); err != nil {
+		return nil, err
+	}
 	return &GitTreeEntryResolver{
 		commit:      r,
 		stat:        stat,
@@ -218,6 +222,10 @@ func (r *GitCommitResolver) Blob(ctx context.Context, args *struct {
 	if !stat.Mode().IsRegular() {
 		return nil, fmt.Errorf("not a blob: %q", args.Path)
 	}
+	// 🚨 SECURITY: Hide files the user cannot read.
+	if err := checkPathPerms(ctx, r.repoResolver.repo.ID, args.Path, false); err != nil {
+		return nil, err
+	}
 	return &GitTreeEntryResolver{
 		commit: r,
 		stat:   stat,
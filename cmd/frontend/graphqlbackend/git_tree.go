@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -52,6 +53,21 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 		}
 	}
 
+	// 🚨 SECURITY: Hide entries the user cannot read.
+	m, err := db.AuthzPathMatcher(ctx, r.commit.repoResolver.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		readable := entries[:0]
+		for _, entry := range entries {
+			if entry.IsDir() && m.CanReadDir(entry.Name()) || !entry.IsDir() && m.CanReadPath(entry.Name()) {
+				readable = append(readable, entry)
+			}
+		}
+		entries = readable
+	}
+
 	sort.Sort(byDirectory(entries))
 
 	if args.First != nil && len(entries) > int(*args.First) {
@@ -309,6 +309,23 @@ func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName
 	})
 }
 
+func (r *GitTreeEntryResolver) DocumentSymbols(ctx context.Context, args *struct{ ToolName *string }) ([]DocumentSymbolResolver, error) {
+	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
+
+	var toolName string
+	if args.ToolName != nil {
+		toolName = *args.ToolName
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.GitBlobDocumentSymbols(ctx, &GitBlobLSIFDataArgs{
+		Repo:      r.Repository().Type(),
+		Commit:    api.CommitID(r.Commit().OID()),
+		Path:      r.Path(),
+		ExactPath: true,
+		ToolName:  toolName,
+	})
+}
+
 type fileInfo struct {
 	path  string
 	size  int64
@@ -12,9 +12,11 @@ import (
 	"sync"
 
 	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/highlight"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -247,6 +249,13 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 				return
 			}
 
+			// 🚨 SECURITY: Hide diffs of files the user cannot read.
+			var matcher *authz.PathMatcher
+			matcher, err = db.AuthzPathMatcher(ctx, cmp.repo.repo.ID)
+			if err != nil {
+				return
+			}
+
 			var iter *git.DiffFileIterator
 			iter, err = git.Diff(ctx, git.DiffOptions{
 				Repo: *cachedRepo,
@@ -271,6 +280,9 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 				if err != nil {
 					return
 				}
+				if !canReadFileDiff(matcher, fileDiff) {
+					continue
+				}
 				fileDiffs = append(fileDiffs, fileDiff)
 				if args.First != nil && len(fileDiffs) == int(*args.First+afterIdx) {
 					// Check for hasNextPage.
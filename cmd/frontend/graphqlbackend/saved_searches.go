@@ -3,6 +3,10 @@ package graphqlbackend
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -10,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/cmd/query-runner/queryrunnerapi"
 	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 )
 
@@ -59,6 +64,9 @@ func savedSearchByID(ctx context.Context, id graphql.ID) (*savedSearchResolver,
 			UserID:          ss.Config.UserID,
 			OrgID:           ss.Config.OrgID,
 			SlackWebhookURL: ss.Config.SlackWebhookURL,
+			NotifyWebhook:   ss.Config.NotifyWebhook,
+			WebhookURL:      ss.Config.WebhookURL,
+			WebhookSecret:   ss.Config.WebhookSecret,
 		},
 	}
 	return savedSearch, nil
@@ -100,6 +108,92 @@ func (r savedSearchResolver) Namespace(ctx context.Context) (*NamespaceResolver,
 
 func (r savedSearchResolver) SlackWebhookURL() *string { return r.s.SlackWebhookURL }
 
+func (r savedSearchResolver) NotifyWebhook() bool { return r.s.NotifyWebhook }
+
+func (r savedSearchResolver) WebhookURL() *string { return r.s.WebhookURL }
+
+func (r savedSearchResolver) HasWebhookSecret() bool {
+	return r.s.WebhookSecret != nil && *r.s.WebhookSecret != ""
+}
+
+func (r savedSearchResolver) WebhookDeliveries(ctx context.Context, args *struct {
+	First      int32
+	OnlyFailed bool
+}) ([]*savedSearchWebhookDeliveryResolver, error) {
+	// 🚨 SECURITY: Resolvers of saved searches are only created for users with access to the
+	// saved search.
+	deliveries, err := db.SavedSearchWebhookDeliveries.List(ctx, db.SavedSearchWebhookDeliveryListOptions{
+		SavedSearchID: r.s.ID,
+		OnlyFailed:    args.OnlyFailed,
+		LimitOffset:   &db.LimitOffset{Limit: int(args.First)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchWebhookDeliveryResolver, 0, len(deliveries))
+	for _, d := range deliveries {
+		resolvers = append(resolvers, &savedSearchWebhookDeliveryResolver{d: d})
+	}
+	return resolvers, nil
+}
+
+type savedSearchWebhookDeliveryResolver struct {
+	d *types.SavedSearchWebhookDelivery
+}
+
+func (r *savedSearchWebhookDeliveryResolver) URL() string { return r.d.URL }
+
+func (r *savedSearchWebhookDeliveryResolver) Payload() string { return string(r.d.Payload) }
+
+func (r *savedSearchWebhookDeliveryResolver) Attempts() int32 { return r.d.Attempts }
+
+func (r *savedSearchWebhookDeliveryResolver) StatusCode() *int32 { return r.d.StatusCode }
+
+func (r *savedSearchWebhookDeliveryResolver) Error() *string { return r.d.Error }
+
+func (r *savedSearchWebhookDeliveryResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.d.CreatedAt}
+}
+
+func (r *savedSearchWebhookDeliveryResolver) DeliveredAt() *DateTime {
+	return DateTimeOrNil(r.d.DeliveredAt)
+}
+
+func (r savedSearchResolver) Runs(ctx context.Context, args *struct {
+	First int32
+}) ([]*savedSearchRunResolver, error) {
+	// 🚨 SECURITY: Resolvers of saved searches are only created for users with access to the
+	// saved search.
+	runs, err := db.QueryRunnerRuns.List(ctx, db.QueryRunnerRunListOptions{
+		Query:       r.s.Query,
+		LimitOffset: &db.LimitOffset{Limit: int(args.First)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &savedSearchRunResolver{r: run})
+	}
+	return resolvers, nil
+}
+
+type savedSearchRunResolver struct {
+	r *types.QueryRunnerRun
+}
+
+func (r *savedSearchRunResolver) Runner() string { return r.r.Runner }
+
+func (r *savedSearchRunResolver) ScheduledAt() DateTime { return DateTime{Time: r.r.ScheduledAt} }
+
+func (r *savedSearchRunResolver) StartedAt() DateTime { return DateTime{Time: r.r.StartedAt} }
+
+func (r *savedSearchRunResolver) FinishedAt() DateTime { return DateTime{Time: r.r.FinishedAt} }
+
+func (r *savedSearchRunResolver) ResultCount() *int32 { return r.r.ResultCount }
+
+func (r *savedSearchRunResolver) Error() *string { return r.r.Error }
+
 func toSavedSearchResolver(entry types.SavedSearch) *savedSearchResolver {
 	return &savedSearchResolver{entry}
 }
@@ -145,12 +239,15 @@ func (r *schemaResolver) SendSavedSearchTestNotification(ctx context.Context, ar
 }
 
 func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
-	Description string
-	Query       string
-	NotifyOwner bool
-	NotifySlack bool
-	OrgID       *graphql.ID
-	UserID      *graphql.ID
+	Description   string
+	Query         string
+	NotifyOwner   bool
+	NotifySlack   bool
+	NotifyWebhook bool
+	WebhookURL    *string
+	WebhookSecret *string
+	OrgID         *graphql.ID
+	UserID        *graphql.ID
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to create a saved search for the specified user or org.
@@ -179,14 +276,20 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 	if !queryHasPatternType(args.Query) {
 		return nil, errMissingPatternType
 	}
+	if err := validateWebhookURL(ctx, args.NotifyWebhook, args.WebhookURL); err != nil {
+		return nil, err
+	}
 
 	ss, err := db.SavedSearches.Create(ctx, &types.SavedSearch{
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		UserID:        userID,
+		OrgID:         orgID,
+		NotifyWebhook: args.NotifyWebhook,
+		WebhookURL:    args.WebhookURL,
+		WebhookSecret: args.WebhookSecret,
 	})
 	if err != nil {
 		return nil, err
@@ -196,13 +299,16 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 }
 
 func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
-	ID          graphql.ID
-	Description string
-	Query       string
-	NotifyOwner bool
-	NotifySlack bool
-	OrgID       *graphql.ID
-	UserID      *graphql.ID
+	ID            graphql.ID
+	Description   string
+	Query         string
+	NotifyOwner   bool
+	NotifySlack   bool
+	NotifyWebhook bool
+	WebhookURL    *string
+	WebhookSecret *string
+	OrgID         *graphql.ID
+	UserID        *graphql.ID
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to update a saved search for the specified user or org.
@@ -236,15 +342,21 @@ func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
 	if !queryHasPatternType(args.Query) {
 		return nil, errMissingPatternType
 	}
+	if err := validateWebhookURL(ctx, args.NotifyWebhook, args.WebhookURL); err != nil {
+		return nil, err
+	}
 
 	ss, err := db.SavedSearches.Update(ctx, &types.SavedSearch{
-		ID:          id,
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		ID:            id,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		UserID:        userID,
+		OrgID:         orgID,
+		NotifyWebhook: args.NotifyWebhook,
+		WebhookURL:    args.WebhookURL,
+		WebhookSecret: args.WebhookSecret,
 	})
 	if err != nil {
 		return nil, err
@@ -290,3 +402,49 @@ func queryHasPatternType(query string) bool {
 }
 
 var errMissingPatternType = errors.New("a `patternType:` filter is required in the query for all saved searches. `patternType` can be \"literal\" or \"regexp\"")
+
+// lookupWebhookHost resolves the host of a webhook URL. It is a variable so that tests can
+// replace it.
+var lookupWebhookHost = net.DefaultResolver.LookupIPAddr
+
+// validateWebhookURL checks that the webhook URL of a saved search is an absolute http(s) URL, if
+// new results are posted to it.
+//
+// 🚨 SECURITY: The URL must not point to a loopback, link-local or private address, so that users
+// can't use webhooks to probe services inside the cluster. The query-runner checks the address
+// again when it connects, because DNS records can change after validation. Hosts that can't be
+// resolved are accepted here for the same reason.
+func validateWebhookURL(ctx context.Context, notifyWebhook bool, webhookURL *string) error {
+	if !notifyWebhook {
+		return nil
+	}
+	if webhookURL == nil || *webhookURL == "" {
+		return errors.New("a webhook URL is required to post new results to a webhook")
+	}
+	u, err := url.Parse(*webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: it must be an absolute http or https URL", *webhookURL)
+	}
+
+	errNonPublic := fmt.Errorf("invalid webhook URL %q: it must not point to a loopback, link-local or private address", *webhookURL)
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !httpcli.IsPublicIP(ip) {
+			return errNonPublic
+		}
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errNonPublic
+	}
+	addrs, err := lookupWebhookHost(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !httpcli.IsPublicIP(addr.IP) {
+			return errNonPublic
+		}
+	}
+	return nil
+}
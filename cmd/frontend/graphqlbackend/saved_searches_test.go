@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"net"
 	"reflect"
 	"testing"
 
@@ -61,12 +62,15 @@ func TestCreateSavedSearch(t *testing.T) {
 	}
 	userID := MarshalUserID(key)
 	savedSearches, err := (&schemaResolver{}).CreateSavedSearch(ctx, &struct {
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{Description: "test query", Query: "test type:diff patternType:regexp", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -91,12 +95,15 @@ func TestCreateSavedSearch(t *testing.T) {
 
 	// Ensure create saved search errors when patternType is not provided in the query.
 	_, err = (&schemaResolver{}).CreateSavedSearch(ctx, &struct {
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{Description: "test query", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for createSavedSearch when query does not provide a patternType: field.")
@@ -119,13 +126,16 @@ func TestUpdateSavedSearch(t *testing.T) {
 	}
 	userID := MarshalUserID(key)
 	savedSearches, err := (&schemaResolver{}).UpdateSavedSearch(ctx, &struct {
-		ID          graphql.ID
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		ID            graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff patternType:regexp", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -151,13 +161,16 @@ func TestUpdateSavedSearch(t *testing.T) {
 
 	// Ensure update saved search errors when patternType is not provided in the query.
 	_, err = (&schemaResolver{}).UpdateSavedSearch(ctx, &struct {
-		ID          graphql.ID
-		Description string
-		Query       string
-		NotifyOwner bool
-		NotifySlack bool
-		OrgID       *graphql.ID
-		UserID      *graphql.ID
+		ID            graphql.ID
+		Description   string
+		Query         string
+		NotifyOwner   bool
+		NotifySlack   bool
+		NotifyWebhook bool
+		WebhookURL    *string
+		WebhookSecret *string
+		OrgID         *graphql.ID
+		UserID        *graphql.ID
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for updateSavedSearch when query does not provide a patternType: field.")
@@ -195,3 +208,45 @@ func TestDeleteSavedSearch(t *testing.T) {
 		t.Errorf("Database method db.SavedSearches.Delete not called")
 	}
 }
+
+func TestValidateWebhookURL(t *testing.T) {
+	lookupWebhookHost = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		case "rebind.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	defer func() { lookupWebhookHost = net.DefaultResolver.LookupIPAddr }()
+
+	strPtr := func(s string) *string { return &s }
+	tests := []struct {
+		notifyWebhook bool
+		webhookURL    *string
+		wantErr       bool
+	}{
+		{notifyWebhook: false, webhookURL: nil},
+		{notifyWebhook: false, webhookURL: strPtr("not a URL")},
+		{notifyWebhook: true, webhookURL: strPtr("https://example.com/hook")},
+		{notifyWebhook: true, webhookURL: strPtr("http://bot.unresolvable:8080/saved-searches")},
+		{notifyWebhook: true, webhookURL: strPtr("https://93.184.216.34/hook")},
+		{notifyWebhook: true, webhookURL: nil, wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr(""), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("ftp://example.com"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("/relative"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("http://localhost:3080/.api/graphql"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("http://127.0.0.1:5432"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("http://[::1]/"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("http://169.254.169.254/latest/meta-data"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("http://10.0.0.5/"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("https://rebind.example.com/hook"), wantErr: true},
+	}
+	for _, test := range tests {
+		err := validateWebhookURL(context.Background(), test.notifyWebhook, test.webhookURL)
+		if (err != nil) != test.wantErr {
+			t.Errorf("validateWebhookURL(%v, %v): got error %v, want error %v", test.notifyWebhook, test.webhookURL, err, test.wantErr)
+		}
+	}
+}
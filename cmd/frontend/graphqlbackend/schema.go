@@ -314,6 +314,13 @@ type Mutation {
         query: String!
         notifyOwner: Boolean!
         notifySlack: Boolean!
+        # Whether to post new results to webhookURL.
+        notifyWebhook: Boolean = false
+        # The http(s) URL that new results are posted to. Required if notifyWebhook is true.
+        webhookURL: String
+        # The secret used to sign the webhook payloads. If set, each payload is signed with
+        # HMAC-SHA256 and the signature is sent in the X-Sourcegraph-Signature header.
+        webhookSecret: String
         orgID: ID
         userID: ID
     ): SavedSearch!
@@ -324,6 +331,13 @@ type Mutation {
         query: String!
         notifyOwner: Boolean!
         notifySlack: Boolean!
+        # Whether to post new results to webhookURL.
+        notifyWebhook: Boolean = false
+        # The http(s) URL that new results are posted to. Required if notifyWebhook is true.
+        webhookURL: String
+        # The secret used to sign the webhook payloads. If null, the existing secret is kept. If
+        # empty, the existing secret is removed.
+        webhookSecret: String
         orgID: ID
         userID: ID
     ): SavedSearch!
@@ -342,6 +356,20 @@ type Mutation {
     # Deletes an LSIF index.
     deleteLSIFIndex(id: ID!): EmptyResponse
 
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # Overrides the indexer used to automatically index a repository. When the indexer is null,
+    # the override is removed and the indexer is inferred from the repository contents again.
+    updateLSIFIndexerOverride(
+        # The repository to configure.
+        repository: ID!
+        # The name of the indexer to run, e.g. lsif-java.
+        indexer: String
+        # The arguments passed to the indexer.
+        indexerArgs: [String!]
+    ): EmptyResponse
+
     # Set the permissions of a repository (i.e., which users may view it on Sourcegraph). This
     # operation overwrites the previous permissions for the repository.
     setRepositoryPermissionsForUsers(
@@ -352,6 +380,25 @@ type Mutation {
         # permitted to view the repository on Sourcegraph.
         userPermissions: [UserPermission!]!
     ): EmptyResponse!
+    # Set the sub-repository permissions of a user for a repository, i.e. which paths of the
+    # repository the user may view on Sourcegraph. Paths are matched with globs relative to the
+    # repository root (e.g. "secret/**" or "**/*.pem"). A path excluded by any glob is hidden; if
+    # there are include globs, only paths matched by one of them are visible. This operation
+    # overwrites the previous sub-repository permissions of the user for the repository, and
+    # passing no globs at all removes them.
+    #
+    # Only site admins may perform this mutation. It does not change whether the user may view the
+    # repository itself.
+    setSubRepositoryPermissionsForUser(
+        # The repository whose sub-repository permissions to set.
+        repository: ID!
+        # The user whose sub-repository permissions to set.
+        user: ID!
+        # The globs of the paths the user may view. An empty list means all paths not excluded.
+        pathIncludes: [String!]!
+        # The globs of the paths the user may not view.
+        pathExcludes: [String!]!
+    ): EmptyResponse!
     # Schedule a permissions sync for given repository. This queries the repository's code host for
     # all users' permissions associated with the repository, so that the current permissions apply
     # to all users' operations on that repository on Sourcegraph.
@@ -439,8 +486,62 @@ type Mutation {
         changesetSpecs: [ID!]!
     ): CampaignSpec!
 
+    # Create a campaign spec whose steps are executed on the server instead of locally by src-cli.
+    # A job is enqueued for every repository matched by the "on" attribute of the campaign spec.
+    # The changeset specs produced by the jobs are attached to the campaign spec as they complete,
+    # which can be followed with CampaignSpec.jobs.
+    #
+    # Only site admins may execute campaign specs.
+    executeCampaignSpec(
+        # The namespace (either a user or organization). A campaign spec can only be applied to (or
+        # used to create) campaigns in this namespace.
+        namespace: ID!
+
+        # The campaign spec as YAML (or the equivalent JSON).
+        campaignSpec: String!
+    ): CampaignSpec!
+
+    # Put an errored campaign spec job back into the queue.
+    retryCampaignSpecJob(campaignSpecJob: ID!): CampaignSpecJob!
+
     # Enqueue the given changeset for high-priority syncing.
     syncChangeset(changeset: ID!): EmptyResponse!
+
+    # Mark the given draft changeset as ready for review on the code host.
+    undraftChangeset(changeset: ID!): ExternalChangeset!
+
+    # Rebase the given changeset onto the latest revision of its base branch
+    # and force-push its head branch to the code host. This fails if the
+    # changes conflict with the base branch.
+    rebaseChangeset(changeset: ID!): ExternalChangeset!
+
+    # Run an operation on all changesets of the given campaign that match the filters. A job is
+    # enqueued for every matching changeset in a repository the viewer can access. The results are
+    # available with Campaign.bulkOperations as the jobs complete.
+    #
+    # Only the author of the campaign and site admins may run bulk operations.
+    createChangesetBulkOperation(
+        # The campaign whose changesets the operation runs on.
+        campaign: ID!
+
+        # The operation to run on each changeset.
+        operation: ChangesetBulkOperationType!
+
+        # The comment to post on each changeset. Required if the operation is COMMENT.
+        comment: String
+
+        # Only include changesets with the given external state.
+        externalState: ChangesetExternalState
+
+        # Only include changesets with the given review state.
+        reviewState: ChangesetReviewState
+
+        # Only include changesets with the given check state.
+        checkState: ChangesetCheckState
+
+        # Only include changesets in the given repositories.
+        repositories: [ID!]
+    ): ChangesetBulkOperation!
 }
 
 # The type of the changeset spec.
@@ -577,7 +678,15 @@ type GitBranchChangesetDescription {
     #
     # Another ChangesetSpec with the same description, but "published: true",
     # can later be applied publish the changeset.
+    #
+    # This is also true if the changeset is published as a draft.
     published: Boolean!
+
+    # Whether or not the changeset described here should be created as a
+    # draft (a GitHub draft pull request or a GitLab work in progress merge
+    # request) right after applying the ChangesetSpec this description
+    # belongs to.
+    draft: Boolean!
 }
 
 # A description of a Git commit.
@@ -634,6 +743,10 @@ type CampaignSpec implements Node {
     # The specs for changesets associated with this campaign.
     changesetSpecs(first: Int, after: String): ChangesetSpecConnection!
 
+    # The server-side executions of the steps of this campaign spec, one per repository. This is
+    # empty unless the campaign spec was created with executeCampaignSpec.
+    jobs(first: Int, after: String): CampaignSpecJobConnection!
+
     # The user who created this campaign spec (or null if the user no longer exists).
     creator: User
 
@@ -654,6 +767,60 @@ type CampaignSpec implements Node {
     viewerCanAdminister: Boolean!
 }
 
+# The state of a campaign spec job.
+enum CampaignSpecJobState {
+    # The job is waiting to be executed, either for the first time or to be retried.
+    QUEUED
+    # The job is being executed.
+    PROCESSING
+    # The job was executed successfully.
+    COMPLETED
+    # The job failed on every attempt and won't be retried unless retryCampaignSpecJob is used.
+    ERRORED
+}
+
+# The server-side execution of the steps of a campaign spec in a single repository.
+type CampaignSpecJob {
+    # The unique ID for the campaign spec job.
+    id: ID!
+
+    # The repository the steps are executed in, or null if the viewer can't access it.
+    repository: Repository
+
+    # The state of the job.
+    state: CampaignSpecJobState!
+
+    # The error of the most recent failed attempt, if any.
+    failureMessage: String
+
+    # The number of failed attempts.
+    numFailures: Int!
+
+    # The combined output of the steps of the most recent attempt. It is empty if the viewer can't
+    # access the repository.
+    log: String!
+
+    # The date when the most recent attempt started.
+    startedAt: DateTime
+
+    # The date when the job completed or errored.
+    finishedAt: DateTime
+
+    # The changeset spec created from the changes made by the steps, or null if the job hasn't
+    # completed or the steps didn't change anything.
+    changesetSpec: ChangesetSpec
+}
+
+# A list of campaign spec jobs.
+type CampaignSpecJobConnection {
+    # The total number of campaign spec jobs in the connection.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+    # A list of campaign spec jobs.
+    nodes: [CampaignSpecJob!]!
+}
+
 # A user (identified either by username or email address) with its repository permission.
 input UserPermission {
     # Depending on the bindID option in the permissions.userMapping site configuration property,
@@ -724,6 +891,99 @@ type Campaign implements Node {
 
     # The diff stat for all the changesets in the campaign.
     diffStat: DiffStat!
+
+    # The bulk operations run on the changesets of this campaign, most recent first.
+    bulkOperations(first: Int, after: String): ChangesetBulkOperationConnection!
+}
+
+# The operation run by a changeset bulk operation.
+enum ChangesetBulkOperationType {
+    # Post a comment on each changeset.
+    COMMENT
+    # Close each open changeset on the code host.
+    CLOSE
+    # Sync each changeset with the code host.
+    SYNC
+    # Update the title, body and base branch of each open changeset created by the campaign to
+    # match the current campaign spec.
+    REPUBLISH
+}
+
+# An operation run on a set of changesets of a campaign.
+type ChangesetBulkOperation {
+    # The unique ID for the bulk operation.
+    id: ID!
+
+    # The operation run on the changesets.
+    operation: ChangesetBulkOperationType!
+
+    # The comment posted on the changesets, if the operation is COMMENT.
+    comment: String
+
+    # The user who created the bulk operation (or null if the user no longer exists).
+    initiator: User
+
+    # The date when the bulk operation was created.
+    createdAt: DateTime!
+
+    # The per-changeset results of the bulk operation.
+    jobs(
+        first: Int
+        after: String
+        # Only include jobs with the given state.
+        state: ChangesetBulkOperationJobState
+    ): ChangesetBulkOperationJobConnection!
+}
+
+# A list of changeset bulk operations.
+type ChangesetBulkOperationConnection {
+    # The total number of bulk operations in the connection.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+    # A list of bulk operations.
+    nodes: [ChangesetBulkOperation!]!
+}
+
+# The state of a changeset bulk operation job.
+enum ChangesetBulkOperationJobState {
+    # The job is waiting to be run.
+    QUEUED
+    # The job is being run.
+    PROCESSING
+    # The operation was run on the changeset successfully.
+    COMPLETED
+    # The operation failed on the changeset.
+    ERRORED
+}
+
+# The result of a changeset bulk operation on a single changeset.
+type ChangesetBulkOperationJob {
+    # The unique ID for the job.
+    id: ID!
+
+    # The changeset the operation is run on.
+    changeset: Changeset!
+
+    # The state of the job.
+    state: ChangesetBulkOperationJobState!
+
+    # The error of the most recent failed attempt, if any. It is null if the viewer can't access
+    # the repository of the changeset.
+    failureMessage: String
+
+    # The date when the job completed or errored.
+    finishedAt: DateTime
+}
+
+# A list of changeset bulk operation jobs.
+type ChangesetBulkOperationJobConnection {
+    # The total number of jobs in the connection.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+    # A list of jobs.
+    nodes: [ChangesetBulkOperationJob!]!
 }
 
 # The counts of changesets in certain states at a specific point in time.
@@ -773,6 +1033,9 @@ enum ChangesetState {
 # The state of a changeset on the code host on which it's hosted.
 enum ChangesetExternalState {
     OPEN
+    # The changeset is open, but a draft (a GitHub draft pull request or a
+    # GitLab work in progress merge request) that isn't ready for review yet.
+    DRAFT
     CLOSED
     MERGED
     DELETED
@@ -787,6 +1050,17 @@ enum ChangesetReviewState {
     DISMISSED
 }
 
+# Whether a changeset can be merged into its base branch without conflicts.
+enum ChangesetMergeableState {
+    # The changeset can be merged without conflicts.
+    MERGEABLE
+    # The head branch of the changeset conflicts with the base branch. It
+    # needs to be rebased before it can be merged.
+    CONFLICTING
+    # The code host hasn't determined whether the changeset can be merged.
+    UNKNOWN
+}
+
 # The state of checks (e.g., for continuous integration) on a changeset.
 enum ChangesetCheckState {
     PENDING
@@ -938,6 +1212,11 @@ type ExternalChangeset implements Node & Changeset {
     # checks have been configured.
     checkState: ChangesetCheckState
 
+    # Whether the head branch of this changeset can be merged into the base branch without
+    # conflicts, as reported by the code host. This is only set once the changeset has been synced
+    # and is UNKNOWN if the changeset is not open or the code host hasn't computed it yet.
+    mergeableState: ChangesetMergeableState
+
     # An error that has occurred when publishing or updating the changeset. This is only set when the changeset state is ERRORED and the viewer can administer this changeset.
     error: String
 }
@@ -1316,6 +1595,15 @@ type Query {
         # the first N results (relative to the cursor) should be returned. i.e.
         # how many results to return per page. It must be in the range of 0-5000.
         first: Int
+
+        # (experimental) Continues a search where it stopped instead of running it again.
+        #
+        # Pass an empty string to start a continuable search, and the
+        # 'SearchResults.continuation' of its results to fetch the next results of
+        # the same query. Continued searches read the results of unindexed
+        # repositories from searcher's cache. Results of indexed repositories are not
+        # continued. Cannot be combined with 'first' or structural search.
+        continuation: String
     ): Search
     # All saved searches configured for the current user, merged from all configurations.
     savedSearches: [SavedSearch!]!
@@ -1589,6 +1877,10 @@ type SearchResults {
     #
     # This field is only applcable when the original request was a paginated one.
     pageInfo: PageInfo!
+    # (experimental) The cursor to pass as the 'continuation' argument of the search to
+    # fetch more results of unindexed repositories, or null if there are none or the
+    # search was not continuable.
+    continuation: String
 }
 
 # Statistics about search results.
@@ -1654,6 +1946,60 @@ type SavedSearch implements Node {
     namespace: Namespace!
     # The Slack webhook URL associated with this saved search, if any.
     slackWebhookURL: String
+    # Whether or not to post new results to the webhook URL.
+    notifyWebhook: Boolean!
+    # The URL that new results are posted to, if any.
+    webhookURL: String
+    # Whether or not webhook payloads are signed with a secret. The secret itself is never returned.
+    hasWebhookSecret: Boolean!
+    # The most recent deliveries of new results to the webhook URL, most recent first.
+    webhookDeliveries(
+        # Returns the first n deliveries from the list.
+        first: Int = 20
+        # Only return deliveries that failed.
+        onlyFailed: Boolean = false
+    ): [SavedSearchWebhookDelivery!]!
+    # The most recent runs of the saved search query by the query runner, most recent first. Runs
+    # are kept for 7 days.
+    runs(
+        # Returns the first n runs from the list.
+        first: Int = 20
+    ): [SavedSearchRun!]!
+}
+
+# A delivery of new results of a saved search to its webhook URL.
+type SavedSearchWebhookDelivery {
+    # The URL that the payload was posted to.
+    url: String!
+    # The JSON payload that was posted.
+    payload: String!
+    # The number of attempts to deliver the payload. Failed attempts are retried with backoff.
+    attempts: Int!
+    # The HTTP status code of the response to the last attempt, if any.
+    statusCode: Int
+    # The error of the last attempt, if the delivery failed.
+    error: String
+    # When the delivery was created.
+    createdAt: DateTime!
+    # When the payload was delivered, or null if the delivery failed.
+    deliveredAt: DateTime
+}
+
+# A run of a saved search query by the query runner.
+type SavedSearchRun {
+    # The query runner replica that ran the query.
+    runner: String!
+    # When the query was due to run. The time between this and startedAt is how far the query
+    # runner lagged behind.
+    scheduledAt: DateTime!
+    # When the query started running.
+    startedAt: DateTime!
+    # When the query finished running.
+    finishedAt: DateTime!
+    # The number of results, if the search succeeded.
+    resultCount: Int
+    # The error of the search, if it failed.
+    error: String
 }
 
 # A search query description.
@@ -3024,6 +3370,14 @@ type GitBlob implements TreeEntry & File2 {
         # An optional filter for the name of the tool that produced the upload data.
         toolName: String
     ): GitBlobLSIFData
+
+    # (experimental) The outline of symbols declared in this blob. Symbols are read from
+    # LSIF data when it provides document symbols, and from the symbols service otherwise,
+    # so this is available even if lsif is null.
+    documentSymbols(
+        # An optional filter for the name of the tool that produced the upload data.
+        toolName: String
+    ): [DocumentSymbol!]!
 }
 
 # LSIF data available for a tree entry.
@@ -3082,6 +3436,42 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
         first: Int
     ): LocationConnection!
 
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # A list of locations defining the type of the symbol under the given document position.
+    typeDefinitions(
+        # The line on which the symbol occurs (zero-based, inclusive).
+        line: Int!
+
+        # The character (not byte) of the start line on which the symbol occurs (zero-based, inclusive).
+        character: Int!
+    ): LocationConnection!
+
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # A list of implementations of the symbol under the given document position.
+    implementations(
+        # The line on which the symbol occurs (zero-based, inclusive).
+        line: Int!
+
+        # The character (not byte) of the start line on which the symbol occurs (zero-based, inclusive).
+        character: Int!
+
+        # When specified, indicates that this request should be paginated and
+        # to fetch results starting at this cursor.
+        #
+        # A future request can be made for more results by passing in the
+        # 'LocationConnection.pageInfo.endCursor' that is returned.
+        after: String
+
+        # When specified, indicates that this request should be paginated and
+        # the first N results (relative to the cursor) should be returned. i.e.
+        # how many results to return per page.
+        first: Int
+    ): LocationConnection!
+
     # (experimental) The LSIF API may change substantially in the near future as we
     # continue to adjust it for our use cases. Changes will not be documented in the
     # CHANGELOG during this time.
@@ -3099,6 +3489,34 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
     # CHANGELOG during this time.
     # Code diagnostics provided through LSIF.
     diagnostics(first: Int): DiagnosticConnection!
+
+    # (experimental) The LSIF API may change substantially in the near future as we
+    # continue to adjust it for our use cases. Changes will not be documented in the
+    # CHANGELOG during this time.
+    # The outline of symbols declared in this document. Symbols are read from the LSIF
+    # index when it provides document symbols, and from the symbols service otherwise.
+    documentSymbols: [DocumentSymbol!]!
+}
+
+# A symbol declared within a document, along with the symbols nested within it.
+type DocumentSymbol {
+    # The name of the symbol.
+    name: String!
+
+    # Additional detail about the symbol, such as its signature.
+    detail: String
+
+    # The kind of the symbol.
+    kind: SymbolKind!
+
+    # The range enclosing the symbol, including its body.
+    range: Range!
+
+    # The range of the symbol's identifier.
+    selectionRange: Range!
+
+    # The symbols lexically nested within this symbol.
+    children: [DocumentSymbol!]!
 }
 
 # A highlighted file.
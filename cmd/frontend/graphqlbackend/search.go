@@ -59,6 +59,7 @@ type SearchArgs struct {
 	After          *string
 	First          *int32
 	VersionContext *string
+	Continuation   *string
 }
 
 type SearchImplementer interface {
@@ -131,11 +132,17 @@ func NewSearchImplementer(ctx context.Context, args *SearchArgs) (SearchImplemen
 		}
 	}
 
+	continuation, err := processContinuationRequest(args, queryInfo, searchType)
+	if err != nil {
+		return nil, err
+	}
+
 	return &searchResolver{
 		query:          queryInfo,
 		originalQuery:  args.Query,
 		versionContext: args.VersionContext,
 		pagination:     pagination,
+		continuation:   continuation,
 		patternType:    searchType,
 		zoekt:          search.Indexed(),
 		searcherURLs:   search.SearcherURLs(),
@@ -279,6 +286,7 @@ type searchResolver struct {
 	query          query.QueryInfo       // the query, either containing and/or expressions or otherwise ordinary
 	originalQuery  string                // the raw string of the original search query
 	pagination     *searchPaginationInfo // pagination information, or nil if the request is not paginated.
+	continuation   *searchContinuation   // the search to continue, or nil if the request is not continuable.
 	patternType    query.SearchType
 	versionContext *string
 
@@ -291,6 +299,10 @@ type searchResolver struct {
 
 	zoekt        *searchbackend.Zoekt
 	searcherURLs *endpoint.Map
+
+	// resultChannel, if non-nil, receives results as each search backend
+	// produces them. See StreamSearch.
+	resultChannel chan<- SearchEvent
 }
 
 // rawQuery returns the original query string input.
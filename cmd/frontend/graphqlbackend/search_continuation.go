@@ -0,0 +1,265 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// searchContinuation is a decoded search continuation cursor. It records the
+// unindexed repository revisions whose searcher results were cut off by the
+// result limit, along with the searcher cursor to continue each of them from.
+//
+// Searcher caches the matches of every page of a search, so continuing a
+// search reads the next pages from that cache instead of searching the
+// repository archives again. Zoekt has no equivalent, so the results of
+// indexed repositories cannot be continued.
+type searchContinuation struct {
+	// Query is the query of the search that returned the continuation. A
+	// continuation can only continue that search.
+	Query string
+
+	// Repos are the repository revisions to continue searching. The
+	// continuation that starts a continuable search has none.
+	Repos []searcherContinuation
+}
+
+// searcherContinuation continues the search of a single repository revision.
+type searcherContinuation struct {
+	Repo   api.RepoName
+	Rev    string       // the revision specifier the user requested
+	Commit api.CommitID // the commit Rev resolved to when the search started
+	Cursor string       // the searcher cursor of the next page
+}
+
+const searchContinuationKind = "SearchContinuation"
+
+// marshalSearchContinuation marshals a search continuation cursor.
+func marshalSearchContinuation(c *searchContinuation) string {
+	return string(relay.MarshalID(searchContinuationKind, c))
+}
+
+// unmarshalSearchContinuation unmarshals a search continuation cursor. The
+// empty cursor starts a continuable search.
+func unmarshalSearchContinuation(cursor *string) (*searchContinuation, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+	if *cursor == "" {
+		return &searchContinuation{}, nil
+	}
+	if kind := relay.UnmarshalKind(graphql.ID(*cursor)); kind != searchContinuationKind {
+		return nil, fmt.Errorf("cannot unmarshal search continuation type: %q", kind)
+	}
+	var c *searchContinuation
+	if err := relay.UnmarshalSpec(graphql.ID(*cursor), &c); err != nil {
+		return nil, err
+	}
+	if len(c.Repos) == 0 {
+		return nil, errors.New("search: continuation has no repositories to continue")
+	}
+	return c, nil
+}
+
+// processContinuationRequest decodes the continuation cursor of a search
+// request and checks that the search can be continued.
+func processContinuationRequest(args *SearchArgs, queryInfo query.QueryInfo, searchType query.SearchType) (*searchContinuation, error) {
+	continuation, err := unmarshalSearchContinuation(args.Continuation)
+	if err != nil || continuation == nil {
+		return nil, err
+	}
+	if args.First != nil || queryInfo.BoolValue(query.FieldStable) {
+		return nil, errors.New("search: 'continuation' cannot be combined with paginated or stable searches")
+	}
+	if _, ok := queryInfo.(*query.OrdinaryQuery); !ok {
+		return nil, errors.New("search: 'continuation' is not supported for queries containing and/or expressions")
+	}
+	if searchType == query.SearchTypeStructural {
+		return nil, errors.New("search: 'continuation' is not supported for structural search")
+	}
+	if len(continuation.Repos) > 0 && continuation.Query != args.Query {
+		return nil, errors.New("search: 'continuation' was returned by a search for a different query")
+	}
+	continuation.Query = args.Query
+	return continuation, nil
+}
+
+// nextContinuation returns the continuation of a continuable search that
+// stopped at the given searcher continuations. It is nil if the search is not
+// continuable or there is nothing left to continue.
+func (r *searchResolver) nextContinuation(repos []searcherContinuation) *searchContinuation {
+	if r.continuation == nil || len(repos) == 0 {
+		return nil
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		if repos[i].Repo != repos[j].Repo {
+			return repos[i].Repo < repos[j].Repo
+		}
+		return repos[i].Rev < repos[j].Rev
+	})
+	return &searchContinuation{Query: r.continuation.Query, Repos: repos}
+}
+
+// Continuation returns the cursor to continue the search with, or nil if the
+// search was not continuable or no unindexed repository has more results.
+func (sr *SearchResultsResolver) Continuation() *string {
+	if sr.continuation == nil {
+		return nil
+	}
+	c := marshalSearchContinuation(sr.continuation)
+	return &c
+}
+
+// continuedResults continues the searches of the unindexed repository
+// revisions of r.continuation with the next page of their searcher results.
+// Only searcher can continue a search, so indexed repositories and result types
+// other than text matches are not searched again.
+func (r *searchResolver) continuedResults(ctx context.Context) (_ *SearchResultsResolver, err error) {
+	tr, ctx := trace.New(ctx, "graphql.SearchResults.continuedResults", r.rawQuery())
+	tr.LogFields(otlog.Int("repos", len(r.continuation.Repos)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	start := time.Now()
+
+	ctx, cancel, err := r.withTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	options := &getPatternInfoOptions{}
+	if r.patternType == query.SearchTypeLiteral {
+		options = &getPatternInfoOptions{performLiteralSearch: true}
+	}
+	p, err := r.getPatternInfo(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, &badRequestError{err}
+	}
+
+	// 🚨 SECURITY: Repositories are looked up by name rather than trusting the
+	// cursor, so that repositories the current user cannot access are skipped
+	// like repositories that no longer exist.
+	var repos []*types.Repo
+	var continuations []searcherContinuation
+	for _, c := range r.continuation.Repos {
+		repo, err := db.Repos.GetByName(ctx, c.Repo)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		repos = append(repos, repo)
+		continuations = append(continuations, c)
+	}
+
+	var fetchTimeout time.Duration
+	if len(repos) == 1 || r.searchTimeoutFieldSet() {
+		// Like searchFilesInRepos, give a single repo or an explicit timeout
+		// the remaining deadline to fetch the archive.
+		deadline, _ := ctx.Deadline()
+		fetchTimeout = time.Until(deadline)
+	} else {
+		fetchTimeout = 500 * time.Millisecond
+	}
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		searchErr   error
+		fileMatches []*FileMatchResolver
+		next        []searcherContinuation
+	)
+	common := searchResultsCommon{
+		maxResultsCount: r.maxResults(),
+		repos:           repos,
+		partial:         make(map[api.RepoName]struct{}),
+	}
+	for i, repo := range repos {
+		limitCtx, limitDone, err := textSearchLimiter.Acquire(ctx)
+		if err != nil {
+			// The search timed out before this repository was searched, so
+			// it can be continued from the same page later.
+			mu.Lock()
+			common.timedout = append(common.timedout, repo)
+			next = append(next, continuations[i])
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(ctx context.Context, done context.CancelFunc, repo *types.Repo, c searcherContinuation) {
+			defer wg.Done()
+			defer done()
+
+			matches, limitHit, repoNext, err := continueSearchFilesInRepo(ctx, r.searcherURLs, repo, c, p, fetchTimeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if ctx.Err() == nil {
+				common.searched = append(common.searched, repo)
+			}
+			if limitHit {
+				common.partial[repo.Name] = struct{}{}
+			}
+			repoRev := &search.RepositoryRevisions{Repo: repo, Revs: []search.RevisionSpecifier{{RevSpec: c.Rev}}}
+			if fatalErr := handleRepoSearchResult(&common, repoRev, limitHit, false, err); fatalErr != nil {
+				if searchErr == nil {
+					searchErr = errors.Wrapf(fatalErr, "failed to search %s", repoRev.String())
+				}
+				return
+			}
+			if err != nil && (errcode.IsTimeout(err) || errcode.IsTemporary(err)) {
+				// Searching this repository again may succeed, so it can be
+				// continued from the same page later.
+				next = append(next, c)
+				return
+			}
+			common.resultCount += int32(len(matches))
+			fileMatches = append(fileMatches, matches...)
+			if repoNext != nil {
+				next = append(next, *repoNext)
+			}
+		}(limitCtx, limitDone, repo, continuations[i])
+	}
+	wg.Wait()
+
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	// 🚨 SECURITY: Remove results in paths the user cannot read.
+	results, err := filterResultsByPathPerms(ctx, fileMatchesToSearchResults(fileMatches))
+	if err != nil {
+		return nil, err
+	}
+	sortResults(results)
+
+	return &SearchResultsResolver{
+		start:               start,
+		searchResultsCommon: common,
+		SearchResults:       results,
+		continuation:        r.nextContinuation(next),
+	}, nil
+}
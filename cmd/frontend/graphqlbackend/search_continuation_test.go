@@ -0,0 +1,153 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestSearchContinuation_marshal(t *testing.T) {
+	want := &searchContinuation{
+		Query: "foo",
+		Repos: []searcherContinuation{{Repo: "r", Rev: "master", Commit: "c", Cursor: "o2"}},
+	}
+	cursor := marshalSearchContinuation(want)
+	got, err := unmarshalSearchContinuation(&cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if c, err := unmarshalSearchContinuation(nil); c != nil || err != nil {
+		t.Errorf("got %+v, %v for a nil cursor, want nil", c, err)
+	}
+
+	empty := ""
+	if c, err := unmarshalSearchContinuation(&empty); err != nil || c == nil || len(c.Repos) != 0 {
+		t.Errorf("got %+v, %v for the empty cursor, want a continuation without repos", c, err)
+	}
+
+	done := marshalSearchContinuation(&searchContinuation{Query: "foo"})
+	if _, err := unmarshalSearchContinuation(&done); err == nil {
+		t.Error("expected an error for a continuation without repos")
+	}
+}
+
+func TestSearchContinuation_processContinuationRequest(t *testing.T) {
+	cursor := marshalSearchContinuation(&searchContinuation{
+		Query: "foo",
+		Repos: []searcherContinuation{{Repo: "r", Rev: "master", Commit: "c", Cursor: "o2"}},
+	})
+	empty := ""
+	first := int32(10)
+
+	tests := []struct {
+		name       string
+		args       *SearchArgs
+		searchType query.SearchType
+		wantErr    bool
+	}{
+		{name: "continue", args: &SearchArgs{Query: "foo", Continuation: &cursor}},
+		{name: "start", args: &SearchArgs{Query: "bar", Continuation: &empty}},
+		{name: "different query", args: &SearchArgs{Query: "bar", Continuation: &cursor}, wantErr: true},
+		{name: "paginated", args: &SearchArgs{Query: "foo", Continuation: &cursor, First: &first}, wantErr: true},
+		{name: "stable", args: &SearchArgs{Query: "foo stable:yes", Continuation: &empty}, wantErr: true},
+		{name: "structural", args: &SearchArgs{Query: "foo", Continuation: &empty}, searchType: query.SearchTypeStructural, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := query.ParseAndCheck(test.args.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := processContinuationRequest(test.args, q, test.searchType)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got continuation %+v", c)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Query != test.args.Query {
+				t.Errorf("got query %q, want %q", c.Query, test.args.Query)
+			}
+		})
+	}
+}
+
+func TestSearchContinuation_continuedResults(t *testing.T) {
+	pages := map[string]string{
+		"o2": `{"Matches":[{"Path":"b.go"}],"LimitHit":true,"Cursor":"o3"}`,
+		"o3": `{"Matches":[{"Path":"c.go"}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("Cursor")]
+		if !ok {
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer ts.Close()
+
+	db.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == "deleted" {
+			return nil, &errcode.Mock{Message: "repo not found", IsNotFound: true}
+		}
+		return &types.Repo{ID: 1, Name: name}, nil
+	}
+	defer func() { db.Mocks.Repos = db.MockRepos{} }()
+
+	q, err := query.ParseAndCheck("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &searchResolver{
+		query:        q,
+		patternType:  query.SearchTypeLiteral,
+		searcherURLs: endpoint.Static(ts.URL),
+		continuation: &searchContinuation{
+			Query: "foo",
+			Repos: []searcherContinuation{
+				{Repo: "deleted", Rev: "master", Commit: "c", Cursor: "o2"},
+				{Repo: "r", Rev: "master", Commit: "c", Cursor: "o2"},
+			},
+		},
+	}
+
+	var paths []string
+	for i := 0; i < len(pages)+1; i++ {
+		results, err := r.continuedResults(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results.SearchResults {
+			if fm, ok := result.ToFileMatch(); ok {
+				paths = append(paths, fm.JPath)
+			}
+		}
+		if results.continuation == nil {
+			break
+		}
+		if n := len(results.continuation.Repos); n != 1 {
+			t.Fatalf("got %d repos to continue, want 1", n)
+		}
+		r.continuation = results.continuation
+	}
+	if want := []string{"b.go", "c.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
@@ -111,6 +111,7 @@ func reposToAdd(ctx context.Context, args *search.TextParameters, repos []*searc
 			newArgs.Repos = repos
 			newArgs.Query = q
 			newArgs.UseFullDeadline = true
+			newArgs.Continuable = false
 			matches, _, err := searchFilesInRepos(ctx, &newArgs)
 			if err != nil {
 				return nil, err
@@ -138,6 +139,7 @@ func reposToAdd(ctx context.Context, args *search.TextParameters, repos []*searc
 			newArgs.Repos = repos
 			newArgs.Query = q
 			newArgs.UseFullDeadline = true
+			newArgs.Continuable = false
 			matches, _, err := searchFilesInRepos(ctx, &newArgs)
 			if err != nil {
 				return nil, err
@@ -65,6 +65,11 @@ type searchResultsCommon struct {
 	timedout []*types.Repo
 
 	indexUnavailable bool // True if indexed search is enabled but was not available during this search.
+
+	// searcherContinuations continue the searches of unindexed repository
+	// revisions that have more results. They are only set by continuable
+	// searches.
+	searcherContinuations []searcherContinuation
 }
 
 func (c *searchResultsCommon) LimitHit() bool {
@@ -127,6 +132,7 @@ func (c *searchResultsCommon) update(other searchResultsCommon) {
 	c.excluded.archived = c.excluded.archived + other.excluded.archived
 	c.timedout = append(c.timedout, other.timedout...)
 	c.resultCount += other.resultCount
+	c.searcherContinuations = append(c.searcherContinuations, other.searcherContinuations...)
 
 	if c.partial == nil {
 		c.partial = make(map[api.RepoName]struct{})
@@ -167,6 +173,10 @@ type SearchResultsResolver struct {
 	// cursor to return for paginated search requests, or nil if the request
 	// wasn't paginated.
 	cursor *searchCursor
+
+	// continuation to return for continuable search requests, or nil if the
+	// request wasn't continuable or there is nothing left to continue.
+	continuation *searchContinuation
 }
 
 func (sr *SearchResultsResolver) Results() []SearchResultResolver {
@@ -631,6 +641,12 @@ func (r *searchResolver) evaluateLeaf(ctx context.Context) (*SearchResultsResolv
 		return r.paginatedResults(ctx)
 	}
 
+	// Continuing a search only searches the repositories it stopped at. See
+	// continuedResults for more details.
+	if r.continuation != nil && len(r.continuation.Repos) > 0 {
+		return r.continuedResults(ctx)
+	}
+
 	rr, err := r.resultsWithTimeoutSuggestion(ctx)
 	if rr != nil {
 		r.logSearchLatency(ctx, rr.ElapsedMilliseconds())
@@ -1274,6 +1290,7 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 	excludePatterns = append(excludePatterns, langExcludePatterns...)
 
 	languages, _ := q.StringValues(query.FieldLang)
+	symbolKinds, _ := q.StringValues(query.FieldKind)
 
 	patternInfo := &search.TextPatternInfo{
 		IsRegExp:                     isRegExp,
@@ -1285,6 +1302,7 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 		FilePatternsReposMustInclude: filePatternsReposMustInclude,
 		FilePatternsReposMustExclude: filePatternsReposMustExclude,
 		Languages:                    languages,
+		SymbolKinds:                  symbolKinds,
 		PathPatternsAreCaseSensitive: q.IsCaseSensitive(),
 		CombyRule:                    strings.Join(combyRule, ""),
 	}
@@ -1485,6 +1503,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		Repos:           repos,
 		Query:           r.query,
 		UseFullDeadline: r.searchTimeoutFieldSet(),
+		Continuable:     r.continuation != nil,
 		Zoekt:           r.zoekt,
 		SearcherURLs:    r.searcherURLs,
 	}
@@ -1570,6 +1589,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*repoCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, repoResults, repoCommon)
 			})
 		case "symbol":
 			wg := waitGroup(len(resultTypes) == 1)
@@ -1602,6 +1622,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*symbolsCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, fileMatchesToSearchResults(symbolFileMatches), symbolsCommon)
 			})
 		case "file", "path":
 			if searchedFileContentsOrPaths {
@@ -1658,6 +1679,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*fileCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, fileMatchesToSearchResults(fileResults), fileCommon)
 			})
 		case "diff":
 			wg := waitGroup(len(resultTypes) == 1)
@@ -1697,6 +1719,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*diffCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, diffResults, diffCommon)
 			})
 		case "commit":
 			wg := waitGroup(len(resultTypes) == 1)
@@ -1737,6 +1760,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*commitCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, commitResults, commitCommon)
 			})
 		case "codemod":
 			wg := waitGroup(true)
@@ -1761,6 +1785,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 					common.update(*codemodCommon)
 					commonMu.Unlock()
 				}
+				r.sendResults(ctx, codemodResults, codemodCommon)
 			})
 		}
 	}
@@ -1805,6 +1830,12 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		alert = alertForQuotesInQueryInLiteralMode(r.query.ParseTree())
 	}
 
+	// 🚨 SECURITY: Remove results in paths the user cannot read.
+	results, err = filterResultsByPathPerms(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+
 	// If we have some results, only log the error instead of returning it,
 	// because otherwise the client would not receive the partial results
 	if len(results) > 0 && multiErr != nil {
@@ -1812,13 +1843,18 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		multiErr = nil
 	}
 
-	sortResults(results)
+	// Symbol results are ranked by relevance across repositories, so we keep
+	// their order if they are the only results.
+	if len(resultTypes) != 1 || resultTypes[0] != "symbol" {
+		sortResults(results)
+	}
 
 	resultsResolver := SearchResultsResolver{
 		start:               start,
 		searchResultsCommon: common,
 		SearchResults:       results,
 		alert:               alert,
+		continuation:        r.nextContinuation(common.searcherContinuations),
 	}
 
 	return &resultsResolver, multiErr.ErrorOrNil()
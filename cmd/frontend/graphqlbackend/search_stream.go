@@ -0,0 +1,126 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// SearchEvent is a batch of search results sent while a search is in
+// progress.
+//
+// Each event carries the results and statistics produced by a single search
+// backend (e.g. indexed text search, symbol search, or commit search). A file
+// may appear in more than one event when several backends match it. Alerts and
+// continuations are only attached to the final event of a search.
+type SearchEvent struct {
+	Results []SearchResultResolver
+	Stats   searchResultsCommon
+	Alert   *searchAlert
+
+	// Continuation continues a continuable search. See
+	// SearchResultsResolver.Continuation.
+	Continuation *string
+}
+
+// StreamSearch runs the search described by args and sends results to events
+// as each search backend produces them, followed by a final event carrying the
+// search alert (if any). It uses the same search pipeline and limits as the
+// GraphQL search resolver and returns once the search has completed. The
+// events channel is not closed.
+//
+// Searches that cannot be streamed, such as paginated searches, continued
+// searches and queries containing and/or expressions, are sent as a single
+// event once complete.
+func StreamSearch(ctx context.Context, args *SearchArgs, events chan<- SearchEvent) error {
+	impl, err := NewSearchImplementer(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	r, ok := impl.(*searchResolver)
+	if !ok || !r.streamable() {
+		rr, err := impl.Results(ctx)
+		if err != nil {
+			return err
+		}
+
+		return sendSearchEvent(ctx, events, SearchEvent{
+			Results:      rr.SearchResults,
+			Stats:        rr.searchResultsCommon,
+			Alert:        rr.alert,
+			Continuation: rr.Continuation(),
+		})
+	}
+
+	r.resultChannel = events
+	rr, err := r.Results(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Results and statistics have already been streamed as each backend
+	// completed, so only the alert and continuation remain to be sent.
+	return sendSearchEvent(ctx, events, SearchEvent{Alert: rr.alert, Continuation: rr.Continuation()})
+}
+
+// streamable returns true if results can be sent to the result channel as
+// each backend produces them. Paginated and and/or searches post-process the
+// results of several underlying searches, so their partial results are not
+// meaningful on their own. Continued searches only search a single backend.
+func (r *searchResolver) streamable() bool {
+	if _, ok := r.query.(*query.OrdinaryQuery); !ok {
+		return false
+	}
+	if r.continuation != nil && len(r.continuation.Repos) > 0 {
+		return false
+	}
+
+	return r.pagination == nil && !r.query.BoolValue(query.FieldStable)
+}
+
+// sendResults sends the results and statistics produced by a single search
+// backend to the result channel, if the search is being streamed.
+//
+// 🚨 SECURITY: The results are filtered by the sub-repository permissions of
+// the current user before they are sent, the same way as the aggregated
+// results. If filtering fails, no results are sent; the error surfaces when
+// the aggregated results are filtered.
+func (r *searchResolver) sendResults(ctx context.Context, results []SearchResultResolver, common *searchResultsCommon) {
+	if r.resultChannel == nil {
+		return
+	}
+
+	// filterResultsByPathPerms filters in place, but results are also
+	// aggregated by the caller, so filter a copy.
+	results, err := filterResultsByPathPerms(ctx, append([]SearchResultResolver(nil), results...))
+	if err != nil {
+		results = nil
+	}
+
+	event := SearchEvent{Results: results}
+	if common != nil {
+		event.Stats = *common
+	}
+
+	_ = sendSearchEvent(ctx, r.resultChannel, event)
+}
+
+// sendSearchEvent sends event to events, giving up once ctx is done.
+func sendSearchEvent(ctx context.Context, events chan<- SearchEvent, event SearchEvent) error {
+	select {
+	case events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fileMatchesToSearchResults converts file matches into search results.
+func fileMatchesToSearchResults(fileMatches []*FileMatchResolver) []SearchResultResolver {
+	results := make([]SearchResultResolver, 0, len(fileMatches))
+	for _, fm := range fileMatches {
+		results = append(results, fm)
+	}
+	return results
+}
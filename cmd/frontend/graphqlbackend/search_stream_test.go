@@ -0,0 +1,97 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestSearchResolverStreamable(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		paginated  bool
+		streamable bool
+	}{
+		{name: "ordinary", query: "foo", streamable: true},
+		{name: "paginated", query: "foo", paginated: true, streamable: false},
+		{name: "stable", query: "foo stable:yes", streamable: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := query.ParseAndCheck(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &searchResolver{query: q}
+			if test.paginated {
+				r.pagination = &searchPaginationInfo{}
+			}
+			if streamable := r.streamable(); streamable != test.streamable {
+				t.Errorf("unexpected streamable. want=%v have=%v", test.streamable, streamable)
+			}
+		})
+	}
+}
+
+func TestSearchResolverSendResults(t *testing.T) {
+	// Results are dropped when the search is not being streamed.
+	(&searchResolver{}).sendResults(context.Background(), nil, nil)
+
+	events := make(chan SearchEvent, 1)
+	r := &searchResolver{resultChannel: events}
+	results := fileMatchesToSearchResults([]*FileMatchResolver{{JPath: "main.go"}})
+	r.sendResults(context.Background(), results, &searchResultsCommon{limitHit: true})
+
+	event := <-events
+	if len(event.Results) != 1 {
+		t.Fatalf("unexpected result count. want=%d have=%d", 1, len(event.Results))
+	}
+	if !event.Stats.LimitHit() {
+		t.Errorf("expected limit hit")
+	}
+
+	// Sending gives up once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = &searchResolver{resultChannel: make(chan SearchEvent)}
+	r.sendResults(ctx, results, nil)
+}
+
+func TestSearchResolverSendResultsPathPerms(t *testing.T) {
+	restricted, err := authz.NewPathMatcher(&authz.SubRepoPermissions{PathExcludes: []string{"secret/**"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.MockAuthzPathMatcher = func(_ context.Context, repoID api.RepoID) (*authz.PathMatcher, error) {
+		return restricted, nil
+	}
+	defer func() { db.MockAuthzPathMatcher = nil }()
+
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "repo"}}
+	public := &FileMatchResolver{JPath: "main.go", Repo: repo}
+	secret := &FileMatchResolver{JPath: "secret/key", Repo: repo}
+
+	events := make(chan SearchEvent, 1)
+	r := &searchResolver{resultChannel: events}
+	results := fileMatchesToSearchResults([]*FileMatchResolver{secret, public})
+	r.sendResults(context.Background(), results, nil)
+
+	event := <-events
+	if want := []SearchResultResolver{public}; !reflect.DeepEqual(event.Results, want) {
+		t.Errorf("unexpected streamed results. want=%v have=%v", want, event.Results)
+	}
+
+	// The results aggregated by the caller are left untouched.
+	if want := []SearchResultResolver{secret, public}; !reflect.DeepEqual(results, want) {
+		t.Errorf("unexpected aggregated results. want=%v have=%v", want, results)
+	}
+}
@@ -216,6 +216,11 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 		if err != nil {
 			return nil, err
 		}
+		// 🚨 SECURITY: Remove symbols in paths the user cannot read.
+		fileMatches, err = filterFileMatchesByPathPerms(ctx, fileMatches)
+		if err != nil {
+			return nil, err
+		}
 
 		results = make([]*searchSuggestionResolver, 0)
 		for _, fileMatch := range fileMatches {
@@ -227,7 +232,7 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 				if len(sr.symbol.Name) < 12 {
 					score++
 				}
-				switch ctagsKindToLSPSymbolKind(sr.symbol.Kind) {
+				switch CtagsKindToLSPSymbolKind(sr.symbol.Kind) {
 				case lsp.SKFunction, lsp.SKMethod:
 					score += 2
 				case lsp.SKClass:
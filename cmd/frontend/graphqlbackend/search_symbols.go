@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -45,6 +46,11 @@ var mockSearchSymbols func(ctx context.Context, args *search.TextParameters, lim
 // searchSymbols searches the given repos in parallel for symbols matching the given search query
 // it can be used for both search suggestions and search results
 //
+// Indexed repositories are searched with a single Zoekt query. Unindexed repositories are still
+// searched one at a time by the symbols service, limited to maxUnindexedRepoRevSearchesPerQuery.
+// The results of all repositories are collected before they are ranked and limited, so that the
+// limit keeps the most relevant symbols rather than the ones that arrived first.
+//
 // May return partial results and an error
 func searchSymbols(ctx context.Context, args *search.TextParameters, limit int) (res []*FileMatchResolver, common *searchResultsCommon, err error) {
 	if mockSearchSymbols != nil {
@@ -97,27 +103,15 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 		run = parallel.NewRun(conf.SearchSymbolsParallelism())
 		mu  sync.Mutex
 
-		unflattened       [][]*FileMatchResolver
-		flattenedSize     int
-		overLimitCanceled bool
+		allMatches []*FileMatchResolver
 	)
 
+	// Matches are not limited as they arrive: each backend returns a bounded
+	// number of symbols, and the limits are applied after ranking below.
 	addMatches := func(matches []*FileMatchResolver) {
 		if len(matches) > 0 {
 			common.resultCount += int32(len(matches))
-			sort.Slice(matches, func(i, j int) bool {
-				a, b := matches[i].uri, matches[j].uri
-				return a > b
-			})
-			unflattened = append(unflattened, matches)
-			flattenedSize += len(matches)
-
-			if flattenedSize > int(args.PatternInfo.FileMatchLimit) {
-				tr.LazyPrintf("cancel due to result size: %d > %d", flattenedSize, args.PatternInfo.FileMatchLimit)
-				overLimitCanceled = true
-				common.limitHit = true
-				cancelAll()
-			}
+			allMatches = append(allMatches, matches...)
 		}
 	}
 
@@ -139,8 +133,8 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 		if limitHit {
 			common.limitHit = true
 		}
-		tr.LogFields(otlog.Object("searchErr", searchErr), otlog.Error(err), otlog.Bool("overLimitCanceled", overLimitCanceled))
-		if searchErr != nil && err == nil && !overLimitCanceled {
+		tr.LogFields(otlog.Object("searchErr", searchErr), otlog.Error(err))
+		if searchErr != nil && err == nil {
 			err = searchErr
 			tr.LazyPrintf("cancel indexed symbol search due to error: %v", err)
 		}
@@ -180,12 +174,125 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 		})
 	}
 	err = run.Wait()
-	flattened := flattenFileMatches(unflattened, int(args.PatternInfo.FileMatchLimit))
-	res2 := limitSymbolResults(flattened, limit)
-	common.limitHit = symbolCount(res2) < symbolCount(res)
+
+	// Rank the matches of all repositories together, so that the limits below
+	// keep the most relevant symbols.
+	rankSymbolMatches(allMatches, args.PatternInfo)
+	if len(allMatches) > int(args.PatternInfo.FileMatchLimit) {
+		tr.LazyPrintf("limiting result size: %d > %d", len(allMatches), args.PatternInfo.FileMatchLimit)
+		allMatches = allMatches[:args.PatternInfo.FileMatchLimit]
+		common.limitHit = true
+	}
+	res2 := limitSymbolResults(allMatches, limit)
+	common.limitHit = common.limitHit || symbolCount(res2) < symbolCount(allMatches)
 	return res2, common, err
 }
 
+// filterSymbolKinds returns the symbols whose kind is one of kinds (see
+// symbolKindMatches). All symbols are returned if kinds is empty.
+func filterSymbolKinds(symbols []*searchSymbolResult, kinds []string) []*searchSymbolResult {
+	if len(kinds) == 0 {
+		return symbols
+	}
+	filtered := symbols[:0]
+	for _, s := range symbols {
+		if symbolKindMatches(s.symbol.Kind, kinds) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// rankSymbolMatches sorts fileMatches so that the most relevant symbols come
+// first, regardless of the repository they are in. The symbols of each file
+// are sorted the same way, and a file ranks as high as its best symbol.
+func rankSymbolMatches(fileMatches []*FileMatchResolver, patternInfo *search.TextPatternInfo) {
+	literal := symbolPatternLiteral(patternInfo)
+	fileScores := make(map[*FileMatchResolver]int, len(fileMatches))
+	for _, fm := range fileMatches {
+		scores := make(map[*searchSymbolResult]int, len(fm.symbols))
+		for _, s := range fm.symbols {
+			scores[s] = symbolScore(s.symbol, literal)
+		}
+		sort.SliceStable(fm.symbols, func(i, j int) bool {
+			return scores[fm.symbols[i]] > scores[fm.symbols[j]]
+		})
+		if len(fm.symbols) > 0 {
+			fileScores[fm] = scores[fm.symbols[0]]
+		}
+	}
+	sort.SliceStable(fileMatches, func(i, j int) bool {
+		a, b := fileMatches[i], fileMatches[j]
+		if fileScores[a] != fileScores[b] {
+			return fileScores[a] > fileScores[b]
+		}
+		if len(a.JPath) != len(b.JPath) {
+			return len(a.JPath) < len(b.JPath)
+		}
+		return a.uri < b.uri
+	})
+}
+
+// symbolPatternLiteral returns the symbol name the search pattern matches
+// exactly, ignoring anchors. It returns the empty string if the pattern is a
+// regular expression that matches more than one name.
+func symbolPatternLiteral(patternInfo *search.TextPatternInfo) string {
+	if !patternInfo.IsRegExp {
+		return patternInfo.Pattern
+	}
+	pattern := strings.TrimSuffix(strings.TrimPrefix(patternInfo.Pattern, "^"), "$")
+	if regexp.QuoteMeta(pattern) != pattern {
+		return ""
+	}
+	return pattern
+}
+
+// symbolScore is the relevance of symbol for a search for literal. Symbols
+// named literal score highest, followed by symbols whose name starts with it.
+// Definitions of types and functions score higher than variables, and symbols
+// in vendored code score lower.
+func symbolScore(symbol protocol.Symbol, literal string) int {
+	score := 0
+	if literal != "" {
+		switch {
+		case symbol.Name == literal:
+			score += 30
+		case strings.EqualFold(symbol.Name, literal):
+			score += 20
+		case strings.HasPrefix(strings.ToLower(symbol.Name), strings.ToLower(literal)):
+			score += 10
+		}
+	}
+
+	switch CtagsKindToLSPSymbolKind(symbol.Kind) {
+	case lsp.SKClass, lsp.SKInterface, lsp.SKStruct, lsp.SKEnum:
+		score += 5
+	case lsp.SKFunction, lsp.SKMethod, lsp.SKConstructor:
+		score += 4
+	case lsp.SKModule, lsp.SKNamespace, lsp.SKPackage:
+		score += 3
+	case lsp.SKConstant:
+		score += 2
+	case lsp.SKField, lsp.SKProperty, lsp.SKVariable, lsp.SKEnumMember:
+		score += 1
+	}
+
+	if isVendoredPath(symbol.Path) {
+		score -= 5
+	}
+	return score
+}
+
+// isVendoredPath reports whether path is in a directory of third-party code.
+func isVendoredPath(path string) bool {
+	for _, dir := range []string{"vendor", "node_modules", "third_party"} {
+		if strings.HasPrefix(path, dir+"/") || strings.Contains(path, "/"+dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // limitSymbolResults returns a new version of res containing no more than limit symbol matches.
 func limitSymbolResults(res []*FileMatchResolver, limit int) []*FileMatchResolver {
 	res2 := make([]*FileMatchResolver, 0, len(res))
@@ -258,6 +365,7 @@ func searchSymbolsInRepo(ctx context.Context, repoRevs *search.RepositoryRevisio
 		IsRegExp:        patternInfo.IsRegExp,
 		IncludePatterns: patternInfo.IncludePatterns,
 		ExcludePattern:  patternInfo.ExcludePattern,
+		Kinds:           expandSymbolKinds(patternInfo.SymbolKinds),
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
 	})
@@ -302,7 +410,8 @@ func makeFileMatchURIFromSymbol(symbolResult *searchSymbolResult, inputRev strin
 	return uri
 }
 
-func symbolRange(s protocol.Symbol) lsp.Range {
+// SymbolRange returns the range of the name of the given ctags symbol.
+func SymbolRange(s protocol.Symbol) lsp.Range {
 	ch := ctagsSymbolCharacter(s)
 	return lsp.Range{
 		Start: lsp.Position{Line: s.Line - 1, Character: ch},
@@ -324,62 +433,123 @@ func ctagsSymbolCharacter(s protocol.Symbol) int {
 	return 0
 }
 
-func ctagsKindToLSPSymbolKind(kind string) lsp.SymbolKind {
-	// Ctags kinds are determined by the parser and do not (in general) match LSP symbol kinds.
-	switch strings.ToLower(kind) {
-	case "file":
-		return lsp.SKFile
-	case "module":
-		return lsp.SKModule
-	case "namespace":
-		return lsp.SKNamespace
-	case "package", "packagename", "subprogspec":
-		return lsp.SKPackage
-	case "class", "type", "service", "typedef", "union", "section", "subtype", "component":
-		return lsp.SKClass
-	case "method", "methodspec":
-		return lsp.SKMethod
-	case "property":
-		return lsp.SKProperty
-	case "field", "member", "anonmember", "recordfield":
-		return lsp.SKField
-	case "constructor":
-		return lsp.SKConstructor
-	case "enum", "enumerator":
-		return lsp.SKEnum
-	case "interface":
-		return lsp.SKInterface
-	case "function", "func", "subroutine", "macro", "subprogram", "procedure", "command", "singletonmethod":
-		return lsp.SKFunction
-	case "variable", "var", "functionvar", "define", "alias", "val":
-		return lsp.SKVariable
-	case "constant", "const":
-		return lsp.SKConstant
-	case "string", "message", "heredoc":
-		return lsp.SKString
-	case "number":
-		return lsp.SKNumber
-	case "bool", "boolean":
-		return lsp.SKBoolean
-	case "array":
-		return lsp.SKArray
-	case "object", "literal", "map":
-		return lsp.SKObject
-	case "key", "label", "target", "selector", "id", "tag":
-		return lsp.SKKey
-	case "null":
-		return lsp.SKNull
-	case "enum member", "enumconstant":
-		return lsp.SKEnumMember
-	case "struct":
-		return lsp.SKStruct
-	case "event":
-		return lsp.SKEvent
-	case "operator":
-		return lsp.SKOperator
-	case "type parameter", "annotation":
-		return lsp.SKTypeParameter
+// ctagsKinds maps ctags symbol kinds to the closest LSP symbol kinds. Ctags
+// kinds are determined by the parser and do not (in general) match LSP symbol
+// kinds.
+var ctagsKinds = map[string]lsp.SymbolKind{
+	"file":            lsp.SKFile,
+	"module":          lsp.SKModule,
+	"namespace":       lsp.SKNamespace,
+	"package":         lsp.SKPackage,
+	"packagename":     lsp.SKPackage,
+	"subprogspec":     lsp.SKPackage,
+	"class":           lsp.SKClass,
+	"type":            lsp.SKClass,
+	"service":         lsp.SKClass,
+	"typedef":         lsp.SKClass,
+	"union":           lsp.SKClass,
+	"section":         lsp.SKClass,
+	"subtype":         lsp.SKClass,
+	"component":       lsp.SKClass,
+	"method":          lsp.SKMethod,
+	"methodspec":      lsp.SKMethod,
+	"property":        lsp.SKProperty,
+	"field":           lsp.SKField,
+	"member":          lsp.SKField,
+	"anonmember":      lsp.SKField,
+	"recordfield":     lsp.SKField,
+	"constructor":     lsp.SKConstructor,
+	"enum":            lsp.SKEnum,
+	"enumerator":      lsp.SKEnum,
+	"interface":       lsp.SKInterface,
+	"function":        lsp.SKFunction,
+	"func":            lsp.SKFunction,
+	"subroutine":      lsp.SKFunction,
+	"macro":           lsp.SKFunction,
+	"subprogram":      lsp.SKFunction,
+	"procedure":       lsp.SKFunction,
+	"command":         lsp.SKFunction,
+	"singletonmethod": lsp.SKFunction,
+	"variable":        lsp.SKVariable,
+	"var":             lsp.SKVariable,
+	"functionvar":     lsp.SKVariable,
+	"define":          lsp.SKVariable,
+	"alias":           lsp.SKVariable,
+	"val":             lsp.SKVariable,
+	"constant":        lsp.SKConstant,
+	"const":           lsp.SKConstant,
+	"string":          lsp.SKString,
+	"message":         lsp.SKString,
+	"heredoc":         lsp.SKString,
+	"number":          lsp.SKNumber,
+	"bool":            lsp.SKBoolean,
+	"boolean":         lsp.SKBoolean,
+	"array":           lsp.SKArray,
+	"object":          lsp.SKObject,
+	"literal":         lsp.SKObject,
+	"map":             lsp.SKObject,
+	"key":             lsp.SKKey,
+	"label":           lsp.SKKey,
+	"target":          lsp.SKKey,
+	"selector":        lsp.SKKey,
+	"id":              lsp.SKKey,
+	"tag":             lsp.SKKey,
+	"null":            lsp.SKNull,
+	"enum member":     lsp.SKEnumMember,
+	"enumconstant":    lsp.SKEnumMember,
+	"struct":          lsp.SKStruct,
+	"event":           lsp.SKEvent,
+	"operator":        lsp.SKOperator,
+	"type parameter":  lsp.SKTypeParameter,
+	"annotation":      lsp.SKTypeParameter,
+}
+
+// CtagsKindToLSPSymbolKind converts a ctags symbol kind into the closest LSP symbol kind.
+// Zero is returned for unknown kinds.
+func CtagsKindToLSPSymbolKind(kind string) lsp.SymbolKind {
+	if k, ok := ctagsKinds[strings.ToLower(kind)]; ok {
+		return k
 	}
 	log15.Debug("Unknown ctags kind", "kind", kind)
 	return 0
 }
+
+// symbolKindMatches reports whether the ctags kind of a symbol is one of the
+// kinds of a kind: filter. Both are compared by their LSP symbol kind, so that
+// e.g. kind:function matches Go's "func" symbols. Kinds without an LSP
+// equivalent are compared by name.
+func symbolKindMatches(kind string, kinds []string) bool {
+	lspKind := ctagsKinds[strings.ToLower(kind)]
+	for _, k := range kinds {
+		if strings.EqualFold(kind, k) || lspKind != 0 && ctagsKinds[strings.ToLower(k)] == lspKind {
+			return true
+		}
+	}
+	return false
+}
+
+// expandSymbolKinds returns the lowercase ctags kinds that symbolKindMatches
+// matches for kinds, for backends that filter kinds by name, such as the
+// symbols service.
+func expandSymbolKinds(kinds []string) []string {
+	if len(kinds) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(kinds))
+	for _, k := range kinds {
+		set[strings.ToLower(k)] = struct{}{}
+		if lspKind := ctagsKinds[strings.ToLower(k)]; lspKind != 0 {
+			for ctagsKind, other := range ctagsKinds {
+				if other == lspKind {
+					set[ctagsKind] = struct{}{}
+				}
+			}
+		}
+	}
+	expanded := make([]string, 0, len(set))
+	for k := range set {
+		expanded = append(expanded, k)
+	}
+	sort.Strings(expanded)
+	return expanded
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/gituri"
+	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
@@ -257,3 +258,114 @@ func TestLimitingSymbolResults(t *testing.T) {
 		}
 	})
 }
+
+func TestFilterSymbolKinds(t *testing.T) {
+	newSymbols := func() []*searchSymbolResult {
+		return []*searchSymbolResult{
+			{symbol: protocol.Symbol{Name: "a", Kind: "function"}},
+			{symbol: protocol.Symbol{Name: "b", Kind: "Class"}},
+			{symbol: protocol.Symbol{Name: "c", Kind: "variable"}},
+			{symbol: protocol.Symbol{Name: "d", Kind: "func"}},
+			{symbol: protocol.Symbol{Name: "e", Kind: "typedef"}},
+			{symbol: protocol.Symbol{Name: "f", Kind: "unknownkind"}},
+		}
+	}
+	names := func(symbols []*searchSymbolResult) []string {
+		var names []string
+		for _, s := range symbols {
+			names = append(names, s.symbol.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		kinds []string
+		want  []string
+	}{
+		{kinds: nil, want: []string{"a", "b", "c", "d", "e", "f"}},
+		{kinds: []string{"class"}, want: []string{"b", "e"}},
+		{kinds: []string{"type"}, want: []string{"b", "e"}},
+		{kinds: []string{"FUNCTION", "variable"}, want: []string{"a", "c", "d"}},
+		{kinds: []string{"func"}, want: []string{"a", "d"}},
+		{kinds: []string{"unknownKind"}, want: []string{"f"}},
+		{kinds: []string{"struct"}, want: nil},
+	}
+	for _, test := range tests {
+		if got := names(filterSymbolKinds(newSymbols(), test.kinds)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("kinds %v: got %v, want %v", test.kinds, got, test.want)
+		}
+	}
+}
+
+func TestExpandSymbolKinds(t *testing.T) {
+	if got := expandSymbolKinds(nil); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+
+	got := expandSymbolKinds([]string{"Function", "unknownKind"})
+	want := []string{"command", "func", "function", "macro", "procedure", "singletonmethod", "subprogram", "subroutine", "unknownkind"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected kinds (-want +got):\n%s", diff)
+	}
+}
+
+func TestRankSymbolMatches(t *testing.T) {
+	fileMatch := func(uri string, symbols ...protocol.Symbol) *FileMatchResolver {
+		fm := &FileMatchResolver{JPath: symbols[0].Path, uri: uri}
+		for _, s := range symbols {
+			fm.symbols = append(fm.symbols, &searchSymbolResult{symbol: s})
+		}
+		return fm
+	}
+	fileMatches := []*FileMatchResolver{
+		fileMatch("git://a#util.go",
+			protocol.Symbol{Name: "newParserOptions", Kind: "function", Path: "util.go"},
+		),
+		fileMatch("git://b#vendor/parser/parser.go",
+			protocol.Symbol{Name: "NewParser", Kind: "function", Path: "vendor/parser/parser.go"},
+		),
+		fileMatch("git://c#parse.go",
+			protocol.Symbol{Name: "p", Kind: "variable", Path: "parse.go"},
+			protocol.Symbol{Name: "NewParser", Kind: "function", Path: "parse.go"},
+		),
+		fileMatch("git://d#parser.go",
+			protocol.Symbol{Name: "newparser", Kind: "variable", Path: "parser.go"},
+		),
+	}
+
+	rankSymbolMatches(fileMatches, &search.TextPatternInfo{Pattern: "^NewParser$", IsRegExp: true})
+
+	var got []string
+	for _, fm := range fileMatches {
+		for _, s := range fm.symbols {
+			got = append(got, fm.uri+":"+s.symbol.Name)
+		}
+	}
+	want := []string{
+		"git://c#parse.go:NewParser",
+		"git://c#parse.go:p",
+		"git://b#vendor/parser/parser.go:NewParser",
+		"git://d#parser.go:newparser",
+		"git://a#util.go:newParserOptions",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected ranking (-want +got):\n%s", diff)
+	}
+}
+
+func TestSymbolPatternLiteral(t *testing.T) {
+	tests := []struct {
+		patternInfo search.TextPatternInfo
+		want        string
+	}{
+		{search.TextPatternInfo{Pattern: "foo.bar"}, "foo.bar"},
+		{search.TextPatternInfo{Pattern: "^foo$", IsRegExp: true}, "foo"},
+		{search.TextPatternInfo{Pattern: "foo", IsRegExp: true}, "foo"},
+		{search.TextPatternInfo{Pattern: "^foo.*$", IsRegExp: true}, ""},
+	}
+	for _, test := range tests {
+		if got := symbolPatternLiteral(&test.patternInfo); got != test.want {
+			t.Errorf("symbolPatternLiteral(%+v) = %q, want %q", test.patternInfo, got, test.want)
+		}
+	}
+}
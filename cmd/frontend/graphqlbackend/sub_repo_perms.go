@@ -0,0 +1,140 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// errPathNotFound returns the error for a path that the current user cannot
+// read. It is the same error as for a path that does not exist, so that users
+// cannot learn about paths they cannot read.
+func errPathNotFound(path string) error {
+	return &os.PathError{Op: "stat", Path: path, Err: os.ErrNotExist}
+}
+
+// checkPathPerms returns an error if the current user cannot read the file
+// (or, if isDir, the directory) at path in the repository.
+func checkPathPerms(ctx context.Context, repoID api.RepoID, path string, isDir bool) error {
+	m, err := db.AuthzPathMatcher(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if isDir && !m.CanReadDir(path) || !isDir && !m.CanReadPath(path) {
+		return errPathNotFound(path)
+	}
+	return nil
+}
+
+// pathMatcherCache memoizes the path matchers of the current user for
+// multiple repositories.
+type pathMatcherCache map[api.RepoID]*authz.PathMatcher
+
+func (c pathMatcherCache) get(ctx context.Context, repoID api.RepoID) (*authz.PathMatcher, error) {
+	if m, ok := c[repoID]; ok {
+		return m, nil
+	}
+	m, err := db.AuthzPathMatcher(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	c[repoID] = m
+	return m, nil
+}
+
+// filterResultsByPathPerms removes the search results in paths that the
+// current user cannot read, preserving the order of results. This covers file
+// matches from searcher and Zoekt as well as symbol results.
+//
+// Diffs of commit search results are not filtered by path, so they are removed
+// entirely from repositories in which the user cannot read some paths.
+//
+// NOTE: The results slice is filtered in place and returned. Do not use it
+// after calling this function.
+func filterResultsByPathPerms(ctx context.Context, results []SearchResultResolver) ([]SearchResultResolver, error) {
+	if !db.AuthzPathsMayBeRestricted(ctx) {
+		return results, nil
+	}
+
+	matchers := pathMatcherCache{}
+	filtered := results[:0]
+	for _, result := range results {
+		switch r := result.(type) {
+		case *FileMatchResolver:
+			m, err := matchers.get(ctx, r.Repo.repo.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !m.CanReadPath(r.JPath) {
+				continue
+			}
+		case *commitSearchResultResolver:
+			if r.diffPreview != nil {
+				m, err := matchers.get(ctx, r.commit.repoResolver.repo.ID)
+				if err != nil {
+					return nil, err
+				}
+				if m != nil {
+					continue
+				}
+			}
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered, nil
+}
+
+// filterFileMatchesByPathPerms removes the file matches in paths that the
+// current user cannot read, preserving their order.
+//
+// NOTE: The fileMatches slice is filtered in place and returned. Do not use it
+// after calling this function.
+func filterFileMatchesByPathPerms(ctx context.Context, fileMatches []*FileMatchResolver) ([]*FileMatchResolver, error) {
+	if !db.AuthzPathsMayBeRestricted(ctx) {
+		return fileMatches, nil
+	}
+
+	matchers := pathMatcherCache{}
+	filtered := fileMatches[:0]
+	for _, fm := range fileMatches {
+		m, err := matchers.get(ctx, fm.Repo.repo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if m.CanReadPath(fm.JPath) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, nil
+}
+
+// filterSymbolsByPathPerms removes the symbols in paths of the repository that
+// the current user cannot read.
+func filterSymbolsByPathPerms(ctx context.Context, repoID api.RepoID, symbols []*symbolResolver) ([]*symbolResolver, error) {
+	m, err := db.AuthzPathMatcher(ctx, repoID)
+	if err != nil || m == nil {
+		return symbols, err
+	}
+	filtered := symbols[:0]
+	for _, s := range symbols {
+		if m.CanReadPath(s.symbol.Path) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
+// canReadFileDiff reports whether m allows reading both sides of fileDiff.
+func canReadFileDiff(m *authz.PathMatcher, fileDiff *diff.FileDiff) bool {
+	for _, p := range []*string{diffPathOrNull(fileDiff.OrigName), diffPathOrNull(fileDiff.NewName)} {
+		if p != nil && !m.CanReadPath(*p) {
+			return false
+		}
+	}
+	return true
+}
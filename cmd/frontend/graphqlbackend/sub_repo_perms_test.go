@@ -0,0 +1,48 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestFilterResultsByPathPerms(t *testing.T) {
+	restricted, err := authz.NewPathMatcher(&authz.SubRepoPermissions{PathExcludes: []string{"secret/**"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := map[api.RepoID]int{}
+	db.MockAuthzPathMatcher = func(_ context.Context, repoID api.RepoID) (*authz.PathMatcher, error) {
+		calls[repoID]++
+		if repoID == 1 {
+			return restricted, nil
+		}
+		return nil, nil
+	}
+	defer func() { db.MockAuthzPathMatcher = nil }()
+
+	repo1 := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "repo1"}}
+	repo2 := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "repo2"}}
+	public1 := &FileMatchResolver{JPath: "a.go", Repo: repo1}
+	secret1 := &FileMatchResolver{JPath: "secret/key", Repo: repo1}
+	secret2 := &FileMatchResolver{JPath: "secret/key", Repo: repo2}
+	diff1 := &commitSearchResultResolver{commit: &GitCommitResolver{repoResolver: repo1}, diffPreview: &highlightedString{}}
+	commit1 := &commitSearchResultResolver{commit: &GitCommitResolver{repoResolver: repo1}}
+
+	results, err := filterResultsByPathPerms(context.Background(), []SearchResultResolver{public1, secret1, secret2, diff1, commit1, repo1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SearchResultResolver{public1, secret2, commit1, repo1}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("got %v, want %v", results, want)
+	}
+	if want := (map[api.RepoID]int{1: 1, 2: 1}); !reflect.DeepEqual(calls, want) {
+		t.Errorf("got matcher calls %v, want %v", calls, want)
+	}
+}
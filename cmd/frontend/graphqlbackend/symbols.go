@@ -28,6 +28,11 @@ func (r *GitTreeEntryResolver) Symbols(ctx context.Context, args *symbolsArgs) (
 	if err != nil && len(symbols) == 0 {
 		return nil, err
 	}
+	// 🚨 SECURITY: Hide symbols in paths the user cannot read.
+	symbols, err = filterSymbolsByPathPerms(ctx, r.commit.repoResolver.repo.ID, symbols)
+	if err != nil {
+		return nil, err
+	}
 	return &symbolConnectionResolver{symbols: symbols, first: args.First}, nil
 }
 
@@ -36,6 +41,11 @@ func (r *GitCommitResolver) Symbols(ctx context.Context, args *symbolsArgs) (*sy
 	if err != nil && len(symbols) == 0 {
 		return nil, err
 	}
+	// 🚨 SECURITY: Hide symbols in paths the user cannot read.
+	symbols, err = filterSymbolsByPathPerms(ctx, r.repoResolver.repo.ID, symbols)
+	if err != nil {
+		return nil, err
+	}
 	return &symbolConnectionResolver{symbols: symbols, first: args.First}, nil
 }
 
@@ -215,7 +225,7 @@ func toSymbolResolver(symbol protocol.Symbol, baseURI *gituri.URI, lang string,
 		language: lang,
 		uri:      baseURI.WithFilePath(symbol.Path),
 	}
-	symbolRange := symbolRange(symbol)
+	symbolRange := SymbolRange(symbol)
 	resolver.location = &locationResolver{
 		resource: &GitTreeEntryResolver{
 			commit: commitResolver,
@@ -255,7 +265,7 @@ func (r *symbolResolver) ContainerName() *string {
 }
 
 func (r *symbolResolver) Kind() string /* enum SymbolKind */ {
-	kind := ctagsKindToLSPSymbolKind(r.symbol.Kind)
+	kind := CtagsKindToLSPSymbolKind(r.symbol.Kind)
 	if kind == 0 {
 		return "UNKNOWN"
 	}
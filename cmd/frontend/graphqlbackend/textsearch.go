@@ -191,7 +191,33 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 	if mockTextSearch != nil {
 		return mockTextSearch(ctx, repo, commit, p, fetchTimeout)
 	}
+	matches, limitHit, _, err = searcherSearch(ctx, searcherURLs, repo, commit, p, fetchTimeout, false, "")
+	return matches, limitHit, err
+}
+
+// textSearchPage searches repo@commit with p like textSearch, but returns at
+// most p.FileMatchLimit matches ordered by path. If cursor is nil the first
+// page is returned, otherwise the page after the one which returned cursor.
+// next is the cursor of the next page. It is nil on the last page.
+//
+// Searcher finds the matches of all pages at once and caches them, so
+// continuing a search with a cursor does not re-run it.
+func textSearchPage(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.Repo, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration, cursor *string) (matches []*FileMatchResolver, limitHit bool, next *string, err error) {
+	var c string
+	if cursor != nil {
+		c = *cursor
+	}
+	matches, limitHit, c, err = searcherSearch(ctx, searcherURLs, repo, commit, p, fetchTimeout, true, c)
+	if c != "" {
+		next = &c
+	}
+	return matches, limitHit, next, err
+}
 
+// searcherSearch sends a search request to searcher. If paginate is true,
+// searcher returns the page of matches after cursor and the cursor of the next
+// page.
+func searcherSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.Repo, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration, paginate bool, cursor string) (matches []*FileMatchResolver, limitHit bool, next string, err error) {
 	tr, ctx := trace.New(ctx, "searcher.client", fmt.Sprintf("%s@%s", repo.Name, commit))
 	defer func() {
 		tr.SetError(err)
@@ -214,7 +240,7 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 	if deadline, ok := ctx.Deadline(); ok {
 		t, err := deadline.MarshalText()
 		if err != nil {
-			return nil, false, err
+			return nil, false, "", err
 		}
 		q.Set("Deadline", string(t))
 	}
@@ -238,6 +264,12 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 	// these fields from old frontends that do not (and provide a default in the latter case).
 	q.Set("PatternMatchesContent", strconv.FormatBool(p.PatternMatchesContent))
 	q.Set("PatternMatchesPath", strconv.FormatBool(p.PatternMatchesPath))
+	if paginate {
+		q.Set("Paginate", "true")
+	}
+	if cursor != "" {
+		q.Set("Cursor", cursor)
+	}
 	rawQuery := q.Encode()
 
 	// Searcher caches the file contents for repo@commit since it is
@@ -257,7 +289,7 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 
 		searcherURL, err := searcherURLs.Get(consistentHashKey, excludedSearchURLs)
 		if err != nil {
-			return nil, false, err
+			return nil, false, "", err
 		}
 
 		// Fallback to a bad host if nothing is left
@@ -265,25 +297,25 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 			tr.LazyPrintf("failed to find endpoint, trying again without excludes")
 			searcherURL, err = searcherURLs.Get(consistentHashKey, nil)
 			if err != nil {
-				return nil, false, err
+				return nil, false, "", err
 			}
 		}
 
 		url := searcherURL + "?" + rawQuery
 		tr.LazyPrintf("attempt %d: %s", attempt, url)
-		matches, limitHit, err = textSearchURL(ctx, url)
+		matches, limitHit, next, err = textSearchURL(ctx, url)
 		if err == nil || errcode.IsTimeout(err) {
-			return matches, limitHit, err
+			return matches, limitHit, next, err
 		}
 
 		// If we are canceled, return that error.
 		if err := ctx.Err(); err != nil {
-			return nil, false, err
+			return nil, false, "", err
 		}
 
 		// If not temporary or our last attempt then don't try again.
 		if !errcode.IsTemporary(err) || attempt == maxAttempts {
-			return nil, false, err
+			return nil, false, "", err
 		}
 
 		tr.LazyPrintf("transient error %s", err.Error())
@@ -292,10 +324,10 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 	}
 }
 
-func textSearchURL(ctx context.Context, url string) ([]*FileMatchResolver, bool, error) {
+func textSearchURL(ctx context.Context, url string) ([]*FileMatchResolver, bool, string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, false, err
+		return nil, false, "", err
 	}
 	req = req.WithContext(ctx)
 
@@ -314,30 +346,31 @@ func textSearchURL(ctx context.Context, url string) ([]*FileMatchResolver, bool,
 		if ctx.Err() != nil {
 			err = ctx.Err()
 		}
-		return nil, false, errors.Wrap(err, "searcher request failed")
+		return nil, false, "", errors.Wrap(err, "searcher request failed")
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return nil, false, err
+			return nil, false, "", err
 		}
-		return nil, false, errors.WithStack(&searcherError{StatusCode: resp.StatusCode, Message: string(body)})
+		return nil, false, "", errors.WithStack(&searcherError{StatusCode: resp.StatusCode, Message: string(body)})
 	}
 
 	r := struct {
 		Matches     []*FileMatchResolver
 		LimitHit    bool
 		DeadlineHit bool
+		Cursor      string
 	}{}
 	err = json.NewDecoder(resp.Body).Decode(&r)
 	if err != nil {
-		return nil, false, errors.Wrap(err, "searcher response invalid")
+		return nil, false, "", errors.Wrap(err, "searcher response invalid")
 	}
 	if r.DeadlineHit {
 		err = context.DeadlineExceeded
 	}
-	return r.Matches, r.LimitHit, err
+	return r.Matches, r.LimitHit, r.Cursor, err
 }
 
 type searcherError struct {
@@ -386,6 +419,56 @@ func searchFilesInRepo(ctx context.Context, searcherURLs *endpoint.Map, repo *ty
 		return nil, false, err
 	}
 
+	setFileMatchesRepo(matches, repo, rev, commit)
+	return matches, limitHit, err
+}
+
+var mockSearchFilesInRepoPage func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, next *searcherContinuation, err error)
+
+// searchFilesInRepoPage searches repo@rev like searchFilesInRepo, but returns
+// the first page of matches of a paginated searcher request. next continues the
+// search with the next page. It is nil on the last page.
+func searchFilesInRepoPage(ctx context.Context, searcherURLs *endpoint.Map, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, next *searcherContinuation, err error) {
+	if mockSearchFilesInRepoPage != nil {
+		return mockSearchFilesInRepoPage(ctx, repo, gitserverRepo, rev, info, fetchTimeout)
+	}
+
+	commit, err := git.ResolveRevision(ctx, gitserverRepo, nil, rev, git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	shouldBeSearched, err := repoShouldBeSearched(ctx, searcherURLs, info, gitserverRepo, commit, fetchTimeout)
+	if err != nil || !shouldBeSearched {
+		return nil, false, nil, err
+	}
+
+	return continueSearchFilesInRepo(ctx, searcherURLs, repo, searcherContinuation{Repo: repo.Name, Rev: rev, Commit: commit}, info, fetchTimeout)
+}
+
+// continueSearchFilesInRepo returns the page of matches that c continues the
+// search of a repository revision with. next continues the search with the
+// page after it. It is nil on the last page.
+func continueSearchFilesInRepo(ctx context.Context, searcherURLs *endpoint.Map, repo *types.Repo, c searcherContinuation, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, next *searcherContinuation, err error) {
+	var cursor *string
+	if c.Cursor != "" {
+		cursor = &c.Cursor
+	}
+	matches, limitHit, nextCursor, err := textSearchPage(ctx, searcherURLs, gitserver.Repo{Name: repo.Name}, c.Commit, info, fetchTimeout, cursor)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	setFileMatchesRepo(matches, repo, c.Rev, c.Commit)
+	if nextCursor != nil {
+		next = &searcherContinuation{Repo: repo.Name, Rev: c.Rev, Commit: c.Commit, Cursor: *nextCursor}
+	}
+	return matches, limitHit, next, nil
+}
+
+// setFileMatchesRepo sets the repository revision of file matches returned by
+// searcher.
+func setFileMatchesRepo(matches []*FileMatchResolver, repo *types.Repo, rev string, commit api.CommitID) {
 	workspace := fileMatchURI(repo.Name, rev, "")
 	repoResolver := &RepositoryResolver{repo: repo}
 	for _, fm := range matches {
@@ -394,8 +477,6 @@ func searchFilesInRepo(ctx context.Context, searcherURLs *endpoint.Map, repo *ty
 		fm.CommitID = commit
 		fm.InputRev = &rev
 	}
-
-	return matches, limitHit, err
 }
 
 // repoShouldBeSearched determines whether a repository should be searched in, based on whether the repository
@@ -532,6 +613,11 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		unflattened       [][]*FileMatchResolver
 		flattenedSize     int
 		overLimitCanceled bool // canceled because we were over the limit
+
+		// pages are the searcher results of a continuable search. They are
+		// returned in full, so that the search of each repository revision
+		// can be continued right after the last match of its page.
+		pages []*FileMatchResolver
 	)
 
 	// addMatches assumes the caller holds mu.
@@ -630,7 +716,17 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 					defer wg.Done()
 					defer done()
 
-					matches, repoLimitHit, err := searchFilesInRepo(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), repoRev.RevSpecs()[0], args.PatternInfo, fetchTimeout)
+					var (
+						matches      []*FileMatchResolver
+						repoLimitHit bool
+						next         *searcherContinuation
+						err          error
+					)
+					if args.Continuable {
+						matches, repoLimitHit, next, err = searchFilesInRepoPage(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), repoRev.RevSpecs()[0], args.PatternInfo, fetchTimeout)
+					} else {
+						matches, repoLimitHit, err = searchFilesInRepo(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), repoRev.RevSpecs()[0], args.PatternInfo, fetchTimeout)
+					}
 					if err != nil {
 						tr.LogFields(otlog.String("repo", string(repoRev.Repo.Name)), otlog.Error(err), otlog.Bool("timeout", errcode.IsTimeout(err)), otlog.Bool("temporary", errcode.IsTemporary(err)))
 						log15.Warn("searchFilesInRepo failed", "error", err, "repo", repoRev.Repo.Name)
@@ -660,6 +756,14 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 							cancel()
 						}
 					}
+					if args.Continuable {
+						common.resultCount += int32(len(matches))
+						pages = append(pages, matches...)
+						if next != nil {
+							common.searcherContinuations = append(common.searcherContinuations, *next)
+						}
+						return
+					}
 					addMatches(matches)
 				}(limitCtx, limitDone) // ends the Go routine for a call to searcher for a repo
 			} // ends the for loop iterating over repo's revs
@@ -756,6 +860,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 	}
 
 	flattened := flattenFileMatches(unflattened, int(args.PatternInfo.FileMatchLimit))
+	flattened = append(flattened, pages...)
 	return flattened, common, nil
 }
 
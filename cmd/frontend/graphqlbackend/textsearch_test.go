@@ -2,6 +2,8 @@ package graphqlbackend
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sort"
 	"strings"
@@ -308,3 +310,46 @@ func TestLimitSearcherRepos(t *testing.T) {
 		})
 	}
 }
+
+func TestTextSearchPage(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"Matches":[{"Path":"a.go"},{"Path":"b.go"}],"LimitHit":true,"Cursor":"o2"}`,
+		"o2": `{"Matches":[{"Path":"c.go"}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Paginate") != "true" {
+			t.Errorf("got Paginate %q, want true", r.URL.Query().Get("Paginate"))
+		}
+		page, ok := pages[r.URL.Query().Get("Cursor")]
+		if !ok {
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer ts.Close()
+
+	var (
+		paths  []string
+		cursor *string
+	)
+	for i := 0; i < len(pages)+1; i++ {
+		matches, limitHit, next, err := textSearchPage(context.Background(), endpoint.Static(ts.URL), gitserver.Repo{Name: "r"}, "c", &search.TextPatternInfo{Pattern: "p", FileMatchLimit: 2}, time.Second, cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if limitHit != (next != nil) {
+			t.Errorf("got limitHit %t with next cursor %v", limitHit, next)
+		}
+		for _, m := range matches {
+			paths = append(paths, m.JPath)
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	if want := []string{"a.go", "b.go", "c.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
@@ -352,7 +352,10 @@ func zoektSearch(ctx context.Context, args *search.TextParameters, repos *indexe
 
 			var symbols []*searchSymbolResult
 			if typ == symbolRequest {
-				symbols = zoektFileMatchToSymbolResults(repoResolver, inputRev, &file)
+				symbols = filterSymbolKinds(zoektFileMatchToSymbolResults(repoResolver, inputRev, &file), args.PatternInfo.SymbolKinds)
+				if len(symbols) == 0 {
+					continue
+				}
 			}
 
 			matches = append(matches, &FileMatchResolver{
@@ -18,8 +18,10 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/vfsutil"
@@ -99,6 +101,13 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		return nil
 	}
 
+	// 🚨 SECURITY: Users whose access to the repository is restricted to some
+	// paths can only read the files and directories they have access to.
+	pathMatcher, err := db.AuthzPathMatcher(r.Context(), common.Repo.ID)
+	if err != nil {
+		return err
+	}
+
 	const (
 		textPlain       = "text/plain"
 		applicationZip  = "application/zip"
@@ -145,6 +154,14 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 
 	switch contentType {
 	case applicationZip, applicationXTar:
+		if pathMatcher != nil {
+			// Archives cannot be filtered by path, so they are not available to
+			// users who cannot read all paths.
+			requestType = "403"
+			http.Error(w, "archives are not available for this repository", http.StatusForbidden)
+			return nil // request handled
+		}
+
 		// Set the proper filename field, so that downloading "/github.com/gorilla/mux/-/raw"
 		// gives us a "mux.zip" file (e.g. when downloading via a browser).
 		ext := ".zip"
@@ -237,6 +254,10 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		}
 
 		fi, err := git.Stat(r.Context(), *cachedRepo, common.CommitID, requestedPath)
+		if err == nil && !canReadPath(pathMatcher, fi) {
+			// Pretend that paths the user cannot read do not exist.
+			err = &os.PathError{Op: "stat", Path: requestedPath, Err: os.ErrNotExist}
+		}
 		if err != nil {
 			if os.IsNotExist(err) {
 				requestType = "404"
@@ -255,6 +276,9 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 			size = int64(len(infos))
 			var names []string
 			for _, info := range infos {
+				if !canReadPath(pathMatcher, info) {
+					continue
+				}
 				// A previous version of this code returned relative paths so we trim the paths
 				// here too so as not to break backwards compatibility
 				name := path.Base(info.Name())
@@ -281,6 +305,15 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 	}
 }
 
+// canReadPath reports whether the user can read the file or directory fi
+// according to m.
+func canReadPath(m *authz.PathMatcher, fi os.FileInfo) bool {
+	if fi.IsDir() {
+		return m.CanReadDir(fi.Name())
+	}
+	return m.CanReadPath(fi.Name())
+}
+
 // openArchiveReader runs git archive and streams the output. Note: we do not
 // use vfsutil since most archives are just streamed once so caching locally
 // is not useful. Additionally we transfer the output over the internet, so we
@@ -207,7 +207,7 @@ func Main(enterpriseSetupHook func() enterprise.Services) error {
 	}
 
 	// Create the external HTTP handler.
-	externalHandler, err := newExternalHTTPHandler(schema, enterprise.GithubWebhook, enterprise.BitbucketServerWebhook, enterprise.NewCodeIntelUploadHandler)
+	externalHandler, err := newExternalHTTPHandler(schema, enterprise.GithubWebhook, enterprise.GitLabWebhook, enterprise.BitbucketServerWebhook, enterprise.CampaignsExport, enterprise.NewCodeIntelUploadHandler)
 	if err != nil {
 		return err
 	}
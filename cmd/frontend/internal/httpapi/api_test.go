@@ -19,7 +19,9 @@ func newTest() *httptestutil.Client {
 		router.New(mux.NewRouter()),
 		nil,
 		enterpriseServices.GithubWebhook,
+		enterpriseServices.GitLabWebhook,
 		enterpriseServices.BitbucketServerWebhook,
+		enterpriseServices.CampaignsExport,
 		enterpriseServices.NewCodeIntelUploadHandler,
 	))
 }
@@ -310,6 +310,7 @@ func serveSavedQueriesSetInfo(w http.ResponseWriter, r *http.Request) error {
 		LastExecuted: info.LastExecuted,
 		LatestResult: info.LatestResult,
 		ExecDuration: info.ExecDuration,
+		Results:      info.Results,
 	})
 	if err != nil {
 		return errors.Wrap(err, "SavedQueries.Set")
@@ -334,6 +335,86 @@ func serveSavedQueriesDeleteInfo(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func serveSavedQueriesRecordWebhookDelivery(w http.ResponseWriter, r *http.Request) error {
+	var delivery api.SavedQueryWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	d := &types.SavedSearchWebhookDelivery{
+		SavedSearchID: delivery.SavedSearchID,
+		URL:           delivery.URL,
+		Payload:       delivery.Payload,
+		Attempts:      delivery.Attempts,
+		CreatedAt:     delivery.CreatedAt,
+		DeliveredAt:   delivery.DeliveredAt,
+	}
+	if delivery.StatusCode != 0 {
+		d.StatusCode = &delivery.StatusCode
+	}
+	if delivery.Error != "" {
+		d.Error = &delivery.Error
+	}
+	if _, err := db.SavedSearchWebhookDeliveries.Create(r.Context(), d); err != nil {
+		return errors.Wrap(err, "SavedSearchWebhookDeliveries.Create")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+	return nil
+}
+
+func serveSavedQueriesClaim(w http.ResponseWriter, r *http.Request) error {
+	var claim api.SavedQueryClaim
+	if err := json.NewDecoder(r.Body).Decode(&claim); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	claimed, err := db.QueryRunnerState.Claim(r.Context(), claim.Query, claim.Runner, claim.Lease)
+	if err != nil {
+		return errors.Wrap(err, "QueryRunnerState.Claim")
+	}
+	if err := json.NewEncoder(w).Encode(claimed); err != nil {
+		return errors.Wrap(err, "Encode")
+	}
+	return nil
+}
+
+func serveSavedQueriesRelease(w http.ResponseWriter, r *http.Request) error {
+	var claim api.SavedQueryClaim
+	if err := json.NewDecoder(r.Body).Decode(&claim); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	if err := db.QueryRunnerState.Release(r.Context(), claim.Query, claim.Runner); err != nil {
+		return errors.Wrap(err, "QueryRunnerState.Release")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+	return nil
+}
+
+func serveSavedQueriesRecordRun(w http.ResponseWriter, r *http.Request) error {
+	var run api.SavedQueryRun
+	if err := json.NewDecoder(r.Body).Decode(&run); err != nil {
+		return errors.Wrap(err, "Decode")
+	}
+	qr := &types.QueryRunnerRun{
+		Query:       run.Query,
+		Runner:      run.Runner,
+		ScheduledAt: run.ScheduledAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+	if run.Error != "" {
+		qr.Error = &run.Error
+	} else {
+		qr.ResultCount = &run.ResultCount
+	}
+	if _, err := db.QueryRunnerRuns.Create(r.Context(), qr); err != nil {
+		return errors.Wrap(err, "QueryRunnerRuns.Create")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+	return nil
+}
+
 func serveSettingsGetForSubject(w http.ResponseWriter, r *http.Request) error {
 	var subject api.SettingsSubject
 	if err := json.NewDecoder(r.Body).Decode(&subject); err != nil {
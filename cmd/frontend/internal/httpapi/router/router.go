@@ -10,6 +10,8 @@ const (
 	LSIFUpload = "lsif.upload"
 	GraphQL    = "graphql"
 
+	SearchStream = "search.stream"
+
 	SrcCliVersion  = "src-cli.version"
 	SrcCliDownload = "src-cli.download"
 
@@ -20,37 +22,46 @@ const (
 	Telemetry   = "telemetry"
 
 	GitHubWebhooks          = "github.webhooks"
+	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
 
-	SavedQueriesListAll    = "internal.saved-queries.list-all"
-	SavedQueriesGetInfo    = "internal.saved-queries.get-info"
-	SavedQueriesSetInfo    = "internal.saved-queries.set-info"
-	SavedQueriesDeleteInfo = "internal.saved-queries.delete-info"
-	SettingsGetForSubject  = "internal.settings.get-for-subject"
-	OrgsListUsers          = "internal.orgs.list-users"
-	OrgsGetByName          = "internal.orgs.get-by-name"
-	UsersGetByUsername     = "internal.users.get-by-username"
-	UserEmailsGetEmail     = "internal.user-emails.get-email"
-	ExternalURL            = "internal.app-url"
-	CanSendEmail           = "internal.can-send-email"
-	SendEmail              = "internal.send-email"
-	Extension              = "internal.extension"
-	GitExec                = "internal.git.exec"
-	GitInfoRefs            = "internal.git.info-refs"
-	GitResolveRevision     = "internal.git.resolve-revision"
-	GitTar                 = "internal.git.tar"
-	GitUploadPack          = "internal.git.upload-pack"
-	PhabricatorRepoCreate  = "internal.phabricator.repo.create"
-	ReposGetByName         = "internal.repos.get-by-name"
-	ReposInventoryUncached = "internal.repos.inventory-uncached"
-	ReposInventory         = "internal.repos.inventory"
-	ReposList              = "internal.repos.list"
-	ReposIndex             = "internal.repos.index"
-	ReposListEnabled       = "internal.repos.list-enabled"
-	Configuration          = "internal.configuration"
-	SearchConfiguration    = "internal.search-configuration"
-	ExternalServiceConfigs = "internal.external-services.configs"
-	ExternalServicesList   = "internal.external-services.list"
+	CampaignsExport = "campaigns.export"
+
+	SCIM = "scim"
+
+	SavedQueriesListAll               = "internal.saved-queries.list-all"
+	SavedQueriesGetInfo               = "internal.saved-queries.get-info"
+	SavedQueriesSetInfo               = "internal.saved-queries.set-info"
+	SavedQueriesDeleteInfo            = "internal.saved-queries.delete-info"
+	SavedQueriesRecordWebhookDelivery = "internal.saved-queries.record-webhook-delivery"
+	SavedQueriesClaim                 = "internal.saved-queries.claim"
+	SavedQueriesRelease               = "internal.saved-queries.release"
+	SavedQueriesRecordRun             = "internal.saved-queries.record-run"
+	SettingsGetForSubject             = "internal.settings.get-for-subject"
+	OrgsListUsers                     = "internal.orgs.list-users"
+	OrgsGetByName                     = "internal.orgs.get-by-name"
+	UsersGetByUsername                = "internal.users.get-by-username"
+	UserEmailsGetEmail                = "internal.user-emails.get-email"
+	ExternalURL                       = "internal.app-url"
+	CanSendEmail                      = "internal.can-send-email"
+	SendEmail                         = "internal.send-email"
+	Extension                         = "internal.extension"
+	GitExec                           = "internal.git.exec"
+	GitInfoRefs                       = "internal.git.info-refs"
+	GitResolveRevision                = "internal.git.resolve-revision"
+	GitTar                            = "internal.git.tar"
+	GitUploadPack                     = "internal.git.upload-pack"
+	PhabricatorRepoCreate             = "internal.phabricator.repo.create"
+	ReposGetByName                    = "internal.repos.get-by-name"
+	ReposInventoryUncached            = "internal.repos.inventory-uncached"
+	ReposInventory                    = "internal.repos.inventory"
+	ReposList                         = "internal.repos.list"
+	ReposIndex                        = "internal.repos.index"
+	ReposListEnabled                  = "internal.repos.list-enabled"
+	Configuration                     = "internal.configuration"
+	SearchConfiguration               = "internal.search-configuration"
+	ExternalServiceConfigs            = "internal.external-services.configs"
+	ExternalServicesList              = "internal.external-services.list"
 )
 
 // New creates a new API router with route URL pattern definitions but
@@ -64,9 +75,13 @@ func New(base *mux.Router) *mux.Router {
 
 	addRegistryRoute(base)
 	addGraphQLRoute(base)
+	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/github-webhooks").Methods("POST").Name(GitHubWebhooks)
+	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
+	base.Path("/campaigns/{Campaign}/export/{Export:burndown|changesets}").Methods("GET").Name(CampaignsExport)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
+	base.Path("/scim/v2/{rest:.*}").Name(SCIM)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
 
@@ -94,6 +109,10 @@ func NewInternal(base *mux.Router) *mux.Router {
 	base.Path("/saved-queries/get-info").Methods("POST").Name(SavedQueriesGetInfo)
 	base.Path("/saved-queries/set-info").Methods("POST").Name(SavedQueriesSetInfo)
 	base.Path("/saved-queries/delete-info").Methods("POST").Name(SavedQueriesDeleteInfo)
+	base.Path("/saved-queries/record-webhook-delivery").Methods("POST").Name(SavedQueriesRecordWebhookDelivery)
+	base.Path("/saved-queries/claim").Methods("POST").Name(SavedQueriesClaim)
+	base.Path("/saved-queries/release").Methods("POST").Name(SavedQueriesRelease)
+	base.Path("/saved-queries/record-run").Methods("POST").Name(SavedQueriesRecordRun)
 	base.Path("/settings/get-for-subject").Methods("POST").Name(SettingsGetForSubject)
 	base.Path("/orgs/list-users").Methods("POST").Name(OrgsListUsers)
 	base.Path("/orgs/get-by-name").Methods("POST").Name(OrgsGetByName)
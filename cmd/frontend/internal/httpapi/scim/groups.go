@@ -0,0 +1,440 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// groupResource is the SCIM representation of a group, which maps to a
+// Sourcegraph organization.
+type groupResource struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []member `json:"members,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type member struct {
+	Value string `json:"value"`
+	Ref   string `json:"$ref,omitempty"`
+}
+
+func newGroupResource(org *types.Org, userIDs []int32) *groupResource {
+	r := &groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: orgDisplayName(org),
+		Meta:        newMeta("Group", org.ID, org.CreatedAt, org.UpdatedAt),
+	}
+	for _, id := range userIDs {
+		r.Members = append(r.Members, member{Value: strconv.Itoa(int(id)), Ref: location("User", id)})
+	}
+	return r
+}
+
+func (h *handler) serveGroups(w http.ResponseWriter, r *http.Request, id string) error {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			return h.listGroups(w, r)
+		case http.MethodPost:
+			return h.createGroup(w, r)
+		}
+		return errMethodNotAllowed(r)
+	}
+
+	orgID, err := parseID("Group", id)
+	if err != nil {
+		return err
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return h.writeGroup(w, r, http.StatusOK, orgID)
+	case http.MethodPut:
+		return h.replaceGroup(w, r, orgID)
+	case http.MethodPatch:
+		return h.patchGroup(w, r, orgID)
+	case http.MethodDelete:
+		return h.deleteGroup(w, r, orgID)
+	}
+	return errMethodNotAllowed(r)
+}
+
+func (h *handler) listGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	filter, ok, err := parseFilter(r, "displayName")
+	if err != nil {
+		return err
+	}
+
+	var (
+		orgs  []*types.Org
+		total int
+	)
+	if ok {
+		org, err := h.store.GetOrgByDisplayName(ctx, filter)
+		if err != nil && !errcode.IsNotFound(err) {
+			return err
+		}
+		if org != nil {
+			total = 1
+			if p.offset() == 0 {
+				orgs = []*types.Org{org}
+			}
+		}
+	} else {
+		// A count of 0 only asks for the total number of results.
+		orgs, total, err = h.store.ListOrgs(ctx, p.offset(), maxInt(p.count, 1))
+		if err != nil {
+			return err
+		}
+	}
+	if len(orgs) > p.count {
+		orgs = orgs[:p.count]
+	}
+
+	resources := make([]*groupResource, 0, len(orgs))
+	for _, org := range orgs {
+		members, err := h.store.ListOrgMembers(ctx, org.ID)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, newGroupResource(org, members))
+	}
+
+	writeJSON(w, http.StatusOK, &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+	return nil
+}
+
+func (h *handler) createGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var in groupResource
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+	if in.DisplayName == "" {
+		return errBadRequest("invalidValue", "displayName is required")
+	}
+	members, err := h.parseMembers(ctx, in.Members)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.store.GetOrgByDisplayName(ctx, in.DisplayName); err == nil {
+		return errConflict("a group with the same displayName already exists")
+	} else if !errcode.IsNotFound(err) {
+		return err
+	}
+
+	// Organization names have the same constraints as usernames.
+	name, err := normalizeUsername(in.DisplayName)
+	if err != nil {
+		return err
+	}
+	org, err := h.store.CreateOrg(ctx, name, &in.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	if err := h.setMembers(ctx, org.ID, members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, http.StatusCreated, org.ID)
+}
+
+func (h *handler) writeGroup(w http.ResponseWriter, r *http.Request, status int, id int32) error {
+	org, err := h.store.GetOrg(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	members, err := h.store.ListOrgMembers(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, status, newGroupResource(org, members))
+	return nil
+}
+
+func (h *handler) replaceGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	var in groupResource
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+	if in.DisplayName == "" {
+		return errBadRequest("invalidValue", "displayName is required")
+	}
+	members, err := h.parseMembers(ctx, in.Members)
+	if err != nil {
+		return err
+	}
+
+	if err := h.updateGroup(ctx, id, &in.DisplayName); err != nil {
+		return err
+	}
+	if err := h.setMembers(ctx, id, members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, http.StatusOK, id)
+}
+
+// memberFilter matches the path of PATCH operations on a single member, e.g.
+// `members[value eq "1"]`.
+var memberFilter = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+func (h *handler) patchGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	var in patchRequest
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+
+	if _, err := h.store.GetOrg(ctx, id); err != nil {
+		return err
+	}
+	current, err := h.store.ListOrgMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	p := groupPatch{h: h, ctx: ctx, members: current}
+	for _, op := range in.Operations {
+		if err := p.apply(strings.ToLower(op.Op), op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+
+	if p.displayName != nil {
+		if err := h.updateGroup(ctx, id, p.displayName); err != nil {
+			return err
+		}
+	}
+	if err := h.setMembers(ctx, id, p.members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, http.StatusOK, id)
+}
+
+func (h *handler) deleteGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	if _, err := h.store.GetOrg(r.Context(), id); err != nil {
+		return err
+	}
+	if err := h.store.DeleteOrg(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// updateGroup updates the display name of the organization. The name of an
+// organization can't be changed.
+func (h *handler) updateGroup(ctx context.Context, id int32, displayName *string) error {
+	org, err := h.store.GetOrg(ctx, id)
+	if err != nil {
+		return err
+	}
+	if orgDisplayName(org) == *displayName {
+		return nil
+	}
+
+	if other, err := h.store.GetOrgByDisplayName(ctx, *displayName); err == nil && other.ID != id {
+		return errConflict("a group with the same displayName already exists")
+	} else if err != nil && !errcode.IsNotFound(err) {
+		return err
+	}
+
+	_, err = h.store.UpdateOrg(ctx, id, displayName)
+	return err
+}
+
+// setMembers adds and removes members of the organization so that its members
+// are exactly the given users.
+func (h *handler) setMembers(ctx context.Context, orgID int32, userIDs []int32) error {
+	current, err := h.store.ListOrgMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range current {
+		if !containsID(userIDs, id) {
+			if err := h.store.RemoveOrgMember(ctx, orgID, id); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range userIDs {
+		if !containsID(current, id) {
+			if err := h.store.AddOrgMember(ctx, orgID, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseMembers returns the user IDs of the given members, all of which must be
+// active users.
+func (h *handler) parseMembers(ctx context.Context, members []member) ([]int32, error) {
+	userIDs := make([]int32, 0, len(members))
+	for _, m := range members {
+		id, err := h.parseMember(ctx, m.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !containsID(userIDs, id) {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+func (h *handler) parseMember(ctx context.Context, value string) (int32, error) {
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, errBadRequest("invalidValue", "member "+strconv.Quote(value)+" is not a user ID")
+	}
+
+	_, active, err := h.store.GetUser(ctx, int32(id))
+	if errcode.IsNotFound(err) || (err == nil && !active) {
+		return 0, errBadRequest("invalidValue", "member "+strconv.Quote(value)+" is not an active user")
+	}
+	return int32(id), err
+}
+
+// groupPatch accumulates the changes of the operations of a PATCH request.
+type groupPatch struct {
+	h   *handler
+	ctx context.Context
+
+	displayName *string
+	members     []int32
+}
+
+// apply applies a single PATCH operation. Operations on attributes other than
+// the display name and the members are ignored.
+func (p *groupPatch) apply(op, path string, value json.RawMessage) error {
+	switch op {
+	case "add", "replace", "remove":
+	default:
+		return errBadRequest("invalidSyntax", "unsupported operation "+strconv.Quote(op))
+	}
+
+	if m := memberFilter.FindStringSubmatch(path); m != nil {
+		if op != "remove" {
+			return errBadRequest("invalidPath", "only remove operations are supported on a single member")
+		}
+		id, err := strconv.ParseInt(m[1], 10, 32)
+		if err != nil {
+			return errBadRequest("invalidValue", "member "+strconv.Quote(m[1])+" is not a user ID")
+		}
+		p.members = removeIDs(p.members, []int32{int32(id)})
+		return nil
+	}
+
+	switch strings.ToLower(path) {
+	case "":
+		if op == "remove" {
+			return errBadRequest("noTarget", "path is required for remove operations")
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attrs); err != nil {
+			return errBadRequest("invalidValue", "expected an object value")
+		}
+		for attr, v := range attrs {
+			if err := p.apply(op, attr, v); err != nil {
+				return err
+			}
+		}
+
+	case "displayname":
+		if op == "remove" {
+			return errBadRequest("mutability", "displayName can't be removed")
+		}
+		s, err := parseString(value)
+		if err != nil {
+			return err
+		}
+		p.displayName = &s
+
+	case "members":
+		var members []member
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return errBadRequest("invalidValue", "expected an array of members")
+			}
+		}
+
+		if op == "remove" {
+			if len(members) == 0 {
+				p.members = nil
+				return nil
+			}
+			var ids []int32
+			for _, m := range members {
+				id, err := strconv.ParseInt(m.Value, 10, 32)
+				if err != nil {
+					return errBadRequest("invalidValue", "member "+strconv.Quote(m.Value)+" is not a user ID")
+				}
+				ids = append(ids, int32(id))
+			}
+			p.members = removeIDs(p.members, ids)
+			return nil
+		}
+
+		ids, err := p.h.parseMembers(p.ctx, members)
+		if err != nil {
+			return err
+		}
+		if op == "replace" {
+			p.members = ids
+			return nil
+		}
+		for _, id := range ids {
+			if !containsID(p.members, id) {
+				p.members = append(p.members, id)
+			}
+		}
+	}
+	return nil
+}
+
+func containsID(ids []int32, id int32) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeIDs(ids, remove []int32) []int32 {
+	remaining := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !containsID(remove, id) {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining
+}
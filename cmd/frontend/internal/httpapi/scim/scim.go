@@ -0,0 +1,368 @@
+// Package scim implements a SCIM 2.0 (https://tools.ietf.org/html/rfc7644)
+// service provider, which lets identity providers (such as Okta or Azure
+// Active Directory) provision users and organizations. SCIM users map to
+// Sourcegraph users, and SCIM groups map to Sourcegraph organizations.
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	contentType = "application/scim+json"
+
+	// defaultCount is the page size of list responses when the client does not
+	// specify a count.
+	defaultCount = 100
+	// maxCount is the maximum page size of list responses.
+	maxCount = 1000
+)
+
+// NewHandler returns a new SCIM 2.0 API handler. It expects the resource path
+// (e.g. "Users/1") in the "rest" route variable.
+//
+// 🚨 SECURITY: The handler authenticates every request itself, and only allows
+// access tokens of site admins.
+func NewHandler() http.Handler {
+	return &handler{store: dbStore{}}
+}
+
+type handler struct {
+	store Store
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, err := authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	r = r.WithContext(ctx)
+
+	resource, id := splitPath(mux.Vars(r)["rest"])
+	switch resource {
+	case "Users":
+		err = h.serveUsers(w, r, id)
+	case "Groups":
+		err = h.serveGroups(w, r, id)
+	case "ServiceProviderConfig":
+		err = serveServiceProviderConfig(w, r, id)
+	default:
+		err = errNotFound("resource type %q is not supported", resource)
+	}
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+// splitPath splits the given resource path into the resource type and the
+// optional resource ID.
+func splitPath(path string) (resource, id string) {
+	path = strings.Trim(path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return path, ""
+}
+
+// authenticate validates the access token of the request and returns a
+// context with its subject user as the actor.
+//
+// Identity providers usually send "Authorization: Bearer TOKEN", which is why
+// the SCIM API accepts that scheme in addition to the "token" scheme. Session
+// cookies are never accepted, so the API can't be used through CSRF.
+//
+// 🚨 SECURITY: The subject user of the access token must be a site admin.
+func authenticate(r *http.Request) (context.Context, error) {
+	var token string
+	if fields := strings.Fields(r.Header.Get("Authorization")); len(fields) == 2 {
+		switch strings.ToLower(fields[0]) {
+		case "bearer", authz.SchemeToken:
+			token = fields[1]
+		}
+	}
+	if token == "" {
+		return nil, &scimError{status: http.StatusUnauthorized, detail: "An access token is required."}
+	}
+
+	if conf.AccessTokensAllow() == conf.AccessTokensNone {
+		return nil, &scimError{status: http.StatusUnauthorized, detail: "Access token authorization is disabled."}
+	}
+
+	// 🚨 SECURITY: It's important we check for the correct scope to know what this
+	// token is allowed to do.
+	userID, err := db.AccessTokens.Lookup(r.Context(), token, authz.ScopeUserAll)
+	if err != nil {
+		log15.Error("SCIM: invalid access token.", "err", err)
+		return nil, &scimError{status: http.StatusUnauthorized, detail: "Invalid access token."}
+	}
+
+	if err := backend.CheckUserIsSiteAdmin(r.Context(), userID); err != nil {
+		return nil, &scimError{status: http.StatusForbidden, detail: "The subject user of the access token must be a site admin."}
+	}
+	return actor.WithActor(r.Context(), &actor.Actor{UID: userID}), nil
+}
+
+// meta is the common "meta" attribute of all resources.
+type meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+func newMeta(resourceType string, id int32, created, lastModified time.Time) *meta {
+	return &meta{
+		ResourceType: resourceType,
+		Created:      created,
+		LastModified: lastModified,
+		Location:     location(resourceType, id),
+	}
+}
+
+// location returns the URL of the resource with the given type and ID.
+func location(resourceType string, id int32) string {
+	return fmt.Sprintf("%s/.api/scim/v2/%ss/%d", strings.TrimSuffix(conf.ExternalURL(), "/"), resourceType, id)
+}
+
+type listResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// pagination is the 1-based pagination of a list request.
+type pagination struct {
+	startIndex int
+	count      int
+}
+
+func parsePagination(r *http.Request) (pagination, error) {
+	p := pagination{startIndex: 1, count: defaultCount}
+
+	if v := r.URL.Query().Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, errBadRequest("invalidValue", "startIndex must be an integer")
+		}
+		if n > 1 {
+			p.startIndex = n
+		}
+	}
+
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, errBadRequest("invalidValue", "count must be an integer")
+		}
+		switch {
+		case n < 0:
+			p.count = 0
+		case n > maxCount:
+			p.count = maxCount
+		default:
+			p.count = n
+		}
+	}
+	return p, nil
+}
+
+func (p pagination) offset() int {
+	return p.startIndex - 1
+}
+
+var equalityFilter = regexp.MustCompile(`^\s*(\w+(?:\.\w+)?)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseFilter parses the filter of a list request. Only equality filters on
+// the given attribute (e.g. `userName eq "alice"`) are supported, which is
+// what identity providers use to look up existing resources.
+func parseFilter(r *http.Request, attribute string) (value string, ok bool, err error) {
+	filter := r.URL.Query().Get("filter")
+	if filter == "" {
+		return "", false, nil
+	}
+
+	m := equalityFilter.FindStringSubmatch(filter)
+	if m == nil || !strings.EqualFold(m[1], attribute) {
+		return "", false, errBadRequest("invalidFilter", fmt.Sprintf("only %q equality filters are supported", attribute))
+	}
+
+	if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &value); err != nil {
+		return "", false, errBadRequest("invalidFilter", "invalid filter value")
+	}
+	return value, true, nil
+}
+
+// patchRequest is the body of a PATCH request.
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errBadRequest("invalidSyntax", err.Error())
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log15.Error("SCIM: failed to write response.", "err", err)
+	}
+}
+
+// parseID parses the ID of a user or group.
+func parseID(resourceType, id string) (int32, error) {
+	n, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return 0, errNotFound("%s %q not found", resourceType, id)
+	}
+	return int32(n), nil
+}
+
+// parseBool parses a boolean value of a PATCH operation, which some identity
+// providers send as a string (e.g. "False").
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, errBadRequest("invalidValue", "expected a boolean value")
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errBadRequest("invalidValue", "expected a boolean value")
+	}
+	return b, nil
+}
+
+// parseString parses a string value of a PATCH operation.
+func parseString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errBadRequest("invalidValue", "expected a string value")
+	}
+	return s, nil
+}
+
+func serveServiceProviderConfig(w http.ResponseWriter, r *http.Request, id string) error {
+	if id != "" {
+		return errNotFound("ServiceProviderConfig has no resources")
+	}
+	if r.Method != http.MethodGet {
+		return errMethodNotAllowed(r)
+	}
+
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{schemaServiceProviderConfig},
+		"patch":          supported{true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxCount},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Access token",
+			"description": "Authentication with an access token of a site admin",
+		}},
+	})
+	return nil
+}
+
+// scimError is an error that is reported to the client as a SCIM error
+// response.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return fmt.Sprintf("SCIM %d: %s", e.status, e.detail)
+}
+
+func errNotFound(format string, args ...interface{}) error {
+	return &scimError{status: http.StatusNotFound, detail: fmt.Sprintf(format, args...)}
+}
+
+func errBadRequest(scimType, detail string) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: detail}
+}
+
+func errConflict(detail string) error {
+	return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: detail}
+}
+
+func errMethodNotAllowed(r *http.Request) error {
+	return &scimError{status: http.StatusMethodNotAllowed, detail: fmt.Sprintf("method %s is not allowed", r.Method)}
+}
+
+// writeError writes the given error as a SCIM error response. Errors that are
+// not SCIM errors are reported as internal server errors, except for not found
+// errors.
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*scimError)
+	if !ok {
+		if errcode.IsNotFound(err) {
+			e = &scimError{status: http.StatusNotFound, detail: err.Error()}
+		} else {
+			log15.Error("SCIM: internal error.", "err", err)
+			e = &scimError{status: http.StatusInternalServerError, detail: http.StatusText(http.StatusInternalServerError)}
+		}
+	}
+
+	writeJSON(w, e.status, &errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(e.status),
+		ScimType: e.scimType,
+		Detail:   e.detail,
+	})
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
@@ -0,0 +1,507 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// fakeStore is an in-memory implementation of Store.
+type fakeStore struct {
+	users    map[int32]*types.User
+	inactive map[int32]bool
+	emails   map[int32][]string
+	orgs     map[int32]*types.Org
+	members  map[int32][]int32
+	nextID   int32
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		users:    map[int32]*types.User{},
+		inactive: map[int32]bool{},
+		emails:   map[int32][]string{},
+		orgs:     map[int32]*types.Org{},
+		members:  map[int32][]int32{},
+	}
+}
+
+func (s *fakeStore) GetUser(_ context.Context, id int32) (*types.User, bool, error) {
+	u, ok := s.users[id]
+	if !ok {
+		return nil, false, db.NewUserNotFoundError(id)
+	}
+	return u, !s.inactive[id], nil
+}
+
+func (s *fakeStore) GetUserByUsername(_ context.Context, username string) (*types.User, bool, error) {
+	var deactivated *types.User
+	for id, u := range s.users {
+		if u.Username != username {
+			continue
+		}
+		if !s.inactive[id] {
+			return u, true, nil
+		}
+		if deactivated == nil || id > deactivated.ID {
+			deactivated = u
+		}
+	}
+	if deactivated != nil {
+		return deactivated, false, nil
+	}
+	return nil, false, db.NewUserNotFoundError(0)
+}
+
+func (s *fakeStore) ListUsers(_ context.Context, offset, limit int) ([]*types.User, int, error) {
+	var users []*types.User
+	for id, u := range s.users {
+		if !s.inactive[id] {
+			users = append(users, u)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	total := len(users)
+	if offset > len(users) {
+		offset = len(users)
+	}
+	users = users[offset:]
+	if limit < len(users) {
+		users = users[:limit]
+	}
+	return users, total, nil
+}
+
+func (s *fakeStore) CreateUser(_ context.Context, newUser db.NewUser) (*types.User, error) {
+	if _, active, err := s.GetUserByUsername(context.Background(), newUser.Username); err == nil && active {
+		return nil, db.MockCannotCreateUserUsernameExistsErr
+	}
+	s.nextID++
+	u := &types.User{ID: s.nextID, Username: newUser.Username, DisplayName: newUser.DisplayName}
+	s.users[u.ID] = u
+	if newUser.Email != "" {
+		s.emails[u.ID] = []string{newUser.Email}
+	}
+	return u, nil
+}
+
+func (s *fakeStore) UpdateUser(_ context.Context, id int32, update db.UserUpdate) error {
+	u := s.users[id]
+	if update.Username != "" {
+		u.Username = update.Username
+	}
+	if update.DisplayName != nil {
+		u.DisplayName = *update.DisplayName
+	}
+	return nil
+}
+
+func (s *fakeStore) SetUserActive(_ context.Context, id int32, active bool) error {
+	s.inactive[id] = !active
+	if !active {
+		delete(s.emails, id)
+	}
+	return nil
+}
+
+func (s *fakeStore) DeleteUser(_ context.Context, id int32) error {
+	delete(s.users, id)
+	delete(s.inactive, id)
+	delete(s.emails, id)
+	return nil
+}
+
+func (s *fakeStore) ListUserEmails(_ context.Context, id int32) ([]string, error) {
+	return s.emails[id], nil
+}
+
+func (s *fakeStore) SetUserEmails(_ context.Context, id int32, emails []string) error {
+	s.emails[id] = emails
+	return nil
+}
+
+func (s *fakeStore) GetOrg(_ context.Context, id int32) (*types.Org, error) {
+	org, ok := s.orgs[id]
+	if !ok {
+		return nil, &db.OrgNotFoundError{}
+	}
+	return org, nil
+}
+
+func (s *fakeStore) GetOrgByDisplayName(_ context.Context, displayName string) (*types.Org, error) {
+	for _, org := range s.orgs {
+		if orgDisplayName(org) == displayName {
+			return org, nil
+		}
+	}
+	return nil, &db.OrgNotFoundError{}
+}
+
+func (s *fakeStore) ListOrgs(_ context.Context, offset, limit int) ([]*types.Org, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+func (s *fakeStore) CreateOrg(_ context.Context, name string, displayName *string) (*types.Org, error) {
+	s.nextID++
+	org := &types.Org{ID: s.nextID, Name: name, DisplayName: displayName}
+	s.orgs[org.ID] = org
+	return org, nil
+}
+
+func (s *fakeStore) UpdateOrg(_ context.Context, id int32, displayName *string) (*types.Org, error) {
+	s.orgs[id].DisplayName = displayName
+	return s.orgs[id], nil
+}
+
+func (s *fakeStore) DeleteOrg(_ context.Context, id int32) error {
+	delete(s.orgs, id)
+	delete(s.members, id)
+	return nil
+}
+
+func (s *fakeStore) ListOrgMembers(_ context.Context, orgID int32) ([]int32, error) {
+	return s.members[orgID], nil
+}
+
+func (s *fakeStore) AddOrgMember(_ context.Context, orgID, userID int32) error {
+	s.members[orgID] = append(s.members[orgID], userID)
+	return nil
+}
+
+func (s *fakeStore) RemoveOrgMember(_ context.Context, orgID, userID int32) error {
+	s.members[orgID] = removeIDs(s.members[orgID], []int32{userID})
+	return nil
+}
+
+// mockAuth mocks the access tokens "admin" (of a site admin) and "user" (of a
+// regular user).
+func mockAuth(t *testing.T) {
+	db.Mocks.AccessTokens.Lookup = func(token, scope string) (int32, error) {
+		switch token {
+		case "admin":
+			return 1, nil
+		case "user":
+			return 2, nil
+		}
+		return 0, errors.New("invalid token")
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, SiteAdmin: id == 1}, nil
+	}
+	t.Cleanup(func() {
+		db.Mocks.AccessTokens = db.MockAccessTokens{}
+		db.Mocks.Users = db.MockUsers{}
+	})
+}
+
+type testClient struct {
+	handler http.Handler
+}
+
+func newTestClient(store Store) *testClient {
+	m := mux.NewRouter()
+	m.Path("/.api/scim/v2/{rest:.*}").Handler(&handler{store: store})
+	return &testClient{handler: m}
+}
+
+// do sends a request with the given access token and returns the status code
+// and the decoded response body.
+func (c *testClient) do(t *testing.T, token, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, "/.api/scim/v2/"+path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	var resp map[string]interface{}
+	if rec.Body.Len() > 0 {
+		if got := rec.Header().Get("Content-Type"); got != contentType {
+			t.Fatalf("content type: want %q but got %q", contentType, got)
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func (c *testClient) mustDo(t *testing.T, wantCode int, method, path string, body interface{}) map[string]interface{} {
+	t.Helper()
+
+	code, resp := c.do(t, "admin", method, path, body)
+	if code != wantCode {
+		t.Fatalf("%s %s: want status %d but got %d: %v", method, path, wantCode, code, resp)
+	}
+	return resp
+}
+
+func TestAuthentication(t *testing.T) {
+	mockAuth(t)
+	c := newTestClient(newFakeStore())
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{name: "no token", wantCode: http.StatusUnauthorized},
+		{name: "invalid token", token: "invalid", wantCode: http.StatusUnauthorized},
+		{name: "not a site admin", token: "user", wantCode: http.StatusForbidden},
+		{name: "site admin", token: "admin", wantCode: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, resp := c.do(t, test.token, "GET", "Users", nil)
+			if code != test.wantCode {
+				t.Fatalf("want status %d but got %d: %v", test.wantCode, code, resp)
+			}
+			if code != http.StatusOK && resp["schemas"].([]interface{})[0] != schemaError {
+				t.Fatalf("want error response but got %v", resp)
+			}
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	mockAuth(t)
+	store := newFakeStore()
+	c := newTestClient(store)
+
+	user := c.mustDo(t, http.StatusCreated, "POST", "Users", map[string]interface{}{
+		"schemas":  []string{schemaUser},
+		"userName": "alice@example.com",
+		"name":     map[string]string{"givenName": "Alice", "familyName": "Smith"},
+		"emails": []map[string]interface{}{
+			{"value": "alice@old.example.com"},
+			{"value": "alice@example.com", "primary": true},
+		},
+	})
+	id := user["id"].(string)
+	if user["userName"] != "alice" || user["displayName"] != "Alice Smith" || user["active"] != true {
+		t.Fatalf("unexpected user: %v", user)
+	}
+	if diff := cmp.Diff([]string{"alice@example.com", "alice@old.example.com"}, store.emails[1]); diff != "" {
+		t.Fatalf("emails mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("create existing", func(t *testing.T) {
+		c.mustDo(t, http.StatusConflict, "POST", "Users", map[string]interface{}{"userName": "alice"})
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		resp := c.mustDo(t, http.StatusOK, "GET", `Users?filter=userName+eq+"alice@example.com"`, nil)
+		if resp["totalResults"] != float64(1) {
+			t.Fatalf("want 1 result but got %v", resp)
+		}
+		resp = c.mustDo(t, http.StatusOK, "GET", `Users?filter=userName+eq+"bob"`, nil)
+		if resp["totalResults"] != float64(0) {
+			t.Fatalf("want 0 results but got %v", resp)
+		}
+		c.mustDo(t, http.StatusBadRequest, "GET", `Users?filter=displayName+eq+"Alice"`, nil)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		resp := c.mustDo(t, http.StatusOK, "PATCH", "Users/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "Replace", "path": "name.givenName", "value": "Alicia"},
+				{"op": "Replace", "path": "name.familyName", "value": "Smith"},
+				{"op": "Replace", "path": `emails[type eq "work"].value`, "value": "alicia@example.com"},
+			},
+		})
+		if resp["displayName"] != "Alicia Smith" {
+			t.Fatalf("unexpected user: %v", resp)
+		}
+		if diff := cmp.Diff([]string{"alicia@example.com", "alice@old.example.com"}, store.emails[1]); diff != "" {
+			t.Fatalf("emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("deactivate and reactivate", func(t *testing.T) {
+		c.mustDo(t, http.StatusOK, "PATCH", "Users/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "replace", "value": map[string]interface{}{"active": "False"}},
+			},
+		})
+		resp := c.mustDo(t, http.StatusOK, "GET", "Users/"+id, nil)
+		if resp["active"] != false {
+			t.Fatalf("want inactive user but got %v", resp)
+		}
+
+		// The identity provider finds the deactivated user by its user name
+		// and reactivates it rather than creating a new user.
+		resp = c.mustDo(t, http.StatusOK, "GET", `Users?filter=userName+eq+"alice"`, nil)
+		resources, _ := resp["Resources"].([]interface{})
+		if resp["totalResults"] != float64(1) || len(resources) != 1 {
+			t.Fatalf("want 1 result but got %v", resp)
+		}
+		if found := resources[0].(map[string]interface{}); found["id"] != id || found["active"] != false {
+			t.Fatalf("want inactive user %s but got %v", id, found)
+		}
+		resp = c.mustDo(t, http.StatusOK, "PATCH", "Users/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "replace", "path": "active", "value": true},
+			},
+		})
+		if resp["id"] != id || resp["active"] != true {
+			t.Fatalf("want reactivated user %s but got %v", id, resp)
+		}
+		if len(store.users) != 1 {
+			t.Fatalf("want 1 user but got %d", len(store.users))
+		}
+
+		c.mustDo(t, http.StatusOK, "PATCH", "Users/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "replace", "path": "active", "value": false},
+			},
+		})
+		resp = c.mustDo(t, http.StatusOK, "PUT", "Users/"+id, map[string]interface{}{
+			"userName": "alice",
+			"active":   true,
+			"emails":   []map[string]interface{}{{"value": "alice@example.com", "primary": true}},
+		})
+		if resp["active"] != true || resp["displayName"] != nil {
+			t.Fatalf("unexpected user: %v", resp)
+		}
+		if diff := cmp.Diff([]string{"alice@example.com"}, store.emails[1]); diff != "" {
+			t.Fatalf("emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c.mustDo(t, http.StatusNoContent, "DELETE", "Users/"+id, nil)
+		c.mustDo(t, http.StatusNotFound, "GET", "Users/"+id, nil)
+	})
+}
+
+func TestGroups(t *testing.T) {
+	mockAuth(t)
+	store := newFakeStore()
+	c := newTestClient(store)
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		c.mustDo(t, http.StatusCreated, "POST", "Users", map[string]interface{}{"userName": name})
+	}
+
+	group := c.mustDo(t, http.StatusCreated, "POST", "Groups", map[string]interface{}{
+		"schemas":     []string{schemaGroup},
+		"displayName": "Platform Team",
+		"members":     []map[string]string{{"value": "1"}, {"value": "2"}},
+	})
+	id := group["id"].(string)
+	if name := store.orgs[4].Name; name != "Platform-Team" {
+		t.Fatalf("want organization name %q but got %q", "Platform-Team", name)
+	}
+
+	members := func() string {
+		ids := store.members[4]
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		b, _ := json.Marshal(ids)
+		return string(b)
+	}
+
+	t.Run("create with unknown member", func(t *testing.T) {
+		c.mustDo(t, http.StatusBadRequest, "POST", "Groups", map[string]interface{}{
+			"displayName": "Other",
+			"members":     []map[string]string{{"value": "42"}},
+		})
+	})
+
+	t.Run("create existing", func(t *testing.T) {
+		c.mustDo(t, http.StatusConflict, "POST", "Groups", map[string]interface{}{"displayName": "Platform Team"})
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		resp := c.mustDo(t, http.StatusOK, "GET", `Groups?filter=displayName+eq+"Platform%20Team"`, nil)
+		if resp["totalResults"] != float64(1) {
+			t.Fatalf("want 1 result but got %v", resp)
+		}
+	})
+
+	t.Run("patch members", func(t *testing.T) {
+		c.mustDo(t, http.StatusOK, "PATCH", "Groups/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "add", "path": "members", "value": []map[string]string{{"value": "3"}}},
+				{"op": "remove", "path": `members[value eq "1"]`},
+			},
+		})
+		if got := members(); got != "[2,3]" {
+			t.Fatalf("want members [2,3] but got %s", got)
+		}
+
+		c.mustDo(t, http.StatusOK, "PATCH", "Groups/"+id, map[string]interface{}{
+			"Operations": []map[string]interface{}{
+				{"op": "remove", "path": "members", "value": []map[string]string{{"value": "2"}}},
+			},
+		})
+		if got := members(); got != "[3]" {
+			t.Fatalf("want members [3] but got %s", got)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		resp := c.mustDo(t, http.StatusOK, "PUT", "Groups/"+id, map[string]interface{}{
+			"displayName": "Platform",
+			"members":     []map[string]string{{"value": "1"}},
+		})
+		if resp["displayName"] != "Platform" {
+			t.Fatalf("unexpected group: %v", resp)
+		}
+		if got := members(); got != "[1]" {
+			t.Fatalf("want members [1] but got %s", got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c.mustDo(t, http.StatusNoContent, "DELETE", "Groups/"+id, nil)
+		c.mustDo(t, http.StatusNotFound, "GET", "Groups/"+id, nil)
+	})
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter    string
+		wantValue string
+		wantOK    bool
+		wantErr   bool
+	}{
+		{filter: ""},
+		{filter: `userName eq "alice"`, wantValue: "alice", wantOK: true},
+		{filter: `username EQ "a \"quoted\" name"`, wantValue: `a "quoted" name`, wantOK: true},
+		{filter: `userName sw "a"`, wantErr: true},
+		{filter: `emails eq "a@example.com"`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?filter="+strings.NewReplacer(" ", "+", `"`, "%22").Replace(test.filter), nil)
+			value, ok, err := parseFilter(r, "userName")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("want error %v but got %v", test.wantErr, err)
+			}
+			if value != test.wantValue || ok != test.wantOK {
+				t.Fatalf("want (%q, %v) but got (%q, %v)", test.wantValue, test.wantOK, value, ok)
+			}
+		})
+	}
+}
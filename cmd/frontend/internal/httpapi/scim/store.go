@@ -0,0 +1,259 @@
+package scim
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// Store is the interface for the users and organizations operations needed to
+// serve the SCIM API.
+type Store interface {
+	// GetUser returns the user with the given ID, including a deactivated user.
+	GetUser(ctx context.Context, id int32) (user *types.User, active bool, err error)
+	// GetUserByUsername returns the user with the given username. If no active
+	// user has the username, the most recently deactivated one is returned.
+	GetUserByUsername(ctx context.Context, username string) (user *types.User, active bool, err error)
+	// ListUsers returns a page of active users and the total count of active users.
+	ListUsers(ctx context.Context, offset, limit int) ([]*types.User, int, error)
+	CreateUser(ctx context.Context, newUser db.NewUser) (*types.User, error)
+	UpdateUser(ctx context.Context, id int32, update db.UserUpdate) error
+	// SetUserActive deactivates (i.e. soft-deletes) or reactivates the user.
+	SetUserActive(ctx context.Context, id int32, active bool) error
+	DeleteUser(ctx context.Context, id int32) error
+	// ListUserEmails returns the emails of the user, the primary email first.
+	ListUserEmails(ctx context.Context, id int32) ([]string, error)
+	// SetUserEmails sets the verified emails of the user to exactly the given
+	// emails, where the first one becomes the primary email.
+	SetUserEmails(ctx context.Context, id int32, emails []string) error
+
+	GetOrg(ctx context.Context, id int32) (*types.Org, error)
+	// GetOrgByDisplayName returns the organization with the given display name
+	// (or name, if it has no display name).
+	GetOrgByDisplayName(ctx context.Context, displayName string) (*types.Org, error)
+	// ListOrgs returns a page of organizations and the total count of organizations.
+	ListOrgs(ctx context.Context, offset, limit int) ([]*types.Org, int, error)
+	CreateOrg(ctx context.Context, name string, displayName *string) (*types.Org, error)
+	UpdateOrg(ctx context.Context, id int32, displayName *string) (*types.Org, error)
+	DeleteOrg(ctx context.Context, id int32) error
+	ListOrgMembers(ctx context.Context, orgID int32) ([]int32, error)
+	AddOrgMember(ctx context.Context, orgID, userID int32) error
+	RemoveOrgMember(ctx context.Context, orgID, userID int32) error
+}
+
+// dbStore implements Store with the global database stores.
+type dbStore struct{}
+
+func (dbStore) GetUser(ctx context.Context, id int32) (*types.User, bool, error) {
+	user, err := db.Users.GetByID(ctx, id)
+	if err == nil {
+		return user, true, nil
+	} else if !errcode.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	user, err = db.Users.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, false, nil
+}
+
+func (dbStore) GetUserByUsername(ctx context.Context, username string) (*types.User, bool, error) {
+	user, err := db.Users.GetByUsername(ctx, username)
+	if err == nil {
+		return user, true, nil
+	} else if !errcode.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	user, err = db.Users.GetDeletedByUsername(ctx, username)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, false, nil
+}
+
+func (dbStore) ListUsers(ctx context.Context, offset, limit int) ([]*types.User, int, error) {
+	users, err := db.Users.List(ctx, &db.UsersListOptions{
+		LimitOffset: &db.LimitOffset{Offset: offset, Limit: limit},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := db.Users.Count(ctx, &db.UsersListOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, count, nil
+}
+
+func (dbStore) CreateUser(ctx context.Context, newUser db.NewUser) (*types.User, error) {
+	return db.Users.Create(ctx, newUser)
+}
+
+func (dbStore) UpdateUser(ctx context.Context, id int32, update db.UserUpdate) error {
+	return db.Users.Update(ctx, id, update)
+}
+
+func (dbStore) SetUserActive(ctx context.Context, id int32, active bool) error {
+	if active {
+		return db.Users.Recover(ctx, id)
+	}
+	return db.Users.Delete(ctx, id)
+}
+
+func (dbStore) DeleteUser(ctx context.Context, id int32) error {
+	return db.Users.HardDelete(ctx, id)
+}
+
+func (dbStore) ListUserEmails(ctx context.Context, id int32) ([]string, error) {
+	primary, _, err := db.UserEmails.GetPrimaryEmail(ctx, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	all, err := db.UserEmails.ListByUser(ctx, db.UserEmailsListOptions{UserID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	emails := []string{primary}
+	for _, e := range all {
+		if e.Email != primary {
+			emails = append(emails, e.Email)
+		}
+	}
+	return emails, nil
+}
+
+func (dbStore) SetUserEmails(ctx context.Context, id int32, emails []string) error {
+	existing, err := db.UserEmails.ListByUser(ctx, db.UserEmailsListOptions{UserID: id})
+	if err != nil {
+		return err
+	}
+	primary, _, err := db.UserEmails.GetPrimaryEmail(ctx, id)
+	if err != nil && !errcode.IsNotFound(err) {
+		return err
+	}
+
+	// The primary email is the oldest verified email. If the primary email
+	// changes, all existing emails are removed and added back in the given order.
+	reset := len(emails) > 0 && !strings.EqualFold(primary, emails[0])
+
+	keep := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		if !reset && containsFold(emails, e.Email) {
+			keep[strings.ToLower(e.Email)] = true
+			continue
+		}
+		if err := db.UserEmails.Remove(ctx, id, e.Email); err != nil {
+			return errors.Wrapf(err, "remove email %q", e.Email)
+		}
+	}
+
+	// 🚨 SECURITY: Emails provisioned by the identity provider are trusted, and
+	// are therefore added as verified.
+	for _, email := range emails {
+		if keep[strings.ToLower(email)] {
+			continue
+		}
+		if err := db.UserEmails.Add(ctx, id, email, nil); err != nil {
+			return errors.Wrapf(err, "add email %q", email)
+		}
+		if err := db.UserEmails.SetVerified(ctx, id, email, true); err != nil {
+			return errors.Wrapf(err, "verify email %q", email)
+		}
+	}
+	return nil
+}
+
+func (dbStore) GetOrg(ctx context.Context, id int32) (*types.Org, error) {
+	return db.Orgs.GetByID(ctx, id)
+}
+
+func (dbStore) GetOrgByDisplayName(ctx context.Context, displayName string) (*types.Org, error) {
+	orgs, err := db.Orgs.List(ctx, &db.OrgsListOptions{Query: displayName})
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		if orgDisplayName(org) == displayName {
+			return org, nil
+		}
+	}
+	return nil, &db.OrgNotFoundError{Message: "display name " + displayName}
+}
+
+func (dbStore) ListOrgs(ctx context.Context, offset, limit int) ([]*types.Org, int, error) {
+	orgs, err := db.Orgs.List(ctx, &db.OrgsListOptions{
+		LimitOffset: &db.LimitOffset{Offset: offset, Limit: limit},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := db.Orgs.Count(ctx, db.OrgsListOptions{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return orgs, count, nil
+}
+
+func (dbStore) CreateOrg(ctx context.Context, name string, displayName *string) (*types.Org, error) {
+	return db.Orgs.Create(ctx, name, displayName)
+}
+
+func (dbStore) UpdateOrg(ctx context.Context, id int32, displayName *string) (*types.Org, error) {
+	return db.Orgs.Update(ctx, id, displayName)
+}
+
+func (dbStore) DeleteOrg(ctx context.Context, id int32) error {
+	return db.Orgs.Delete(ctx, id)
+}
+
+func (dbStore) ListOrgMembers(ctx context.Context, orgID int32) ([]int32, error) {
+	members, err := db.OrgMembers.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int32, len(members))
+	for i := range members {
+		userIDs[i] = members[i].UserID
+	}
+	return userIDs, nil
+}
+
+func (dbStore) AddOrgMember(ctx context.Context, orgID, userID int32) error {
+	_, err := db.OrgMembers.Create(ctx, orgID, userID)
+	return err
+}
+
+func (dbStore) RemoveOrgMember(ctx context.Context, orgID, userID int32) error {
+	return db.OrgMembers.Remove(ctx, orgID, userID)
+}
+
+// orgDisplayName returns the display name of the organization, or its name if
+// it has no display name.
+func orgDisplayName(org *types.Org) string {
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		return *org.DisplayName
+	}
+	return org.Name
+}
+
+func containsFold(ss []string, s string) bool {
+	for i := range ss {
+		if strings.EqualFold(ss[i], s) {
+			return true
+		}
+	}
+	return false
+}
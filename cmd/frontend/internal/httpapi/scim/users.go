@@ -0,0 +1,615 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// userResource is the SCIM representation of a user.
+type userResource struct {
+	Schemas     []string  `json:"schemas"`
+	ID          string    `json:"id,omitempty"`
+	UserName    string    `json:"userName"`
+	Name        *userName `json:"name,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Emails      []email   `json:"emails,omitempty"`
+	Active      *bool     `json:"active,omitempty"`
+	Meta        *meta     `json:"meta,omitempty"`
+}
+
+type userName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// displayName returns the display name of the user, falling back to the name
+// of the user when no display name is given.
+func (u *userResource) displayName() string {
+	if u.DisplayName != "" || u.Name == nil {
+		return u.DisplayName
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// emailAddresses returns the email addresses of the user, the primary email
+// first. When no emails are given but the user name is an email address, the
+// user name is used as the primary email.
+func (u *userResource) emailAddresses() []string {
+	var emails []string
+	for _, e := range u.Emails {
+		if e.Value == "" || containsFold(emails, e.Value) {
+			continue
+		}
+		if e.Primary {
+			emails = append([]string{e.Value}, emails...)
+		} else {
+			emails = append(emails, e.Value)
+		}
+	}
+	if len(emails) == 0 && u.Emails == nil && strings.Count(u.UserName, "@") == 1 {
+		emails = []string{u.UserName}
+	}
+	return emails
+}
+
+func newUserResource(user *types.User, active bool, emails []string) *userResource {
+	r := &userResource{
+		Schemas:     []string{schemaUser},
+		ID:          strconv.Itoa(int(user.ID)),
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Meta:        newMeta("User", user.ID, user.CreatedAt, user.UpdatedAt),
+	}
+	if user.DisplayName != "" {
+		r.Name = &userName{Formatted: user.DisplayName}
+	}
+	for i, e := range emails {
+		r.Emails = append(r.Emails, email{Value: e, Type: "work", Primary: i == 0})
+	}
+	return r
+}
+
+func (h *handler) serveUsers(w http.ResponseWriter, r *http.Request, id string) error {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			return h.listUsers(w, r)
+		case http.MethodPost:
+			return h.createUser(w, r)
+		}
+		return errMethodNotAllowed(r)
+	}
+
+	userID, err := parseID("User", id)
+	if err != nil {
+		return err
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return h.getUser(w, r, userID)
+	case http.MethodPut:
+		return h.replaceUser(w, r, userID)
+	case http.MethodPatch:
+		return h.patchUser(w, r, userID)
+	case http.MethodDelete:
+		return h.deleteUser(w, r, userID)
+	}
+	return errMethodNotAllowed(r)
+}
+
+func (h *handler) listUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	p, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	filter, ok, err := parseFilter(r, "userName")
+	if err != nil {
+		return err
+	}
+
+	var (
+		users    []*types.User
+		total    int
+		inactive = map[int32]bool{}
+	)
+	if ok {
+		// Identity providers look up users by the user name they know, which is
+		// normalized the same way as on creation. Deactivated users are
+		// returned too, so that the identity provider reactivates the same
+		// account instead of creating a new one.
+		username, err := normalizeUsername(filter)
+		if err != nil {
+			return err
+		}
+		user, active, err := h.store.GetUserByUsername(ctx, username)
+		if err != nil && !errcode.IsNotFound(err) {
+			return err
+		}
+		if user != nil {
+			total = 1
+			inactive[user.ID] = !active
+			if p.offset() == 0 {
+				users = []*types.User{user}
+			}
+		}
+	} else {
+		// A count of 0 only asks for the total number of results.
+		users, total, err = h.store.ListUsers(ctx, p.offset(), maxInt(p.count, 1))
+		if err != nil {
+			return err
+		}
+	}
+	if len(users) > p.count {
+		users = users[:p.count]
+	}
+
+	resources := make([]*userResource, 0, len(users))
+	for _, user := range users {
+		if inactive[user.ID] {
+			// Emails of deactivated users are removed.
+			resources = append(resources, newUserResource(user, false, nil))
+			continue
+		}
+		emails, err := h.store.ListUserEmails(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, newUserResource(user, true, emails))
+	}
+
+	writeJSON(w, http.StatusOK, &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+	return nil
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var in userResource
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+	if in.UserName == "" {
+		return errBadRequest("invalidValue", "userName is required")
+	}
+	username, err := normalizeUsername(in.UserName)
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Emails provisioned by the identity provider are trusted, and
+	// are therefore created as verified.
+	newUser := db.NewUser{
+		Username:        username,
+		DisplayName:     in.displayName(),
+		EmailIsVerified: true,
+	}
+	emails := in.emailAddresses()
+	if len(emails) > 0 {
+		newUser.Email = emails[0]
+	}
+
+	user, err := h.store.CreateUser(ctx, newUser)
+	if err != nil {
+		if db.IsUsernameExists(err) || db.IsEmailExists(err) {
+			return errConflict(err.Error())
+		}
+		return err
+	}
+
+	if len(emails) > 1 {
+		if err := h.store.SetUserEmails(ctx, user.ID, emails); err != nil {
+			return err
+		}
+	}
+
+	active := in.Active == nil || *in.Active
+	if !active {
+		if err := h.store.SetUserActive(ctx, user.ID, false); err != nil {
+			return err
+		}
+	}
+
+	return h.writeUser(w, r, http.StatusCreated, user.ID)
+}
+
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	return h.writeUser(w, r, http.StatusOK, id)
+}
+
+func (h *handler) writeUser(w http.ResponseWriter, r *http.Request, status int, id int32) error {
+	user, active, err := h.store.GetUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	var emails []string
+	if active {
+		// Emails of deactivated users are removed.
+		emails, err = h.store.ListUserEmails(r.Context(), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	writeJSON(w, status, newUserResource(user, active, emails))
+	return nil
+}
+
+func (h *handler) replaceUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	var in userResource
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+	if in.UserName == "" {
+		return errBadRequest("invalidValue", "userName is required")
+	}
+
+	displayName := in.displayName()
+	u := userUpdate{
+		username:    &in.UserName,
+		displayName: &displayName,
+		active:      in.Active,
+	}
+	if in.Emails != nil {
+		u.emails = in.emailAddresses()
+		u.setEmails = true
+	}
+
+	if err := h.updateUser(r.Context(), id, u); err != nil {
+		return err
+	}
+	return h.writeUser(w, r, http.StatusOK, id)
+}
+
+func (h *handler) patchUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	var in patchRequest
+	if err := readJSON(r, &in); err != nil {
+		return err
+	}
+
+	_, active, err := h.store.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	p := userPatch{
+		loadEmails: func() ([]string, error) {
+			if !active {
+				return nil, nil
+			}
+			return h.store.ListUserEmails(ctx, id)
+		},
+	}
+	for _, op := range in.Operations {
+		if err := p.apply(op.Op, op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+
+	if err := h.updateUser(ctx, id, p.update()); err != nil {
+		return err
+	}
+	return h.writeUser(w, r, http.StatusOK, id)
+}
+
+func (h *handler) deleteUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	if _, _, err := h.store.GetUser(r.Context(), id); err != nil {
+		return err
+	}
+	if err := h.store.DeleteUser(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// userUpdate is the set of changes to a user requested by a PUT or PATCH
+// request. Nil fields are left unchanged.
+type userUpdate struct {
+	username    *string
+	displayName *string
+	emails      []string
+	setEmails   bool
+	active      *bool
+}
+
+// updateUser applies the given changes to the user. Deactivated users can't be
+// changed, except for being reactivated.
+func (h *handler) updateUser(ctx context.Context, id int32, u userUpdate) error {
+	user, active, err := h.store.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if u.active != nil && *u.active && !active {
+		if err := h.store.SetUserActive(ctx, id, true); err != nil {
+			if db.IsUsernameExists(err) {
+				return errConflict(err.Error())
+			}
+			return err
+		}
+		active = true
+	}
+	if !active {
+		return nil
+	}
+
+	var update db.UserUpdate
+	if u.username != nil {
+		username, err := normalizeUsername(*u.username)
+		if err != nil {
+			return err
+		}
+		if username != user.Username {
+			update.Username = username
+		}
+	}
+	if u.displayName != nil && *u.displayName != user.DisplayName {
+		update.DisplayName = u.displayName
+	}
+	if update != (db.UserUpdate{}) {
+		if err := h.store.UpdateUser(ctx, id, update); err != nil {
+			if db.IsUsernameExists(err) {
+				return errConflict(err.Error())
+			}
+			return err
+		}
+	}
+
+	if u.setEmails {
+		if err := h.store.SetUserEmails(ctx, id, u.emails); err != nil {
+			return err
+		}
+	}
+
+	if u.active != nil && !*u.active {
+		return h.store.SetUserActive(ctx, id, false)
+	}
+	return nil
+}
+
+// userPatch accumulates the changes of the operations of a PATCH request.
+type userPatch struct {
+	userUpdate
+
+	givenName, familyName *string
+
+	// loadEmails returns the current emails of the user, and is used when
+	// operations add or remove individual emails.
+	loadEmails func() ([]string, error)
+	loaded     bool
+}
+
+// apply applies a single PATCH operation. Operations on attributes that are
+// not stored by Sourcegraph (e.g. "title" or "externalId") are ignored, since
+// identity providers send them regardless of the schema of the service
+// provider.
+func (p *userPatch) apply(op, path string, value json.RawMessage) error {
+	op = strings.ToLower(op)
+	switch op {
+	case "add", "replace", "remove":
+	default:
+		return errBadRequest("invalidSyntax", "unsupported operation "+strconv.Quote(op))
+	}
+
+	if path == "" {
+		if op == "remove" {
+			return errBadRequest("noTarget", "path is required for remove operations")
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attrs); err != nil {
+			return errBadRequest("invalidValue", "expected an object value")
+		}
+		for attr, v := range attrs {
+			if err := p.apply(op, attr, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch lower := strings.ToLower(path); lower {
+	case "active":
+		if op == "remove" {
+			return errBadRequest("mutability", "active can't be removed")
+		}
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		p.active = &active
+
+	case "username":
+		if op == "remove" {
+			return errBadRequest("mutability", "userName can't be removed")
+		}
+		s, err := parseString(value)
+		if err != nil {
+			return err
+		}
+		p.username = &s
+
+	case "displayname", "name.formatted":
+		var s string
+		if op != "remove" {
+			var err error
+			if s, err = parseString(value); err != nil {
+				return err
+			}
+		}
+		p.displayName = &s
+
+	case "name.givenname", "name.familyname":
+		var s string
+		if op != "remove" {
+			var err error
+			if s, err = parseString(value); err != nil {
+				return err
+			}
+		}
+		if lower == "name.givenname" {
+			p.givenName = &s
+		} else {
+			p.familyName = &s
+		}
+
+	case "name":
+		if op == "remove" {
+			s := ""
+			p.displayName = &s
+			return nil
+		}
+		var name userName
+		if err := json.Unmarshal(value, &name); err != nil {
+			return errBadRequest("invalidValue", "expected a name object")
+		}
+		s := (&userResource{Name: &name}).displayName()
+		p.displayName = &s
+
+	case "emails":
+		return p.applyEmails(op, value)
+
+	case `emails[type eq "work"].value`, `emails[primary eq true].value`:
+		if op == "remove" {
+			return p.applyEmails(op, nil)
+		}
+		s, err := parseString(value)
+		if err != nil {
+			return err
+		}
+		return p.replacePrimaryEmail(s)
+	}
+	return nil
+}
+
+func (p *userPatch) load() error {
+	if p.loaded || p.setEmails {
+		return nil
+	}
+	emails, err := p.loadEmails()
+	if err != nil {
+		return err
+	}
+	p.emails, p.loaded = emails, true
+	return nil
+}
+
+func (p *userPatch) applyEmails(op string, value json.RawMessage) error {
+	var emails []email
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return errBadRequest("invalidValue", "expected an array of emails")
+		}
+	}
+	given := (&userResource{Emails: emails}).emailAddresses()
+
+	switch op {
+	case "replace":
+		p.emails = given
+
+	case "add":
+		if err := p.load(); err != nil {
+			return err
+		}
+		for _, e := range given {
+			if !containsFold(p.emails, e) {
+				p.emails = append(p.emails, e)
+			}
+		}
+
+	case "remove":
+		if len(given) == 0 {
+			p.emails = nil
+			break
+		}
+		if err := p.load(); err != nil {
+			return err
+		}
+		remaining := p.emails[:0]
+		for _, e := range p.emails {
+			if !containsFold(given, e) {
+				remaining = append(remaining, e)
+			}
+		}
+		p.emails = remaining
+	}
+	p.setEmails = true
+	return nil
+}
+
+func (p *userPatch) replacePrimaryEmail(value string) error {
+	if err := p.load(); err != nil {
+		return err
+	}
+	emails := []string{value}
+	for i, e := range p.emails {
+		if i > 0 && !strings.EqualFold(e, value) {
+			emails = append(emails, e)
+		}
+	}
+	p.emails, p.setEmails = emails, true
+	return nil
+}
+
+// update returns the accumulated changes of the PATCH request.
+func (p *userPatch) update() userUpdate {
+	u := p.userUpdate
+	if u.displayName == nil && (p.givenName != nil || p.familyName != nil) {
+		var given, family string
+		if p.givenName != nil {
+			given = *p.givenName
+		}
+		if p.familyName != nil {
+			family = *p.familyName
+		}
+		s := strings.TrimSpace(given + " " + family)
+		u.displayName = &s
+	}
+	return u
+}
+
+// normalizeUsername returns the Sourcegraph username for the given SCIM user
+// name, which is often an email address.
+func normalizeUsername(name string) (string, error) {
+	username, err := auth.NormalizeUsername(name)
+	if err != nil {
+		return "", errBadRequest("invalidValue", err.Error())
+	}
+	return username, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,300 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+// streamSearch is the function used to run streaming searches. It is replaced
+// in tests.
+var streamSearch = graphqlbackend.StreamSearch
+
+// serveSearchStream runs the search given by the "q" query parameter and
+// streams its results to the client as server-sent events. Results are sent as
+// each search backend produces them rather than once all backends have
+// completed. The optional "continuation" query parameter continues a search
+// like the continuation argument of the GraphQL search query.
+//
+// The following events are sent:
+//
+//   - filematches, repomatches, commitmatches: a JSON array of results
+//   - progress: the statistics accumulated so far in the search
+//   - alert: a search alert, at most once per search
+//   - error: the search failed
+//   - done: the search has completed, always the last event. It carries the
+//     continuation of a continuable search with more results.
+func serveSearchStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	args, err := searchStreamArgs(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	events := make(chan graphqlbackend.SearchEvent)
+	var searchErr error
+	go func() {
+		defer close(events)
+		searchErr = streamSearch(ctx, args, events)
+	}()
+
+	ew := &eventWriter{w: w, flusher: flusher}
+	progress := newStreamProgress()
+	var done streamDone
+	for event := range events {
+		// Keep draining events after a write failure so that the search is not
+		// blocked on the channel until the request context is canceled.
+		if ew.err != nil {
+			continue
+		}
+
+		fileMatches, repoMatches, commitMatches := convertSearchResults(event.Results)
+		if len(fileMatches) > 0 {
+			ew.write("filematches", fileMatches)
+		}
+		if len(repoMatches) > 0 {
+			ew.write("repomatches", repoMatches)
+		}
+		if len(commitMatches) > 0 {
+			ew.write("commitmatches", commitMatches)
+		}
+
+		progress.update(event)
+		ew.write("progress", progress)
+
+		if event.Alert != nil {
+			ew.write("alert", streamAlert{
+				Title:       event.Alert.Title(),
+				Description: event.Alert.Description(),
+			})
+		}
+		if event.Continuation != nil {
+			done.Continuation = event.Continuation
+		}
+	}
+
+	if searchErr != nil {
+		ew.write("error", streamError{Message: searchErr.Error()})
+	}
+	ew.write("done", done)
+
+	if ew.err != nil {
+		log15.Warn("failed to write search stream", "error", ew.err)
+	}
+}
+
+// searchStreamArgs reads the search arguments from the query parameters of a
+// streaming search request.
+func searchStreamArgs(r *http.Request) (*graphqlbackend.SearchArgs, error) {
+	query := r.URL.Query()
+
+	args := &graphqlbackend.SearchArgs{
+		Version: "V2",
+		Query:   query.Get("q"),
+	}
+	if args.Query == "" {
+		return nil, fmt.Errorf("no query found")
+	}
+	if v := query.Get("v"); v != "" {
+		args.Version = v
+	}
+	if t := query.Get("t"); t != "" {
+		args.PatternType = &t
+	}
+	if c, ok := query["continuation"]; ok && len(c) > 0 {
+		args.Continuation = &c[0]
+	}
+
+	return args, nil
+}
+
+// eventWriter writes server-sent events, remembering the first write error.
+type eventWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+	err     error
+}
+
+func (ew *eventWriter) write(event string, data interface{}) {
+	if ew.err != nil {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		ew.err = err
+		return
+	}
+
+	if _, err := fmt.Fprintf(ew.w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		ew.err = err
+		return
+	}
+
+	ew.flusher.Flush()
+}
+
+type streamFileMatch struct {
+	Repository  string            `json:"repository"`
+	Commit      string            `json:"commit,omitempty"`
+	Path        string            `json:"path"`
+	LineMatches []streamLineMatch `json:"lineMatches,omitempty"`
+	Symbols     []streamSymbol    `json:"symbols,omitempty"`
+	LimitHit    bool              `json:"limitHit"`
+}
+
+type streamLineMatch struct {
+	Preview          string    `json:"preview"`
+	LineNumber       int32     `json:"lineNumber"`
+	OffsetAndLengths [][]int32 `json:"offsetAndLengths"`
+}
+
+type streamSymbol struct {
+	Name          string  `json:"name"`
+	ContainerName *string `json:"containerName,omitempty"`
+	Kind          string  `json:"kind"`
+}
+
+type streamRepoMatch struct {
+	Repository string `json:"repository"`
+}
+
+type streamCommitMatch struct {
+	Label  string `json:"label"`
+	URL    string `json:"url"`
+	Detail string `json:"detail"`
+}
+
+type streamAlert struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+}
+
+type streamError struct {
+	Message string `json:"message"`
+}
+
+type streamDone struct {
+	Continuation *string `json:"continuation,omitempty"`
+}
+
+// convertSearchResults converts search results into their streamed
+// representation, grouped by result type. Codemod results are not streamed.
+func convertSearchResults(results []graphqlbackend.SearchResultResolver) (fileMatches []streamFileMatch, repoMatches []streamRepoMatch, commitMatches []streamCommitMatch) {
+	for _, result := range results {
+		if fm, ok := result.ToFileMatch(); ok {
+			fileMatches = append(fileMatches, convertFileMatch(fm))
+		}
+		if repo, ok := result.ToRepository(); ok {
+			repoMatches = append(repoMatches, streamRepoMatch{Repository: repo.Name()})
+		}
+		if commit, ok := result.ToCommitSearchResult(); ok {
+			commitMatches = append(commitMatches, streamCommitMatch{
+				Label:  commit.Label().Text(),
+				URL:    commit.URL(),
+				Detail: commit.Detail().Text(),
+			})
+		}
+	}
+
+	return fileMatches, repoMatches, commitMatches
+}
+
+func convertFileMatch(fm *graphqlbackend.FileMatchResolver) streamFileMatch {
+	lineMatches := make([]streamLineMatch, 0, len(fm.LineMatches()))
+	for _, lm := range fm.LineMatches() {
+		lineMatches = append(lineMatches, streamLineMatch{
+			Preview:          lm.Preview(),
+			LineNumber:       lm.LineNumber(),
+			OffsetAndLengths: lm.OffsetAndLengths(),
+		})
+	}
+
+	symbols := make([]streamSymbol, 0, len(fm.Symbols()))
+	for _, symbol := range fm.Symbols() {
+		symbols = append(symbols, streamSymbol{
+			Name:          symbol.Name(),
+			ContainerName: symbol.ContainerName(),
+			Kind:          symbol.Kind(),
+		})
+	}
+
+	var repository string
+	if fm.Repo != nil {
+		repository = fm.Repo.Name()
+	}
+
+	return streamFileMatch{
+		Repository:  repository,
+		Commit:      string(fm.CommitID),
+		Path:        fm.JPath,
+		LineMatches: lineMatches,
+		Symbols:     symbols,
+		LimitHit:    fm.LimitHit(),
+	}
+}
+
+// streamProgress is the cumulative progress of a streaming search.
+type streamProgress struct {
+	ResultCount int      `json:"resultCount"`
+	LimitHit    bool     `json:"limitHit"`
+	Cloning     []string `json:"cloning"`
+	Missing     []string `json:"missing"`
+	Timedout    []string `json:"timedout"`
+
+	cloning  map[string]struct{}
+	missing  map[string]struct{}
+	timedout map[string]struct{}
+}
+
+func newStreamProgress() *streamProgress {
+	return &streamProgress{
+		Cloning:  []string{},
+		Missing:  []string{},
+		Timedout: []string{},
+		cloning:  map[string]struct{}{},
+		missing:  map[string]struct{}{},
+		timedout: map[string]struct{}{},
+	}
+}
+
+// update adds the results and statistics of the given event to the progress.
+func (p *streamProgress) update(event graphqlbackend.SearchEvent) {
+	p.ResultCount += len(event.Results)
+	p.LimitHit = p.LimitHit || event.Stats.LimitHit()
+	p.Cloning = addRepositoryNames(p.Cloning, p.cloning, event.Stats.Cloning())
+	p.Missing = addRepositoryNames(p.Missing, p.missing, event.Stats.Missing())
+	p.Timedout = addRepositoryNames(p.Timedout, p.timedout, event.Stats.Timedout())
+}
+
+// addRepositoryNames adds the names of repos not yet in seen to names and
+// returns the sorted result.
+func addRepositoryNames(names []string, seen map[string]struct{}, repos []*graphqlbackend.RepositoryResolver) []string {
+	for _, repo := range repos {
+		if _, ok := seen[repo.Name()]; ok {
+			continue
+		}
+
+		seen[repo.Name()] = struct{}{}
+		names = append(names, repo.Name())
+	}
+
+	sort.Strings(names)
+	return names
+}
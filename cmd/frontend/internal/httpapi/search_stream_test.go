@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+)
+
+func TestServeSearchStream(t *testing.T) {
+	defer func() { streamSearch = graphqlbackend.StreamSearch }()
+
+	var args *graphqlbackend.SearchArgs
+	streamSearch = func(ctx context.Context, a *graphqlbackend.SearchArgs, events chan<- graphqlbackend.SearchEvent) error {
+		args = a
+
+		repo := graphqlbackend.NewRepositoryResolver(&types.Repo{Name: "github.com/foo/bar"})
+		events <- graphqlbackend.SearchEvent{
+			Results: []graphqlbackend.SearchResultResolver{
+				&graphqlbackend.FileMatchResolver{JPath: "main.go", Repo: repo, CommitID: "deadbeef"},
+			},
+		}
+		events <- graphqlbackend.SearchEvent{
+			Results: []graphqlbackend.SearchResultResolver{repo},
+		}
+		return nil
+	}
+
+	req := httptest.NewRequest("GET", "/search/stream?q=foo&t=literal", nil)
+	rec := httptest.NewRecorder()
+	serveSearchStream(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d", http.StatusOK, rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("unexpected content type. want=%q have=%q", "text/event-stream", contentType)
+	}
+	if args.Query != "foo" || args.PatternType == nil || *args.PatternType != "literal" {
+		t.Errorf("unexpected search args: %+v", args)
+	}
+
+	expected := strings.Join([]string{
+		"event: filematches",
+		`data: [{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","limitHit":false}]`,
+		"",
+		"event: progress",
+		`data: {"resultCount":1,"limitHit":false,"cloning":[],"missing":[],"timedout":[]}`,
+		"",
+		"event: repomatches",
+		`data: [{"repository":"github.com/foo/bar"}]`,
+		"",
+		"event: progress",
+		`data: {"resultCount":2,"limitHit":false,"cloning":[],"missing":[],"timedout":[]}`,
+		"",
+		"event: done",
+		"data: {}",
+		"",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(expected, rec.Body.String()); diff != "" {
+		t.Errorf("unexpected stream (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeSearchStreamError(t *testing.T) {
+	defer func() { streamSearch = graphqlbackend.StreamSearch }()
+
+	streamSearch = func(ctx context.Context, a *graphqlbackend.SearchArgs, events chan<- graphqlbackend.SearchEvent) error {
+		return errors.New("oops")
+	}
+
+	req := httptest.NewRequest("GET", "/search/stream?q=foo", nil)
+	rec := httptest.NewRecorder()
+	serveSearchStream(rec, req)
+
+	expected := "event: error\ndata: {\"message\":\"oops\"}\n\nevent: done\ndata: {}\n\n"
+	if diff := cmp.Diff(expected, rec.Body.String()); diff != "" {
+		t.Errorf("unexpected stream (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeSearchStreamContinuation(t *testing.T) {
+	defer func() { streamSearch = graphqlbackend.StreamSearch }()
+
+	var args *graphqlbackend.SearchArgs
+	streamSearch = func(ctx context.Context, a *graphqlbackend.SearchArgs, events chan<- graphqlbackend.SearchEvent) error {
+		args = a
+
+		continuation := "next"
+		events <- graphqlbackend.SearchEvent{Continuation: &continuation}
+		return nil
+	}
+
+	req := httptest.NewRequest("GET", "/search/stream?q=foo&continuation=", nil)
+	rec := httptest.NewRecorder()
+	serveSearchStream(rec, req)
+
+	if args.Continuation == nil || *args.Continuation != "" {
+		t.Errorf("unexpected search args: %+v", args)
+	}
+
+	expected := strings.Join([]string{
+		"event: progress",
+		`data: {"resultCount":0,"limitHit":false,"cloning":[],"missing":[],"timedout":[]}`,
+		"",
+		"event: done",
+		`data: {"continuation":"next"}`,
+		"",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(expected, rec.Body.String()); diff != "" {
+		t.Errorf("unexpected stream (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeSearchStreamMissingQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/search/stream", nil)
+	rec := httptest.NewRecorder()
+	serveSearchStream(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code. want=%d have=%d", http.StatusBadRequest, rec.Code)
+	}
+}
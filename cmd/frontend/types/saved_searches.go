@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // SavedSearch represents a saved search
 type SavedSearch struct {
 	ID              int32 // the globally unique DB ID
@@ -10,4 +12,32 @@ type SavedSearch struct {
 	UserID          *int32  // if non-nil, the owner is this user. UserID/OrgID are mutually exclusive.
 	OrgID           *int32  // if non-nil, the owner is this organization. UserID/OrgID are mutually exclusive.
 	SlackWebhookURL *string // if non-nil && NotifySlack == true, indicates that this Slack webhook URL should be used instead of the owners default Slack webhook.
+	NotifyWebhook   bool    // whether or not to post new results of this saved search to WebhookURL
+	WebhookURL      *string // the URL that new results are posted to if NotifyWebhook == true
+	WebhookSecret   *string // if non-nil, the secret used to sign webhook payloads
+}
+
+// SavedSearchWebhookDelivery represents a delivery of a saved search webhook payload.
+type SavedSearchWebhookDelivery struct {
+	ID            int64
+	SavedSearchID int32
+	URL           string
+	Payload       []byte     // the JSON payload that was posted
+	Attempts      int32      // the number of attempts, including the last one
+	StatusCode    *int32     // the HTTP status code of the response to the last attempt, if any
+	Error         *string    // the error of the last attempt, if it failed
+	CreatedAt     time.Time  // when the delivery was created
+	DeliveredAt   *time.Time // when the delivery succeeded, if it did
+}
+
+// QueryRunnerRun represents a run of a saved search query by the query runner.
+type QueryRunnerRun struct {
+	ID          int64
+	Query       string
+	Runner      string    // the hostname of the query runner replica that ran the query
+	ScheduledAt time.Time // when the query was due to run
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	ResultCount *int32  // the number of results, if the search succeeded
+	Error       *string // the error of the search, if it failed
 }
@@ -50,6 +50,8 @@ func main() {
 		ReposDir:                reposDir,
 		DeleteStaleRepositories: runRepoCleanup,
 		DesiredPercentFree:      wantPctFree2,
+		PartialClone:            server.ExternalServicePartialClone,
+		Hostname:                os.Getenv("HOSTNAME"),
 	}
 	gitserver.RegisterMetrics()
 
@@ -64,7 +66,11 @@ func main() {
 	// Create Handler now since it also initializes state
 	handler := ot.Middleware(gitserver.Handler())
 
-	go debugserver.Start()
+	go debugserver.Start(debugserver.Endpoint{
+		Name:    "Migration Status",
+		Path:    "/migration-status",
+		Handler: gitserver.MigrationStatusHandler(),
+	})
 
 	janitorInterval2, err := time.ParseDuration(janitorInterval)
 	if err != nil {
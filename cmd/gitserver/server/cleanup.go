@@ -55,7 +55,9 @@ var (
 // 1. Remove corrupt repos.
 // 2. Remove stale lock files.
 // 3. Remove inactive repos on sourcegraph.com
-// 4. Reclone repos after a while. (simulate git gc)
+// 4. Remove repos that moved to other gitservers.
+// 5. Sync secondary replicas with their primary.
+// 6. Reclone repos after a while. (simulate git gc)
 func (s *Server) cleanupRepos() {
 	bCtx, bCancel := s.serverContext()
 	defer bCancel()
@@ -76,6 +78,18 @@ func (s *Server) cleanupRepos() {
 		return true, nil
 	}
 
+	maybeRebalance := func(dir GitDir) (done bool, err error) {
+		ctx, cancel := context.WithTimeout(bCtx, longGitCommandTimeout)
+		defer cancel()
+		return s.maybeRebalance(ctx, dir)
+	}
+
+	maybeSyncReplica := func(dir GitDir) (done bool, err error) {
+		ctx, cancel := context.WithTimeout(bCtx, longGitCommandTimeout)
+		defer cancel()
+		return s.maybeSyncReplica(ctx, dir)
+	}
+
 	ensureGitAttributes := func(dir GitDir) (done bool, err error) {
 		return false, setGitAttributes(dir)
 	}
@@ -176,9 +190,15 @@ func (s *Server) cleanupRepos() {
 		// If git is interrupted it can leave lock files lying around. It does
 		// not clean these up, and instead fails commands.
 		{"remove stale locks", removeStaleLocks},
+		// When the list of gitservers changes, repositories move to other
+		// gitservers. Remove them here once their new replicas have them.
+		{"maybe rebalance", maybeRebalance},
 		// We always want to have the same git attributes file at
 		// info/attributes.
 		{"ensure git attributes", ensureGitAttributes},
+		// Secondary replicas serve reads when the primary is unreachable, so
+		// keep their refs in sync with the primary.
+		{"maybe sync replica", maybeSyncReplica},
 		// Old git clones accumulate loose git objects that waste space and
 		// slow down git operations. Periodically do a fresh clone to avoid
 		// these problems. git gc is slow and resource intensive. It is
@@ -186,6 +206,7 @@ func (s *Server) cleanupRepos() {
 		{"maybe reclone", maybeReclone},
 	}
 
+	s.migration.beginJanitorRun()
 	err := bestEffortWalk(s.ReposDir, func(dir string, fi os.FileInfo) error {
 		if s.ignorePath(dir) {
 			if fi.IsDir() {
@@ -216,6 +237,8 @@ func (s *Server) cleanupRepos() {
 	if err != nil {
 		log15.Error("cleanup: error iterating over repositories", "error", err)
 	}
+	addr, _ := s.selfAddr(bCtx)
+	s.migration.endJanitorRun(addr)
 
 	if s.DiskSizer == nil {
 		s.DiskSizer = &StatDiskSizer{}
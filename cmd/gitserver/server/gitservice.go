@@ -102,6 +102,14 @@ func (s *gitServiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	cmd := exec.CommandContext(r.Context(), "git", args...)
 	cmd.Env = env
+	if isPartialClone(GitDir(dir)) {
+		// Objects missing from a partial clone are fetched from its remote
+		// while they are packed, so configure the command as we do for
+		// fetches. Newer versions of git disable these fetches in upload-pack
+		// unless asked to.
+		cmd.Env = append(cmd.Env, "GIT_NO_LAZY_FETCH=0")
+		configureRemoteGitCommand(cmd, tlsExternal().(*tlsConfig))
+	}
 	cmd.Stdout = flowrateWriter(w)
 	cmd.Stdin = body
 	if err := cmd.Run(); err != nil {
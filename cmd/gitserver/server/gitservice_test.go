@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -75,3 +76,37 @@ func TestGitServiceHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGitServiceHandlerPartialClone(t *testing.T) {
+	root := tmpDir(t)
+	remote := filepath.Join(root, "remote")
+
+	// Setup a remote that allows partial clones, and a blob-less partial
+	// clone of it to serve.
+	runCmd(t, root, "git", "init", remote)
+	runCmd(t, remote, "git", "config", "uploadpack.allowFilter", "true")
+	runCmd(t, remote, "git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	runCmd(t, remote, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, remote, "git", "add", "hello.txt")
+	runCmd(t, remote, "git", "commit", "-m", "hello")
+	runCmd(t, root, "git", "clone", "--mirror", "--filter="+partialCloneFilter, "file://"+remote, filepath.Join(root, "partial", ".git"))
+
+	if !isPartialClone(GitDir(filepath.Join(root, "partial", ".git"))) {
+		t.Fatal("expected a partial clone")
+	}
+
+	ts := httptest.NewServer(&gitServiceHandler{
+		Dir: func(s string) string {
+			return filepath.Join(root, s, ".git")
+		},
+	})
+	defer ts.Close()
+
+	// A full clone needs the blobs missing from the partial clone, which are
+	// fetched from the remote while serving it.
+	dst := tmpDir(t)
+	runCmd(t, dst, "git", "clone", ts.URL+"/partial", "clone")
+	if contents := runCmd(t, filepath.Join(dst, "clone"), "cat", "hello.txt"); strings.TrimSpace(contents) != "hello world" {
+		t.Fatalf("unexpected file contents: %q", contents)
+	}
+}
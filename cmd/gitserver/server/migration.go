@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// peerLookupTimeout is how long we wait for the other gitservers to report
+// whether they have a repository before cloning it from the code host.
+const peerLookupTimeout = 5 * time.Second
+
+// maxIncomingMoves is the number of repositories cloned from other gitservers
+// shown in the migration status.
+const maxIncomingMoves = 100
+
+var reposClonedFromPeer = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_repos_cloned_from_peer",
+	Help: "number of repos cloned from another gitserver rather than the code host",
+}, []string{"status"})
+
+// findPeerClone returns the address of another gitserver that has repo
+// cloned. This is the case when repo moved to this gitserver because the list
+// of gitservers changed. The empty string is returned if no other gitserver
+// has repo, or if the address of this gitserver is unknown.
+func (s *Server) findPeerClone(ctx context.Context, repo api.RepoName) string {
+	self, ok := s.selfAddr(ctx)
+	if !ok {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, peerLookupTimeout)
+	defer cancel()
+
+	// Errors only mean that some gitservers could not be asked, so we use
+	// whichever gitservers did answer.
+	addrs, err := s.peerClient().ClonedAddrs(ctx, repo)
+	if err != nil {
+		log15.Debug("failed to ask all gitservers for repo", "repo", repo, "error", err)
+	}
+	for _, addr := range addrs {
+		if addr != self {
+			return addr
+		}
+	}
+	return ""
+}
+
+// cloneFromPeer mirrors repo from the git smart HTTP endpoint of the gitserver
+// at addr into tmpPath, and then points its origin remote at remoteURL so that
+// later fetches go to the code host. It returns false if the clone failed, in
+// which case tmpPath is removed so the repository can be cloned from the code
+// host instead.
+func (s *Server) cloneFromPeer(ctx context.Context, lock *RepositoryLock, repo api.RepoName, addr, remoteURL, tmpPath string) bool {
+	move := incomingMove{Repo: repo, From: addr, Started: time.Now()}
+	defer func() {
+		move.Duration = time.Since(move.Started).String()
+		s.migration.incoming(move)
+	}()
+
+	peerURL := "http://" + addr + "/git/" + string(repo)
+	log15.Info("cloning repo from gitserver", "repo", repo, "gitserver", addr, "tmp", tmpPath)
+
+	err := func() error {
+		cmd := exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", peerURL, tmpPath)
+		cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
+
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		go readCloneProgress(newURLRedactor(peerURL), lock, pr, false)
+
+		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		}
+
+		cmd = exec.CommandContext(ctx, "git", "remote", "set-url", "origin", remoteURL)
+		GitDir(tmpPath).Set(cmd)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "failed to set remote URL. Output: %s", newURLRedactor(remoteURL).redact(string(output)))
+		}
+		return nil
+	}()
+	if err != nil {
+		log15.Warn("failed to clone repo from gitserver, cloning from code host", "repo", repo, "gitserver", addr, "error", err)
+		reposClonedFromPeer.WithLabelValues("failed").Inc()
+		move.Error = err.Error()
+		_ = os.RemoveAll(tmpPath)
+		return false
+	}
+
+	reposClonedFromPeer.WithLabelValues("succeeded").Inc()
+	return true
+}
+
+// outgoingMove is a repository on this gitserver that belongs on another
+// gitserver.
+type outgoingMove struct {
+	Repo api.RepoName
+	From string
+	To   string
+
+	// Removed is true if the repository was removed from this gitserver
+	// because its new gitserver has it cloned.
+	Removed bool
+}
+
+// incomingMove is a repository cloned from another gitserver.
+type incomingMove struct {
+	Repo     api.RepoName
+	From     string
+	Started  time.Time
+	Duration string
+	Error    string `json:",omitempty"`
+}
+
+// migrationStatus is the status of repositories moving to and from a
+// gitserver.
+type migrationStatus struct {
+	// Addr is the address of this gitserver, if known. Repositories are only
+	// moved away from gitservers that know their address.
+	Addr string
+
+	// LastJanitorRun is when the janitor last finished looking for
+	// repositories that belong on other gitservers.
+	LastJanitorRun time.Time
+
+	// Outgoing are the repositories found by the last janitor run that belong
+	// on other gitservers.
+	Outgoing []outgoingMove
+
+	// Incoming are the most recent repositories cloned from other gitservers,
+	// most recent first.
+	Incoming []incomingMove
+}
+
+// migrationTracker tracks repositories moving to and from a gitserver. The
+// zero value is ready to use.
+type migrationTracker struct {
+	mu      sync.Mutex
+	pending []outgoingMove // found by the janitor run in progress
+	status  migrationStatus
+}
+
+func (t *migrationTracker) beginJanitorRun() {
+	t.mu.Lock()
+	t.pending = nil
+	t.mu.Unlock()
+}
+
+func (t *migrationTracker) endJanitorRun(addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Addr = addr
+	t.status.LastJanitorRun = time.Now()
+	t.status.Outgoing = t.pending
+	t.pending = nil
+}
+
+func (t *migrationTracker) outgoing(m outgoingMove) {
+	t.mu.Lock()
+	t.pending = append(t.pending, m)
+	t.mu.Unlock()
+}
+
+func (t *migrationTracker) incoming(m incomingMove) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Incoming = append([]incomingMove{m}, t.status.Incoming...)
+	if len(t.status.Incoming) > maxIncomingMoves {
+		t.status.Incoming = t.status.Incoming[:maxIncomingMoves]
+	}
+}
+
+func (t *migrationTracker) snapshot() migrationStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	status := t.status
+	status.Outgoing = append([]outgoingMove{}, t.status.Outgoing...)
+	status.Incoming = append([]incomingMove{}, t.status.Incoming...)
+	return status
+}
+
+// MigrationStatusHandler returns a handler which shows the repositories moving
+// to and from this gitserver as JSON. It is intended for the debug server.
+func (s *Server) MigrationStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := json.MarshalIndent(s.migration.snapshot(), "", "  ")
+		if err != nil {
+			http.Error(w, "failed to marshal migration status: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(p)
+	})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+)
+
+func TestCloneRepoFromPeer(t *testing.T) {
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	runCmd(t, remote, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, remote, "git", "add", "hello.txt")
+	runCmd(t, remote, "git", "commit", "-m", "hello")
+	peerCommit := runCmd(t, remote, "git", "rev-parse", "HEAD")
+
+	// The peer gitserver has the repository cloned, but is behind the code
+	// host. This lets us tell where the clone came from.
+	const repo = api.RepoName("example.com/foo/bar")
+	peer := &Server{ReposDir: tmpDir(t)}
+	runCmd(t, peer.ReposDir, "git", "clone", "--mirror", remote, string(peer.dir(repo)))
+	runCmd(t, remote, "git", "commit", "--allow-empty", "-m", "not on peer")
+
+	srv := httptest.NewServer(peer.Handler())
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	peerAddr := u.Host
+
+	s := &Server{
+		ReposDir:         tmpDir(t),
+		Hostname:         "localhost",
+		ctx:              context.Background(),
+		locker:           &RepositoryLocker{},
+		cloneLimiter:     mutablelimiter.New(1),
+		cloneableLimiter: mutablelimiter.New(1),
+		peers: &gitserver.Client{
+			// localhost:1 is this gitserver, which is not listening.
+			Addrs:      func(ctx context.Context) []string { return []string{"localhost:1", peerAddr} },
+			HTTPClient: http.DefaultClient,
+		},
+	}
+	if _, err := s.cloneRepo(context.Background(), repo, "file://"+remote, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Dir(string(s.dir(repo)))
+	if commit := runCmd(t, dir, "git", "rev-parse", "HEAD"); commit != peerCommit {
+		t.Errorf("expected repo to be cloned from peer at %s, have %s", peerCommit, commit)
+	}
+	if remoteURL := runCmd(t, dir, "git", "config", "remote.origin.url"); strings.TrimSpace(remoteURL) != "file://"+remote {
+		t.Errorf("expected origin to point at the code host, have %q", remoteURL)
+	}
+
+	rec := httptest.NewRecorder()
+	s.MigrationStatusHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/migration-status", nil))
+	var status migrationStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Incoming) != 1 || status.Incoming[0].Repo != repo || status.Incoming[0].From != peerAddr || status.Incoming[0].Error != "" {
+		t.Errorf("unexpected incoming moves: %+v", status.Incoming)
+	}
+}
+
+func TestFindPeerClone_UnknownAddr(t *testing.T) {
+	s := &Server{
+		Hostname: "gitserver-2",
+		peers: &gitserver.Client{
+			Addrs: func(ctx context.Context) []string { return []string{"gitserver-0:3178", "gitserver-1:3178"} },
+		},
+	}
+	if addr := s.findPeerClone(context.Background(), "example.com/foo/bar"); addr != "" {
+		t.Errorf("expected no peer when the address of this gitserver is unknown, have %q", addr)
+	}
+}
+
+func TestMigrationTracker(t *testing.T) {
+	var tracker migrationTracker
+
+	tracker.beginJanitorRun()
+	tracker.outgoing(outgoingMove{Repo: "a", From: "gitserver-0", To: "gitserver-1"})
+	tracker.outgoing(outgoingMove{Repo: "b", From: "gitserver-0", To: "gitserver-2", Removed: true})
+
+	// Moves found by a run in progress are not shown until it finishes.
+	if status := tracker.snapshot(); len(status.Outgoing) != 0 {
+		t.Fatalf("unexpected outgoing moves before the run finished: %+v", status.Outgoing)
+	}
+
+	tracker.endJanitorRun("gitserver-0")
+	status := tracker.snapshot()
+	if status.Addr != "gitserver-0" || status.LastJanitorRun.IsZero() {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if len(status.Outgoing) != 2 || status.Outgoing[0].Repo != "a" || !status.Outgoing[1].Removed {
+		t.Errorf("unexpected outgoing moves: %+v", status.Outgoing)
+	}
+
+	for i := 0; i < maxIncomingMoves+1; i++ {
+		tracker.incoming(incomingMove{Repo: api.RepoName(strings.Repeat("x", i+1))})
+	}
+	status = tracker.snapshot()
+	if len(status.Incoming) != maxIncomingMoves {
+		t.Fatalf("expected %d incoming moves, have %d", maxIncomingMoves, len(status.Incoming))
+	}
+	if want := api.RepoName(strings.Repeat("x", maxIncomingMoves+1)); status.Incoming[0].Repo != want {
+		t.Errorf("expected most recent incoming move first, have %q", status.Incoming[0].Repo)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	repoupdaterprotocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+// partialCloneFilter is the object filter used for partial clones. A blob-less
+// clone contains every commit and tree, but file contents are only fetched from
+// the remote once a command needs them.
+const partialCloneFilter = "blob:none"
+
+// partialCloneKinds are the kinds of code host connections that support the
+// partialClone option.
+var partialCloneKinds = []string{
+	extsvc.KindGitHub,
+	extsvc.KindGitLab,
+	extsvc.KindBitbucketServer,
+	extsvc.KindOther,
+}
+
+// ExternalServicePartialClone returns true if the code host connection that
+// owns repo has partial cloning enabled. The owning connection is the one that
+// supplied the repository's clone URL, as reported by repo-updater. It is the
+// default implementation of Server.PartialClone.
+//
+// Errors looking up the repository or its connection are logged, and the
+// repository is then fully cloned.
+func ExternalServicePartialClone(ctx context.Context, repo api.RepoName) bool {
+	partial, err := externalServicePartialClone(ctx, repo)
+	if err != nil {
+		log15.Warn("failed to determine if repository should be partially cloned", "repo", repo, "error", err)
+		return false
+	}
+	return partial
+}
+
+func externalServicePartialClone(ctx context.Context, repo api.RepoName) (bool, error) {
+	result, err := repoupdater.DefaultClient.RepoLookup(ctx, repoupdaterprotocol.RepoLookupArgs{Repo: repo})
+	if err != nil {
+		return false, errors.Wrap(err, "RepoLookup")
+	}
+	if result.Repo == nil || result.Repo.VCS.ExternalServiceID == 0 {
+		return false, nil
+	}
+
+	services, err := api.InternalClient.ExternalServicesList(ctx, api.ExternalServicesListRequest{Kinds: partialCloneKinds})
+	if err != nil {
+		return false, errors.Wrap(err, "ExternalServicesList")
+	}
+
+	for _, svc := range services {
+		if svc.ID != result.Repo.VCS.ExternalServiceID {
+			continue
+		}
+
+		var c struct {
+			PartialClone bool `json:"partialClone"`
+		}
+		if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+			return false, errors.Wrapf(err, "external service id=%d", svc.ID)
+		}
+		return c.PartialClone, nil
+	}
+
+	return false, nil
+}
+
+// isPartialClone returns true if the repository is a partial clone. Missing
+// objects of a partial clone are fetched from its promisor remote on demand.
+// Like quickRevParseHead, it reads the git config file directly rather than
+// executing a child process.
+func isPartialClone(dir GitDir) bool {
+	f, err := os.Open(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := bytes.Fields(bytes.ToLower(scanner.Bytes()))
+		if len(fields) == 3 && string(fields[0]) == "promisor" && string(fields[1]) == "=" && string(fields[2]) == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	repoupdaterprotocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+func TestExternalServicePartialClone(t *testing.T) {
+	repoupdater.MockRepoLookup = func(args repoupdaterprotocol.RepoLookupArgs) (*repoupdaterprotocol.RepoLookupResult, error) {
+		ids := map[api.RepoName]int64{
+			"github.com/foo/bar":        1,
+			"ghe.example.com/foo/bar":   2,
+			"ghe.example.com/foo/baz":   3,
+			"monorepo.example.com/mono": 4,
+			"unknown.example.com/foo":   0,
+		}
+		id, ok := ids[args.Repo]
+		if !ok {
+			return &repoupdaterprotocol.RepoLookupResult{ErrorNotFound: true}, nil
+		}
+		return &repoupdaterprotocol.RepoLookupResult{
+			Repo: &repoupdaterprotocol.RepoInfo{
+				Name: args.Repo,
+				VCS:  repoupdaterprotocol.VCSInfo{URL: "https://" + string(args.Repo), ExternalServiceID: id},
+			},
+		}, nil
+	}
+	defer func() { repoupdater.MockRepoLookup = nil }()
+
+	api.MockExternalServicesList = func(opts api.ExternalServicesListRequest) ([]*api.ExternalService, error) {
+		// Two connections to the same host, only one of which enables partial
+		// clones.
+		return []*api.ExternalService{
+			{ID: 1, Kind: extsvc.KindGitHub, Config: `{"url": "https://github.com"}`},
+			{ID: 2, Kind: extsvc.KindGitHub, Config: `{"url": "https://ghe.example.com", "partialClone": true}`},
+			{ID: 3, Kind: extsvc.KindGitHub, Config: `{"url": "https://ghe.example.com", "partialClone": false}`},
+			{ID: 4, Kind: extsvc.KindOther, Config: `{
+				// comments are allowed
+				"url": "ssh://git@monorepo.example.com",
+				"partialClone": true,
+			}`},
+		}, nil
+	}
+	defer func() { api.MockExternalServicesList = nil }()
+
+	tests := map[api.RepoName]bool{
+		"github.com/foo/bar":        false,
+		"ghe.example.com/foo/bar":   true,
+		"ghe.example.com/foo/baz":   false,
+		"monorepo.example.com/mono": true,
+		"unknown.example.com/foo":   false,
+		"gitlab.example.com/foo":    false,
+	}
+	for repo, want := range tests {
+		if have := ExternalServicePartialClone(context.Background(), repo); have != want {
+			t.Errorf("unexpected partial clone for %q. want=%v have=%v", repo, want, have)
+		}
+	}
+}
+
+func TestCloneRepoPartial(t *testing.T) {
+	remote := tmpDir(t)
+
+	repo := remote
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repo, name, arg...)
+	}
+
+	// Setup a repo that allows partial clones with a commit so we can see if
+	// we can clone it.
+	cmd("git", "init", ".")
+	cmd("git", "config", "uploadpack.allowFilter", "true")
+	cmd("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	cmd("sh", "-c", "echo hello world > hello.txt")
+	cmd("git", "add", "hello.txt")
+	cmd("git", "commit", "-m", "hello")
+	wantCommit := cmd("git", "rev-parse", "HEAD")
+
+	reposDir := tmpDir(t)
+
+	s := &Server{
+		ReposDir:         reposDir,
+		ctx:              context.Background(),
+		locker:           &RepositoryLocker{},
+		cloneLimiter:     mutablelimiter.New(1),
+		cloneableLimiter: mutablelimiter.New(1),
+		PartialClone:     func(ctx context.Context, repo api.RepoName) bool { return true },
+	}
+	_, err := s.cloneRepo(context.Background(), "example.com/foo/bar", "file://"+remote, &cloneOptions{Block: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := s.dir(api.RepoName("example.com/foo/bar"))
+	if !isPartialClone(dst) {
+		t.Fatal("expected a partial clone")
+	}
+
+	repo = filepath.Dir(string(dst))
+	if gotCommit := cmd("git", "rev-parse", "HEAD"); wantCommit != gotCommit {
+		t.Fatal("failed to clone:", gotCommit)
+	}
+
+	// The file contents are fetched from the remote on demand.
+	if contents := cmd("git", "cat-file", "-p", "HEAD:hello.txt"); strings.TrimSpace(contents) != "hello world" {
+		t.Fatalf("unexpected file contents: %q", contents)
+	}
+}
+
+func TestReadCloneProgressPartial(t *testing.T) {
+	locker := &RepositoryLocker{}
+	dir := GitDir("/testroot/example.com/foo/bar/.git")
+	lock, ok := locker.TryAcquire(dir, "starting partial clone")
+	if !ok {
+		t.Fatal("failed to acquire lock")
+	}
+	defer lock.Release()
+
+	progress := strings.NewReader("Receiving objects:  50%\rReceiving objects: 100%\n")
+	readCloneProgress(newURLRedactor("https://example.com/foo/bar"), lock, progress, true)
+
+	want := "partial clone: Receiving objects: 100%"
+	if status, _ := locker.Status(dir); status != want {
+		t.Fatalf("unexpected clone progress. want=%q have=%q", want, status)
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// rebalanceUpdateInterval is the Since duration of the update requested on the
+// new replicas of a repository that moved. Replicas that fetched the
+// repository more recently than this are not updated again.
+const rebalanceUpdateInterval = time.Hour
+
+var (
+	reposRebalanced = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_rebalanced",
+		Help: "number of repos removed because they moved to other gitservers",
+	})
+	replicasSynced = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_replicas_synced",
+		Help: "number of replicas updated because their ref hash differed from the primary",
+	})
+)
+
+// peerClient returns the client used to talk to the other gitservers.
+func (s *Server) peerClient() *gitserver.Client {
+	if s.peers != nil {
+		return s.peers
+	}
+	return gitserver.DefaultClient
+}
+
+// replicas returns the address of this gitserver along with the addresses of
+// the gitservers repo should be cloned on. ok is false if the address of this
+// gitserver is unknown, in which case repositories are neither rebalanced nor
+// synced with their primary.
+func (s *Server) replicas(ctx context.Context, repo api.RepoName) (self string, replicas []string, ok bool) {
+	self, ok = s.selfAddr(ctx)
+	if !ok {
+		return "", nil, false
+	}
+	return self, s.peerClient().AddrsForRepo(ctx, repo), true
+}
+
+// selfAddr returns the address of this gitserver. ok is false if none of the
+// gitserver addresses match Hostname.
+func (s *Server) selfAddr(ctx context.Context) (addr string, ok bool) {
+	if s.Hostname == "" {
+		return "", false
+	}
+
+	for _, addr := range s.peerClient().Addrs(ctx) {
+		if hostnameMatch(s.Hostname, addr) {
+			return addr, true
+		}
+	}
+	return "", false
+}
+
+// hostnameMatch returns true if addr is an address of the host with the given
+// hostname. For example, "gitserver-0" matches both "gitserver-0:3178" and
+// "gitserver-0.gitserver:3178".
+func hostnameMatch(hostname, addr string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	host, hostname = strings.ToLower(host), strings.ToLower(hostname)
+	return host == hostname || strings.HasPrefix(host, hostname+".")
+}
+
+// maybeRebalance removes dir if this gitserver is no longer one of the
+// replicas of its repository, which happens when the list of gitservers
+// changes. The repository is first cloned on its replicas, and is only removed
+// once a replica reports it as cloned so that it stays available while it
+// moves.
+func (s *Server) maybeRebalance(ctx context.Context, dir GitDir) (done bool, err error) {
+	repo := s.name(dir)
+	self, replicas, ok := s.replicas(ctx, repo)
+	if !ok || containsAddr(replicas, self) {
+		return false, nil
+	}
+
+	remoteURL, err := repoRemoteURL(ctx, dir)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get remote URL")
+	}
+
+	// The new replicas clone the repository from this gitserver, see
+	// findPeerClone.
+	resp, err := s.peerClient().RequestRepoUpdate(ctx, gitserver.Repo{Name: repo, URL: remoteURL}, rebalanceUpdateInterval)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to request repo update on replicas")
+	}
+	s.migration.outgoing(outgoingMove{Repo: repo, From: self, To: replicas[0], Removed: resp.Cloned})
+	if !resp.Cloned {
+		// The replicas are still cloning. Keep serving the repository until
+		// a later run finds it cloned.
+		return false, nil
+	}
+
+	log15.Info("removing repo moved to other gitservers", "repo", repo, "replicas", replicas)
+	if err := s.removeRepoDirectory(dir); err != nil {
+		return true, err
+	}
+	reposRebalanced.Inc()
+	return true, nil
+}
+
+// maybeSyncReplica updates dir if this gitserver is a secondary replica of its
+// repository and its ref hash differs from the one on the primary. This keeps
+// the refs of replicas consistent with the primary, so reads that fail over to
+// a replica see the same commits.
+func (s *Server) maybeSyncReplica(ctx context.Context, dir GitDir) (done bool, err error) {
+	repo := s.name(dir)
+	self, replicas, ok := s.replicas(ctx, repo)
+	if !ok || len(replicas) < 2 || replicas[0] == self || !containsAddr(replicas, self) {
+		return false, nil
+	}
+
+	res, err := s.peerClient().RepoInfo(ctx, repo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get repo info from primary")
+	}
+	primary := res.Results[repo]
+	if primary == nil || !primary.Cloned || primary.RefHash == "" {
+		return false, nil
+	}
+
+	refHash, err := repoRefHash(dir)
+	if err != nil {
+		return false, err
+	}
+	if refHash == primary.RefHash {
+		return false, nil
+	}
+
+	remoteURL, err := repoRemoteURL(ctx, dir)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get remote URL")
+	}
+
+	log15.Info("updating replica with refs differing from primary", "repo", repo, "primary", replicas[0])
+	replicasSynced.Inc()
+	return false, s.doRepoUpdate(ctx, repo, remoteURL)
+}
+
+func containsAddr(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
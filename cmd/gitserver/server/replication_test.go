@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestHostnameMatch(t *testing.T) {
+	tests := []struct {
+		hostname string
+		addr     string
+		want     bool
+	}{
+		{"gitserver-0", "gitserver-0:3178", true},
+		{"gitserver-0", "gitserver-0.gitserver:3178", true},
+		{"gitserver-0", "gitserver-0", true},
+		{"Gitserver-0", "gitserver-0:3178", true},
+		{"gitserver-1", "gitserver-10:3178", false},
+		{"gitserver-1", "gitserver-0:3178", false},
+		{"gitserver", "127.0.0.1:3178", false},
+	}
+	for _, test := range tests {
+		if have := hostnameMatch(test.hostname, test.addr); have != test.want {
+			t.Errorf("hostnameMatch(%q, %q): want %v, have %v", test.hostname, test.addr, test.want, have)
+		}
+	}
+}
+
+func TestMaybeRebalance(t *testing.T) {
+	origRepoRemoteURL := repoRemoteURL
+	repoRemoteURL = func(ctx context.Context, dir GitDir) (string, error) {
+		return "https://github.com/foo/bar", nil
+	}
+	defer func() { repoRemoteURL = origRepoRemoteURL }()
+
+	const repo = api.RepoName("github.com/foo/bar")
+	addrs := []string{"gitserver-0:3178", "gitserver-1:3178"}
+
+	tests := []struct {
+		name        string
+		owner       bool
+		cloned      bool
+		wantRemoved bool
+	}{
+		{name: "owner", owner: true},
+		{name: "moved, cloning on replica", cloned: false},
+		{name: "moved, cloned on replica", cloned: true, wantRemoved: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := tmpDir(t)
+			mkFiles(t, root, string(repo)+"/.git/HEAD")
+
+			var updates []string
+			peers := &gitserver.Client{
+				Addrs: func(ctx context.Context) []string { return addrs },
+				HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+					if r.URL.Path != "/repo-update" {
+						return nil, fmt.Errorf("unexpected request: %s", r.URL)
+					}
+					var req protocol.RepoUpdateRequest
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						return nil, err
+					}
+					updates = append(updates, r.URL.Host+" "+string(req.Repo)+" "+req.URL)
+
+					body, _ := json.Marshal(protocol.RepoUpdateResponse{Cloned: test.cloned})
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewReader(body)),
+					}, nil
+				}),
+			}
+
+			primary := peers.AddrForRepo(context.Background(), repo)
+			self := primary
+			if !test.owner {
+				for _, addr := range addrs {
+					if addr != primary {
+						self = addr
+					}
+				}
+			}
+
+			s := &Server{
+				ReposDir: root,
+				Hostname: strings.Split(self, ":")[0],
+				peers:    peers,
+			}
+			dir := s.dir(repo)
+			removed, err := s.maybeRebalance(context.Background(), dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != test.wantRemoved {
+				t.Errorf("unexpected removed: want %v, have %v", test.wantRemoved, removed)
+			}
+
+			_, err = os.Stat(filepath.Join(root, string(repo)))
+			if exists := err == nil; exists == test.wantRemoved {
+				t.Errorf("unexpected repo directory existence: %v", exists)
+			}
+
+			var want []string
+			if !test.owner {
+				want = []string{primary + " " + string(repo) + " https://github.com/foo/bar"}
+			}
+			if fmt.Sprint(want) != fmt.Sprint(updates) {
+				t.Errorf("unexpected repo updates: want %v, have %v", want, updates)
+			}
+		})
+	}
+}
+
+func TestMaybeRebalance_UnknownAddr(t *testing.T) {
+	root := tmpDir(t)
+	mkFiles(t, root, "github.com/foo/bar/.git/HEAD")
+
+	s := &Server{
+		ReposDir: root,
+		Hostname: "sourcegraph",
+		peers: &gitserver.Client{
+			Addrs: func(ctx context.Context) []string { return []string{"127.0.0.1:3178"} },
+		},
+	}
+	removed, err := s.maybeRebalance(context.Background(), s.dir("github.com/foo/bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed {
+		t.Fatal("unexpected removal when the address of this gitserver is unknown")
+	}
+}
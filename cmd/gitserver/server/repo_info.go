@@ -47,6 +47,12 @@ func (s *Server) repoInfo(ctx context.Context, repo api.RepoName) (*protocol.Rep
 		} else {
 			resp.LastChanged = &lastChanged
 		}
+
+		if refHash, err := repoRefHash(dir); err != nil {
+			log15.Warn("error getting ref hash", "repo", repo, "err", err)
+		} else {
+			resp.RefHash = refHash
+		}
 	}
 	return &resp, nil
 }
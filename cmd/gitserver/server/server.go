@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -116,6 +117,23 @@ type Server struct {
 	// DiskSizer tells how much disk is free and how large the disk is.
 	DiskSizer DiskSizer
 
+	// PartialClone, if non-nil, returns true if the given repository should
+	// be cloned as a blob-less partial clone rather than a full mirror.
+	PartialClone func(ctx context.Context, repo api.RepoName) bool
+
+	// Hostname is the hostname of this gitserver. It is matched against the
+	// gitserver addresses to determine which repositories this gitserver
+	// should hold. If empty, the janitor does not rebalance repositories or
+	// sync replicas.
+	Hostname string
+
+	// peers is the client used to talk to the other gitservers. If nil,
+	// gitserver.DefaultClient is used.
+	peers *gitserver.Client
+
+	// migration tracks repositories moving to and from this gitserver.
+	migration migrationTracker
+
 	// skipCloneForTests is set by tests to avoid clones.
 	skipCloneForTests bool
 
@@ -693,6 +711,12 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	cmdStart = time.Now()
 	cmd := exec.CommandContext(ctx, "git", req.Args...)
 	dir.Set(cmd)
+	if isPartialClone(dir) {
+		// Objects missing from a partial clone are fetched from the remote on
+		// demand, so configure the command as we do for fetches.
+		cmd.Env = os.Environ()
+		configureRemoteGitCommand(cmd, tlsExternal().(*tlsConfig))
+	}
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
 
@@ -775,10 +799,16 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		return "", fmt.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactor.redact(err.Error()))
 	}
 
+	partial := s.PartialClone != nil && s.PartialClone(ctx, repo)
+	status := "starting clone"
+	if partial {
+		status = "starting partial clone"
+	}
+
 	// Mark this repo as currently being cloned. We have to check again if someone else isn't already
 	// cloning since we released the lock. We released the lock since isCloneable is a potentially
 	// slow operation.
-	lock, ok := s.locker.TryAcquire(dir, "starting clone")
+	lock, ok := s.locker.TryAcquire(dir, status)
 	if !ok {
 		// Someone else beat us to it
 		status, _ := s.locker.Status(dir)
@@ -826,25 +856,38 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		tmpPath = filepath.Join(tmpPath, ".git")
 		tmp := GitDir(tmpPath)
 
-		var cmd *exec.Cmd
-		if useRefspecOverrides() {
-			cmd, err = refspecOverridesCloneCmd(ctx, url, tmpPath)
-			if err != nil {
-				return err
+		// A repository that moved between gitservers is fetched from the
+		// gitserver that has it rather than from the code host.
+		cloned := false
+		if !partial && !useRefspecOverrides() {
+			if peer := s.findPeerClone(ctx, repo); peer != "" {
+				cloned = s.cloneFromPeer(ctx, lock, repo, peer, url, tmpPath)
 			}
-		} else {
-			cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
 		}
-		// see issue #7322: skip LFS content in repositories with Git LFS configured
-		cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
-		log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
 
-		pr, pw := io.Pipe()
-		defer pw.Close()
-		go readCloneProgress(redactor, lock, pr)
+		if !cloned {
+			var cmd *exec.Cmd
+			if useRefspecOverrides() {
+				cmd, err = refspecOverridesCloneCmd(ctx, url, tmpPath)
+				if err != nil {
+					return err
+				}
+			} else if partial {
+				cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--filter="+partialCloneFilter, "--progress", url, tmpPath)
+			} else {
+				cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
+			}
+			// see issue #7322: skip LFS content in repositories with Git LFS configured
+			cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
+			log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath, "partial", partial)
+
+			pr, pw := io.Pipe()
+			defer pw.Close()
+			go readCloneProgress(redactor, lock, pr, partial)
 
-		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
-			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+			if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+				return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+			}
 		}
 
 		removeBadRefs(ctx, tmp)
@@ -901,12 +944,16 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 }
 
 // readCloneProgress scans the reader and saves the most recent line of output
-// as the lock status.
-func readCloneProgress(redactor *urlRedactor, lock *RepositoryLock, pr io.Reader) {
+// as the lock status. The status of partial clones is prefixed to indicate
+// that file contents will be fetched on demand.
+func readCloneProgress(redactor *urlRedactor, lock *RepositoryLock, pr io.Reader, partial bool) {
 	scan := bufio.NewScanner(pr)
 	scan.Split(scanCRLF)
 	for scan.Scan() {
 		progress := scan.Text()
+		if partial {
+			progress = "partial clone: " + progress
+		}
 
 		// 🚨 SECURITY: The output could include the clone url with may contain a sensitive token.
 		// Redact the full url and any found HTTP credentials to be safe.
@@ -1319,7 +1366,12 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, url)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch", "--prune", url,
+		args := []string{"fetch", "--prune"}
+		if isPartialClone(dir) {
+			// Keep the clone partial by not fetching the contents of new files.
+			args = append(args, "--filter="+partialCloneFilter)
+		}
+		cmd = exec.CommandContext(ctx, "git", append(args, url,
 			// Normal git refs
 			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
 			// GitHub pull requests
@@ -1329,7 +1381,7 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 			// Bitbucket pull requests
 			"+refs/pull-requests/*:refs/pull-requests/*",
 			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+			"+refs/sourcegraph/*:refs/sourcegraph/*")...)
 	}
 	dir.Set(cmd)
 
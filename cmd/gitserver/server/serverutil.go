@@ -266,6 +266,20 @@ var repoLastChanged = func(dir GitDir) (time.Time, error) {
 	return fi.ModTime(), nil
 }
 
+// repoRefHash returns the contents of the repo's sg_refhash, which is the hash
+// of its refs computed by computeRefHash the last time it was fetched. The
+// empty string is returned if sg_refhash is missing.
+func repoRefHash(dir GitDir) (string, error) {
+	b, err := ioutil.ReadFile(dir.Path("sg_refhash"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(b)), nil
+}
+
 // repoRemoteURL returns the "origin" remote fetch URL for the Git repository in dir. If the repository
 // doesn't exist or the remote doesn't exist and have a fetch URL, an error is returned. If there are
 // multiple fetch URLs, only the first is returned.
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -61,6 +62,30 @@ func diffSavedQueryConfigs(oldList, newList map[api.SavedQueryIDSpec]api.ConfigS
 	return deleted, updated, created
 }
 
+// watchSavedQueryChanges periodically lists all saved queries and sends the
+// notifications for saved queries that were created, updated or deleted since
+// the last time, until ctx is done.
+func watchSavedQueryChanges(ctx context.Context) {
+	var oldList map[api.SavedQueryIDSpec]api.ConfigSavedQuery
+	for {
+		allSavedQueries, err := api.InternalClient.SavedQueriesListAll(ctx)
+		if err != nil {
+			log15.Error("executor: error fetching saved queries list (trying again in 5s", "error", err)
+		} else {
+			if oldList != nil {
+				sendNotificationsForCreatedOrUpdatedOrDeleted(oldList, allSavedQueries)
+			}
+			oldList = allSavedQueries
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func sendNotificationsForCreatedOrUpdatedOrDeleted(oldList, newList map[api.SavedQueryIDSpec]api.ConfigSavedQuery) {
 	deleted, updated, created := diffSavedQueryConfigs(oldList, newList)
 	for oldVal, newVal := range deleted {
@@ -164,5 +189,10 @@ func serveTestNotification(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := webhookNotifyTest(r.Context(), args.SavedSearch); err != nil {
+		writeError(w, fmt.Errorf("error sending webhook notification: %s", err))
+		return
+	}
+
 	log15.Info("saved query test notification sent", "spec", args.SavedSearch.Spec, "key", args.SavedSearch.Spec.Key)
 }
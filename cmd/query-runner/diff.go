@@ -0,0 +1,240 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+const (
+	// maxPreviewLength is the maximum length of a line preview stored for a result.
+	maxPreviewLength = 200
+
+	// maxDiffLines is the maximum number of added and removed results included in the
+	// compact diff of a notification.
+	maxDiffLines = 20
+)
+
+// extractResults converts the results of a search response to the entries stored in the
+// result set of a saved search. Every line match of a file match is a separate entry, so
+// that a notification can tell which lines were added or removed.
+func extractResults(results []interface{}) []api.SavedQueryResult {
+	var entries []api.SavedQueryResult
+	for _, result := range results {
+		m, ok := result.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch m["__typename"] {
+		case "FileMatch":
+			repo := stringAt(m, "repository", "name")
+			path := stringAt(m, "file", "path")
+			lineMatches, _ := m["lineMatches"].([]interface{})
+			if len(lineMatches) == 0 {
+				entries = append(entries, api.SavedQueryResult{Repo: repo, Path: path})
+				continue
+			}
+			for _, lm := range lineMatches {
+				lm, ok := lm.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				lineNumber, _ := lm["lineNumber"].(float64)
+				preview, _ := lm["preview"].(string)
+				entries = append(entries, api.SavedQueryResult{
+					Repo:       repo,
+					Path:       path,
+					LineNumber: int(lineNumber) + 1,
+					Preview:    truncatePreview(preview),
+				})
+			}
+
+		case "CommitSearchResult":
+			commit, _ := m["commit"].(map[string]interface{})
+			message, _ := commit["message"].(string)
+			if i := strings.Index(message, "\n"); i >= 0 {
+				message = message[:i]
+			}
+			oid, _ := commit["oid"].(string)
+			entries = append(entries, api.SavedQueryResult{
+				Repo:    stringAt(commit, "repository", "name"),
+				Commit:  oid,
+				Preview: truncatePreview(message),
+			})
+		}
+	}
+	return entries
+}
+
+// stringAt returns the string value of the field at the given path in the (decoded JSON)
+// object m, or "" if there is none.
+func stringAt(m map[string]interface{}, path ...string) string {
+	for _, key := range path[:len(path)-1] {
+		m, _ = m[key].(map[string]interface{})
+	}
+	s, _ := m[path[len(path)-1]].(string)
+	return s
+}
+
+func truncatePreview(preview string) string {
+	preview = strings.TrimSpace(preview)
+	if len(preview) > maxPreviewLength {
+		// Don't cut a multi-byte character in half.
+		i := maxPreviewLength
+		for i > 0 && !isRuneStart(preview[i]) {
+			i--
+		}
+		preview = preview[:i] + "…"
+	}
+	return preview
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
+
+// newResultSet returns the result set of the search response, with its entries sorted and
+// fingerprinted so that it can be compared against the result set of the next run.
+func newResultSet(v *gqlSearchResponse) *api.SavedQueryResults {
+	entries := extractResults(v.Data.Search.Results.Results)
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if ka, kb := resultKey(a), resultKey(b); ka != kb {
+			return ka < kb
+		}
+		return a.LineNumber < b.LineNumber
+	})
+
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintln(h, resultKey(e))
+	}
+	return &api.SavedQueryResults{
+		Fingerprint: hex.EncodeToString(h.Sum(nil)),
+		Entries:     entries,
+		LimitHit:    v.Data.Search.Results.LimitHit,
+	}
+}
+
+// resultKey identifies a result across runs. The line number is deliberately not part of
+// it, so that a match that merely moved because lines were inserted above it is not
+// reported as removed and added again.
+func resultKey(e api.SavedQueryResult) string {
+	return strings.Join([]string{e.Repo, e.Path, e.Commit, e.Preview}, "\x00")
+}
+
+// resultDiff describes how the result set of a saved search changed since its last run.
+type resultDiff struct {
+	Added   []api.SavedQueryResult
+	Removed []api.SavedQueryResult
+
+	// Incomplete is whether either result set hit the result limit, in which case changes
+	// beyond the limit are not reported.
+	Incomplete bool
+}
+
+// diffResultSets returns the results that are in the new result set but not in the old one,
+// and vice versa. A result that occurs more than once (e.g. the same line in a file
+// several times) is counted as many times.
+//
+// A result set that hit the result limit omits an arbitrary part of the results, so a
+// result missing from it may still match. Results are therefore not reported as added if
+// the old result set hit the limit, and not reported as removed if the new one did.
+func diffResultSets(old, new *api.SavedQueryResults) *resultDiff {
+	d := &resultDiff{}
+	if old != nil && new != nil && old.Fingerprint == new.Fingerprint {
+		return d
+	}
+
+	oldCounts := map[string]int{}
+	if old != nil {
+		d.Incomplete = old.LimitHit
+		for _, e := range old.Entries {
+			oldCounts[resultKey(e)]++
+		}
+	}
+	newCounts := map[string]int{}
+	if new != nil {
+		d.Incomplete = d.Incomplete || new.LimitHit
+		for _, e := range new.Entries {
+			k := resultKey(e)
+			newCounts[k]++
+			if newCounts[k] > oldCounts[k] {
+				d.Added = append(d.Added, e)
+			}
+		}
+	}
+	if old != nil && old.LimitHit {
+		d.Added = nil
+	}
+	if old != nil && (new == nil || !new.LimitHit) {
+		seen := map[string]int{}
+		for _, e := range old.Entries {
+			k := resultKey(e)
+			seen[k]++
+			if seen[k] > newCounts[k] {
+				d.Removed = append(d.Removed, e)
+			}
+		}
+	}
+	return d
+}
+
+func (d *resultDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// summary returns a short description of the diff, such as "2 new and 1 removed results".
+func (d *resultDiff) summary() string {
+	var parts []string
+	if len(d.Added) > 0 {
+		parts = append(parts, fmt.Sprintf("%d new", len(d.Added)))
+	}
+	if len(d.Removed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d removed", len(d.Removed)))
+	}
+	if len(parts) == 0 {
+		return "no changed results"
+	}
+	plural := "s"
+	if len(d.Added)+len(d.Removed) == 1 {
+		plural = ""
+	}
+	return strings.Join(parts, " and ") + " result" + plural
+}
+
+// text returns a compact, line-oriented diff of the added (prefixed with "+") and removed
+// (prefixed with "-") results, limited to maxDiffLines lines.
+func (d *resultDiff) text() string {
+	var lines []string
+	for _, e := range d.Added {
+		lines = append(lines, "+ "+formatResult(e))
+	}
+	for _, e := range d.Removed {
+		lines = append(lines, "- "+formatResult(e))
+	}
+	if len(lines) > maxDiffLines {
+		lines = append(lines[:maxDiffLines], fmt.Sprintf("... and %d more", len(lines)-maxDiffLines))
+	}
+	if d.Incomplete {
+		lines = append(lines, "(the result limit was hit, so changes beyond it are not reported)")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatResult(e api.SavedQueryResult) string {
+	switch {
+	case e.Commit != "":
+		commit := e.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		return fmt.Sprintf("%s@%s: %s", e.Repo, commit, e.Preview)
+	case e.LineNumber > 0:
+		return fmt.Sprintf("%s/%s:%d: %s", e.Repo, e.Path, e.LineNumber, e.Preview)
+	default:
+		return fmt.Sprintf("%s/%s", e.Repo, e.Path)
+	}
+}
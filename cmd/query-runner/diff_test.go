@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestExtractResults(t *testing.T) {
+	var results []interface{}
+	if err := json.Unmarshal([]byte(`[
+		{
+			"__typename": "FileMatch",
+			"repository": {"name": "github.com/a/b"},
+			"file": {"path": "main.go"},
+			"lineMatches": [
+				{"preview": "  // TODO: fix  ", "lineNumber": 9},
+				{"preview": "// TODO: test", "lineNumber": 19}
+			]
+		},
+		{
+			"__typename": "FileMatch",
+			"repository": {"name": "github.com/a/b"},
+			"file": {"path": "TODO.md"},
+			"lineMatches": []
+		},
+		{
+			"__typename": "CommitSearchResult",
+			"commit": {
+				"repository": {"name": "github.com/a/b"},
+				"oid": "0123456789abcdef",
+				"message": "Fix the TODO\n\nLonger description."
+			}
+		}
+	]`), &results); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []api.SavedQueryResult{
+		{Repo: "github.com/a/b", Path: "main.go", LineNumber: 10, Preview: "// TODO: fix"},
+		{Repo: "github.com/a/b", Path: "main.go", LineNumber: 20, Preview: "// TODO: test"},
+		{Repo: "github.com/a/b", Path: "TODO.md"},
+		{Repo: "github.com/a/b", Commit: "0123456789abcdef", Preview: "Fix the TODO"},
+	}
+	if got := extractResults(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffResultSets(t *testing.T) {
+	resultSet := func(entries ...api.SavedQueryResult) *api.SavedQueryResults {
+		var results []interface{}
+		for _, e := range entries {
+			results = append(results, map[string]interface{}{
+				"__typename": "FileMatch",
+				"repository": map[string]interface{}{"name": e.Repo},
+				"file":       map[string]interface{}{"path": e.Path},
+				"lineMatches": []interface{}{
+					map[string]interface{}{"preview": e.Preview, "lineNumber": float64(e.LineNumber - 1)},
+				},
+			})
+		}
+		v := &gqlSearchResponse{}
+		v.Data.Search.Results.Results = results
+		return newResultSet(v)
+	}
+	a := api.SavedQueryResult{Repo: "r", Path: "a.go", LineNumber: 1, Preview: "a"}
+	b := api.SavedQueryResult{Repo: "r", Path: "b.go", LineNumber: 2, Preview: "b"}
+	c := api.SavedQueryResult{Repo: "r", Path: "c.go", LineNumber: 3, Preview: "c"}
+
+	t.Run("unchanged", func(t *testing.T) {
+		old, new := resultSet(a, b), resultSet(b, a)
+		if old.Fingerprint != new.Fingerprint {
+			t.Errorf("got different fingerprints %q and %q for the same results", old.Fingerprint, new.Fingerprint)
+		}
+		if d := diffResultSets(old, new); !d.empty() {
+			t.Errorf("got non-empty diff %+v", d)
+		}
+	})
+
+	t.Run("moved line", func(t *testing.T) {
+		moved := a
+		moved.LineNumber = 5
+		if d := diffResultSets(resultSet(a), resultSet(moved)); !d.empty() {
+			t.Errorf("got non-empty diff %+v", d)
+		}
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		d := diffResultSets(resultSet(a, b), resultSet(b, c, c))
+		want := &resultDiff{
+			Added:   []api.SavedQueryResult{c, c},
+			Removed: []api.SavedQueryResult{a},
+		}
+		if !reflect.DeepEqual(d, want) {
+			t.Fatalf("got %+v, want %+v", d, want)
+		}
+		if got, want := d.summary(), "2 new and 1 removed results"; got != want {
+			t.Errorf("got summary %q, want %q", got, want)
+		}
+		if got, want := d.text(), "+ r/c.go:3: c\n+ r/c.go:3: c\n- r/a.go:1: a"; got != want {
+			t.Errorf("got text %q, want %q", got, want)
+		}
+	})
+
+	t.Run("limit hit", func(t *testing.T) {
+		limitHit := func(rs *api.SavedQueryResults) *api.SavedQueryResults {
+			rs.LimitHit = true
+			return rs
+		}
+
+		tests := []struct {
+			name     string
+			old, new *api.SavedQueryResults
+			want     *resultDiff
+		}{
+			{
+				name: "old",
+				old:  limitHit(resultSet(a, b)),
+				new:  resultSet(b, c),
+				want: &resultDiff{Removed: []api.SavedQueryResult{a}, Incomplete: true},
+			},
+			{
+				name: "new",
+				old:  resultSet(a, b),
+				new:  limitHit(resultSet(b, c)),
+				want: &resultDiff{Added: []api.SavedQueryResult{c}, Incomplete: true},
+			},
+			{
+				name: "both",
+				old:  limitHit(resultSet(a, b)),
+				new:  limitHit(resultSet(b, c)),
+				want: &resultDiff{Incomplete: true},
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				if d := diffResultSets(test.old, test.new); !reflect.DeepEqual(d, test.want) {
+					t.Errorf("got %+v, want %+v", d, test.want)
+				}
+			})
+		}
+	})
+}
+
+func TestResultDiffText(t *testing.T) {
+	d := &resultDiff{Incomplete: true}
+	for i := 0; i < maxDiffLines+2; i++ {
+		d.Added = append(d.Added, api.SavedQueryResult{Repo: "r", Commit: "0123456789abcdef", Preview: "msg"})
+	}
+	want := ""
+	for i := 0; i < maxDiffLines; i++ {
+		want += "+ r@0123456: msg\n"
+	}
+	want += "... and 2 more\n(the result limit was hit, so changes beyond it are not reported)"
+	if got := d.text(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := d.summary(), "22 new results"; got != want {
+		t.Errorf("got summary %q, want %q", got, want)
+	}
+}
@@ -43,24 +43,20 @@ func (n *notifier) emailNotify(ctx context.Context) {
 				ownership = "your organization's"
 			}
 
-			plural := ""
-			if n.results.Data.Search.Results.ApproximateResultCount != "1" {
-				plural = "s"
-			}
 			if err := sendEmail(ctx, recipient.spec.userID, "results", newSearchResultsEmailTemplates, struct {
-				URL                    string
-				Description            string
-				Query                  string
-				ApproximateResultCount string
-				Ownership              string
-				PluralResults          string
+				URL         string
+				Description string
+				Query       string
+				Summary     string
+				Diff        string
+				Ownership   string
 			}{
-				URL:                    searchURL(n.newQuery, utmSourceEmail),
-				Description:            n.query.Description,
-				Query:                  n.query.Query,
-				ApproximateResultCount: n.results.Data.Search.Results.ApproximateResultCount,
-				Ownership:              ownership,
-				PluralResults:          plural,
+				URL:         searchURL(n.newQuery, utmSourceEmail),
+				Description: n.query.Description,
+				Query:       n.query.Query,
+				Summary:     n.diff.summary(),
+				Diff:        n.diff.text(),
+				Ownership:   ownership,
 			}); err != nil {
 				log15.Error("Failed to send email notification for new saved search results.", "userID", recipient.spec.userID, "error", err)
 			}
@@ -69,20 +65,24 @@ func (n *notifier) emailNotify(ctx context.Context) {
 }
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `[{{.ApproximateResultCount}} new result{{.PluralResults}}] {{.Description}}`,
+	Subject: `[{{.Summary}}] {{.Description}}`,
 	Text: `
-{{.ApproximateResultCount}} new search result{{.PluralResults}} found for {{.Ownership}} saved search:
+{{.Summary}} found for {{.Ownership}} saved search:
 
   "{{.Description}}"
 
-View the new result{{.PluralResults}} on Sourcegraph: {{.URL}}
+{{.Diff}}
+
+View the results on Sourcegraph: {{.URL}}
 `,
 	HTML: `
-<strong>{{.ApproximateResultCount}}</strong> new search result{{.PluralResults}} found for {{.Ownership}} saved search:
+<strong>{{.Summary}}</strong> found for {{.Ownership}} saved search:
 
 <p style="padding-left: 16px">&quot;{{.Description}}&quot;</p>
 
-<p><a href="{{.URL}}">View the new result{{.PluralResults}} on Sourcegraph</a></p>
+<pre>{{.Diff}}</pre>
+
+<p><a href="{{.URL}}">View the results on Sourcegraph</a></p>
 `,
 })
 
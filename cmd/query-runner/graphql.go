@@ -35,6 +35,12 @@ const gqlSearchQuery = `query Search(
 				__typename
 				... on FileMatch {
 					resource
+					repository {
+						name
+					}
+					file {
+						path
+					}
 					limitHit
 					lineMatches {
 						preview
@@ -113,6 +119,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
@@ -1,4 +1,8 @@
 // Command query-runner runs saved queries and notifies subscribers when the queries have new results.
+//
+// Multiple replicas of the query runner can run at the same time: every replica runs the saved
+// queries that are due, after claiming a lease on each query in the DB so that no query runs on
+// more than one replica at once.
 package main
 
 import (
@@ -23,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/eventlogger"
+	"github.com/sourcegraph/sourcegraph/internal/leader"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
 )
 
@@ -30,6 +35,10 @@ var forceRunInterval = env.Get("FORCE_RUN_INTERVAL", "", "Force an interval to r
 
 const port = "3183"
 
+// resultSetLimit is the maximum number of results of a saved search that are
+// compared between runs (unless the query specifies a count: itself).
+const resultSetLimit = 1000
+
 func main() {
 	env.Lock()
 	env.HandleHelpFlag()
@@ -53,6 +62,10 @@ func main() {
 
 	http.HandleFunc(queryrunnerapi.PathTestNotification, serveTestNotification)
 
+	// Only one replica sends the notifications for created, updated and
+	// deleted saved searches, so that users don't receive them several times.
+	go leader.Do(ctx, "query-runner-saved-query-changes", leader.Options{}, watchSavedQueryChanges)
+
 	go func() {
 		err := executor.run(ctx)
 		if err != nil {
@@ -83,10 +96,27 @@ func writeError(w http.ResponseWriter, err error) {
 // it will send one notification on server startup, effectively.
 var debugPretendSavedQueryResultsExist = false
 
-var executor = &executorT{}
+var executor = &executorT{runner: runnerName()}
+
+// runLease is how long a replica holds the lease on running a query. It must
+// be longer than running the query takes, including retries.
+const runLease = 10 * time.Minute
 
 type executorT struct {
 	forceRunInterval *time.Duration
+
+	// runner identifies this replica when claiming the lease on running a
+	// query and in the run history.
+	runner string
+}
+
+// runnerName returns the name that identifies this replica: its hostname,
+// which is unique per pod.
+func runnerName() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return fmt.Sprintf("query-runner-%d", os.Getpid())
 }
 
 func (e *executorT) run(ctx context.Context) error {
@@ -103,7 +133,6 @@ func (e *executorT) run(ctx context.Context) error {
 	// TODO(slimsag): Make gitserver notify us about repositories being updated
 	// as we could avoid executing queries if repositories haven't updated
 	// (impossible for new results to exist).
-	var oldList map[api.SavedQueryIDSpec]api.ConfigSavedQuery
 	for {
 		allSavedQueries, err := api.InternalClient.SavedQueriesListAll(context.Background())
 		if err != nil {
@@ -112,11 +141,8 @@ func (e *executorT) run(ctx context.Context) error {
 			continue
 		}
 
-		if oldList != nil {
-			sendNotificationsForCreatedOrUpdatedOrDeleted(oldList, allSavedQueries)
-		}
-		oldList = allSavedQueries
-
+		// Map iteration order is random, so replicas don't all compete for
+		// the same queries first.
 		start := time.Now()
 		for spec, config := range allSavedQueries {
 			err := e.runQuery(ctx, spec, config)
@@ -138,16 +164,10 @@ func (e *executorT) run(ctx context.Context) error {
 // runQuery runs the given query if an appropriate amount of time has elapsed
 // since it last ran.
 func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) error {
-	if !query.Notify && !query.NotifySlack {
+	if !query.Notify && !query.NotifySlack && !query.NotifyWebhook {
 		// No need to run this query because there will be nobody to notify.
 		return nil
 	}
-	if !strings.Contains(query.Query, "type:diff") && !strings.Contains(query.Query, "type:commit") {
-		// TODO(slimsag): we temporarily do not support non-commit search
-		// queries, since those do not support the after:"time" operator.
-		return nil
-	}
-
 	info, err := api.InternalClient.SavedQueriesGetInfo(ctx, query.Query)
 	if err != nil {
 		return errors.Wrap(err, "SavedQueriesGetInfo")
@@ -155,39 +175,57 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 
 	// If the saved query was executed recently in the past, then skip it to
 	// avoid putting too much pressure on searcher/gitserver.
-	if info != nil {
-		// We assume a run interval of 30x that which it takes to execute the
-		// query. For example, a query which takes 2s to execute will run (2s*30)
-		// every minute.
-		//
-		// Additionally, in case queries run very quickly (e.g. our after:
-		// queries with no results often return in ~15ms), we impose a minimum
-		// run interval of 10s.
-		runInterval := info.ExecDuration * 30
-		if runInterval < 10*time.Second {
-			runInterval = 10 * time.Second
-		}
-		if e.forceRunInterval != nil {
-			runInterval = *e.forceRunInterval
-		}
-		if time.Since(info.LastExecuted) < runInterval {
-			return nil // too early to run the query
+	if _, due := e.schedule(info); !due {
+		return nil // too early to run the query
+	}
+
+	// Claim the query, so that no other replica runs it at the same time.
+	claimed, err := api.InternalClient.SavedQueriesClaim(ctx, query.Query, e.runner, runLease)
+	if err != nil {
+		return errors.Wrap(err, "SavedQueriesClaim")
+	}
+	if !claimed {
+		return nil // another replica is running the query
+	}
+	defer func() {
+		if err := api.InternalClient.SavedQueriesRelease(context.Background(), query.Query, e.runner); err != nil {
+			log15.Error("executor: failed to release query", "error", err, "query_description", query.Description)
 		}
+	}()
+
+	// Another replica may have run the query since we got its info, so check
+	// again now that we hold the lease.
+	info, err = api.InternalClient.SavedQueriesGetInfo(ctx, query.Query)
+	if err != nil {
+		return errors.Wrap(err, "SavedQueriesGetInfo")
+	}
+	scheduledAt, due := e.schedule(info)
+	if !due {
+		return nil
 	}
 
-	// Construct a new query which finds search results introduced after the
-	// last time we queried.
-	var latestKnownResult time.Time
-	if info != nil {
-		latestKnownResult = info.LatestResult
-	} else {
-		// We've never executed this search query before, so use the current
-		// time. We'll most certainly find nothing, which is okay.
-		latestKnownResult = time.Now()
+	// Commit and diff searches support the after:"time" operator, so we
+	// construct a new query which finds search results introduced after the
+	// last time we queried. Other searches are run as-is, and their result set
+	// is compared against that of the last run.
+	commitSearch := isCommitSearch(query.Query)
+	newQuery := query.Query
+	if commitSearch {
+		var latestKnownResult time.Time
+		if info != nil {
+			latestKnownResult = info.LatestResult
+		} else {
+			// We've never executed this search query before, so use the current
+			// time. We'll most certainly find nothing, which is okay.
+			latestKnownResult = time.Now()
+		}
+		afterTime := latestKnownResult.UTC().Format(time.RFC3339)
+		newQuery = strings.Join([]string{query.Query, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
+	} else if !strings.Contains(query.Query, "count:") {
+		newQuery = strings.Join([]string{query.Query, fmt.Sprintf("count:%d", resultSetLimit)}, " ")
 	}
-	afterTime := latestKnownResult.UTC().Format(time.RFC3339)
-	newQuery := strings.Join([]string{query.Query, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
-	if debugPretendSavedQueryResultsExist {
+	pretendResultsExist := debugPretendSavedQueryResultsExist
+	if pretendResultsExist {
 		debugPretendSavedQueryResultsExist = false
 		newQuery = query.Query
 	}
@@ -197,12 +235,43 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	// fails in order to avoid e.g. failed saved queries from executing
 	// constantly and potentially causing harm to the system. We'll retry at
 	// our normal interval, regardless of errors.
+	startedAt := time.Now()
 	v, execDuration, searchErr := performSearch(ctx, newQuery)
+	e.recordRun(ctx, query.Query, scheduledAt, startedAt, v, searchErr)
+
+	// Determine the results that changed since the last run. If the search
+	// failed, we keep the previous result set to compare the next run against.
+	var (
+		results *api.SavedQueryResults
+		diff    *resultDiff
+	)
+	if info != nil {
+		results = info.Results
+	}
+	if searchErr == nil {
+		if commitSearch {
+			diff = &resultDiff{Added: extractResults(v.Data.Search.Results.Results)}
+		} else {
+			results = newResultSet(v)
+			switch {
+			case pretendResultsExist:
+				diff = diffResultSets(nil, results)
+			case info != nil && info.Results != nil:
+				diff = diffResultSets(info.Results, results)
+			default:
+				// We've never recorded a result set for this search query
+				// before, so there is nothing to compare against yet.
+				diff = &resultDiff{}
+			}
+		}
+	}
+
 	if err := api.InternalClient.SavedQueriesSetInfo(ctx, &api.SavedQueryInfo{
 		Query:        query.Query,
 		LastExecuted: time.Now(),
-		LatestResult: latestResultTime(info, v, searchErr),
+		LatestResult: latestResultTime(info, v, commitSearch, diff, searchErr),
 		ExecDuration: execDuration,
+		Results:      results,
 	}); err != nil {
 		return errors.Wrap(err, "SavedQueriesSetInfo")
 	}
@@ -210,18 +279,75 @@ func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, que
 	if searchErr != nil {
 		return searchErr
 	}
+	if !commitSearch {
+		// The result limit is an implementation detail, so link to the
+		// search query as the user wrote it.
+		newQuery = query.Query
+	}
 
 	// Send notifications for new search results in a separate goroutine, so
 	// that we don't block other search queries from running in sequence (which
 	// is done intentionally, to ensure no overloading of searcher/gitserver).
 	go func() {
-		if err := notify(context.Background(), spec, query, newQuery, v); err != nil {
+		if err := notify(context.Background(), spec, query, newQuery, v, diff); err != nil {
 			log15.Error("executor: failed to send notifications", "error", err)
 		}
 	}()
 	return nil
 }
 
+// schedule returns when the query with the given info is due to run, and
+// whether it is due now.
+func (e *executorT) schedule(info *api.SavedQueryInfo) (scheduledAt time.Time, due bool) {
+	now := time.Now()
+	if info == nil {
+		// We've never executed this search query before.
+		return now, true
+	}
+
+	// We assume a run interval of 30x that which it takes to execute the
+	// query. For example, a query which takes 2s to execute will run (2s*30)
+	// every minute.
+	//
+	// Additionally, in case queries run very quickly (e.g. our after:
+	// queries with no results often return in ~15ms), we impose a minimum
+	// run interval of 10s.
+	runInterval := info.ExecDuration * 30
+	if runInterval < 10*time.Second {
+		runInterval = 10 * time.Second
+	}
+	if e.forceRunInterval != nil {
+		runInterval = *e.forceRunInterval
+	}
+	scheduledAt = info.LastExecuted.Add(runInterval)
+	return scheduledAt, !now.Before(scheduledAt)
+}
+
+// recordRun records a run of the query in the run history and metrics, so
+// that operators can see how far the query runner lags behind.
+func (e *executorT) recordRun(ctx context.Context, query string, scheduledAt, startedAt time.Time, v *gqlSearchResponse, searchErr error) {
+	run := &api.SavedQueryRun{
+		Query:       query,
+		Runner:      e.runner,
+		ScheduledAt: scheduledAt,
+		StartedAt:   startedAt,
+		FinishedAt:  time.Now(),
+	}
+	status := "success"
+	if searchErr != nil {
+		status = "error"
+		run.Error = searchErr.Error()
+	} else {
+		run.ResultCount = int32(len(v.Data.Search.Results.Results))
+	}
+	runLag.Observe(startedAt.Sub(scheduledAt).Seconds())
+	runDuration.WithLabelValues(status).Observe(run.FinishedAt.Sub(startedAt).Seconds())
+
+	if err := api.InternalClient.SavedQueriesRecordRun(ctx, run); err != nil {
+		log15.Error("executor: failed to record run", "error", err, "query", query)
+	}
+}
+
 func performSearch(ctx context.Context, query string) (v *gqlSearchResponse, execDuration time.Duration, err error) {
 	attempts := 0
 	for {
@@ -254,7 +380,21 @@ func performSearch(ctx context.Context, query string) (v *gqlSearchResponse, exe
 	}
 }
 
-func latestResultTime(prevInfo *api.SavedQueryInfo, v *gqlSearchResponse, searchErr error) time.Time {
+// isCommitSearch reports whether the query is a commit or diff search, which
+// supports the after:"time" operator.
+func isCommitSearch(query string) bool {
+	return strings.Contains(query, "type:diff") || strings.Contains(query, "type:commit")
+}
+
+func latestResultTime(prevInfo *api.SavedQueryInfo, v *gqlSearchResponse, commitSearch bool, diff *resultDiff, searchErr error) time.Time {
+	if searchErr == nil && !commitSearch {
+		// The results of other searches carry no time, so the latest result
+		// is the last time we found the result set changed.
+		if !diff.empty() || prevInfo == nil {
+			return time.Now()
+		}
+		return prevInfo.LatestResult
+	}
 	if searchErr != nil || len(v.Data.Search.Results.Results) == 0 {
 		// Error performing the search, or there were no results. Assume the
 		// previous info's result time.
@@ -275,12 +415,12 @@ func latestResultTime(prevInfo *api.SavedQueryInfo, v *gqlSearchResponse, search
 
 var externalURL *url.URL
 
-// notify handles sending notifications for new search results.
-func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse) error {
-	if len(results.Data.Search.Results.Results) == 0 {
+// notify handles sending notifications for added and removed search results.
+func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, newQuery string, results *gqlSearchResponse, diff *resultDiff) error {
+	if diff.empty() {
 		return nil
 	}
-	log15.Info("sending notifications", "new_results", len(results.Data.Search.Results.Results), "description", query.Description)
+	log15.Info("sending notifications", "new_results", len(diff.Added), "removed_results", len(diff.Removed), "description", query.Description)
 
 	// Determine which users to notify.
 	recipients, err := getNotificationRecipients(ctx, spec, query)
@@ -294,12 +434,14 @@ func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSave
 		query:      query,
 		newQuery:   newQuery,
 		results:    results,
+		diff:       diff,
 		recipients: recipients,
 	}
 
-	// Send Slack and email notifications.
+	// Send Slack, email and webhook notifications.
 	n.slackNotify(ctx)
 	n.emailNotify(ctx)
+	n.webhookNotify(ctx)
 	return nil
 }
 
@@ -308,6 +450,7 @@ type notifier struct {
 	query      api.ConfigSavedQuery
 	newQuery   string
 	results    *gqlSearchResponse
+	diff       *resultDiff
 	recipients recipients
 }
 
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	runLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "src_query_runner_run_lag_seconds",
+		Help:    "Time between when a saved query was due to run and when it started running",
+		Buckets: []float64{1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+	})
+
+	runDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "src_query_runner_run_duration_seconds",
+		Help: "Time it took to run a saved query",
+	}, []string{"status"})
+)
@@ -11,16 +11,11 @@ import (
 )
 
 func (n *notifier) slackNotify(ctx context.Context) {
-	plural := ""
-	if n.results.Data.Search.Results.ApproximateResultCount != "1" {
-		plural = "s"
-	}
-
-	text := fmt.Sprintf(`*%s* new result%s found for saved search <%s|"%s">`,
-		n.results.Data.Search.Results.ApproximateResultCount,
-		plural,
+	text := fmt.Sprintf("*%s* for saved search <%s|\"%s\">\n```\n%s\n```",
+		n.diff.summary(),
 		searchURL(n.newQuery, utmSourceSlack),
 		n.query.Description,
+		n.diff.text(),
 	)
 	for _, recipient := range n.recipients {
 		if err := slackNotify(ctx, recipient, text, n.query.SlackWebhookURL); err != nil {
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const (
+	utmSourceWebhook = "saved-search-webhook"
+
+	// The event types of webhook payloads, also sent in the X-Sourcegraph-Event header.
+	webhookEventResults = "saved_search.results"
+	webhookEventTest    = "saved_search.test"
+)
+
+// webhookPayload is the JSON payload posted to the webhook URL of a saved search.
+type webhookPayload struct {
+	Event                  string             `json:"event"`
+	SavedSearch            webhookSavedSearch `json:"savedSearch"`
+	SearchURL              string             `json:"searchURL"`
+	ApproximateResultCount string             `json:"approximateResultCount,omitempty"`
+
+	// Added and Removed are the results that changed since the saved search last ran.
+	Added   []api.SavedQueryResult `json:"added,omitempty"`
+	Removed []api.SavedQueryResult `json:"removed,omitempty"`
+
+	// Results are the full search results, which are only included for commit and diff
+	// searches (whose results are all new).
+	Results []interface{} `json:"results,omitempty"`
+}
+
+type webhookSavedSearch struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Query       string `json:"query"`
+}
+
+var (
+	// webhookClient posts to webhooks. Webhook URLs are supplied by users, so it refuses to
+	// connect to loopback, link-local and private addresses, which would let users probe
+	// services that are only reachable from inside the cluster.
+	webhookClient = newWebhookClient()
+
+	// webhookMaxAttempts is the number of attempts to deliver a payload, and webhookBackoff the
+	// time to wait before the first retry, which is doubled for every following retry.
+	webhookMaxAttempts = 5
+	webhookBackoff     = 2 * time.Second
+
+	// recordWebhookDelivery records deliveries in the DB. It is a variable so that tests can
+	// replace it.
+	recordWebhookDelivery = api.InternalClient.SavedQueriesRecordWebhookDelivery
+)
+
+func newWebhookClient() *http.Client {
+	cli := &http.Client{Timeout: 30 * time.Second}
+	if err := httpcli.PublicOnlyTransportOpt(cli); err != nil {
+		// Only fails if the transport is not an *http.Transport, which it always is here.
+		panic(err)
+	}
+	return cli
+}
+
+func (n *notifier) webhookNotify(ctx context.Context) {
+	if !n.query.NotifyWebhook {
+		return
+	}
+
+	payload := &webhookPayload{
+		Event:                  webhookEventResults,
+		SavedSearch:            newWebhookSavedSearch(n.spec, n.query),
+		SearchURL:              searchURL(n.newQuery, utmSourceWebhook),
+		ApproximateResultCount: n.results.Data.Search.Results.ApproximateResultCount,
+		Added:                  n.diff.Added,
+		Removed:                n.diff.Removed,
+	}
+	if isCommitSearch(n.query.Query) {
+		payload.Results = n.results.Data.Search.Results.Results
+	}
+	if err := deliverWebhook(ctx, n.spec, n.query, payload); err != nil {
+		log15.Error("Failed to post saved search webhook notification.", "description", n.query.Description, "error", err)
+		return
+	}
+	logEvent(0, "SavedSearchWebhookNotificationSent", "results")
+}
+
+func webhookNotifyTest(ctx context.Context, query api.SavedQuerySpecAndConfig) error {
+	if !query.Config.NotifyWebhook {
+		return nil
+	}
+
+	return deliverWebhook(ctx, query.Spec, query.Config, &webhookPayload{
+		Event:       webhookEventTest,
+		SavedSearch: newWebhookSavedSearch(query.Spec, query.Config),
+		SearchURL:   searchURL(query.Config.Query, utmSourceWebhook),
+	})
+}
+
+func newWebhookSavedSearch(spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) webhookSavedSearch {
+	return webhookSavedSearch{
+		ID:          spec.Key,
+		Description: query.Description,
+		Query:       query.Query,
+	}
+}
+
+// deliverWebhook posts the payload to the webhook URL of the saved search, retrying with
+// exponential backoff if the webhook can't be reached or responds with a server error, and records
+// the delivery in the DB.
+func deliverWebhook(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery, payload *webhookPayload) error {
+	if query.WebhookURL == nil || *query.WebhookURL == "" {
+		return errors.New("unable to post webhook notification because the saved search has no webhook URL configured")
+	}
+	savedSearchID, err := strconv.ParseInt(spec.Key, 10, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid saved search ID %q", spec.Key)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal payload")
+	}
+	var secret string
+	if query.WebhookSecret != nil {
+		secret = *query.WebhookSecret
+	}
+
+	d := &api.SavedQueryWebhookDelivery{
+		SavedSearchID: int32(savedSearchID),
+		URL:           *query.WebhookURL,
+		Payload:       body,
+		CreatedAt:     time.Now(),
+	}
+	backoff := webhookBackoff
+	for {
+		d.Attempts++
+		statusCode, err := postWebhook(ctx, d.URL, secret, payload.Event, body)
+		d.StatusCode = int32(statusCode)
+		if err == nil {
+			now := time.Now()
+			d.DeliveredAt = &now
+			d.Error = ""
+			break
+		}
+		var nonPublic *httpcli.ErrNonPublicAddress
+		if errors.As(err, &nonPublic) {
+			// The dial error contains the resolved address, which is not revealed to users.
+			// Retrying won't help either.
+			d.Error = nonPublic.Error()
+			break
+		}
+		d.Error = err.Error()
+
+		if int(d.Attempts) >= webhookMaxAttempts || !retryWebhook(statusCode) {
+			break
+		}
+		log15.Warn("Failed to post saved search webhook notification, retrying.", "url", d.URL, "attempt", d.Attempts, "backoff", backoff, "error", err)
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			d.Error = ctx.Err().Error()
+		case <-t.C:
+			backoff *= 2
+			continue
+		}
+		break
+	}
+
+	if err := recordWebhookDelivery(ctx, d); err != nil {
+		log15.Error("Failed to record saved search webhook delivery.", "url", d.URL, "error", err)
+	}
+	if d.DeliveredAt == nil {
+		return fmt.Errorf("posting to webhook %s failed after %d attempt(s): %s", d.URL, d.Attempts, d.Error)
+	}
+	return nil
+}
+
+// postWebhook posts the JSON body to the URL, signed with the secret (if any). It returns the
+// status code of the response, or 0 if no response was received.
+func postWebhook(ctx context.Context, url, secret, event string, body []byte) (statusCode int, err error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sourcegraph-Saved-Search-Webhook")
+	req.Header.Set("X-Sourcegraph-Event", event)
+	if secret != "" {
+		req.Header.Set("X-Sourcegraph-Signature", webhookSignature(secret, body))
+	}
+
+	resp, err := webhookClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Read (part of) the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookSignature returns the signature of the body sent in the X-Sourcegraph-Signature header,
+// which is the hex-encoded HMAC-SHA256 of the body, keyed with the secret of the saved search.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryWebhook reports whether a failed delivery attempt with the given status code (0 if no
+// response was received) should be retried. Client errors other than rate limiting are not
+// retried, because they won't go away by themselves.
+func retryWebhook(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	origBackoff, origRecord, origClient := webhookBackoff, recordWebhookDelivery, webhookClient
+	defer func() { webhookBackoff, recordWebhookDelivery, webhookClient = origBackoff, origRecord, origClient }()
+	webhookBackoff = time.Millisecond
+	// The test servers listen on a loopback address, which webhookClient refuses to connect to.
+	webhookClient = &http.Client{}
+
+	var recorded []*api.SavedQueryWebhookDelivery
+	recordWebhookDelivery = func(ctx context.Context, d *api.SavedQueryWebhookDelivery) error {
+		recorded = append(recorded, d)
+		return nil
+	}
+
+	secret := "s3cr3t"
+	payload := &webhookPayload{
+		Event:       webhookEventResults,
+		SavedSearch: webhookSavedSearch{ID: "7", Description: "d", Query: "q"},
+		Results:     []interface{}{map[string]interface{}{"__typename": "CommitSearchResult"}},
+	}
+
+	tests := map[string]struct {
+		statuses      []int // the status codes of the responses to consecutive requests
+		wantAttempts  int32
+		wantStatus    int32
+		wantDelivered bool
+	}{
+		"success": {
+			statuses:      []int{http.StatusOK},
+			wantAttempts:  1,
+			wantStatus:    http.StatusOK,
+			wantDelivered: true,
+		},
+		"retries server errors": {
+			statuses:      []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusNoContent},
+			wantAttempts:  3,
+			wantStatus:    http.StatusNoContent,
+			wantDelivered: true,
+		},
+		"gives up after max attempts": {
+			statuses:     []int{500, 500, 500, 500, 500, 500},
+			wantAttempts: int32(webhookMaxAttempts),
+			wantStatus:   500,
+		},
+		"does not retry client errors": {
+			statuses:     []int{http.StatusNotFound, http.StatusOK},
+			wantAttempts: 1,
+			wantStatus:   http.StatusNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorded = nil
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if got, want := r.Header.Get("X-Sourcegraph-Signature"), webhookSignature(secret, body); got != want {
+					t.Errorf("got signature %q, want %q", got, want)
+				}
+				if got, want := r.Header.Get("X-Sourcegraph-Event"), webhookEventResults; got != want {
+					t.Errorf("got event %q, want %q", got, want)
+				}
+				var got webhookPayload
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Error(err)
+				} else if got.SavedSearch != payload.SavedSearch || len(got.Results) != 1 {
+					t.Errorf("got payload %+v, want %+v", got, payload)
+				}
+				w.WriteHeader(test.statuses[requests])
+				requests++
+			}))
+			defer srv.Close()
+
+			err := deliverWebhook(ctx,
+				api.SavedQueryIDSpec{Key: "7"},
+				api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &srv.URL, WebhookSecret: &secret},
+				payload,
+			)
+			if test.wantDelivered && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if !test.wantDelivered && err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if len(recorded) != 1 {
+				t.Fatalf("got %d recorded deliveries, want 1", len(recorded))
+			}
+			d := recorded[0]
+			if d.SavedSearchID != 7 || d.URL != srv.URL {
+				t.Errorf("got delivery of saved search %d to %q, want saved search 7 to %q", d.SavedSearchID, d.URL, srv.URL)
+			}
+			if d.Attempts != test.wantAttempts || int(d.Attempts) != requests {
+				t.Errorf("got %d attempts (%d requests), want %d", d.Attempts, requests, test.wantAttempts)
+			}
+			if d.StatusCode != test.wantStatus {
+				t.Errorf("got status code %d, want %d", d.StatusCode, test.wantStatus)
+			}
+			if delivered := d.DeliveredAt != nil; delivered != test.wantDelivered || (d.Error == "") != test.wantDelivered {
+				t.Errorf("got delivered %v with error %q, want delivered %v", delivered, d.Error, test.wantDelivered)
+			}
+		})
+	}
+}
+
+func TestDeliverWebhookNonPublicAddress(t *testing.T) {
+	origRecord := recordWebhookDelivery
+	defer func() { recordWebhookDelivery = origRecord }()
+
+	var recorded []*api.SavedQueryWebhookDelivery
+	recordWebhookDelivery = func(ctx context.Context, d *api.SavedQueryWebhookDelivery) error {
+		recorded = append(recorded, d)
+		return nil
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhook on a loopback address should not be called")
+	}))
+	defer srv.Close()
+
+	err := deliverWebhook(context.Background(),
+		api.SavedQueryIDSpec{Key: "7"},
+		api.ConfigSavedQuery{NotifyWebhook: true, WebhookURL: &srv.URL},
+		&webhookPayload{Event: webhookEventTest},
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(recorded) != 1 {
+		t.Fatalf("got %d recorded deliveries, want 1", len(recorded))
+	}
+	if d := recorded[0]; d.Attempts != 1 || d.DeliveredAt != nil || strings.Contains(d.Error, "127.0.0.1") {
+		t.Errorf("got delivery with %d attempts and error %q, want 1 failed attempt that doesn't reveal the address", d.Attempts, d.Error)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	// Computed with: printf '{"event":"saved_search.test"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=792071ed90edd15602ec28e0b0c01a530e59667b6c9fb973a0909c488a8f968f"
+	if got := webhookSignature("secret", []byte(`{"event":"saved_search.test"}`)); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
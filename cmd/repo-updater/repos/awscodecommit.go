@@ -124,6 +124,9 @@ func (s *AWSCodeCommitSource) makeRepo(r *awscodecommit.Repository) (*Repo, erro
 		URI:          string(reposource.AWSRepoName("", r.Name)),
 		ExternalRepo: awscodecommit.ExternalRepoSpec(r, serviceID),
 		Description:  r.Description,
+		// AWS CodeCommit repositories are only accessible to the IAM users whose policies
+		// allow it, so they are always private.
+		Private: true,
 		Sources: map[string]*SourceInfo{
 			urn: {
 				ID:       urn,
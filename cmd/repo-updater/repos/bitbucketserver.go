@@ -144,6 +144,37 @@ func (s BitbucketServerSource) CloseChangeset(ctx context.Context, c *Changeset)
 	return nil
 }
 
+// MergeChangeset merges the given *Changeset on the code host and updates the
+// Metadata column in the *campaigns.Changeset to the newly merged pull request.
+func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	if err := s.client.MergePullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	if err := s.loadPullRequestData(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading extra metadata")
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// CreateComment posts a comment on the pull request on Bitbucket Server.
+func (s BitbucketServerSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, text)
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	var notFound []*Changeset
@@ -176,6 +207,12 @@ func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Change
 		if err != nil {
 			return errors.Wrap(err, "loading pull request data")
 		}
+		// Only open pull requests can be checked for conflicts.
+		if pr.State == "OPEN" {
+			if err := s.client.LoadPullRequestMergeStatus(ctx, pr); err != nil {
+				return errors.Wrap(err, "loading pull request merge status")
+			}
+		}
 		if err = cs[i].SetMetadata(pr); err != nil {
 			return errors.Wrap(err, "setting changeset metadata")
 		}
@@ -302,9 +302,23 @@ func projectQueryToURL(projectQuery string, perPage int) (string, error) {
 	return u.String(), nil
 }
 
+var _ ChangesetSource = &GitLabSource{}
+var _ DraftChangesetSource = &GitLabSource{}
+
 // CreateChangeset creates a GitLab merge request. If it already exists,
 // *Changeset will be populated and the return value will be true.
 func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, c.Title)
+}
+
+// CreateDraftChangeset creates a GitLab merge request marked as a work in
+// progress by the prefix of its title. If it already exists, *Changeset will
+// be populated and the return value will be true.
+func (s *GitLabSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, gitlab.SetWIP(c.Title))
+}
+
+func (s *GitLabSource) createChangeset(ctx context.Context, c *Changeset, title string) (bool, error) {
 	project := c.Repo.Metadata.(*gitlab.Project)
 	exists := false
 	source := git.AbbreviateRef(c.HeadRef)
@@ -313,7 +327,7 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 	mr, err := s.client.CreateMergeRequest(ctx, project, gitlab.CreateMergeRequestOpts{
 		SourceBranch: source,
 		TargetBranch: target,
-		Title:        c.Title,
+		Title:        title,
 		Description:  c.Body,
 	})
 	if err != nil {
@@ -543,8 +557,14 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 		return errors.New("Changeset is not a GitLab merge request")
 	}
 
+	// Keep drafts as drafts: promoting them is done with UndraftChangeset.
+	title := c.Title
+	if mr.WorkInProgress {
+		title = gitlab.SetWIP(title)
+	}
+
 	updated, err := s.client.UpdateMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr, gitlab.UpdateMergeRequestOpts{
-		Title:        c.Title,
+		Title:        title,
 		Description:  c.Body,
 		TargetBranch: git.AbbreviateRef(c.BaseRef),
 	})
@@ -555,3 +575,58 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	c.Changeset.Metadata = updated
 	return nil
 }
+
+// UndraftChangeset removes the work in progress prefix from the title of the
+// merge request on GitLab.
+func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	// TargetBranch is required, even though we're not actually changing it.
+	updated, err := s.client.UpdateMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr, gitlab.UpdateMergeRequestOpts{
+		Title:        gitlab.UnsetWIP(mr.Title),
+		Description:  mr.Description,
+		TargetBranch: mr.TargetBranch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// MergeChangeset merges the merge request on GitLab.
+func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	updated, err := s.client.MergeMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr)
+	if err != nil {
+		return errors.Wrap(err, "merging GitLab merge request")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// CreateComment adds a note to the merge request on GitLab.
+func (s *GitLabSource) CreateComment(ctx context.Context, c *Changeset, body string) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	if _, err := s.client.CreateMergeRequestNote(ctx, c.Repo.Metadata.(*gitlab.Project), mr, body); err != nil {
+		return errors.Wrap(err, "creating GitLab merge request note")
+	}
+	return nil
+}
@@ -114,6 +114,24 @@ type ChangesetSource interface {
 	CloseChangeset(context.Context, *Changeset) error
 	// UpdateChangeset can update Changesets.
 	UpdateChangeset(context.Context, *Changeset) error
+	// MergeChangeset merges the Changeset on the source with a merge method
+	// that the repository allows.
+	MergeChangeset(context.Context, *Changeset) error
+	// CreateComment posts a comment with the given body on the Changeset on
+	// the source.
+	CreateComment(context.Context, *Changeset, string) error
+}
+
+// A DraftChangesetSource can create draft Changesets and promote them to
+// regular ones. Not every code host supports drafts.
+type DraftChangesetSource interface {
+	// CreateDraftChangeset creates the Changeset as a draft on the source.
+	// If it already exists, *Changeset will be populated and the return
+	// value will be true.
+	CreateDraftChangeset(context.Context, *Changeset) (bool, error)
+	// UndraftChangeset marks the draft Changeset on the source as ready for
+	// review.
+	UndraftChangeset(context.Context, *Changeset) error
 }
 
 // ChangesetsNotFoundError is returned by LoadChangesets if any of the passed
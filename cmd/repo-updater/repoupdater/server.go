@@ -643,8 +643,14 @@ func (s *Server) handleSchedulePermsSync(w http.ResponseWriter, r *http.Request)
 }
 
 func newRepoInfo(r *repos.Repo) (*protocol.RepoInfo, error) {
-	urls := r.CloneURLs()
-	if len(urls) == 0 {
+	var src *repos.SourceInfo
+	for _, s := range r.Sources {
+		if s != nil && s.CloneURL != "" {
+			src = s
+			break
+		}
+	}
+	if src == nil {
 		return nil, fmt.Errorf("no clone urls for repo id=%q name=%q", r.ID, r.Name)
 	}
 
@@ -654,9 +660,12 @@ func newRepoInfo(r *repos.Repo) (*protocol.RepoInfo, error) {
 		Fork:         r.Fork,
 		Archived:     r.Archived,
 		Private:      r.Private,
-		VCS:          protocol.VCSInfo{URL: urls[0]},
+		VCS:          protocol.VCSInfo{URL: src.CloneURL},
 		ExternalRepo: r.ExternalRepo,
 	}
+	if id := src.ExternalServiceID(); id > 0 {
+		info.VCS.ExternalServiceID = id
+	}
 
 	typ, _ := extsvc.ParseServiceType(r.ExternalRepo.ServiceType)
 	switch typ {
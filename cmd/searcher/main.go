@@ -28,6 +28,7 @@ import (
 
 var cacheDir = env.Get("CACHE_DIR", "/tmp", "directory to store cached archives.")
 var cacheSizeMB = env.Get("SEARCHER_CACHE_SIZE_MB", "100000", "maximum size of the on disk cache in megabytes")
+var resultCacheSizeMB = env.Get("SEARCHER_RESULT_CACHE_SIZE_MB", "1000", "maximum size of the on disk search result cache in megabytes. 0 disables the result cache.")
 
 const port = "3181"
 
@@ -46,6 +47,13 @@ func main() {
 		cacheSizeBytes = i * 1000 * 1000
 	}
 
+	var resultCacheSizeBytes int64
+	if i, err := strconv.ParseInt(resultCacheSizeMB, 10, 64); err != nil {
+		log.Fatalf("invalid int %q for SEARCHER_RESULT_CACHE_SIZE_MB: %s", resultCacheSizeMB, err)
+	} else {
+		resultCacheSizeBytes = i * 1000 * 1000
+	}
+
 	service := &search.Service{
 		Store: &store.Store{
 			FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
@@ -58,6 +66,13 @@ func main() {
 	}
 	service.Store.SetMaxConcurrentFetchTar(10)
 	service.Store.Start()
+	if resultCacheSizeBytes > 0 {
+		service.ResultCache = &search.ResultCache{
+			Path:              filepath.Join(cacheDir, "searcher-results"),
+			MaxCacheSizeBytes: resultCacheSizeBytes,
+		}
+		service.ResultCache.Start()
+	}
 	handler := ot.Middleware(service)
 
 	host := ""
@@ -42,6 +42,19 @@ type Request struct {
 	// The deadline for the search request.
 	// It is parsed with time.Time.UnmarshalText.
 	Deadline string
+
+	// Paginate if true returns at most FileMatchLimit matches, ordered by
+	// path, and a Cursor to request the next page with. The matches of all
+	// pages are cut from the same result, so later pages do not search the
+	// repository again if searcher caches results. A paginated request that
+	// hits its deadline or fails returns an error rather than a partial page.
+	Paginate bool
+
+	// Cursor is the Cursor of a previous paginated response. The request
+	// returns the page of matches after it. The rest of the request must be
+	// the same as the one the previous page was requested with. A non-empty
+	// Cursor implies Paginate.
+	Cursor string
 }
 
 // GitserverRepo returns the repository information necessary to perform gitserver requests.
@@ -164,6 +177,10 @@ type Response struct {
 
 	// DeadlineHit is true if Matches may not include all FileMatches because a deadline was hit.
 	DeadlineHit bool
+
+	// Cursor continues a paginated search after Matches. It is empty if there
+	// are no more matches or the request was not paginated.
+	Cursor string
 }
 
 // FileMatch is the struct used by vscode to receive search results
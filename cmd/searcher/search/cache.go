@@ -0,0 +1,215 @@
+package search
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
+)
+
+// ResultCache is an on disk cache of search results. Results are keyed on the
+// repository, commit and pattern of a search, so repeating a search, or
+// requesting the next page of a paginated search, does not search the archive
+// again. Since commits are immutable, cached results never go stale.
+//
+// Like store.Store it evicts the least recently used results once the cache
+// grows larger than MaxCacheSizeBytes. Results which hit a deadline are
+// incomplete, so they are never cached.
+type ResultCache struct {
+	// Path is the directory to store the cache.
+	Path string
+
+	// MaxCacheSizeBytes is the maximum size of the cache in bytes. Note:
+	// We can temporarily be larger than MaxCacheSizeBytes. When we go
+	// over MaxCacheSizeBytes we trigger delete files until we get below
+	// MaxCacheSizeBytes.
+	MaxCacheSizeBytes int64
+
+	// once protects Start
+	once sync.Once
+
+	// cache is the disk backed cache.
+	cache *diskcache.Store
+}
+
+// Start initializes state and starts background goroutines. It can be called
+// more than once. It is optional to call, but starting it earlier avoids a
+// search request paying the cost of initializing.
+func (c *ResultCache) Start() {
+	c.once.Do(func() {
+		c.cache = &diskcache.Store{
+			Dir:       c.Path,
+			Component: "searcher-results",
+		}
+		_ = os.MkdirAll(c.Path, 0700)
+		go c.watchAndEvict()
+	})
+}
+
+// cachedResult is the value stored in the cache.
+type cachedResult struct {
+	Matches  []protocol.FileMatch
+	LimitHit bool
+}
+
+// searchFunc finds up to limit file matches. It is called on cache misses.
+type searchFunc func(ctx context.Context, limit int) (matches []protocol.FileMatch, limitHit bool, err error)
+
+// search returns the result of searching p with a file match limit of limit,
+// which must be in the range [1, maxFileMatches]. If the result is not cached
+// it calls search and caches its result, unless search returns an error.
+func (c *ResultCache) search(ctx context.Context, p *protocol.Request, limit int, search searchFunc) (matches []protocol.FileMatch, limitHit bool, err error) {
+	c.Start()
+
+	key, err := resultCacheKey(p, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if r, ok := c.get(key); ok {
+		resultCacheTotal.WithLabelValues("hit").Inc()
+		return r.Matches, r.LimitHit, nil
+	}
+	if limit < maxFileMatches {
+		// A result found with the highest limit, like the results of
+		// paginated searches, contains the result for any lower limit.
+		maxKey, err := resultCacheKey(p, maxFileMatches)
+		if err != nil {
+			return nil, false, err
+		}
+		if r, ok := c.get(maxKey); ok {
+			resultCacheTotal.WithLabelValues("hit").Inc()
+			if len(r.Matches) > limit {
+				return r.Matches[:limit], true, nil
+			}
+			return r.Matches, r.LimitHit, nil
+		}
+	}
+	resultCacheTotal.WithLabelValues("miss").Inc()
+
+	// We do not fill the cache via diskcache.Store.OpenWithPath, since it
+	// discards the result of a fetch which fails, but on a deadline we
+	// still want to return the partial result.
+	matches, limitHit, err = search(ctx, limit)
+	if err != nil {
+		return matches, limitHit, err
+	}
+	if err := c.put(key, &cachedResult{Matches: matches, LimitHit: limitHit}); err != nil {
+		log.Printf("failed to cache search result for %s@%s: %s", p.Repo, p.Commit, err)
+	}
+	return matches, limitHit, nil
+}
+
+// get returns the cached result for key, if any.
+func (c *ResultCache) get(key string) (*cachedResult, bool) {
+	f, err := c.cache.OpenCached(key)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("failed to open cached search result: %s", err)
+		}
+		return nil, false
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		log.Printf("failed to read cached search result %s: %s", f.Path, err)
+		return nil, false
+	}
+	var r cachedResult
+	if err := json.NewDecoder(zr).Decode(&r); err != nil {
+		log.Printf("failed to decode cached search result %s: %s", f.Path, err)
+		return nil, false
+	}
+	return &r, true
+}
+
+// put stores r in the cache under key.
+func (c *ResultCache) put(key string, r *cachedResult) error {
+	f, err := c.cache.OpenWithPath(context.Background(), key, func(ctx context.Context, path string) error {
+		w, err := os.OpenFile(path, os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(r); err != nil {
+			w.Close()
+			return errors.Wrap(err, "failed to encode search result")
+		}
+		if err := zw.Close(); err != nil {
+			w.Close()
+			return err
+		}
+		return w.Close()
+	})
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// resultCacheKey returns the cache key for the result of searching p with a
+// file match limit of limit. It ignores the fields of p which do not affect
+// the result, like its deadline.
+func resultCacheKey(p *protocol.Request, limit int) (string, error) {
+	info := p.PatternInfo
+	info.FileMatchLimit = limit
+	b, err := json.Marshal(info)
+	if err != nil {
+		return "", err
+	}
+	// Bump the version when the format of cachedResult or the semantics
+	// of a search change.
+	return fmt.Sprintf("v1 %s@%s %s", p.Repo, p.Commit, b), nil
+}
+
+// watchAndEvict is a loop which periodically checks the size of the cache and
+// evicts/deletes items if the cache gets too large.
+func (c *ResultCache) watchAndEvict() {
+	if c.MaxCacheSizeBytes == 0 {
+		return
+	}
+
+	for {
+		time.Sleep(10 * time.Second)
+
+		stats, err := c.cache.Evict(c.MaxCacheSizeBytes)
+		if err != nil {
+			log.Printf("failed to Evict: %s", err)
+			continue
+		}
+		resultCacheSizeBytes.Set(float64(stats.CacheSize))
+		resultCacheEvictions.Add(float64(stats.Evicted))
+	}
+}
+
+var (
+	resultCacheTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "searcher_service_result_cache_total",
+		Help: "Number of search result cache lookups, by whether the result was cached (hit) or not (miss).",
+	}, []string{"status"})
+	resultCacheSizeBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "searcher_service_result_cache_size_bytes",
+		Help: "The total size of cached search results on disk.",
+	})
+	resultCacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_service_result_cache_evictions",
+		Help: "The total number of cached search results evicted.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(resultCacheTotal)
+	prometheus.MustRegister(resultCacheSizeBytes)
+	prometheus.MustRegister(resultCacheEvictions)
+}
@@ -0,0 +1,178 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/search"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestSearch_resultCache(t *testing.T) {
+	store, cleanup, err := newStore(map[string]string{
+		"a.go": "hello",
+		"b.go": "hello",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	d, err := ioutil.TempDir("", "search_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	ts := httptest.NewServer(&search.Service{
+		Store:       store,
+		ResultCache: &search.ResultCache{Path: d},
+	})
+	defer ts.Close()
+
+	req := protocol.Request{
+		Repo:         "foo",
+		URL:          "u",
+		Commit:       "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		PatternInfo:  protocol.PatternInfo{Pattern: "hello", PatternMatchesContent: true},
+		FetchTimeout: "2000ms",
+	}
+	want, err := doSearch(ts.URL, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want) != 2 {
+		t.Fatalf("got %d matches, want 2", len(want))
+	}
+
+	// Searching again must not fetch the archive, which is no longer
+	// available.
+	fetchTar := store.FetchTar
+	store.FetchTar = func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+		return nil, errors.New("archive should not be fetched")
+	}
+	if err := os.RemoveAll(store.Path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := doSearch(ts.URL, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got cached matches %v, want %v", got, want)
+	}
+
+	// A different pattern is not cached.
+	req.Pattern = "world"
+	if _, err := doSearch(ts.URL, &req); err == nil || !strings.Contains(err.Error(), "archive should not be fetched") {
+		t.Errorf("got error %v, want failed archive fetch", err)
+	}
+	store.FetchTar = fetchTar
+}
+
+func TestSearch_paginate(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i < 5; i++ {
+		files[fmt.Sprintf("%d.go", i)] = "hello"
+	}
+	store, cleanup, err := newStore(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	d, err := ioutil.TempDir("", "search_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	for _, cache := range []bool{false, true} {
+		t.Run(fmt.Sprintf("cache=%t", cache), func(t *testing.T) {
+			service := &search.Service{Store: store}
+			if cache {
+				service.ResultCache = &search.ResultCache{Path: d}
+			}
+			ts := httptest.NewServer(service)
+			defer ts.Close()
+
+			req := protocol.Request{
+				Repo:   "foo",
+				URL:    "u",
+				Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+				PatternInfo: protocol.PatternInfo{
+					Pattern:               "hello",
+					PatternMatchesContent: true,
+					FileMatchLimit:        2,
+				},
+				FetchTimeout: "2000ms",
+				Paginate:     true,
+			}
+
+			var pages [][]string
+			for i := 0; i < 5; i++ {
+				resp, err := doSearchResponse(ts.URL, &req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var paths []string
+				for _, m := range resp.Matches {
+					paths = append(paths, m.Path)
+				}
+				pages = append(pages, paths)
+				if wantLimitHit := resp.Cursor != ""; resp.LimitHit != wantLimitHit {
+					t.Errorf("got limitHit %t on page %d, want %t", resp.LimitHit, i, wantLimitHit)
+				}
+				if resp.Cursor == "" {
+					break
+				}
+				req.Cursor = resp.Cursor
+			}
+
+			want := [][]string{{"0.go", "1.go"}, {"2.go", "3.go"}, {"4.go"}}
+			if !reflect.DeepEqual(pages, want) {
+				t.Errorf("got pages %v, want %v", pages, want)
+			}
+		})
+	}
+
+	ts := httptest.NewServer(&search.Service{Store: store})
+	defer ts.Close()
+	_, err = doSearch(ts.URL, &protocol.Request{
+		Repo:        "foo",
+		URL:         "u",
+		Commit:      "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		PatternInfo: protocol.PatternInfo{Pattern: "hello", PatternMatchesContent: true},
+		Cursor:      "bad",
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "non-200 response: code=400 ") {
+		t.Errorf("got error %v for invalid cursor, want HTTP 400 response", err)
+	}
+
+	// A paginated search that hits its deadline fails instead of returning a
+	// page cut from partial results.
+	deadline, _ := time.Now().Add(-time.Minute).MarshalText()
+	resp, err := doSearchResponse(ts.URL, &protocol.Request{
+		Repo:         "foo",
+		URL:          "u",
+		Commit:       "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		PatternInfo:  protocol.PatternInfo{Pattern: "hello", PatternMatchesContent: true},
+		FetchTimeout: "2000ms",
+		Deadline:     string(deadline),
+		Paginate:     true,
+	})
+	if err == nil {
+		t.Errorf("got response %+v for paginated search past its deadline, want error", resp)
+	}
+}
@@ -17,19 +17,23 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	nettrace "golang.org/x/net/trace"
 
 	"github.com/pkg/errors"
 
 	"github.com/gorilla/schema"
+	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
@@ -53,6 +57,9 @@ const (
 type Service struct {
 	Store *store.Store
 	Log   log15.Logger
+
+	// ResultCache if non-nil caches search results.
+	ResultCache *ResultCache
 }
 
 var decoder = schema.NewDecoder()
@@ -99,7 +106,7 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	matches, limitHit, deadlineHit, err := s.search(ctx, &p)
+	matches, limitHit, deadlineHit, cursor, err := s.search(ctx, &p)
 	if err != nil {
 		code := http.StatusInternalServerError
 		if isBadRequest(err) || ctx.Err() == context.Canceled {
@@ -122,6 +129,7 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Matches:     matches,
 		LimitHit:    limitHit,
 		DeadlineHit: deadlineHit,
+		Cursor:      cursor,
 	}
 	// The only reasonable error is the client going away now since we know we
 	// can encode resp. This happens relatively often due to our
@@ -130,7 +138,7 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(&resp)
 }
 
-func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []protocol.FileMatch, limitHit, deadlineHit bool, err error) {
+func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []protocol.FileMatch, limitHit, deadlineHit bool, cursor string, err error) {
 	tr := nettrace.New("search", fmt.Sprintf("%s@%s", p.Repo, p.Commit))
 	tr.LazyPrintf("%s", p.Pattern)
 
@@ -151,6 +159,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 	span.SetTag("patternMatchesContent", p.PatternMatchesContent)
 	span.SetTag("patternMatchesPath", p.PatternMatchesPath)
 	span.SetTag("deadline", p.Deadline)
+	span.SetTag("paginate", p.Paginate)
+	span.SetTag("cursor", p.Cursor)
+	paginate := p.Paginate || p.Cursor != ""
 	defer func(start time.Time) {
 		code := "200"
 		// We often have canceled and timed out requests. We do not want to
@@ -161,8 +172,10 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 		} else if ctx.Err() == context.DeadlineExceeded {
 			code = "timedout"
 			span.SetTag("err", err)
-			deadlineHit = true
-			err = nil // error is fully described by deadlineHit=true return value
+			if !paginate {
+				deadlineHit = true
+				err = nil // error is fully described by deadlineHit=true return value
+			}
 		} else if err != nil {
 			tr.LazyPrintf("error: %v", err)
 			tr.SetError()
@@ -179,6 +192,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 		tr.LazyPrintf("code=%s matches=%d limitHit=%v deadlineHit=%v", code, len(matches), limitHit, deadlineHit)
 		tr.Finish()
 		requestTotal.WithLabelValues(code).Inc()
+		requestDuration.WithLabelValues(code).Observe(time.Since(start).Seconds())
 		span.LogFields(otlog.Int("matches.len", len(matches)))
 		span.SetTag("limitHit", limitHit)
 		span.SetTag("deadlineHit", deadlineHit)
@@ -193,16 +207,58 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 	if !p.IsStructuralPat {
 		rg, err = compile(&p.PatternInfo)
 		if err != nil {
-			return nil, false, false, badRequestError{err.Error()}
+			return nil, false, false, "", badRequestError{err.Error()}
 		}
 	}
 
+	offset, err := parseCursor(p.Cursor)
+	if err != nil {
+		return nil, false, false, "", err
+	}
+
+	limit := p.FileMatchLimit
+	if limit <= 0 || limit > maxFileMatches {
+		limit = maxFileMatches
+	}
+	// Every page of a paginated search is cut from the first maxFileMatches
+	// matches in archive order, sorted by path. The archive of a commit and
+	// thus that result is the same for every page, whether it is cached or
+	// not, so that pages neither overlap nor skip matches.
+	searchLimit := limit
+	if paginate {
+		searchLimit = maxFileMatches
+	}
+
+	searchArchive := func(ctx context.Context, limit int) ([]protocol.FileMatch, bool, error) {
+		return s.searchArchive(ctx, tr, span, p, rg, limit)
+	}
+	if s.ResultCache != nil {
+		matches, limitHit, err = s.ResultCache.search(ctx, p, searchLimit, searchArchive)
+	} else {
+		matches, limitHit, err = searchArchive(ctx, searchLimit)
+	}
+	if !paginate {
+		return matches, limitHit, false, "", err
+	}
+	if err != nil {
+		// A page cut from partial results could overlap or skip the matches
+		// of other pages, so we fail instead.
+		return nil, false, false, "", err
+	}
+
+	matches, limitHit, cursor = paginateMatches(matches, limitHit, offset, limit)
+	return matches, limitHit, false, cursor, nil
+}
+
+// searchArchive fetches the archive of the repository and searches it for up
+// to limit file matches.
+func (s *Service) searchArchive(ctx context.Context, tr nettrace.Trace, span opentracing.Span, p *protocol.Request, rg *readerGrep, limit int) (matches []protocol.FileMatch, limitHit bool, err error) {
 	if p.FetchTimeout == "" {
 		p.FetchTimeout = "500ms"
 	}
 	fetchTimeout, err := time.ParseDuration(p.FetchTimeout)
 	if err != nil {
-		return nil, false, false, err
+		return nil, false, err
 	}
 	prepareCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
 	defer cancel()
@@ -218,7 +274,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 
 	zipPath, zf, err := store.GetZipFileWithRetry(getZf)
 	if err != nil {
-		return nil, false, false, errors.Wrap(err, "failed to get archive")
+		return nil, false, errors.Wrap(err, "failed to get archive")
 	}
 	defer zf.Close()
 
@@ -232,11 +288,67 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 	archiveSize.Observe(float64(bytes))
 
 	if p.IsStructuralPat {
-		matches, limitHit, err = structuralSearch(ctx, zipPath, p.Pattern, p.CombyRule, p.Languages, p.IncludePatterns, p.Repo)
-	} else {
-		matches, limitHit, err = regexSearch(ctx, rg, zf, p.FileMatchLimit, p.PatternMatchesContent, p.PatternMatchesPath)
+		matches, limitHit, err := structuralSearch(ctx, zipPath, p.Pattern, p.CombyRule, p.Languages, p.IncludePatterns, p.Repo)
+		if err != nil {
+			return nil, false, err
+		}
+		matches, structuralLimitHit := limitFileMatches(matches, limit)
+		return matches, limitHit || structuralLimitHit, nil
+	}
+	return regexSearch(ctx, rg, zf, limit, p.PatternMatchesContent, p.PatternMatchesPath)
+}
+
+// limitFileMatches returns the first limit matches ordered by path, and
+// whether any matches were left out. Comby returns all matches of a
+// structural search in no particular order, so sorting them first keeps the
+// result the same for every search.
+func limitFileMatches(matches []protocol.FileMatch, limit int) ([]protocol.FileMatch, bool) {
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Path < matches[j].Path
+	})
+	if len(matches) > limit {
+		return matches[:limit], true
+	}
+	return matches, false
+}
+
+// paginateMatches returns the page of up to limit matches starting at offset,
+// and the cursor of the next page. The cursor is empty on the last page.
+// limitHit is the limitHit of the search all pages are cut from.
+func paginateMatches(all []protocol.FileMatch, allLimitHit bool, offset, limit int) (matches []protocol.FileMatch, limitHit bool, cursor string) {
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Path < all[j].Path
+	})
+	if offset > len(all) {
+		offset = len(all)
 	}
-	return matches, limitHit, false, err
+	end := offset + limit
+	if end >= len(all) {
+		return all[offset:], allLimitHit, ""
+	}
+	return all[offset:end], true, formatCursor(end)
+}
+
+// formatCursor returns the cursor of the page starting at offset. Clients
+// must treat cursors as opaque.
+func formatCursor(offset int) string {
+	return "o" + strconv.Itoa(offset)
+}
+
+// parseCursor returns the offset of the page cursor points to. The empty
+// cursor points to the first page.
+func parseCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(cursor, "o") {
+		return 0, badRequestError{fmt.Sprintf("invalid cursor %q", cursor)}
+	}
+	offset, err := strconv.Atoi(cursor[1:])
+	if err != nil || offset < 0 {
+		return 0, badRequestError{fmt.Sprintf("invalid cursor %q", cursor)}
+	}
+	return offset, nil
 }
 
 func validateParams(p *protocol.Request) error {
@@ -274,6 +386,11 @@ var (
 		Name: "searcher_service_request_total",
 		Help: "Number of returned search requests.",
 	}, []string{"code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "searcher_service_request_duration_seconds",
+		Help:    "Observes the duration of returned search requests.",
+		Buckets: trace.UserLatencyBuckets,
+	}, []string{"code"})
 )
 
 func init() {
@@ -281,6 +398,7 @@ func init() {
 	prometheus.MustRegister(archiveSize)
 	prometheus.MustRegister(archiveFiles)
 	prometheus.MustRegister(requestTotal)
+	prometheus.MustRegister(requestDuration)
 }
 
 type badRequestError struct{ msg string }
@@ -7,6 +7,7 @@ import (
 	"io"
 	"regexp"
 	"regexp/syntax"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -318,7 +319,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 		fileMatchLimit = maxFileMatches
 	}
 
-	// If we reach fileMatchLimit we use cancel to stop the search
+	// We use cancel to stop the search on errors and before the deadline
 	var cancel context.CancelFunc
 	if deadline, ok := ctx.Deadline(); ok {
 		// If a deadline is set, try to finish before the deadline expires.
@@ -333,7 +334,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 	var (
 		filesmu   sync.Mutex // protects files
 		files     = zf.Files
-		matchesmu sync.Mutex // protects matches, limitHit
+		matchesmu sync.Mutex // protects matches, cutoff
 		matches   = []protocol.FileMatch{}
 	)
 
@@ -353,6 +354,20 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 		return matches, limitHit, nil
 	}
 
+	// The matches are the first fileMatchLimit matching files in archive
+	// order, regardless of the order in which the workers find them, so that
+	// the same search always returns the same matches. Once more than
+	// fileMatchLimit files matched, cutoff is the index of the last file
+	// that can still be among them, and later files are skipped.
+	type indexedMatch struct {
+		index int
+		fm    protocol.FileMatch
+	}
+	var (
+		indexed []indexedMatch
+		cutoff  = len(files)
+	)
+
 	var (
 		done          = ctx.Done()
 		wg            sync.WaitGroup
@@ -382,10 +397,20 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 					filesmu.Unlock()
 					return
 				}
+				index := len(zf.Files) - len(files)
 				f := &files[0]
 				files = files[1:]
 				filesmu.Unlock()
 
+				matchesmu.Lock()
+				skip := index > cutoff
+				matchesmu.Unlock()
+				if skip {
+					// Files are handed out in order, so all remaining files
+					// are past the cutoff too.
+					return
+				}
+
 				// decide whether to process, record that decision
 				if !rg.matchPath.MatchPath(f.Name) {
 					atomic.AddUint32(&filesSkipped, 1)
@@ -413,11 +438,11 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 				}
 				if match {
 					matchesmu.Lock()
-					if len(matches) < fileMatchLimit {
-						matches = append(matches, fm)
-					} else {
-						limitHit = true
-						cancel()
+					indexed = append(indexed, indexedMatch{index: index, fm: fm})
+					if len(indexed) > fileMatchLimit {
+						sort.Slice(indexed, func(i, j int) bool { return indexed[i].index < indexed[j].index })
+						indexed = indexed[:fileMatchLimit+1]
+						cutoff = indexed[fileMatchLimit].index
 					}
 					matchesmu.Unlock()
 				}
@@ -427,6 +452,15 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 
 	wg.Wait()
 
+	sort.Slice(indexed, func(i, j int) bool { return indexed[i].index < indexed[j].index })
+	if len(indexed) > fileMatchLimit {
+		limitHit = true
+		indexed = indexed[:fileMatchLimit]
+	}
+	for _, m := range indexed {
+		matches = append(matches, m.fm)
+	}
+
 	err = wgErr
 	if err == nil && ctx.Err() == context.DeadlineExceeded {
 		// We stopped early because we were about to hit the deadline.
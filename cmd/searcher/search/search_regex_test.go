@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"reflect"
 	"regexp"
@@ -475,6 +476,49 @@ func TestMaxMatches(t *testing.T) {
 	}
 }
 
+// Tests that regexSearch returns the first matching files in archive order
+// when it hits the limit, regardless of the order in which workers finish.
+func TestRegexSearchLimitIsDeterministic(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i < 50; i++ {
+		files[fmt.Sprintf("file%02d", i)] = "foo\n"
+	}
+	zipData, err := testutil.CreateZip(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := store.MockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for _, f := range zf.Files[:5] {
+		want = append(want, f.Name)
+	}
+
+	rg, err := compile(&protocol.PatternInfo{Pattern: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		fileMatches, limitHit, err := regexSearch(context.Background(), rg, zf, 5, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !limitHit {
+			t.Fatal("expected limitHit")
+		}
+		got := make([]string, len(fileMatches))
+		for i, fm := range fileMatches {
+			got[i] = fm.Path
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
 // Tests that:
 //
 // - IncludePatterns can match the path in any order
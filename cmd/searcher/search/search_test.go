@@ -359,6 +359,14 @@ func TestSearch_badrequest(t *testing.T) {
 }
 
 func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
+	r, err := doSearchResponse(u, p)
+	if err != nil {
+		return nil, err
+	}
+	return r.Matches, nil
+}
+
+func doSearchResponse(u string, p *protocol.Request) (*protocol.Response, error) {
 	form := url.Values{
 		"Repo":            []string{string(p.Repo)},
 		"URL":             []string{string(p.URL)},
@@ -392,6 +400,18 @@ func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 	if p.PatternMatchesPath {
 		form.Set("PatternMatchesPath", "true")
 	}
+	if p.FileMatchLimit > 0 {
+		form.Set("FileMatchLimit", strconv.Itoa(p.FileMatchLimit))
+	}
+	if p.Paginate {
+		form.Set("Paginate", "true")
+	}
+	if p.Cursor != "" {
+		form.Set("Cursor", p.Cursor)
+	}
+	if p.Deadline != "" {
+		form.Set("Deadline", p.Deadline)
+	}
 	resp, err := http.PostForm(u, form)
 	if err != nil {
 		return nil, err
@@ -410,7 +430,7 @@ func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 	if err != nil {
 		return nil, err
 	}
-	return r.Matches, err
+	return &r, nil
 }
 
 func newStore(files map[string]string) (*store.Store, func(), error) {
@@ -19,7 +19,9 @@ type parseRequest struct {
 	data []byte
 }
 
-func (s *Service) fetchRepositoryArchive(ctx context.Context, repo api.RepoName, commitID api.CommitID) (<-chan parseRequest, <-chan error, error) {
+// fetchRepositoryArchive streams the files of repo@commitID that should be
+// parsed. If paths is non-empty, only those files are fetched.
+func (s *Service) fetchRepositoryArchive(ctx context.Context, repo api.RepoName, commitID api.CommitID, paths []string) (<-chan parseRequest, <-chan error, error) {
 	fetchQueueSize.Inc()
 	s.fetchSem <- 1 // acquire concurrent fetches semaphore
 	fetchQueueSize.Dec()
@@ -59,11 +61,21 @@ func (s *Service) fetchRepositoryArchive(ctx context.Context, repo api.RepoName,
 		span.Finish()
 	}
 
-	r, err := s.FetchTar(ctx, gitserver.Repo{Name: repo}, commitID)
+	r, err := s.FetchTar(ctx, gitserver.Repo{Name: repo}, commitID, paths)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Paths are passed to git as pathspecs, which can match more files than
+	// were asked for.
+	var wantPaths map[string]bool
+	if len(paths) > 0 {
+		wantPaths = make(map[string]bool, len(paths))
+		for _, p := range paths {
+			wantPaths[p] = true
+		}
+	}
+
 	// After this point we are not allowed to return an error. Instead we can
 	// return an error via the errChan we return. If you do want to update this
 	// code please ensure we still always call done once.
@@ -88,7 +100,10 @@ func (s *Service) fetchRepositoryArchive(ctx context.Context, repo api.RepoName,
 				return
 			}
 
-			if path.Ext(hdr.Name) == ".json" {
+			if !isIndexablePath(hdr.Name) {
+				continue
+			}
+			if wantPaths != nil && !wantPaths[hdr.Name] {
 				continue
 			}
 
@@ -133,6 +148,12 @@ func (s *Service) fetchRepositoryArchive(ctx context.Context, repo api.RepoName,
 	return requestCh, errCh, nil
 }
 
+// isIndexablePath reports whether the file at path might be parsed for
+// symbols, judging by its path alone.
+func isIndexablePath(name string) bool {
+	return path.Ext(name) != ".json"
+}
+
 var (
 	fetching = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "symbols_store_fetching",
@@ -0,0 +1,261 @@
+package symbols
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/inconshreveable/log15"
+	"github.com/jmoiron/sqlx"
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+// maxAncestors is the number of ancestors of a commit that are checked for a
+// cached symbols database.
+const maxAncestors = 50
+
+// maxIncrementalPaths is the maximum number of changed files that are parsed
+// incrementally. Above that it is cheaper to fetch the whole archive.
+const maxIncrementalPaths = 1000
+
+// maxDeleteBatchSize is the number of paths deleted per statement, which keeps
+// us well below sqlite's limit on the number of bound variables.
+const maxDeleteBatchSize = 500
+
+// Changes are the paths that differ between two commits.
+type Changes struct {
+	Changed []string // added or modified
+	Deleted []string
+}
+
+// ParseGitDiffNameStatus parses the output of `git diff --name-status -z
+// --no-renames`.
+func ParseGitDiffNameStatus(out []byte) (Changes, error) {
+	var changes Changes
+	fields := bytes.Split(bytes.TrimSuffix(out, []byte{0}), []byte{0})
+	if len(fields) == 1 && len(fields[0]) == 0 {
+		return changes, nil
+	}
+	if len(fields)%2 != 0 {
+		return Changes{}, fmt.Errorf("unexpected git diff output %q", out)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		status, path := string(fields[i]), string(fields[i+1])
+		switch status {
+		case "A", "M", "T":
+			changes.Changed = append(changes.Changed, path)
+		case "D":
+			changes.Deleted = append(changes.Deleted, path)
+		default:
+			return Changes{}, fmt.Errorf("unexpected status %q for path %q in git diff output", status, path)
+		}
+	}
+	return changes, nil
+}
+
+// writeSymbolsToNewDB writes the symbols of repo@commit to the blank database
+// file dbFile. If possible it starts from the database of the nearest cached
+// ancestor and only parses the files that changed since, otherwise it parses
+// every file.
+func (s *Service) writeSymbolsToNewDB(ctx context.Context, dbFile string, repo api.RepoName, commitID api.CommitID) error {
+	ok, err := s.writeSymbolsIncrementally(ctx, dbFile, repo, commitID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		log15.Warn("Failed to write symbols incrementally, parsing all files.", "repo", repo, "commitID", commitID, "error", err)
+		incrementalFailed.Inc()
+	}
+	if ok {
+		return nil
+	}
+
+	indexes.WithLabelValues("full").Inc()
+	return s.writeAllSymbolsToNewDB(ctx, dbFile, repo, commitID)
+}
+
+// writeSymbolsIncrementally copies the database of the nearest cached ancestor
+// of repo@commit to the blank database file dbFile and updates it with the
+// symbols of the files that changed since. It returns false if no suitable
+// ancestor is cached, in which case dbFile is left blank. If an error is
+// returned, dbFile is also left blank.
+func (s *Service) writeSymbolsIncrementally(ctx context.Context, dbFile string, repo api.RepoName, commitID api.CommitID) (ok bool, err error) {
+	if s.ListAncestors == nil || s.GitDiff == nil {
+		return false, nil
+	}
+
+	span, ctx := ot.StartSpanFromContext(ctx, "writeSymbolsIncrementally")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("repo", string(repo))
+	span.SetTag("commit", string(commitID))
+
+	ancestors, err := s.ListAncestors(ctx, repo, commitID, maxAncestors)
+	if err != nil {
+		return false, errors.Wrap(err, "ListAncestors")
+	}
+	ancestor, ancestorDB := s.nearestCachedAncestor(repo, ancestors)
+	if ancestorDB == nil {
+		return false, nil
+	}
+	defer ancestorDB.Close()
+	span.SetTag("ancestor", string(ancestor))
+
+	changes, err := s.GitDiff(ctx, repo, ancestor, commitID)
+	if err != nil {
+		return false, errors.Wrap(err, "GitDiff")
+	}
+	var parsePaths []string
+	for _, p := range changes.Changed {
+		if isIndexablePath(p) {
+			parsePaths = append(parsePaths, p)
+		}
+	}
+	if len(parsePaths) > maxIncrementalPaths {
+		return false, nil
+	}
+	span.SetTag("changed", len(changes.Changed))
+	span.SetTag("deleted", len(changes.Deleted))
+
+	defer func() {
+		if err != nil {
+			// Leave a blank database file behind so that all files can be
+			// parsed into it instead.
+			_ = os.Remove(dbFile + "-journal")
+			if truncateErr := os.Truncate(dbFile, 0); truncateErr != nil {
+				err = errors.Wrap(truncateErr, "failed to reset database file")
+			}
+		}
+	}()
+
+	if err := copyDBFile(dbFile, ancestorDB); err != nil {
+		return false, err
+	}
+
+	if len(parsePaths) == 0 && len(changes.Deleted) == 0 {
+		indexes.WithLabelValues("unchanged").Inc()
+		return true, nil
+	}
+
+	db, err := sqlx.Open("sqlite3_with_pcre", dbFile)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Files that were modified are deleted too, so that their new symbols
+	// replace the old ones.
+	err = deleteSymbols(tx, append(parsePaths, changes.Deleted...))
+	if err != nil {
+		return false, err
+	}
+
+	if len(parsePaths) > 0 {
+		err = s.writeSymbols(ctx, tx, repo, commitID, parsePaths)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	if len(parsePaths) == 0 {
+		indexes.WithLabelValues("unchanged").Inc()
+	} else {
+		indexes.WithLabelValues("incremental").Inc()
+	}
+	return true, nil
+}
+
+// nearestCachedAncestor returns the first of ancestors whose symbols database
+// is in the cache, along with the opened database file. The file is nil if no
+// ancestor is cached.
+func (s *Service) nearestCachedAncestor(repo api.RepoName, ancestors []api.CommitID) (api.CommitID, *diskcache.File) {
+	for _, ancestor := range ancestors {
+		f, err := s.cache.OpenCached(symbolsDBKey(repo, ancestor))
+		if err == nil {
+			return ancestor, f
+		}
+		if !os.IsNotExist(err) {
+			log15.Warn("Failed to open cached symbols database.", "repo", repo, "commitID", ancestor, "error", err)
+		}
+	}
+	return "", nil
+}
+
+// copyDBFile overwrites the database file dst with the contents of src.
+func copyDBFile(dst string, src *diskcache.File) error {
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, src.File); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to copy ancestor database")
+	}
+	return f.Close()
+}
+
+// deleteSymbols deletes the symbols of the given paths.
+func deleteSymbols(tx *sqlx.Tx, paths []string) error {
+	for len(paths) > 0 {
+		batch := paths
+		if len(batch) > maxDeleteBatchSize {
+			batch = batch[:maxDeleteBatchSize]
+		}
+		paths = paths[len(batch):]
+
+		values := make([]*sqlf.Query, len(batch))
+		for i, p := range batch {
+			values[i] = sqlf.Sprintf("%s", p)
+		}
+		q := sqlf.Sprintf("DELETE FROM symbols WHERE path IN (%s)", sqlf.Join(values, ","))
+		if _, err := tx.Exec(q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	indexes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "symbols_store_indexes",
+		Help: "The total number of symbols databases created, by whether all files (full), only changed files (incremental) or no files (unchanged) were parsed.",
+	}, []string{"method"})
+	incrementalFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "symbols_store_incremental_failed",
+		Help: "The total number of incremental symbols database updates that failed and fell back to parsing all files.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(indexes)
+	prometheus.MustRegister(incrementalFailed)
+}
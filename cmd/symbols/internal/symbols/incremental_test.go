@@ -0,0 +1,183 @@
+package symbols
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/pkg/ctags"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestParseGitDiffNameStatus(t *testing.T) {
+	tests := map[string]struct {
+		out  string
+		want Changes
+	}{
+		"empty": {
+			out:  "",
+			want: Changes{},
+		},
+		"changes": {
+			out: "M\x00a.go\x00A\x00dir/b c.go\x00D\x00d.go\x00T\x00e\x00",
+			want: Changes{
+				Changed: []string{"a.go", "dir/b c.go", "e"},
+				Deleted: []string{"d.go"},
+			},
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			got, err := ParseGitDiffNameStatus([]byte(test.out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	for _, out := range []string{"M\x00", "R100\x00a.go\x00b.go\x00"} {
+		if _, err := ParseGitDiffNameStatus([]byte(out)); err == nil {
+			t.Errorf("expected error for %q", out)
+		}
+	}
+}
+
+func TestService_incremental(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { os.RemoveAll(tmpDir) }()
+
+	commits := map[api.CommitID]map[string]string{
+		"c1": {"a.js": "x y", "b.js": "z"},
+		"c2": {"a.js": "x w", "c.json": "u", "d.js": "v"},
+		"c3": {"a.js": "x w", "c.json": "t", "d.js": "v"},
+	}
+	ancestors := map[api.CommitID][]api.CommitID{
+		"c2": {"c1"},
+		"c3": {"c2", "c1"},
+	}
+	diffs := map[api.CommitID]Changes{
+		"c2": {Changed: []string{"a.js", "c.json", "d.js"}, Deleted: []string{"b.js"}},
+		"c3": {Changed: []string{"c.json"}},
+	}
+
+	var (
+		mu      sync.Mutex
+		fetches = map[api.CommitID][]string{}
+	)
+	service := Service{
+		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			mu.Lock()
+			fetches[commit] = paths
+			mu.Unlock()
+			files := map[string]string{}
+			for name, body := range commits[commit] {
+				if len(paths) == 0 || contains(paths, name) {
+					files[name] = body
+				}
+			}
+			return createTar(files)
+		},
+		ListAncestors: func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+			return ancestors[commit], nil
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, base, head api.CommitID) (Changes, error) {
+			if want := ancestors[head][0]; base != want {
+				t.Errorf("got diff base %s for %s, want %s", base, head, want)
+			}
+			return diffs[head], nil
+		},
+		NewParser: func() (ctags.Parser, error) {
+			return wordParser{}, nil
+		},
+		Path: tmpDir,
+	}
+	if err := service.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(commit api.CommitID) []string {
+		result, err := service.search(context.Background(), protocol.SearchArgs{Repo: "r", CommitID: commit, First: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var symbols []string
+		for _, s := range result.Symbols {
+			symbols = append(symbols, s.Path+":"+s.Name)
+		}
+		sort.Strings(symbols)
+		return symbols
+	}
+
+	tests := []struct {
+		commit      api.CommitID
+		wantSymbols []string
+		wantFetched bool
+		wantPaths   []string
+	}{
+		{
+			commit:      "c1",
+			wantSymbols: []string{"a.js:x", "a.js:y", "b.js:z"},
+			wantFetched: true,
+		},
+		{
+			// Only the changed files that are indexed are parsed.
+			commit:      "c2",
+			wantSymbols: []string{"a.js:w", "a.js:x", "d.js:v"},
+			wantFetched: true,
+			wantPaths:   []string{"a.js", "d.js"},
+		},
+		{
+			// No indexed file changed, so nothing is parsed.
+			commit:      "c3",
+			wantSymbols: []string{"a.js:w", "a.js:x", "d.js:v"},
+		},
+	}
+	for _, test := range tests {
+		if got := search(test.commit); !reflect.DeepEqual(got, test.wantSymbols) {
+			t.Errorf("%s: got symbols %v, want %v", test.commit, got, test.wantSymbols)
+		}
+		paths, fetched := fetches[test.commit]
+		if fetched != test.wantFetched {
+			t.Errorf("%s: got fetched %t, want %t", test.commit, fetched, test.wantFetched)
+		}
+		if !reflect.DeepEqual(paths, test.wantPaths) {
+			t.Errorf("%s: got fetched paths %v, want %v", test.commit, paths, test.wantPaths)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// wordParser returns a symbol for each word in a file.
+type wordParser struct{}
+
+func (wordParser) Parse(name string, content []byte) ([]ctags.Entry, error) {
+	var entries []ctags.Entry
+	for _, word := range strings.Fields(string(content)) {
+		entries = append(entries, ctags.Entry{Name: word, Path: name})
+	}
+	return entries, nil
+}
+
+func (wordParser) Close() {}
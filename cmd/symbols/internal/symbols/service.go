@@ -19,8 +19,17 @@ import (
 type Service struct {
 	// FetchTar returns an io.ReadCloser to a tar archive of a repository at the specified Git
 	// remote URL and commit ID. If the error implements "BadRequest() bool", it will be used to
-	// determine if the error is a bad request (eg invalid repo).
-	FetchTar func(context.Context, gitserver.Repo, api.CommitID) (io.ReadCloser, error)
+	// determine if the error is a bad request (eg invalid repo). If paths is non-empty, the archive
+	// only needs to contain those paths.
+	FetchTar func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error)
+
+	// ListAncestors returns up to n ancestors of commit, nearest first. Together with GitDiff it
+	// is used to build the symbols of a commit from those of an ancestor that is already cached,
+	// only parsing the files that changed in between. If either is nil, every file is parsed.
+	ListAncestors func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error)
+
+	// GitDiff returns the paths that differ between the commits base and head.
+	GitDiff func(ctx context.Context, repo api.RepoName, base, head api.CommitID) (Changes, error)
 
 	// MaxConcurrentFetchTar is the maximum number of concurrent calls allowed
 	// to FetchTar. It defaults to 15.
@@ -22,6 +22,7 @@ import (
 
 func init() {
 	sqliteutil.SetLocalLibpath()
+	sqliteutil.MustRegisterSqlite3WithPcre()
 }
 
 func TestIsLiteralEquality(t *testing.T) {
@@ -57,8 +58,6 @@ func TestIsLiteralEquality(t *testing.T) {
 }
 
 func TestService(t *testing.T) {
-	sqliteutil.MustRegisterSqlite3WithPcre()
-
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		t.Fatal(err)
@@ -67,7 +66,7 @@ func TestService(t *testing.T) {
 
 	files := map[string]string{"a.js": "var x = 1"}
 	service := Service{
-		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
 			return createTar(files)
 		},
 		NewParser: func() (ctags.Parser, error) {
@@ -82,8 +81,8 @@ func TestService(t *testing.T) {
 	server := httptest.NewServer(service.Handler())
 	defer server.Close()
 	client := symbolsclient.Client{URL: server.URL}
-	x := protocol.Symbol{Name: "x", Path: "a.js"}
-	y := protocol.Symbol{Name: "y", Path: "a.js"}
+	x := protocol.Symbol{Name: "x", Path: "a.js", Kind: "variable"}
+	y := protocol.Symbol{Name: "y", Path: "a.js", Kind: "variable"}
 
 	tests := map[string]struct {
 		args search.SymbolsParameters
@@ -125,6 +124,14 @@ func TestService(t *testing.T) {
 			args: search.SymbolsParameters{IncludePatterns: []string{"^A.js$"}, IsCaseSensitive: true, First: 10},
 			want: protocol.SearchResult{},
 		},
+		"kind": {
+			args: search.SymbolsParameters{Kinds: []string{"Variable"}, First: 10},
+			want: protocol.SearchResult{Symbols: []protocol.Symbol{x, y}},
+		},
+		"nokindmatch": {
+			args: search.SymbolsParameters{Kinds: []string{"function", "class"}, First: 10},
+			want: protocol.SearchResult{},
+		},
 		"exclude": {
 			args: search.SymbolsParameters{ExcludePattern: "a.js", IsCaseSensitive: true, First: 10},
 			want: protocol.SearchResult{},
@@ -172,7 +179,7 @@ type mockParser []string
 func (m mockParser) Parse(name string, content []byte) ([]ctags.Entry, error) {
 	entries := make([]ctags.Entry, len(m))
 	for i, name := range m {
-		entries[i] = ctags.Entry{Name: name, Path: "a.js"}
+		entries[i] = ctags.Entry{Name: name, Path: "a.js", Kind: "variable"}
 	}
 	return entries, nil
 }
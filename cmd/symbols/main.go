@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -46,8 +47,30 @@ func main() {
 	go debugserver.Start()
 
 	service := symbols.Service{
-		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
-			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar"})
+		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar", Paths: paths})
+		},
+		ListAncestors: func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+			cmd := gitserver.DefaultClient.Command("git", "rev-list", "--first-parent", "--skip=1", "--max-count="+strconv.Itoa(n), string(commit), "--")
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var ancestors []api.CommitID
+			for _, line := range strings.Fields(string(out)) {
+				ancestors = append(ancestors, api.CommitID(line))
+			}
+			return ancestors, nil
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, base, head api.CommitID) (symbols.Changes, error) {
+			cmd := gitserver.DefaultClient.Command("git", "diff", "--name-status", "-z", "--no-renames", string(base), string(head), "--")
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return symbols.Changes{}, err
+			}
+			return symbols.ParseGitDiffNameStatus(out)
 		},
 		NewParser: ctags.New,
 		Path:      cacheDir,
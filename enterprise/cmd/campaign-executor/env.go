@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+var (
+	rawResetInterval = env.Get("CAMPAIGN_EXECUTOR_RESET_INTERVAL", "1m", "How often to reset stalled campaign spec jobs.")
+	rawPollInterval  = env.Get("CAMPAIGN_EXECUTOR_POLL_INTERVAL", "1s", "Interval between queries to the campaign spec job queue.")
+	rawNumHandlers   = env.Get("CAMPAIGN_EXECUTOR_CONCURRENCY", "4", "Number of campaign spec jobs to execute concurrently.")
+	rawJobTimeout    = env.Get("CAMPAIGN_EXECUTOR_JOB_TIMEOUT", "1h", "Maximum duration of a single attempt of a campaign spec job.")
+	rawMaxAttempts   = env.Get("CAMPAIGN_EXECUTOR_MAX_ATTEMPTS", "3", "Number of times a failing campaign spec job is attempted before it is marked as errored.")
+	rawRetryBackoff  = env.Get("CAMPAIGN_EXECUTOR_RETRY_BACKOFF", "1m", "Delay before a failed campaign spec job is retried, multiplied by the number of failed attempts.")
+	rawWorkspaceRoot = env.Get("CAMPAIGN_EXECUTOR_WORKSPACE_ROOT", "", "Directory in which workspaces are created. It must be mounted at the same path in the Docker daemon. Defaults to the system temporary directory.")
+	rawRunner        = env.Get("CAMPAIGN_EXECUTOR_RUNNER", "docker", "How steps are run: 'docker' runs them in sandboxed containers, 'local' runs them as unsandboxed local processes (for development only).")
+	rawDockerOptions = env.Get("CAMPAIGN_EXECUTOR_DOCKER_OPTIONS", "--cpus=1 --memory=2g --network=none", "Additional flags passed to 'docker run' for every step. Steps have no network access unless --network is changed.")
+)
+
+// mustParseInt returns the integer version of the given raw value fatally logs on failure.
+func mustParseInt(rawValue, name string) int {
+	i, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid int %q for %s: %s", rawValue, name, err)
+	}
+
+	return int(i)
+}
+
+// mustParseInterval returns the interval version of the given raw value fatally logs on failure.
+func mustParseInterval(rawValue, name string) time.Duration {
+	d, err := time.ParseDuration(rawValue)
+	if err != nil {
+		log.Fatalf("invalid duration %q for %s: %s", rawValue, name, err)
+	}
+
+	return d
+}
@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type ExecutorOptions struct {
+	// NumHandlers is the number of jobs executed concurrently.
+	NumHandlers int
+
+	// PollInterval is the interval between dequeue attempts when the queue is
+	// empty.
+	PollInterval time.Duration
+
+	// JobTimeout is the maximum duration of a single attempt of a job.
+	JobTimeout time.Duration
+
+	// MaxAttempts is the number of times a failing job is attempted before
+	// it is marked as errored.
+	MaxAttempts int
+
+	// RetryBackoff is the delay before a failed job is retried, multiplied by
+	// the number of failed attempts so far.
+	RetryBackoff time.Duration
+
+	// WorkspaceRoot is the directory in which the workspaces of jobs are
+	// created. When using the DockerRunner, it must be available at the same
+	// path to the Docker daemon.
+	WorkspaceRoot string
+}
+
+func NewExecutor(
+	s *ee.Store,
+	repoStore RepoStore,
+	gitserverClient GitserverClient,
+	runner Runner,
+	options ExecutorOptions,
+	metrics ExecutorMetrics,
+) *workerutil.Worker {
+	rootContext := actor.WithActor(context.Background(), &actor.Actor{Internal: true})
+
+	h := &handler{
+		store: s,
+		processor: &processor{
+			repoStore:       repoStore,
+			gitserverClient: gitserverClient,
+			runner:          runner,
+			workspaceRoot:   options.WorkspaceRoot,
+		},
+		jobTimeout:   options.JobTimeout,
+		maxAttempts:  options.MaxAttempts,
+		retryBackoff: options.RetryBackoff,
+	}
+
+	workerMetrics := workerutil.WorkerMetrics{
+		HandleOperation: metrics.ProcessOperation,
+	}
+
+	return workerutil.NewWorker(rootContext, ee.WorkerutilCampaignSpecJobStore(s), workerutil.WorkerOptions{
+		Name:        "campaign spec job executor",
+		Handler:     h,
+		NumHandlers: options.NumHandlers,
+		Interval:    options.PollInterval,
+		Metrics:     workerMetrics,
+	})
+}
+
+type handler struct {
+	store        *ee.Store
+	processor    Processor
+	jobTimeout   time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+var _ workerutil.Handler = &handler{}
+
+// Handle processes the job and stores its log and changeset spec. Failed jobs
+// are requeued with a delay until they have been attempted maxAttempts times,
+// after which the error is returned so that the worker marks them as errored.
+func (h *handler) Handle(ctx context.Context, tx workerutil.Store, record workerutil.Record) error {
+	job := record.(*campaigns.CampaignSpecJob)
+	store := h.store.With(tx)
+
+	processCtx, cancel := context.WithTimeout(ctx, h.jobTimeout)
+	defer cancel()
+
+	log, err := h.processor.Process(processCtx, store, job)
+	job.Log = log
+	if err == nil {
+		job.FailureMessage = ""
+		return store.UpdateCampaignSpecJob(ctx, job)
+	}
+
+	job.NumFailures++
+	job.FailureMessage = err.Error()
+	if updateErr := store.UpdateCampaignSpecJob(ctx, job); updateErr != nil {
+		return updateErr
+	}
+
+	if int(job.NumFailures) >= h.maxAttempts {
+		return err
+	}
+
+	log15.Warn("Retrying campaign spec job", "id", job.ID, "attempt", job.NumFailures, "err", err)
+	after := time.Now().Add(h.retryBackoff * time.Duration(job.NumFailures))
+	return tx.Requeue(ctx, job.RecordID(), after)
+}
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// GitserverClient is the subset of gitserver operations used to prepare the
+// workspace of a campaign spec job.
+type GitserverClient interface {
+	// DefaultBranch returns the full ref name of the default branch of the
+	// repository and the commit it points to.
+	DefaultBranch(ctx context.Context, repo gitserver.Repo) (ref string, commit api.CommitID, err error)
+
+	// Archive returns a tar archive of the repository at the given commit.
+	Archive(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error)
+}
+
+// DefaultGitserverClient is the GitserverClient backed by the gitservers of
+// the instance.
+var DefaultGitserverClient GitserverClient = &defaultGitserverClient{}
+
+type defaultGitserverClient struct{}
+
+func (c *defaultGitserverClient) DefaultBranch(ctx context.Context, repo gitserver.Repo) (string, api.CommitID, error) {
+	refBytes, _, exitCode, err := git.ExecSafe(ctx, repo, []string{"symbolic-ref", "HEAD"})
+	if err != nil {
+		return "", "", errors.Wrap(err, "git symbolic-ref HEAD")
+	}
+	if exitCode != 0 {
+		return "", "", errors.Errorf("git symbolic-ref HEAD exited with %d", exitCode)
+	}
+
+	commit, err := git.ResolveRevision(ctx, repo, nil, "HEAD", git.ResolveRevisionOptions{})
+	if err != nil {
+		return "", "", errors.Wrap(err, "git.ResolveRevision")
+	}
+
+	return string(bytes.TrimSpace(refBytes)), commit, nil
+}
+
+func (c *defaultGitserverClient) Archive(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+	return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{
+		Treeish: string(commit),
+		Format:  "tar",
+	})
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"fmt"
+)
+
+// maxLogSize is the maximum number of bytes of output of the steps of a job
+// that are stored as its log.
+const maxLogSize = 1 << 20
+
+// logBuffer captures the output of the steps of a job. Once more than its
+// limit is written, only the head and the tail of the output are kept, so that
+// steps that produce a lot of output don't exhaust the memory of the executor
+// or bloat the job record.
+type logBuffer struct {
+	limit int
+
+	head []byte
+	// tail is a ring buffer of the last bytes written after the head filled
+	// up. Its next write position is pos.
+	tail    []byte
+	pos     int
+	full    bool
+	omitted int
+}
+
+func newLogBuffer(limit int) *logBuffer {
+	return &logBuffer{limit: limit}
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if room := b.limit/2 - len(b.head); room > 0 {
+		if room > len(p) {
+			room = len(p)
+		}
+		b.head = append(b.head, p[:room]...)
+		p = p[room:]
+	}
+
+	tailSize := b.limit - b.limit/2
+	for len(p) > 0 && tailSize > 0 {
+		if !b.full && len(b.tail) < tailSize {
+			room := tailSize - len(b.tail)
+			if room > len(p) {
+				room = len(p)
+			}
+			b.tail = append(b.tail, p[:room]...)
+			p = p[room:]
+			b.full = len(b.tail) == tailSize
+			continue
+		}
+
+		// Overwrite the oldest bytes of the tail.
+		m := copy(b.tail[b.pos:], p)
+		b.omitted += m
+		b.pos = (b.pos + m) % tailSize
+		p = p[m:]
+	}
+	b.omitted += len(p)
+
+	return n, nil
+}
+
+// String returns the captured output. If output was dropped, a marker
+// separates the head from the tail of the output.
+func (b *logBuffer) String() string {
+	if b.omitted == 0 {
+		return string(b.head) + string(b.tail)
+	}
+
+	tail := append(append([]byte(nil), b.tail[b.pos:]...), b.tail[:b.pos]...)
+	return fmt.Sprintf("%s\n--- output truncated: %d bytes omitted ---\n%s", b.head, b.omitted, tail)
+}
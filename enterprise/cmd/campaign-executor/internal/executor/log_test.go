@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLogBuffer(t *testing.T) {
+	t.Run("under limit", func(t *testing.T) {
+		b := newLogBuffer(10)
+		fmt.Fprint(b, "abc")
+		fmt.Fprint(b, "defghij")
+		if have, want := b.String(), "abcdefghij"; have != want {
+			t.Errorf("unexpected log. want=%q have=%q", want, have)
+		}
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		b := newLogBuffer(10)
+		for _, s := range []string{"abc", "defghijk", "lmnopqrs", "tuvwxyz"} {
+			if n, err := b.Write([]byte(s)); err != nil || n != len(s) {
+				t.Fatalf("unexpected write result: %d, %v", n, err)
+			}
+		}
+		want := "abcde\n--- output truncated: 16 bytes omitted ---\nvwxyz"
+		if have := b.String(); have != want {
+			t.Errorf("unexpected log. want=%q have=%q", want, have)
+		}
+	})
+
+	t.Run("single large write", func(t *testing.T) {
+		b := newLogBuffer(10)
+		fmt.Fprint(b, strings.Repeat("x", 100)+"end")
+		want := "xxxxx\n--- output truncated: 93 bytes omitted ---\nxxend"
+		if have := b.String(); have != want {
+			t.Errorf("unexpected log. want=%q have=%q", want, have)
+		}
+	})
+}
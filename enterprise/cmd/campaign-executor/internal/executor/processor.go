@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+type Processor interface {
+	// Process runs the steps of the campaign spec of the job and creates the
+	// resulting changeset spec, if any, with the given store. It returns the
+	// output of the steps, even if they failed.
+	Process(ctx context.Context, store *ee.Store, job *campaigns.CampaignSpecJob) (log string, err error)
+}
+
+// RepoStore looks up the repositories of campaign spec jobs.
+type RepoStore interface {
+	Get(ctx context.Context, id api.RepoID) (*types.Repo, error)
+}
+
+type processor struct {
+	repoStore       RepoStore
+	gitserverClient GitserverClient
+	runner          Runner
+	workspaceRoot   string
+}
+
+func (p *processor) Process(ctx context.Context, store *ee.Store, job *campaigns.CampaignSpecJob) (string, error) {
+	spec, err := store.GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{ID: job.CampaignSpecID})
+	if err != nil {
+		return "", errors.Wrap(err, "store.GetCampaignSpec")
+	}
+
+	// 🚨 SECURITY: The access of the creator of the campaign spec to the
+	// repository was checked when the job was enqueued.
+	repo, err := p.repoStore.Get(ctx, job.RepoID)
+	if err != nil {
+		return "", errors.Wrap(err, "repoStore.Get")
+	}
+
+	log := newLogBuffer(maxLogSize)
+	desc, err := p.execute(ctx, log, spec, repo)
+	if err != nil {
+		return log.String(), err
+	}
+
+	if desc == nil {
+		fmt.Fprintln(log, "--- no changes")
+		return log.String(), nil
+	}
+
+	rawSpec, err := json.Marshal(desc)
+	if err != nil {
+		return log.String(), err
+	}
+
+	changesetSpec, err := campaigns.NewChangesetSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return log.String(), errors.Wrap(err, "invalid changeset spec")
+	}
+	changesetSpec.RepoID = repo.ID
+	changesetSpec.UserID = spec.UserID
+	changesetSpec.CampaignSpecID = spec.ID
+
+	if err := store.CreateChangesetSpec(ctx, changesetSpec); err != nil {
+		return log.String(), errors.Wrap(err, "store.CreateChangesetSpec")
+	}
+	job.ChangesetSpecID = changesetSpec.ID
+
+	return log.String(), nil
+}
+
+// execute runs the steps of the campaign spec against the default branch of
+// the repository and returns the description of the resulting changeset, or
+// nil if the steps didn't change anything.
+func (p *processor) execute(ctx context.Context, log io.Writer, spec *campaigns.CampaignSpec, repo *types.Repo) (*campaigns.ChangesetSpecDescription, error) {
+	gitserverRepo := gitserver.Repo{Name: repo.Name}
+
+	baseRef, baseRev, err := p.gitserverClient.DefaultBranch(ctx, gitserverRepo)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving default branch")
+	}
+
+	dir, err := prepareWorkspace(ctx, p.gitserverClient, p.workspaceRoot, gitserverRepo, baseRev)
+	if err != nil {
+		return nil, errors.Wrap(err, "preparing workspace")
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log15.Warn("Failed to remove campaign spec job workspace", "dir", dir, "err", err)
+		}
+	}()
+
+	for i, step := range spec.Spec.Steps {
+		fmt.Fprintf(log, "--- step %d: %s\n", i+1, step.Run)
+		if err := p.runner.Run(ctx, dir, step, log); err != nil {
+			return nil, errors.Wrapf(err, "step %d failed", i+1)
+		}
+	}
+
+	diff, err := workspaceDiff(ctx, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "computing diff")
+	}
+
+	if diff == "" {
+		return nil, nil
+	}
+
+	tmpl := spec.Spec.ChangesetTemplate
+	repoID := graphqlbackend.MarshalRepositoryID(repo.ID)
+
+	return &campaigns.ChangesetSpecDescription{
+		BaseRepository: repoID,
+		BaseRef:        baseRef,
+		BaseRev:        string(baseRev),
+
+		HeadRepository: repoID,
+		HeadRef:        "refs/heads/" + tmpl.Branch,
+
+		Title: tmpl.Title,
+		Body:  tmpl.Body,
+
+		Commits: []campaigns.GitCommitDescription{
+			{Message: tmpl.Commit.Message, Diff: diff},
+		},
+
+		Published: tmpl.Published,
+	}, nil
+}
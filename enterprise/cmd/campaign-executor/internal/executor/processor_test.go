@@ -0,0 +1,165 @@
+package executor
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+type testGitserverClient struct {
+	files map[string]string
+}
+
+func (c *testGitserverClient) DefaultBranch(ctx context.Context, repo gitserver.Repo) (string, api.CommitID, error) {
+	return "refs/heads/master", "deadbeef", nil
+}
+
+func (c *testGitserverClient) Archive(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for name, contents := range c.files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents))}); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(&buf), nil
+}
+
+func testProcessor(t *testing.T) *processor {
+	root, err := ioutil.TempDir("", "campaign-executor-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+
+	return &processor{
+		gitserverClient: &testGitserverClient{files: map[string]string{"README.md": "# Hello\n"}},
+		runner:          LocalRunner{},
+		workspaceRoot:   root,
+	}
+}
+
+func testCampaignSpec(steps ...campaigns.CampaignSpecStep) *campaigns.CampaignSpec {
+	return &campaigns.CampaignSpec{
+		Spec: campaigns.CampaignSpecFields{
+			Steps: steps,
+			ChangesetTemplate: campaigns.ChangesetTemplate{
+				Title:     "Hello World",
+				Body:      "My first campaign!",
+				Branch:    "hello-world",
+				Commit:    campaigns.CommitTemplate{Message: "Append Hello World"},
+				Published: campaigns.PublishedValue{Val: true},
+			},
+		},
+	}
+}
+
+func TestProcessorExecute(t *testing.T) {
+	p := testProcessor(t)
+	repo := &types.Repo{ID: 42, Name: "github.com/sourcegraph/sourcegraph"}
+	spec := testCampaignSpec(
+		campaigns.CampaignSpecStep{Run: "echo $GREETING >> README.md", Env: map[string]string{"GREETING": "Hello World"}},
+		campaigns.CampaignSpecStep{Run: "echo done"},
+	)
+
+	var log bytes.Buffer
+	desc, err := p.execute(context.Background(), &log, spec, repo)
+	if err != nil {
+		t.Fatalf("unexpected error executing steps: %s\nlog:\n%s", err, log.String())
+	}
+
+	repoID := graphqlbackend.MarshalRepositoryID(repo.ID)
+	expectedDiff := `diff --git README.md README.md
+index fec5601..9f343d5 100644
+--- README.md
++++ README.md
+@@ -1 +1,2 @@
+ # Hello
++Hello World
+`
+	expected := &campaigns.ChangesetSpecDescription{
+		BaseRepository: repoID,
+		BaseRef:        "refs/heads/master",
+		BaseRev:        "deadbeef",
+		HeadRepository: repoID,
+		HeadRef:        "refs/heads/hello-world",
+		Title:          "Hello World",
+		Body:           "My first campaign!",
+		Commits: []campaigns.GitCommitDescription{
+			{Message: "Append Hello World", Diff: expectedDiff},
+		},
+		Published: campaigns.PublishedValue{Val: true},
+	}
+	if diff := cmp.Diff(expected, desc); diff != "" {
+		t.Errorf("unexpected changeset spec description (-want +got):\n%s", diff)
+	}
+
+	expectedLog := "--- step 1: echo $GREETING >> README.md\n--- step 2: echo done\ndone\n"
+	if diff := cmp.Diff(expectedLog, log.String()); diff != "" {
+		t.Errorf("unexpected log (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcessorExecuteNoChanges(t *testing.T) {
+	p := testProcessor(t)
+	repo := &types.Repo{ID: 42, Name: "github.com/sourcegraph/sourcegraph"}
+	spec := testCampaignSpec(campaigns.CampaignSpecStep{Run: "cat README.md"})
+
+	var log bytes.Buffer
+	desc, err := p.execute(context.Background(), &log, spec, repo)
+	if err != nil {
+		t.Fatalf("unexpected error executing steps: %s", err)
+	}
+	if desc != nil {
+		t.Errorf("unexpected changeset spec description: %+v", desc)
+	}
+	if !strings.Contains(log.String(), "# Hello") {
+		t.Errorf("expected output of step in log, got %q", log.String())
+	}
+}
+
+func TestProcessorExecuteFailingStep(t *testing.T) {
+	p := testProcessor(t)
+	repo := &types.Repo{ID: 42, Name: "github.com/sourcegraph/sourcegraph"}
+	spec := testCampaignSpec(
+		campaigns.CampaignSpecStep{Run: "echo oops >&2; exit 3"},
+		campaigns.CampaignSpecStep{Run: "echo unreachable"},
+	)
+
+	var log bytes.Buffer
+	if _, err := p.execute(context.Background(), &log, spec, repo); err == nil || !strings.Contains(err.Error(), "step 1 failed") {
+		t.Fatalf("expected step 1 to fail, got %v", err)
+	}
+
+	expectedLog := "--- step 1: echo oops >&2; exit 3\noops\n"
+	if diff := cmp.Diff(expectedLog, log.String()); diff != "" {
+		t.Errorf("unexpected log (-want +got):\n%s", diff)
+	}
+
+	// The workspace is removed even if a step failed.
+	entries, err := ioutil.ReadDir(p.workspaceRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected workspace to be removed, found %d entries", len(entries))
+	}
+}
@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// Runner runs a single step of a campaign spec in a workspace directory and
+// writes its output to log.
+type Runner interface {
+	Run(ctx context.Context, dir string, step campaigns.CampaignSpecStep, log io.Writer) error
+}
+
+// DockerRunner runs each step in a new container of the step's image, with
+// the workspace mounted at /work. The containers run without any capabilities
+// and cannot gain new privileges.
+type DockerRunner struct {
+	// Options are additional flags passed to `docker run`, such as resource
+	// limits or the network to attach the container to.
+	Options []string
+}
+
+var _ Runner = &DockerRunner{}
+
+func (r *DockerRunner) Run(ctx context.Context, dir string, step campaigns.CampaignSpecStep, log io.Writer) error {
+	args := []string{
+		"run", "--rm", "--init",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--mount", fmt.Sprintf("type=bind,source=%s,target=/work", dir),
+		"--workdir", "/work",
+		"--entrypoint", "/bin/sh",
+	}
+	args = append(args, r.Options...)
+	for _, env := range stepEnv(step) {
+		args = append(args, "--env", env)
+	}
+	args = append(args, step.Container, "-c", step.Run)
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = log
+	cmd.Stderr = log
+
+	return errors.Wrap(cmd.Run(), "docker run")
+}
+
+// LocalRunner runs each step as a local shell process in the workspace
+// directory and ignores the container of the step. It doesn't sandbox the
+// steps in any way, and must only be used in tests and development.
+type LocalRunner struct{}
+
+var _ Runner = LocalRunner{}
+
+func (LocalRunner) Run(ctx context.Context, dir string, step campaigns.CampaignSpecStep, log io.Writer) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", step.Run)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), stepEnv(step)...)
+	cmd.Stdout = log
+	cmd.Stderr = log
+
+	return cmd.Run()
+}
+
+// stepEnv returns the environment variables of the step in KEY=value form,
+// sorted by key.
+func stepEnv(step campaigns.CampaignSpecStep) []string {
+	keys := make([]string, 0, len(step.Env))
+	for k := range step.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, k+"="+step.Env[k])
+	}
+	return env
+}
@@ -0,0 +1,23 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestStepEnv(t *testing.T) {
+	step := campaigns.CampaignSpecStep{
+		Env: map[string]string{
+			"PATH":     "/work/bin:$PATH",
+			"GREETING": "Hello World",
+			"EMPTY":    "",
+		},
+	}
+
+	expected := []string{"EMPTY=", "GREETING=Hello World", "PATH=/work/bin:$PATH"}
+	if diff := cmp.Diff(expected, stepEnv(step)); diff != "" {
+		t.Errorf("unexpected env (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,90 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/tar"
+)
+
+// prepareWorkspace extracts an archive of the repository at the given commit
+// into a new directory below root and commits its contents to a new Git
+// repository, so that the changes made by the steps can be diffed against it.
+func prepareWorkspace(ctx context.Context, gitserverClient GitserverClient, root string, repo gitserver.Repo, commit api.CommitID) (_ string, err error) {
+	dir, err := ioutil.TempDir(root, "campaign-spec-job-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	archive, err := gitserverClient.Archive(ctx, repo, commit)
+	if err != nil {
+		return "", errors.Wrap(err, "gitserver.Archive")
+	}
+	defer archive.Close()
+
+	if err := tar.Extract(dir, archive); err != nil {
+		return "", errors.Wrap(err, "tar.Extract")
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"commit", "--quiet", "--allow-empty", "--no-verify", "--message", string(commit)},
+	} {
+		if _, err := runGit(ctx, dir, args...); err != nil {
+			return "", err
+		}
+	}
+
+	return dir, nil
+}
+
+// workspaceDiff returns the changes made to the workspace since it was
+// prepared, in the unified diff format expected by changeset specs.
+func workspaceDiff(ctx context.Context, dir string) (string, error) {
+	if _, err := runGit(ctx, dir, "add", "--all"); err != nil {
+		return "", err
+	}
+
+	diff, err := runGit(ctx, dir, "diff", "--cached", "--no-prefix", "--binary")
+	if err != nil {
+		return "", err
+	}
+
+	return string(diff), nil
+}
+
+// runGit runs git with the given arguments in dir and returns its output. The
+// committer identity is fixed, so that the host's Git configuration doesn't
+// matter.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Sourcegraph",
+		"GIT_AUTHOR_EMAIL=campaigns@sourcegraph.com",
+		"GIT_COMMITTER_NAME=Sourcegraph",
+		"GIT_COMMITTER_EMAIL=campaigns@sourcegraph.com",
+	)
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, errors.Wrap(err, fmt.Sprintf("git %s failed: %s\n", args[0], exitErr.Stderr))
+		}
+		return nil, errors.Wrapf(err, "git %s", args[0])
+	}
+
+	return out, nil
+}
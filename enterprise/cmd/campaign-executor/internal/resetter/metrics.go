@@ -0,0 +1,32 @@
+package resetter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func NewResetterMetrics(r prometheus.Registerer) workerutil.ResetterMetrics {
+	jobResets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_job_queue_reset_total",
+		Help: "Total number of campaign spec jobs put back into queued state",
+	})
+	r.MustRegister(jobResets)
+
+	jobResetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_job_queue_max_resets_total",
+		Help: "Total number of campaign spec jobs that exceed the max number of resets",
+	})
+	r.MustRegister(jobResetFailures)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_job_queue_reset_errors_total",
+		Help: "Total number of errors when running the campaign spec job resetter",
+	})
+	r.MustRegister(errors)
+
+	return workerutil.ResetterMetrics{
+		RecordResets:        jobResets,
+		RecordResetFailures: jobResetFailures,
+		Errors:              errors,
+	}
+}
@@ -0,0 +1,20 @@
+package resetter
+
+import (
+	"time"
+
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func NewJobResetter(
+	s *ee.Store,
+	resetInterval time.Duration,
+	metrics workerutil.ResetterMetrics,
+) *workerutil.Resetter {
+	return workerutil.NewResetter(ee.WorkerutilCampaignSpecJobStore(s), workerutil.ResetterOptions{
+		Name:     "campaign spec job resetter",
+		Interval: resetInterval,
+		Metrics:  metrics,
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/executor"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/resetter"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/tracer"
+)
+
+func main() {
+	env.Lock()
+	env.HandleHelpFlag()
+	tracer.Init()
+
+	var (
+		resetInterval = mustParseInterval(rawResetInterval, "CAMPAIGN_EXECUTOR_RESET_INTERVAL")
+		pollInterval  = mustParseInterval(rawPollInterval, "CAMPAIGN_EXECUTOR_POLL_INTERVAL")
+		numHandlers   = mustParseInt(rawNumHandlers, "CAMPAIGN_EXECUTOR_CONCURRENCY")
+		jobTimeout    = mustParseInterval(rawJobTimeout, "CAMPAIGN_EXECUTOR_JOB_TIMEOUT")
+		maxAttempts   = mustParseInt(rawMaxAttempts, "CAMPAIGN_EXECUTOR_MAX_ATTEMPTS")
+		retryBackoff  = mustParseInterval(rawRetryBackoff, "CAMPAIGN_EXECUTOR_RETRY_BACKOFF")
+		runner        = mustGetRunner(rawRunner, "CAMPAIGN_EXECUTOR_RUNNER")
+	)
+
+	if err := api.InternalClient.WaitForFrontend(context.Background()); err != nil {
+		log.Fatalf("sourcegraph-frontend not reachable: %v", err)
+	}
+
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	store := mustInitializeStore()
+	MustRegisterQueueMonitor(observationContext.Registerer, store)
+	resetterMetrics := resetter.NewResetterMetrics(prometheus.DefaultRegisterer)
+	executorMetrics := executor.NewExecutorMetrics(observationContext)
+	jobResetter := resetter.NewJobResetter(store, resetInterval, resetterMetrics)
+
+	executor := executor.NewExecutor(
+		store,
+		db.Repos,
+		executor.DefaultGitserverClient,
+		runner,
+		executor.ExecutorOptions{
+			NumHandlers:   numHandlers,
+			PollInterval:  pollInterval,
+			JobTimeout:    jobTimeout,
+			MaxAttempts:   maxAttempts,
+			RetryBackoff:  retryBackoff,
+			WorkspaceRoot: rawWorkspaceRoot,
+		},
+		executorMetrics,
+	)
+
+	go jobResetter.Start()
+	go executor.Start()
+	go debugserver.Start()
+
+	// Attempt to clean up after first shutdown signal
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGHUP)
+	<-signals
+
+	go func() {
+		// Insta-shutdown on a second signal
+		<-signals
+		os.Exit(0)
+	}()
+
+	jobResetter.Stop()
+	executor.Stop()
+}
+
+func mustInitializeStore() *ee.Store {
+	postgresDSN := conf.Get().ServiceConnections.PostgresDSN
+	conf.Watch(func() {
+		if newDSN := conf.Get().ServiceConnections.PostgresDSN; postgresDSN != newDSN {
+			log.Fatalf("detected repository DSN change, restarting to take effect: %s", newDSN)
+		}
+	})
+
+	db, err := dbutil.NewDB(postgresDSN, "campaign-executor")
+	if err != nil {
+		log.Fatalf("failed to initialize store: %s", err)
+	}
+
+	// The repositories of jobs are looked up with db.Repos, which uses the
+	// global connection.
+	dbconn.Global = db
+
+	return ee.NewStore(db)
+}
+
+// mustGetRunner returns the executor.Runner with the given name or fatally
+// logs on failure.
+func mustGetRunner(rawValue, name string) executor.Runner {
+	switch rawValue {
+	case "docker":
+		return &executor.DockerRunner{Options: strings.Fields(rawDockerOptions)}
+	case "local":
+		log15.Warn("Campaign spec steps are run as unsandboxed local processes. Use CAMPAIGN_EXECUTOR_RUNNER=docker in production.")
+		return executor.LocalRunner{}
+	default:
+		log.Fatalf("invalid runner %q for %s: must be docker or local", rawValue, name)
+		return nil
+	}
+}
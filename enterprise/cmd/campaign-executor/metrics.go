@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// MustRegisterQueueMonitor emits a metric for the current queue size.
+func MustRegisterQueueMonitor(r prometheus.Registerer, store *ee.Store) {
+	queueSize := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "src_campaign_spec_job_queue_jobs_total",
+		Help: "Total number of campaign spec jobs in the queued state.",
+	}, func() float64 {
+		count, err := store.CountCampaignSpecJobs(context.Background(), ee.CountCampaignSpecJobsOpts{
+			State: campaigns.CampaignSpecJobStateQueued,
+		})
+		if err != nil {
+			log15.Error("Failed to determine queue size", "err", err)
+		}
+
+		return float64(count)
+	})
+	r.MustRegister(queueSize)
+}
@@ -0,0 +1,65 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateConfig(t *testing.T) {
+	userSearch := schema.LDAPUserSearch{BaseDN: "ou=people,dc=example,dc=com"}
+
+	tests := map[string]struct {
+		input        conf.Unified
+		wantProblems conf.Problems
+	}{
+		"valid": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", StartTLS: true, UserSearch: userSearch}},
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ad.example.com", BindDN: "cn=sourcegraph", BindPassword: "secret", UserSearch: userSearch}},
+				},
+			}},
+			wantProblems: nil,
+		},
+		"invalid url": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "https://ldap.example.com", UserSearch: userSearch}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0 has an invalid url \"https://ldap.example.com\", it must start with ldap:// or ldaps://"),
+		},
+		"ldaps with startTLS": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", StartTLS: true, UserSearch: userSearch}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0 uses an ldaps:// url, which is already encrypted, so startTLS must not be set"),
+		},
+		"bindDN without bindPassword": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", BindDN: "cn=sourcegraph", UserSearch: userSearch}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 0 has a bindDN but no bindPassword"),
+		},
+		"duplicate": {
+			input: conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserSearch: userSearch}},
+					{Ldap: &schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserSearch: userSearch}},
+				},
+			}},
+			wantProblems: conf.NewSiteProblems("LDAP auth provider at index 1 is duplicate of index 0, ignoring"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conf.TestValidator(t, test.input, validateConfig, test.wantProblems)
+		})
+	}
+}
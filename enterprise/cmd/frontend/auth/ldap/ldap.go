@@ -0,0 +1,228 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// conn is the subset of the methods of an LDAP connection needed to
+// authenticate users.
+type conn interface {
+	Bind(username, password string) error
+	Search(req *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+// dial connects to the LDAP server of the given configuration, upgrading the
+// connection to TLS with StartTLS if configured. It is a variable so that tests
+// can replace it.
+var dial = func(c *schema.LDAPAuthProvider) (conn, error) {
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse url")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.Certificate)) {
+			return nil, errors.New("invalid certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	l, err := ldap.DialURL(c.Url, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "dial")
+	}
+	l.SetTimeout(30 * time.Second)
+
+	if c.StartTLS && u.Scheme == "ldap" {
+		if err := l.StartTLS(tlsConfig); err != nil {
+			l.Close()
+			return nil, errors.Wrap(err, "StartTLS")
+		}
+	}
+	return l, nil
+}
+
+// errInvalidCredentials is returned when there is no user with the given
+// username, or the password is wrong. Both cases are reported the same way to
+// not reveal which usernames exist.
+var errInvalidCredentials = errors.New("invalid username or password")
+
+// entry is the LDAP entry of an authenticated user.
+type entry struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+}
+
+// attributes returns the attribute mapping of the configuration with defaults
+// applied.
+func attributes(c *schema.LDAPAuthProvider) schema.LDAPAttributes {
+	a := schema.LDAPAttributes{Username: "uid", Email: "mail", DisplayName: "cn"}
+	if c.Attributes != nil {
+		if c.Attributes.Username != "" {
+			a.Username = c.Attributes.Username
+		}
+		if c.Attributes.Email != "" {
+			a.Email = c.Attributes.Email
+		}
+		if c.Attributes.DisplayName != "" {
+			a.DisplayName = c.Attributes.DisplayName
+		}
+	}
+	return a
+}
+
+// authenticate verifies the given username and password against the LDAP
+// server and returns the entry of the user.
+//
+// The user is looked up with the bind DN of the configuration (or
+// anonymously), and then authenticated by binding as the found entry with the
+// given password. Groups are looked up with the bind DN of the configuration
+// again (or as the user, if there is no bind DN).
+//
+// 🚨 SECURITY: The password must be checked by binding as the user. An empty
+// password would result in an unauthenticated bind, which most servers accept
+// for any DN, so it is rejected.
+func authenticate(c *schema.LDAPAuthProvider, username, password string) (*entry, error) {
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	l, err := dial(c)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	if err := bindService(l, c); err != nil {
+		return nil, err
+	}
+
+	attrs := attributes(c)
+	filter := fmt.Sprintf("(%s=%s)", attrs.Username, ldap.EscapeFilter(username))
+	if c.UserSearch.Filter != "" {
+		filter = fmt.Sprintf("(&%s%s)", filter, c.UserSearch.Filter)
+	}
+	res, err := l.Search(ldap.NewSearchRequest(
+		c.UserSearch.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, // More than one entry is an error, don't fetch more
+		0, false,
+		filter,
+		[]string{attrs.Username, attrs.Email, attrs.DisplayName},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.Wrap(err, "search user")
+	}
+	if res == nil || len(res.Entries) == 0 {
+		return nil, errInvalidCredentials
+	}
+	if len(res.Entries) > 1 {
+		return nil, errors.Errorf("search user: found multiple entries for username %q", username)
+	}
+	e := res.Entries[0]
+
+	// 🚨 SECURITY: Check the password of the user.
+	if err := l.Bind(e.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "bind user")
+	}
+
+	u := &entry{
+		DN:          e.DN,
+		Username:    e.GetAttributeValue(attrs.Username),
+		Email:       e.GetAttributeValue(attrs.Email),
+		DisplayName: e.GetAttributeValue(attrs.DisplayName),
+	}
+
+	if c.GroupSearch != nil {
+		if err := bindService(l, c); err != nil {
+			return nil, err
+		}
+		if u.Groups, err = searchGroups(l, c.GroupSearch, e.DN); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// bindService binds as the bind DN of the configuration, if any. Without a
+// bind DN, the connection stays bound as before (anonymously on a new
+// connection).
+func bindService(l conn, c *schema.LDAPAuthProvider) error {
+	if c.BindDN == "" {
+		return nil
+	}
+	return errors.Wrap(l.Bind(c.BindDN, c.BindPassword), "bind service account")
+}
+
+// searchGroups returns the names of the groups the given DN is a member of.
+func searchGroups(l conn, c *schema.LDAPGroupSearch, dn string) ([]string, error) {
+	memberAttr, nameAttr, filter := c.MemberAttribute, c.NameAttribute, c.Filter
+	if memberAttr == "" {
+		memberAttr = "member"
+	}
+	if nameAttr == "" {
+		nameAttr = "cn"
+	}
+	if filter == "" {
+		filter = "(objectClass=groupOfNames)"
+	}
+
+	res, err := l.Search(ldap.NewSearchRequest(
+		c.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false,
+		fmt.Sprintf("(&(%s=%s)%s)", memberAttr, ldap.EscapeFilter(dn), filter),
+		[]string{nameAttr},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "search groups")
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		if name := e.GetAttributeValue(nameAttr); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// orgMemberships returns, for each organization mapped to a group in the given
+// mapping, whether a member of the given groups should be a member of the
+// organization. Group names are compared case-insensitively, like LDAP does.
+func orgMemberships(mapping map[string]string, groups []string) map[string]bool {
+	member := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		member[strings.ToLower(g)] = true
+	}
+
+	orgs := make(map[string]bool, len(mapping))
+	for group, org := range mapping {
+		orgs[org] = orgs[org] || member[strings.ToLower(group)]
+	}
+	return orgs
+}
@@ -0,0 +1,213 @@
+package ldap
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeConn is an in-memory LDAP server with a fixed set of entries and passwords.
+type fakeConn struct {
+	entries   []*ldap.Entry
+	passwords map[string]string // DN -> password
+
+	bound    string // the DN of the last successful bind
+	searches []*ldap.SearchRequest
+}
+
+func (c *fakeConn) Bind(dn, password string) error {
+	if pw, ok := c.passwords[dn]; !ok || pw != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	c.bound = dn
+	return nil
+}
+
+// Search supports only the filters used by authenticate, i.e. conjunctions of equality
+// assertions.
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.searches = append(c.searches, req)
+
+	var res ldap.SearchResult
+	for _, e := range c.entries {
+		if strings.HasSuffix(e.DN, req.BaseDN) && matches(e, req.Filter) {
+			res.Entries = append(res.Entries, e)
+		}
+	}
+	return &res, nil
+}
+
+func (c *fakeConn) Close() {}
+
+func matches(e *ldap.Entry, filter string) bool {
+	for _, part := range strings.Split(strings.Trim(filter, "(&)"), ")(") {
+		kv := strings.SplitN(part, "=", 2)
+		found := false
+		for _, v := range e.GetAttributeValues(kv[0]) {
+			found = found || strings.EqualFold(v, kv[1])
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		entries: []*ldap.Entry{
+			ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"alice"},
+				"mail":        {"alice@example.com"},
+				"cn":          {"Alice Smith"},
+			}),
+			ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"bob"},
+			}),
+			ldap.NewEntry("cn=engineering,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"engineering"},
+				"member":      {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+			}),
+			ldap.NewEntry("cn=admins,ou=groups,dc=example,dc=com", map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"admins"},
+				"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+			}),
+		},
+		passwords: map[string]string{
+			"cn=sourcegraph,dc=example,dc=com":      "service-secret",
+			"uid=alice,ou=people,dc=example,dc=com": "alice-secret",
+			"uid=bob,ou=people,dc=example,dc=com":   "bob-secret",
+		},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	config := &schema.LDAPAuthProvider{
+		Type:         providerType,
+		Url:          "ldap://ldap.example.com",
+		BindDN:       "cn=sourcegraph,dc=example,dc=com",
+		BindPassword: "service-secret",
+		UserSearch: schema.LDAPUserSearch{
+			BaseDN: "ou=people,dc=example,dc=com",
+			Filter: "(objectClass=person)",
+		},
+		GroupSearch: &schema.LDAPGroupSearch{
+			BaseDN: "ou=groups,dc=example,dc=com",
+		},
+	}
+
+	var c *fakeConn
+	orig := dial
+	dial = func(*schema.LDAPAuthProvider) (conn, error) { return c, nil }
+	defer func() { dial = orig }()
+
+	tests := map[string]struct {
+		username, password string
+		want               *entry
+		wantErr            error
+	}{
+		"success": {
+			username: "alice",
+			password: "alice-secret",
+			want: &entry{
+				DN:          "uid=alice,ou=people,dc=example,dc=com",
+				Username:    "alice",
+				Email:       "alice@example.com",
+				DisplayName: "Alice Smith",
+				Groups:      []string{"engineering", "admins"},
+			},
+		},
+		"no email": {
+			username: "bob",
+			password: "bob-secret",
+			want: &entry{
+				DN:       "uid=bob,ou=people,dc=example,dc=com",
+				Username: "bob",
+				Groups:   []string{"engineering"},
+			},
+		},
+		"wrong password": {
+			username: "alice",
+			password: "bob-secret",
+			wantErr:  errInvalidCredentials,
+		},
+		"unknown user": {
+			username: "carol",
+			password: "carol-secret",
+			wantErr:  errInvalidCredentials,
+		},
+		"empty password": {
+			username: "alice",
+			password: "",
+			wantErr:  errInvalidCredentials,
+		},
+		"filter injection": {
+			username: "*",
+			password: "alice-secret",
+			wantErr:  errInvalidCredentials,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c = newFakeConn()
+			got, err := authenticate(config, test.username, test.password)
+			if err != test.wantErr {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got entry %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	t.Run("escapes username", func(t *testing.T) {
+		c = newFakeConn()
+		if _, err := authenticate(config, "a*)(uid=*", "x"); err != errInvalidCredentials {
+			t.Fatalf("got error %v, want %v", err, errInvalidCredentials)
+		}
+		if want := `(&(uid=a\2a\29\28uid=\2a)(objectClass=person))`; c.searches[0].Filter != want {
+			t.Errorf("got filter %q, want %q", c.searches[0].Filter, want)
+		}
+	})
+}
+
+func TestOrgMemberships(t *testing.T) {
+	mapping := map[string]string{
+		"Engineering": "eng",
+		"admins":      "eng",
+		"sales":       "sales",
+	}
+
+	tests := map[string]struct {
+		groups []string
+		want   map[string]bool
+	}{
+		"no groups": {
+			want: map[string]bool{"eng": false, "sales": false},
+		},
+		"case-insensitive": {
+			groups: []string{"engineering"},
+			want:   map[string]bool{"eng": true, "sales": false},
+		},
+		"multiple groups mapped to one org": {
+			groups: []string{"admins", "sales", "unmapped"},
+			want:   map[string]bool{"eng": true, "sales": true},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := orgMemberships(mapping, test.groups); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package ldap implements auth via LDAP (including Active Directory).
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the sign-in endpoint under the auth path
+// prefix ("/.auth"). Unlike other SSO providers, users sign in with a username and password on the
+// Sourcegraph sign-in page, which are posted to the sign-in endpoint and verified against the LDAP
+// server. Upon success, the handler creates a new session and session cookie.
+//
+// 🚨 SECURITY
+var Middleware = &auth.Middleware{
+	API: func(next http.Handler) http.Handler {
+		return next
+	},
+	App: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, authPrefix+"/") {
+				authHandler(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	},
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// authHandler handles the LDAP sign-in endpoint.
+//
+// 🚨 SECURITY
+func authHandler(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimPrefix(r.URL.Path, authPrefix) {
+	case "/login":
+		if r.Method != "POST" {
+			http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		p := getProvider(r.URL.Query().Get("pc"))
+		if p == nil {
+			log15.Error("No LDAP auth provider found with ID.", "id", r.URL.Query().Get("pc"))
+			http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+			return
+		}
+
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		e, err := authenticate(&p.config, creds.Username, creds.Password)
+		if err == errInvalidCredentials {
+			log15.Warn("LDAP auth failed: invalid credentials.", "username", creds.Username)
+			http.Error(w, "Authentication failed", http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			log15.Error("LDAP auth failed: error authenticating with the LDAP server.", "username", creds.Username, "error", err)
+			http.Error(w, "Authentication failed. The LDAP server could not be reached or returned an error. Check the logs for more details.", http.StatusInternalServerError)
+			return
+		}
+
+		ctx := r.Context()
+		actr, safeErrMsg, err := getOrCreateUser(ctx, p, e)
+		if err != nil {
+			log15.Error("LDAP auth failed: error looking up LDAP-authenticated user.", "error", err, "userErr", safeErrMsg)
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		// Failing to sync organizations must not prevent users from signing in, they are synced
+		// again on the next sign-in.
+		if err := syncOrgs(ctx, p, actr.UID, e); err != nil {
+			log15.Error("LDAP auth: failed to sync organizations of user.", "userID", actr.UID, "error", err)
+		}
+
+		if err := session.SetActor(w, r, actr, 0); err != nil {
+			log15.Error("LDAP auth failed: could not initiate session.", "error", err)
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
+			return
+		}
+
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,84 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// getOrCreateUser gets or creates a user account based on the LDAP entry of the user. It returns
+// the authenticated actor if successful; otherwise it returns a friendly error message (safeErrMsg)
+// that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, p *provider, e *entry) (_ *actor.Actor, safeErrMsg string, err error) {
+	login, err := auth.NormalizeUsername(e.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", e.Username), err
+	}
+
+	var data extsvc.AccountData
+	data.SetAccountData(e)
+
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, auth.GetAndSaveUserOp{
+		UserProps: db.NewUser{
+			Username: login,
+			Email:    e.Email,
+			// 🚨 SECURITY: Emails in the directory are managed by its administrators, so they
+			// are trusted like emails of other SSO providers.
+			EmailIsVerified: e.Email != "",
+			DisplayName:     e.DisplayName,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   p.config.Url,
+			AccountID:   e.DN,
+		},
+		ExternalAccountData: data,
+		CreateIfNotExist:    p.allowSignup(),
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// syncOrgs adds the user to or removes the user from the organizations mapped to LDAP groups,
+// according to the groups of the user's LDAP entry. Organizations that don't exist are skipped.
+func syncOrgs(ctx context.Context, p *provider, userID int32, e *entry) error {
+	if p.config.GroupSearch == nil {
+		return nil
+	}
+
+	for name, member := range orgMemberships(p.config.GroupSearch.Orgs, e.Groups) {
+		org, err := db.Orgs.GetByName(ctx, name)
+		if errcode.IsNotFound(err) {
+			log15.Warn("LDAP auth provider: organization mapped to a group does not exist.", "org", name)
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "get organization %q", name)
+		}
+
+		_, err = db.OrgMembers.GetByOrgIDAndUserID(ctx, org.ID, userID)
+		isMember := err == nil
+		if err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrapf(err, "get membership of organization %q", name)
+		}
+
+		switch {
+		case member && !isMember:
+			_, err = db.OrgMembers.Create(ctx, org.ID, userID)
+		case !member && isMember:
+			err = db.OrgMembers.Remove(ctx, org.ID, userID)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "update membership of organization %q", name)
+		}
+	}
+	return nil
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/licensing"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/authz/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
@@ -143,6 +145,8 @@ type ExternalServicesStore interface {
 	ListGitLabConnections(context.Context) ([]*types.GitLabConnection, error)
 	ListGitHubConnections(context.Context) ([]*types.GitHubConnection, error)
 	ListBitbucketServerConnections(context.Context) ([]*types.BitbucketServerConnection, error)
+	ListBitbucketCloudConnections(context.Context) ([]*types.BitbucketCloudConnection, error)
+	ListAWSCodeCommitConnections(context.Context) ([]*types.AWSCodeCommitConnection, error)
 }
 
 // ProvidersFromConfig returns the set of permission-related providers derived from the site config.
@@ -194,6 +198,24 @@ func ProvidersFromConfig(
 		warnings = append(warnings, bbsWarnings...)
 	}
 
+	if bbcConns, err := s.ListBitbucketCloudConnections(ctx); err != nil {
+		seriousProblems = append(seriousProblems, fmt.Sprintf("Could not load Bitbucket Cloud external service configs: %s", err))
+	} else {
+		bbcProviders, bbcProblems, bbcWarnings := bitbucketcloud.NewAuthzProviders(bbcConns)
+		providers = append(providers, bbcProviders...)
+		seriousProblems = append(seriousProblems, bbcProblems...)
+		warnings = append(warnings, bbcWarnings...)
+	}
+
+	if ccConns, err := s.ListAWSCodeCommitConnections(ctx); err != nil {
+		seriousProblems = append(seriousProblems, fmt.Sprintf("Could not load AWS CodeCommit external service configs: %s", err))
+	} else {
+		ccProviders, ccProblems, ccWarnings := awscodecommit.NewAuthzProviders(ccConns)
+		providers = append(providers, ccProviders...)
+		seriousProblems = append(seriousProblems, ccProblems...)
+		warnings = append(warnings, ccWarnings...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfiguration.PermissionsUserMapping != nil &&
 		cfg.SiteConfiguration.PermissionsUserMapping.Enabled && len(providers) > 0 {
@@ -520,3 +520,11 @@ func (s fakeStore) ListBitbucketServerConnections(context.Context) ([]*types.Bit
 	}
 	return conns, nil
 }
+
+func (s fakeStore) ListBitbucketCloudConnections(context.Context) ([]*types.BitbucketCloudConnection, error) {
+	return nil, nil
+}
+
+func (s fakeStore) ListAWSCodeCommitConnections(context.Context) ([]*types.AWSCodeCommitConnection, error) {
+	return nil, nil
+}
@@ -127,6 +127,46 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) SetSubRepositoryPermissionsForUser(ctx context.Context, args *graphqlbackend.SubRepoPermsArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can mutate sub-repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := graphqlbackend.UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the repo ID and user ID are valid.
+	if _, err = db.Repos.Get(ctx, repoID); err != nil {
+		return nil, err
+	}
+	if _, err = db.Users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err = authz.ValidatePathGlobs(args.PathIncludes); err != nil {
+		return nil, err
+	} else if err = authz.ValidatePathGlobs(args.PathExcludes); err != nil {
+		return nil, err
+	}
+
+	err = r.store.SetSubRepoPermissions(ctx, &authz.SubRepoPermissions{
+		UserID:       userID,
+		RepoID:       int32(repoID),
+		PathIncludes: args.PathIncludes,
+		PathExcludes: args.PathExcludes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "set sub-repository permissions")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func (r *Resolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *graphqlbackend.RepositoryIDArgs) (*graphqlbackend.EmptyResponse, error) {
 	// 🚨 SECURITY: Only site admins can query repository permissions.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
@@ -209,6 +209,78 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 	}
 }
 
+func TestResolver_SetSubRepositoryPermissionsForUser(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.Users = db.MockUsers{}
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{}).SetSubRepositoryPermissionsForUser(ctx, &graphqlbackend.SubRepoPermsArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+	var got *authz.SubRepoPermissions
+	edb.Mocks.Perms.SetSubRepoPermissions = func(_ context.Context, p *authz.SubRepoPermissions) error {
+		got = p
+		return nil
+	}
+	t.Cleanup(func() {
+		db.Mocks.Users = db.MockUsers{}
+		db.Mocks.Repos = db.MockRepos{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	})
+
+	r := &Resolver{store: edb.NewPermsStore(nil, clock)}
+	t.Run("invalid glob", func(t *testing.T) {
+		_, err := r.SetSubRepositoryPermissionsForUser(context.Background(), &graphqlbackend.SubRepoPermsArgs{
+			Repository:   graphqlbackend.MarshalRepositoryID(1),
+			User:         graphqlbackend.MarshalUserID(2),
+			PathExcludes: []string{"secret/[**"},
+		})
+		if err == nil {
+			t.Fatal("want error for invalid glob")
+		}
+	})
+
+	_, err := r.SetSubRepositoryPermissionsForUser(context.Background(), &graphqlbackend.SubRepoPermsArgs{
+		Repository:   graphqlbackend.MarshalRepositoryID(1),
+		User:         graphqlbackend.MarshalUserID(2),
+		PathIncludes: []string{"src/**"},
+		PathExcludes: []string{"src/secret/**"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &authz.SubRepoPermissions{
+		UserID:       2,
+		RepoID:       1,
+		PathIncludes: []string{"src/**"},
+		PathExcludes: []string{"src/secret/**"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestResolver_ScheduleRepositoryPermissionsSync(t *testing.T) {
 	t.Run("authenticated as non-admin", func(t *testing.T) {
 		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
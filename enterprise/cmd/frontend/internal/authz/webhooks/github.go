@@ -0,0 +1,172 @@
+package webhooks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	gh "github.com/google/go-github/v28/github"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// githubEventTypes is the set of GitHub webhook event types (the value of
+// "X-GitHub-Event" header) that may change permissions.
+var githubEventTypes = map[string]bool{
+	"member":       true, // Collaborators added to or removed from a repository
+	"membership":   true, // Users added to or removed from a team
+	"organization": true, // Users added to or removed from an organization
+	"repository":   true, // Repositories created, deleted, transferred or changed visibility
+}
+
+// GitHubWebhook receives GitHub organization webhook events that are relevant
+// to permissions and schedules permissions syncing for the affected users and
+// repositories. All other events are handed over to the next handler.
+type GitHubWebhook struct {
+	*Webhook
+}
+
+// NewGitHubWebhook returns a new GitHub permissions webhook handler that hands
+// events not relevant to permissions over to next.
+func NewGitHubWebhook(repos repos.Store, perms *edb.PermsStore, next http.Handler) *GitHubWebhook {
+	return &GitHubWebhook{newWebhook(repos, perms, next)}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitHubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !githubEventTypes[gh.WebHookType(r)] {
+		h.passThrough(w, r)
+		return
+	}
+
+	e, conn, httpErr := h.parseEvent(r)
+	if httpErr != nil {
+		respond(w, httpErr.code, httpErr)
+		return
+	}
+
+	serviceID, err := normalizeServiceID(conn.Url)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	a := h.convertEvent(e)
+	if a.empty() {
+		respond(w, http.StatusOK, nil) // Nothing to do
+		return
+	}
+
+	if err = h.schedule(r.Context(), extsvc.TypeGitHub, serviceID, a); err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "schedule permissions sync"))
+		return
+	}
+	respond(w, http.StatusOK, nil)
+}
+
+func (h *GitHubWebhook) parseEvent(r *http.Request) (interface{}, *schema.GitHubConnection, *httpError) {
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	var externalServiceID int64
+	if rawID := r.FormValue(extsvc.IDParam); rawID != "" {
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with any of the stored secrets
+	// in GitHub external services config. If there are no secrets or no secret
+	// managed to authenticate the request, we return a 401 to the client.
+	args := repos.StoreListExternalServicesArgs{Kinds: []string{extsvc.KindGitHub}}
+	if externalServiceID != 0 {
+		args.IDs = []int64{externalServiceID}
+	}
+	es, err := h.Repos.ListExternalServices(r.Context(), args)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	sig := r.Header.Get("X-Hub-Signature")
+
+	var conn *schema.GitHubConnection
+	for _, e := range es {
+		c, _ := e.Configuration()
+		if conn = authenticateGitHub(c, sig, payload); conn != nil {
+			break
+		}
+	}
+	if conn == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, nil}
+	}
+
+	e, err := gh.ParseWebHook(gh.WebHookType(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, err}
+	}
+	return e, conn, nil
+}
+
+// authenticateGitHub returns the given external service configuration if any of
+// its webhook secrets validates the signature of the payload, and nil otherwise.
+func authenticateGitHub(config interface{}, sig string, payload []byte) *schema.GitHubConnection {
+	conn, ok := config.(*schema.GitHubConnection)
+	if !ok {
+		return nil
+	}
+
+	for _, hook := range conn.Webhooks {
+		if hook.Secret == "" {
+			continue
+		}
+		if gh.ValidateSignature(sig, payload, []byte(hook.Secret)) == nil {
+			return conn
+		}
+	}
+	return nil
+}
+
+// convertEvent returns the external users and repositories whose permissions
+// may have been changed by the given event.
+func (*GitHubWebhook) convertEvent(theirs interface{}) (a affected) {
+	log15.Debug("GitHub permissions webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	addUser := func(u *gh.User) {
+		if u.GetID() != 0 {
+			a.accountIDs = append(a.accountIDs, strconv.FormatInt(u.GetID(), 10))
+		}
+	}
+	addRepo := func(r *gh.Repository) {
+		if r.GetNodeID() != "" {
+			a.repoIDs = append(a.repoIDs, r.GetNodeID())
+		}
+	}
+
+	switch e := theirs.(type) {
+	case *gh.MemberEvent:
+		addUser(e.GetMember())
+		addRepo(e.GetRepo())
+
+	case *gh.MembershipEvent:
+		addUser(e.GetMember())
+
+	case *gh.OrganizationEvent:
+		switch e.GetAction() {
+		case "member_added", "member_removed":
+			addUser(e.GetMembership().GetUser())
+		}
+
+	case *gh.RepositoryEvent:
+		addRepo(e.GetRepo())
+	}
+	return a
+}
@@ -0,0 +1,168 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// gitlabMemberEventNames is the set of GitLab member event names that may
+// change permissions. Group webhooks deliver them with the "Member Hook"
+// event type, system hooks deliver them with the "System Hook" event type.
+var gitlabMemberEventNames = map[string]bool{
+	"user_add_to_group":        true,
+	"user_remove_from_group":   true,
+	"user_update_for_group":    true,
+	"user_add_to_project":      true,
+	"user_remove_from_project": true,
+	"user_update_for_project":  true,
+}
+
+// gitlabMemberEvent is the subset of a GitLab member event payload that is
+// needed to determine the affected user and project.
+type gitlabMemberEvent struct {
+	EventName string `json:"event_name"`
+	UserID    int    `json:"user_id"`
+	ProjectID int    `json:"project_id"`
+}
+
+// GitLabWebhook receives GitLab member webhook events and schedules
+// permissions syncing for the affected users and projects. All other events
+// are handed over to the next handler.
+type GitLabWebhook struct {
+	*Webhook
+}
+
+// NewGitLabWebhook returns a new GitLab permissions webhook handler that hands
+// events not relevant to permissions over to next.
+func NewGitLabWebhook(repos repos.Store, perms *edb.PermsStore, next http.Handler) *GitLabWebhook {
+	return &GitLabWebhook{newWebhook(repos, perms, next)}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("X-Gitlab-Event") {
+	case "Member Hook", "System Hook":
+	default:
+		h.passThrough(w, r)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var e gitlabMemberEvent
+	if err = json.Unmarshal(payload, &e); err != nil {
+		respond(w, http.StatusBadRequest, errors.Wrap(err, "parse event"))
+		return
+	}
+
+	// System hooks also deliver events that have nothing to do with permissions,
+	// restore the body for the next handler to read it again.
+	if !gitlabMemberEventNames[e.EventName] {
+		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+		h.passThrough(w, r)
+		return
+	}
+
+	conn, httpErr := h.authenticate(r)
+	if httpErr != nil {
+		respond(w, httpErr.code, httpErr)
+		return
+	}
+
+	serviceID, err := normalizeServiceID(conn.Url)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	a := h.convertEvent(&e)
+	if a.empty() {
+		respond(w, http.StatusOK, nil) // Nothing to do
+		return
+	}
+
+	if err = h.schedule(r.Context(), extsvc.TypeGitLab, serviceID, a); err != nil {
+		respond(w, http.StatusInternalServerError, errors.Wrap(err, "schedule permissions sync"))
+		return
+	}
+	respond(w, http.StatusOK, nil)
+}
+
+// authenticate returns the configuration of the GitLab external service that
+// has a webhook secret matching the token of the request.
+func (h *GitLabWebhook) authenticate(r *http.Request) (*schema.GitLabConnection, *httpError) {
+	var externalServiceID int64
+	if rawID := r.FormValue(extsvc.IDParam); rawID != "" {
+		var err error
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with any of the stored secrets
+	// in GitLab external services config. If there are no secrets or no secret
+	// matches the token of the request, we return a 401 to the client.
+	args := repos.StoreListExternalServicesArgs{Kinds: []string{extsvc.KindGitLab}}
+	if externalServiceID != 0 {
+		args.IDs = []int64{externalServiceID}
+	}
+	es, err := h.Repos.ListExternalServices(r.Context(), args)
+	if err != nil {
+		return nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	token := []byte(r.Header.Get("X-Gitlab-Token"))
+	if len(token) == 0 {
+		return nil, &httpError{http.StatusUnauthorized, nil}
+	}
+
+	for _, e := range es {
+		c, _ := e.Configuration()
+		conn, ok := c.(*schema.GitLabConnection)
+		if !ok {
+			continue
+		}
+
+		for _, hook := range conn.Webhooks {
+			if hook.Secret == "" {
+				continue
+			}
+			if subtle.ConstantTimeCompare(token, []byte(hook.Secret)) == 1 {
+				return conn, nil
+			}
+		}
+	}
+	return nil, &httpError{http.StatusUnauthorized, nil}
+}
+
+// convertEvent returns the external users and projects whose permissions may
+// have been changed by the given event. Group member events only affect the
+// user, since projects of the group are refreshed through user-centric syncing.
+func (*GitLabWebhook) convertEvent(e *gitlabMemberEvent) (a affected) {
+	log15.Debug("GitLab permissions webhook received", "event", e.EventName)
+
+	if e.UserID != 0 {
+		a.accountIDs = append(a.accountIDs, strconv.Itoa(e.UserID))
+	}
+	if e.ProjectID != 0 {
+		a.repoIDs = append(a.repoIDs, strconv.Itoa(e.ProjectID))
+	}
+	return a
+}
@@ -0,0 +1,148 @@
+// Package webhooks implements inbound code host webhooks that trigger
+// permissions syncing of the affected users and repositories, so that changes
+// in access (especially revocations) take effect without waiting for the
+// periodic sweeps of the background permissions syncer.
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+// Webhook contains the state shared by all code host specific permissions
+// webhook handlers.
+type Webhook struct {
+	Repos repos.Store
+	Perms *edb.PermsStore
+
+	// Next is the handler that receives all events that are not relevant to
+	// permissions. When nil, such events are acknowledged and dropped.
+	Next http.Handler
+
+	repoupdaterClient interface {
+		SchedulePermsSync(ctx context.Context, args protocol.PermsSyncRequest) error
+	}
+}
+
+func newWebhook(repos repos.Store, perms *edb.PermsStore, next http.Handler) *Webhook {
+	return &Webhook{
+		Repos:             repos,
+		Perms:             perms,
+		Next:              next,
+		repoupdaterClient: repoupdater.DefaultClient,
+	}
+}
+
+// passThrough hands the request over to the next handler, if any.
+func (h *Webhook) passThrough(w http.ResponseWriter, r *http.Request) {
+	if h.Next == nil {
+		respond(w, http.StatusOK, nil) // Nothing to do
+		return
+	}
+	h.Next.ServeHTTP(w, r)
+}
+
+// affected is the set of external users and repositories whose permissions
+// may have been changed by a webhook event.
+type affected struct {
+	accountIDs []string
+	repoIDs    []string
+}
+
+func (a affected) empty() bool {
+	return len(a.accountIDs) == 0 && len(a.repoIDs) == 0
+}
+
+// schedule resolves the affected external accounts and repositories of the
+// given code host to their Sourcegraph counterparts, and requests a high
+// priority permissions sync for each of them. External accounts and
+// repositories unknown to Sourcegraph are skipped.
+func (h *Webhook) schedule(ctx context.Context, serviceType, serviceID string, a affected) error {
+	var req protocol.PermsSyncRequest
+
+	if len(a.accountIDs) > 0 {
+		userIDs, err := h.Perms.GetUserIDsByExternalAccounts(ctx, &extsvc.Accounts{
+			ServiceType: serviceType,
+			ServiceID:   serviceID,
+			AccountIDs:  a.accountIDs,
+		})
+		if err != nil {
+			return errors.Wrap(err, "get user IDs by external accounts")
+		}
+		for _, id := range userIDs {
+			req.UserIDs = append(req.UserIDs, id)
+		}
+	}
+
+	if len(a.repoIDs) > 0 {
+		specs := make([]api.ExternalRepoSpec, len(a.repoIDs))
+		for i := range a.repoIDs {
+			specs[i] = api.ExternalRepoSpec{
+				ID:          a.repoIDs[i],
+				ServiceType: serviceType,
+				ServiceID:   serviceID,
+			}
+		}
+		rs, err := h.Repos.ListRepos(ctx, repos.StoreListReposArgs{ExternalRepos: specs})
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+		for _, r := range rs {
+			req.RepoIDs = append(req.RepoIDs, r.ID)
+		}
+	}
+
+	if len(req.UserIDs) == 0 && len(req.RepoIDs) == 0 {
+		log15.Debug("authz.webhooks.schedule.nothingToSync", "serviceID", serviceID, "accounts", a.accountIDs, "repos", a.repoIDs)
+		return nil
+	}
+
+	log15.Debug("authz.webhooks.schedule", "serviceID", serviceID, "users", req.UserIDs, "repos", req.RepoIDs)
+	return h.repoupdaterClient.SchedulePermsSync(ctx, req)
+}
+
+// normalizeServiceID returns the service ID (i.e. normalized base URL) of the
+// code host with the given URL.
+func normalizeServiceID(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, "parse service ID")
+	}
+	return extsvc.NormalizeBaseURL(u).String(), nil
+}
+
+type httpError struct {
+	code int
+	err  error
+}
+
+func (e httpError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("HTTP %d: %v", e.code, e.err)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.code, http.StatusText(e.code))
+}
+
+func respond(w http.ResponseWriter, code int, err error) {
+	if err == nil {
+		w.WriteHeader(code)
+		return
+	}
+
+	log15.Error(err.Error())
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "%v", err)
+}
@@ -0,0 +1,347 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeRepoupdaterClient struct {
+	requests []protocol.PermsSyncRequest
+}
+
+func (c *fakeRepoupdaterClient) SchedulePermsSync(ctx context.Context, args protocol.PermsSyncRequest) error {
+	c.requests = append(c.requests, args)
+	return nil
+}
+
+// nextHandler records whether it was called and the body it received.
+type nextHandler struct {
+	called bool
+	body   string
+}
+
+func (h *nextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.called = true
+	b, _ := ioutil.ReadAll(r.Body)
+	h.body = string(b)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func marshalJSON(t testing.TB, v interface{}) string {
+	t.Helper()
+
+	bs, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bs)
+}
+
+// setupStores returns a fake repos store with an external service of given
+// kind and config, and one repository with the given external ID. It also mocks
+// the mapping of external accounts to users as "account ID -> 100 + account ID".
+func setupStores(t *testing.T, kind, config string, repo api.ExternalRepoSpec) (*repos.FakeStore, *edb.PermsStore) {
+	ctx := context.Background()
+
+	store := new(repos.FakeStore)
+	err := store.UpsertExternalServices(ctx, &repos.ExternalService{
+		Kind:        kind,
+		DisplayName: kind,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.UpsertRepos(ctx, &repos.Repo{
+		Name:         "repo",
+		ExternalRepo: repo,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := map[string]int32{"1": 101, "2": 102}
+	edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(_ context.Context, a *extsvc.Accounts) (map[string]int32, error) {
+		if a.ServiceType != repo.ServiceType || a.ServiceID != repo.ServiceID {
+			return nil, nil
+		}
+
+		userIDs := make(map[string]int32)
+		for _, id := range a.AccountIDs {
+			if userID, ok := accounts[id]; ok {
+				userIDs[id] = userID
+			}
+		}
+		return userIDs, nil
+	}
+	t.Cleanup(func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	})
+
+	return store, edb.NewPermsStore(nil, time.Now)
+}
+
+func TestGitHubWebhook(t *testing.T) {
+	const secret = "secret"
+	store, perms := setupStores(t,
+		extsvc.KindGitHub,
+		marshalJSON(t, &schema.GitHubConnection{
+			Url:      "https://github.com",
+			Webhooks: []*schema.GitHubWebhook{{Org: "sourcegraph", Secret: secret}},
+		}),
+		api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnkx",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+	)
+
+	sign := func(payload []byte, secret string) string {
+		mac := hmac.New(sha1.New, []byte(secret))
+		mac.Write(payload)
+		return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name       string
+		event      string
+		payload    string
+		secret     string
+		wantCode   int
+		wantNext   bool
+		wantSynced []protocol.PermsSyncRequest
+	}{
+		{
+			name:     "not a permissions event",
+			event:    "pull_request",
+			payload:  `{"action":"opened"}`,
+			secret:   secret,
+			wantCode: http.StatusAccepted,
+			wantNext: true,
+		},
+		{
+			name:     "invalid signature",
+			event:    "membership",
+			payload:  `{"action":"removed","member":{"id":1}}`,
+			secret:   "wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "team membership removed",
+			event:    "membership",
+			payload:  `{"action":"removed","scope":"team","member":{"id":1}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{UserIDs: []int32{101}},
+			},
+		},
+		{
+			name:     "organization member removed",
+			event:    "organization",
+			payload:  `{"action":"member_removed","membership":{"user":{"id":2}}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{UserIDs: []int32{102}},
+			},
+		},
+		{
+			name:     "organization member invited",
+			event:    "organization",
+			payload:  `{"action":"member_invited","invitation":{"login":"alice"}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "collaborator removed",
+			event:    "member",
+			payload:  `{"action":"removed","member":{"id":1},"repository":{"node_id":"MDEwOlJlcG9zaXRvcnkx"}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{UserIDs: []int32{101}, RepoIDs: []api.RepoID{1}},
+			},
+		},
+		{
+			name:     "repository privatized",
+			event:    "repository",
+			payload:  `{"action":"privatized","repository":{"node_id":"MDEwOlJlcG9zaXRvcnkx"}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{RepoIDs: []api.RepoID{1}},
+			},
+		},
+		{
+			name:     "unknown user and repository",
+			event:    "member",
+			payload:  `{"action":"removed","member":{"id":3},"repository":{"node_id":"unknown"}}`,
+			secret:   secret,
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &nextHandler{}
+			client := &fakeRepoupdaterClient{}
+			h := NewGitHubWebhook(store, perms, next)
+			h.repoupdaterClient = client
+
+			req := httptest.NewRequest("POST", "/.api/github-webhooks", bytes.NewBufferString(test.payload))
+			req.Header.Set("X-GitHub-Event", test.event)
+			req.Header.Set("X-Hub-Signature", sign([]byte(test.payload), test.secret))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != test.wantCode {
+				t.Fatalf("code: want %d but got %d: %s", test.wantCode, rec.Code, rec.Body.String())
+			}
+			if next.called != test.wantNext {
+				t.Fatalf("next called: want %v but got %v", test.wantNext, next.called)
+			}
+			if test.wantNext && next.body != test.payload {
+				t.Fatalf("next body: want %q but got %q", test.payload, next.body)
+			}
+			if diff := cmp.Diff(test.wantSynced, client.requests); diff != "" {
+				t.Fatalf("synced requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitLabWebhook(t *testing.T) {
+	const secret = "secret"
+	store, perms := setupStores(t,
+		extsvc.KindGitLab,
+		marshalJSON(t, &schema.GitLabConnection{
+			Url:      "https://gitlab.com",
+			Webhooks: []*schema.GitLabWebhook{{Secret: secret}},
+		}),
+		api.ExternalRepoSpec{
+			ID:          "42",
+			ServiceType: extsvc.TypeGitLab,
+			ServiceID:   "https://gitlab.com/",
+		},
+	)
+
+	tests := []struct {
+		name       string
+		event      string
+		payload    string
+		token      string
+		next       bool
+		wantCode   int
+		wantNext   bool
+		wantSynced []protocol.PermsSyncRequest
+	}{
+		{
+			name:     "not a member event without next handler",
+			event:    "Merge Request Hook",
+			payload:  `{"object_kind":"merge_request"}`,
+			token:    secret,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not a member event",
+			event:    "Merge Request Hook",
+			payload:  `{"object_kind":"merge_request"}`,
+			token:    secret,
+			next:     true,
+			wantCode: http.StatusAccepted,
+			wantNext: true,
+		},
+		{
+			name:     "system hook that is not a member event",
+			event:    "System Hook",
+			payload:  `{"event_name":"project_create","project_id":42}`,
+			token:    secret,
+			next:     true,
+			wantCode: http.StatusAccepted,
+			wantNext: true,
+		},
+		{
+			name:     "invalid token",
+			event:    "Member Hook",
+			payload:  `{"event_name":"user_remove_from_group","user_id":1,"group_id":7}`,
+			token:    "wrong",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing token",
+			event:    "Member Hook",
+			payload:  `{"event_name":"user_remove_from_group","user_id":1,"group_id":7}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "group member removed",
+			event:    "Member Hook",
+			payload:  `{"event_name":"user_remove_from_group","user_id":1,"group_id":7}`,
+			token:    secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{UserIDs: []int32{101}},
+			},
+		},
+		{
+			name:     "project member removed",
+			event:    "System Hook",
+			payload:  `{"event_name":"user_remove_from_project","user_id":2,"project_id":42}`,
+			token:    secret,
+			wantCode: http.StatusOK,
+			wantSynced: []protocol.PermsSyncRequest{
+				{UserIDs: []int32{102}, RepoIDs: []api.RepoID{1}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &nextHandler{}
+			client := &fakeRepoupdaterClient{}
+			h := NewGitLabWebhook(store, perms, nil)
+			if test.next {
+				h.Next = next
+			}
+			h.repoupdaterClient = client
+
+			req := httptest.NewRequest("POST", "/.api/gitlab-webhooks", bytes.NewBufferString(test.payload))
+			req.Header.Set("X-Gitlab-Event", test.event)
+			if test.token != "" {
+				req.Header.Set("X-Gitlab-Token", test.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != test.wantCode {
+				t.Fatalf("code: want %d but got %d: %s", test.wantCode, rec.Code, rec.Body.String())
+			}
+			if next.called != test.wantNext {
+				t.Fatalf("next called: want %v but got %v", test.wantNext, next.called)
+			}
+			if test.wantNext && next.body != test.payload {
+				t.Fatalf("next body: want %q but got %q", test.payload, next.body)
+			}
+			if diff := cmp.Diff(test.wantSynced, client.requests); diff != "" {
+				t.Fatalf("synced requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	_ "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth"
 	eauthz "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/authz"
 	authzResolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/authz/resolvers"
+	authzWebhooks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/authz/webhooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/dotcom/productsubscription"
 	_ "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/licensing"
@@ -40,6 +41,7 @@ import (
 	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/graphql"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
@@ -64,6 +66,7 @@ func main() {
 		initLicensing()
 		initAuthz(ctx, &enterpriseServices)
 		initCampaigns(ctx, &enterpriseServices)
+		initAuthzWebhooks(&enterpriseServices)
 		initCodeIntel(&enterpriseServices)
 
 		return enterpriseServices
@@ -146,12 +149,25 @@ func initCampaigns(ctx context.Context, enterpriseServices *enterprise.Services)
 
 	enterpriseServices.CampaignsResolver = campaignsResolvers.NewResolver(dbconn.Global)
 	enterpriseServices.GithubWebhook = campaigns.NewGitHubWebhook(campaignsStore, repositories, msResolutionClock)
+	enterpriseServices.GitLabWebhook = campaigns.NewGitLabWebhook(campaignsStore, repositories, msResolutionClock)
 	enterpriseServices.BitbucketServerWebhook = campaigns.NewBitbucketServerWebhook(
 		campaignsStore,
 		repositories,
 		msResolutionClock,
 		"sourcegraph-"+globalState.SiteID,
 	)
+	enterpriseServices.CampaignsExport = campaigns.NewExportHandler(campaignsStore, msResolutionClock)
+}
+
+// initAuthzWebhooks wraps the code host webhook handlers so that events relevant
+// to permissions schedule permissions syncing, and all other events continue to
+// be handled as before. It must be called after all other handlers are set.
+func initAuthzWebhooks(enterpriseServices *enterprise.Services) {
+	repositories := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
+	permsStore := edb.NewPermsStore(dbconn.Global, msResolutionClock)
+
+	enterpriseServices.GithubWebhook = authzWebhooks.NewGitHubWebhook(repositories, permsStore, enterpriseServices.GithubWebhook)
+	enterpriseServices.GitLabWebhook = authzWebhooks.NewGitLabWebhook(repositories, permsStore, enterpriseServices.GitLabWebhook)
 }
 
 var bundleManagerURL = env.Get("PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL", "", "HTTP address for internal LSIF bundle manager server.")
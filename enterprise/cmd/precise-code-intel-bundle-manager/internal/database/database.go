@@ -31,6 +31,12 @@ type Database interface {
 	// References returns the set of locations referencing the symbol at the given position.
 	References(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
 
+	// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+	TypeDefinitions(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
+
+	// Implementations returns the set of locations implementing the symbol at the given position.
+	Implementations(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
+
 	// Hover returns the hover text of the symbol at the given position.
 	Hover(ctx context.Context, path string, line, character int) (string, bundles.Range, bool, error)
 
@@ -38,14 +44,17 @@ type Database interface {
 	// also returns the size of the complete result set to aid in pagination (along with skip and take).
 	Diagnostics(ctx context.Context, prefix string, skip, take int) ([]bundles.Diagnostic, int, error)
 
+	// DocumentSymbols returns the symbol tree of the document with the given path.
+	DocumentSymbols(ctx context.Context, path string) ([]bundles.DocumentSymbol, error)
+
 	// MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 	// ranges contain the position, then this method will return multiple sets of monikers. Each slice
 	// of monikers are attached to a single range. The order of the output slice is "outside-in", so that
 	// the range attached to earlier monikers enclose the range attached to later monikers.
 	MonikersByPosition(ctx context.Context, path string, line, character int) ([][]bundles.MonikerData, error)
 
-	// MonikerResults returns the locations that define or reference the given moniker. This method
-	// also returns the size of the complete result set to aid in pagination (along with skip and take).
+	// MonikerResults returns the locations that define, reference, or implement the given moniker. This
+	// method also returns the size of the complete result set to aid in pagination (along with skip and take).
 	MonikerResults(ctx context.Context, tableName, scheme, identifier string, skip, take int) ([]bundles.Location, int, error)
 
 	// PackageInformation looks up package information data by identifier.
@@ -220,6 +229,53 @@ func (db *databaseImpl) References(ctx context.Context, path string, line, chara
 	return allLocations, nil
 }
 
+// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+func (db *databaseImpl) TypeDefinitions(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+	_, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
+	if err != nil || !exists {
+		return nil, pkgerrors.Wrap(err, "db.getRangeByPosition")
+	}
+
+	for _, r := range ranges {
+		if r.TypeDefinitionResultID == "" {
+			continue
+		}
+
+		locations, err := db.locations(ctx, []types.ID{r.TypeDefinitionResultID})
+		if err != nil {
+			return nil, err
+		}
+
+		return locations[r.TypeDefinitionResultID], nil
+	}
+
+	return []bundles.Location{}, nil
+}
+
+// Implementations returns the set of locations implementing the symbol at the given position.
+func (db *databaseImpl) Implementations(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+	_, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
+	if err != nil || !exists {
+		return nil, pkgerrors.Wrap(err, "db.getRangeByPosition")
+	}
+
+	var allLocations []bundles.Location
+	for _, r := range ranges {
+		if r.ImplementationResultID == "" {
+			continue
+		}
+
+		locations, err := db.locations(ctx, []types.ID{r.ImplementationResultID})
+		if err != nil {
+			return nil, err
+		}
+
+		allLocations = append(allLocations, locations[r.ImplementationResultID]...)
+	}
+
+	return allLocations, nil
+}
+
 // Hover returns the hover text of the symbol at the given position.
 func (db *databaseImpl) Hover(ctx context.Context, path string, line, character int) (string, bundles.Range, bool, error) {
 	documentData, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
@@ -288,6 +344,37 @@ func (db *databaseImpl) Diagnostics(ctx context.Context, prefix string, skip, ta
 	return diagnostics, totalCount, nil
 }
 
+// DocumentSymbols returns the symbol tree of the document with the given path.
+func (db *databaseImpl) DocumentSymbols(ctx context.Context, path string) ([]bundles.DocumentSymbol, error) {
+	documentData, exists, err := db.getDocumentData(ctx, path)
+	if err != nil || !exists {
+		return nil, pkgerrors.Wrap(err, "db.getDocumentData")
+	}
+
+	return convertSymbols(documentData.Symbols), nil
+}
+
+// convertSymbols converts a tree of bundle symbols into document symbols.
+func convertSymbols(symbols []types.SymbolData) []bundles.DocumentSymbol {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	converted := make([]bundles.DocumentSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		converted = append(converted, bundles.DocumentSymbol{
+			Name:           symbol.Name,
+			Detail:         symbol.Detail,
+			Kind:           symbol.Kind,
+			Range:          newRange(symbol.StartLine, symbol.StartCharacter, symbol.EndLine, symbol.EndCharacter),
+			SelectionRange: newRange(symbol.SelectionStartLine, symbol.SelectionStartCharacter, symbol.SelectionEndLine, symbol.SelectionEndCharacter),
+			Children:       convertSymbols(symbol.Children),
+		})
+	}
+
+	return converted
+}
+
 // MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 // ranges contain the position, then this method will return multiple sets of monikers. Each slice
 // of monikers are attached to a single range. The order of the output slice is "outside-in", so that
@@ -326,8 +413,8 @@ func (db *databaseImpl) MonikersByPosition(ctx context.Context, path string, lin
 	return monikerData, nil
 }
 
-// MonikerResults returns the locations that define or reference the given moniker. This method
-// also returns the size of the complete result set to aid in pagination (along with skip and take).
+// MonikerResults returns the locations that define, reference, or implement the given moniker. This
+// method also returns the size of the complete result set to aid in pagination (along with skip and take).
 func (db *databaseImpl) MonikerResults(ctx context.Context, tableName, scheme, identifier string, skip, take int) (_ []bundles.Location, _ int, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "MonikerResults")
 	span.SetTag("filename", db.filename)
@@ -352,6 +439,10 @@ func (db *databaseImpl) MonikerResults(ctx context.Context, tableName, scheme, i
 		if rows, totalCount, err = db.reader.ReadReferences(ctx, scheme, identifier, skip, take); err != nil {
 			err = pkgerrors.Wrap(err, "reader.ReadReferences")
 		}
+	} else if tableName == "implementations" {
+		if rows, totalCount, err = db.reader.ReadImplementations(ctx, scheme, identifier, skip, take); err != nil {
+			err = pkgerrors.Wrap(err, "reader.ReadImplementations")
+		}
 	}
 
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/cache"
 	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/sqliteutil"
 )
@@ -231,6 +232,68 @@ func TestDatabaseHover(t *testing.T) {
 	}
 }
 
+func TestDatabaseDocumentSymbols(t *testing.T) {
+	// This bundle was produced by an indexer that does not emit document symbols
+	db := openTestDatabase(t)
+	if actual, err := db.DocumentSymbols(context.Background(), "internal/index/indexer.go"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if len(actual) != 0 {
+		t.Errorf("unexpected document symbols. want=%d have=%d", 0, len(actual))
+	}
+}
+
+func TestConvertSymbols(t *testing.T) {
+	symbols := []types.SymbolData{
+		{
+			Name:                    "Foo",
+			Kind:                    23,
+			StartLine:               1,
+			EndLine:                 4,
+			EndCharacter:            1,
+			SelectionStartLine:      1,
+			SelectionStartCharacter: 5,
+			SelectionEndLine:        1,
+			SelectionEndCharacter:   8,
+			Children: []types.SymbolData{
+				{
+					Name:                    "bar",
+					Detail:                  "int",
+					Kind:                    8,
+					StartLine:               2,
+					StartCharacter:          1,
+					EndLine:                 2,
+					EndCharacter:            8,
+					SelectionStartLine:      2,
+					SelectionStartCharacter: 1,
+					SelectionEndLine:        2,
+					SelectionEndCharacter:   4,
+				},
+			},
+		},
+	}
+
+	expected := []bundles.DocumentSymbol{
+		{
+			Name:           "Foo",
+			Kind:           23,
+			Range:          newRange(1, 0, 4, 1),
+			SelectionRange: newRange(1, 5, 1, 8),
+			Children: []bundles.DocumentSymbol{
+				{
+					Name:           "bar",
+					Detail:         "int",
+					Kind:           8,
+					Range:          newRange(2, 1, 2, 8),
+					SelectionRange: newRange(2, 1, 2, 4),
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, convertSymbols(symbols)); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
 func TestDatabaseMonikersByPosition(t *testing.T) {
 	// `func NewMetaData(id, root string, info ToolInfo) *MetaData {`
 	//       ^^^^^^^^^^^
@@ -319,6 +382,7 @@ func TestDatabaseMonikerResults(t *testing.T) {
 		{"definitions", "gomod", "github.com/sourcegraph/lsif-go/protocol:Edge", 0, 100, edgeLocations, 10},
 		{"definitions", "gomod", "github.com/sourcegraph/lsif-go/protocol:Edge", 3, 4, edgeLocations[3:7], 10},
 		{"references", "gomod", "github.com/slimsag/godocmd:ToMarkdown", 0, 100, markdownLocations, 1},
+		{"implementations", "gomod", "github.com/sourcegraph/lsif-go/protocol:Edge", 0, 100, nil, 0},
 	}
 
 	db := openTestDatabase(t)
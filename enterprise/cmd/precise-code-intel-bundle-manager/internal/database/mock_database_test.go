@@ -22,12 +22,18 @@ type MockDatabase struct {
 	// DiagnosticsFunc is an instance of a mock function object controlling
 	// the behavior of the method Diagnostics.
 	DiagnosticsFunc *DatabaseDiagnosticsFunc
+	// DocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentSymbols.
+	DocumentSymbolsFunc *DatabaseDocumentSymbolsFunc
 	// ExistsFunc is an instance of a mock function object controlling the
 	// behavior of the method Exists.
 	ExistsFunc *DatabaseExistsFunc
 	// HoverFunc is an instance of a mock function object controlling the
 	// behavior of the method Hover.
 	HoverFunc *DatabaseHoverFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *DatabaseImplementationsFunc
 	// MonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikerResults.
 	MonikerResultsFunc *DatabaseMonikerResultsFunc
@@ -43,6 +49,9 @@ type MockDatabase struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *DatabaseReferencesFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *DatabaseTypeDefinitionsFunc
 }
 
 // NewMockDatabase creates a new mock of the Database interface. All methods
@@ -64,6 +73,11 @@ func NewMockDatabase() *MockDatabase {
 				return nil, 0, nil
 			},
 		},
+		DocumentSymbolsFunc: &DatabaseDocumentSymbolsFunc{
+			defaultHook: func(context.Context, string) ([]client.DocumentSymbol, error) {
+				return nil, nil
+			},
+		},
 		ExistsFunc: &DatabaseExistsFunc{
 			defaultHook: func(context.Context, string) (bool, error) {
 				return false, nil
@@ -74,6 +88,11 @@ func NewMockDatabase() *MockDatabase {
 				return "", client.Range{}, false, nil
 			},
 		},
+		ImplementationsFunc: &DatabaseImplementationsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 		MonikerResultsFunc: &DatabaseMonikerResultsFunc{
 			defaultHook: func(context.Context, string, string, string, int, int) ([]client.Location, int, error) {
 				return nil, 0, nil
@@ -99,6 +118,11 @@ func NewMockDatabase() *MockDatabase {
 				return nil, nil
 			},
 		},
+		TypeDefinitionsFunc: &DatabaseTypeDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -115,12 +139,18 @@ func NewMockDatabaseFrom(i Database) *MockDatabase {
 		DiagnosticsFunc: &DatabaseDiagnosticsFunc{
 			defaultHook: i.Diagnostics,
 		},
+		DocumentSymbolsFunc: &DatabaseDocumentSymbolsFunc{
+			defaultHook: i.DocumentSymbols,
+		},
 		ExistsFunc: &DatabaseExistsFunc{
 			defaultHook: i.Exists,
 		},
 		HoverFunc: &DatabaseHoverFunc{
 			defaultHook: i.Hover,
 		},
+		ImplementationsFunc: &DatabaseImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		MonikerResultsFunc: &DatabaseMonikerResultsFunc{
 			defaultHook: i.MonikerResults,
 		},
@@ -136,6 +166,9 @@ func NewMockDatabaseFrom(i Database) *MockDatabase {
 		ReferencesFunc: &DatabaseReferencesFunc{
 			defaultHook: i.References,
 		},
+		TypeDefinitionsFunc: &DatabaseTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -471,6 +504,113 @@ func (c DatabaseDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DatabaseDocumentSymbolsFunc describes the behavior when the DocumentSymbols
+// method of the parent MockDatabase instance is invoked.
+type DatabaseDocumentSymbolsFunc struct {
+	defaultHook func(context.Context, string) ([]client.DocumentSymbol, error)
+	hooks       []func(context.Context, string) ([]client.DocumentSymbol, error)
+	history     []DatabaseDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDatabase) DocumentSymbols(v0 context.Context, v1 string) ([]client.DocumentSymbol, error) {
+	r0, r1 := m.DocumentSymbolsFunc.nextHook()(v0, v1)
+	m.DocumentSymbolsFunc.appendCall(DatabaseDocumentSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DocumentSymbols method
+// of the parent MockDatabase instance is invoked and the hook queue is empty.
+func (f *DatabaseDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context, string) ([]client.DocumentSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentSymbols method of the parent MockDatabase instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DatabaseDocumentSymbolsFunc) PushHook(hook func(context.Context, string) ([]client.DocumentSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseDocumentSymbolsFunc) SetDefaultReturn(r0 []client.DocumentSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]client.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseDocumentSymbolsFunc) PushReturn(r0 []client.DocumentSymbol, r1 error) {
+	f.PushHook(func(context.Context, string) ([]client.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *DatabaseDocumentSymbolsFunc) nextHook() func(context.Context, string) ([]client.DocumentSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseDocumentSymbolsFunc) appendCall(r0 DatabaseDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseDocumentSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *DatabaseDocumentSymbolsFunc) History() []DatabaseDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseDocumentSymbolsFuncCall is an object that describes an invocation of
+// method DocumentSymbols on an instance of MockDatabase.
+type DatabaseDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.DocumentSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c DatabaseDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DatabaseExistsFunc describes the behavior when the Exists method of the
 // parent MockDatabase instance is invoked.
 type DatabaseExistsFunc struct {
@@ -699,6 +839,119 @@ func (c DatabaseHoverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// DatabaseImplementationsFunc describes the behavior when the Implementations
+// method of the parent MockDatabase instance is invoked.
+type DatabaseImplementationsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []DatabaseImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDatabase) Implementations(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3)
+	m.ImplementationsFunc.appendCall(DatabaseImplementationsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Implementations method
+// of the parent MockDatabase instance is invoked and the hook queue is empty.
+func (f *DatabaseImplementationsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Implementations method of the parent MockDatabase instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DatabaseImplementationsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseImplementationsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseImplementationsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *DatabaseImplementationsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseImplementationsFunc) appendCall(r0 DatabaseImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *DatabaseImplementationsFunc) History() []DatabaseImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseImplementationsFuncCall is an object that describes an invocation of
+// method Implementations on an instance of MockDatabase.
+type DatabaseImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c DatabaseImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DatabaseMonikerResultsFunc describes the behavior when the MonikerResults
 // method of the parent MockDatabase instance is invoked.
 type DatabaseMonikerResultsFunc struct {
@@ -1280,3 +1533,116 @@ func (c DatabaseReferencesFuncCall) Args() []interface{} {
 func (c DatabaseReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// DatabaseTypeDefinitionsFunc describes the behavior when the TypeDefinitions
+// method of the parent MockDatabase instance is invoked.
+type DatabaseTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []DatabaseTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDatabase) TypeDefinitions(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3)
+	m.TypeDefinitionsFunc.appendCall(DatabaseTypeDefinitionsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions method
+// of the parent MockDatabase instance is invoked and the hook queue is empty.
+func (f *DatabaseTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockDatabase instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DatabaseTypeDefinitionsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DatabaseTypeDefinitionsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DatabaseTypeDefinitionsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *DatabaseTypeDefinitionsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DatabaseTypeDefinitionsFunc) appendCall(r0 DatabaseTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DatabaseTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *DatabaseTypeDefinitionsFunc) History() []DatabaseTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]DatabaseTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DatabaseTypeDefinitionsFuncCall is an object that describes an invocation of
+// method TypeDefinitions on an instance of MockDatabase.
+type DatabaseTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c DatabaseTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DatabaseTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -38,8 +38,11 @@ func (s *Server) handler() http.Handler {
 	mux.Path("/dbs/{id:[0-9]+}/ranges").Methods("GET").HandlerFunc(s.handleRanges)
 	mux.Path("/dbs/{id:[0-9]+}/definitions").Methods("GET").HandlerFunc(s.handleDefinitions)
 	mux.Path("/dbs/{id:[0-9]+}/references").Methods("GET").HandlerFunc(s.handleReferences)
+	mux.Path("/dbs/{id:[0-9]+}/typeDefinitions").Methods("GET").HandlerFunc(s.handleTypeDefinitions)
+	mux.Path("/dbs/{id:[0-9]+}/implementations").Methods("GET").HandlerFunc(s.handleImplementations)
 	mux.Path("/dbs/{id:[0-9]+}/hover").Methods("GET").HandlerFunc(s.handleHover)
 	mux.Path("/dbs/{id:[0-9]+}/diagnostics").Methods("GET").HandlerFunc(s.handleDiagnostics)
+	mux.Path("/dbs/{id:[0-9]+}/documentSymbols").Methods("GET").HandlerFunc(s.handleDocumentSymbols)
 	mux.Path("/dbs/{id:[0-9]+}/monikersByPosition").Methods("GET").HandlerFunc(s.handleMonikersByPosition)
 	mux.Path("/dbs/{id:[0-9]+}/monikerResults").Methods("GET").HandlerFunc(s.handleMonikerResults)
 	mux.Path("/dbs/{id:[0-9]+}/packageInformation").Methods("GET").HandlerFunc(s.handlePackageInformation)
@@ -183,6 +186,28 @@ func (s *Server) handleReferences(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GET /dbs/{id:[0-9]+}/typeDefinitions
+func (s *Server) handleTypeDefinitions(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		typeDefinitions, err := db.TypeDefinitions(ctx, getQuery(r, "path"), getQueryInt(r, "line"), getQueryInt(r, "character"))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.TypeDefinitions")
+		}
+		return typeDefinitions, nil
+	})
+}
+
+// GET /dbs/{id:[0-9]+}/implementations
+func (s *Server) handleImplementations(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		implementations, err := db.Implementations(ctx, getQuery(r, "path"), getQueryInt(r, "line"), getQueryInt(r, "character"))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.Implementations")
+		}
+		return implementations, nil
+	})
+}
+
 // GET /dbs/{id:[0-9]+}/hover
 func (s *Server) handleHover(w http.ResponseWriter, r *http.Request) {
 	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
@@ -220,6 +245,17 @@ func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GET /dbs/{id:[0-9]+}/documentSymbols
+func (s *Server) handleDocumentSymbols(w http.ResponseWriter, r *http.Request) {
+	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
+		symbols, err := db.DocumentSymbols(ctx, getQuery(r, "path"))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db.DocumentSymbols")
+		}
+		return symbols, nil
+	})
+}
+
 // GET /dbs/{id:[0-9]+}/monikersByPosition
 func (s *Server) handleMonikersByPosition(w http.ResponseWriter, r *http.Request) {
 	s.dbQuery(w, r, func(ctx context.Context, db database.Database) (interface{}, error) {
@@ -240,6 +276,8 @@ func (s *Server) handleMonikerResults(w http.ResponseWriter, r *http.Request) {
 			tableName = "definitions"
 		case "reference":
 			tableName = "references"
+		case "implementation":
+			tableName = "implementations"
 		default:
 			return nil, errors.New("illegal tableName supplied")
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
@@ -91,9 +92,12 @@ func (u *Updater) queueRepository(ctx context.Context, repoUsageStatistics store
 		return errors.Wrap(err, "gitserver.Head")
 	}
 
-	exists, err := u.gitserverClient.FileExists(ctx, u.store, repoUsageStatistics.RepositoryID, commit, "go.mod")
-	if err != nil || !exists {
-		return errors.Wrap(err, "gitserver.FileExists")
+	children, err := u.gitserverClient.DirectoryChildren(ctx, u.store, repoUsageStatistics.RepositoryID, commit, []string{""})
+	if err != nil {
+		return errors.Wrap(err, "gitserver.DirectoryChildren")
+	}
+	if len(inference.Languages(children[""])) == 0 {
+		return nil
 	}
 
 	// TODO(efritz) - also check repo size
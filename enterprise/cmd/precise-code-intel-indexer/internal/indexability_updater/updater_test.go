@@ -34,8 +34,15 @@ func TestUpdate(t *testing.T) {
 	}, nil)
 
 	mockGitserverClient := gitservermocks.NewMockClient()
-	mockGitserverClient.FileExistsFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error) {
-		return repositoryID%2 == 0, nil
+	mockGitserverClient.DirectoryChildrenFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit string, dirnames []string) (map[string][]string, error) {
+		switch repositoryID {
+		case 2:
+			return map[string][]string{"": {"go.mod", "go.sum"}}, nil
+		case 4:
+			return map[string][]string{"": {"README.md", "pom.xml"}}, nil
+		default:
+			return map[string][]string{"": {"README.md"}}, nil
+		}
 	})
 	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
 		return fmt.Sprintf("c%d", repositoryID), nil
@@ -51,19 +58,19 @@ func TestUpdate(t *testing.T) {
 		t.Fatalf("unexpected error performing update: %s", err)
 	}
 
-	if len(mockGitserverClient.FileExistsFunc.History()) != 4 {
-		t.Errorf("unexpected number of calls to FileExists. want=%d have=%d", 2, len(mockGitserverClient.FileExistsFunc.History()))
+	if len(mockGitserverClient.DirectoryChildrenFunc.History()) != 4 {
+		t.Errorf("unexpected number of calls to DirectoryChildren. want=%d have=%d", 4, len(mockGitserverClient.DirectoryChildrenFunc.History()))
 	} else {
 		var repositoryIDs []int
-		for _, call := range mockGitserverClient.FileExistsFunc.History() {
+		for _, call := range mockGitserverClient.DirectoryChildrenFunc.History() {
 			repositoryIDs = append(repositoryIDs, call.Arg2)
 			expectedCommit := fmt.Sprintf("c%d", call.Arg2)
 
 			if call.Arg3 != expectedCommit {
 				t.Errorf("unexpected commit argument. want=%q have=%q", expectedCommit, call.Arg3)
 			}
-			if call.Arg4 != "go.mod" {
-				t.Errorf("unexpected file argument. want=%q have=%q", "go.mod", call.Arg4)
+			if diff := cmp.Diff([]string{""}, call.Arg4); diff != "" {
+				t.Errorf("unexpected dirnames argument (-want +got):\n%s", diff)
 			}
 		}
 		sort.Ints(repositoryIDs)
@@ -44,20 +44,7 @@ func (p *processor) Process(ctx context.Context, index store.Index) error {
 }
 
 func (p *processor) index(ctx context.Context, repoDir string, index store.Index) error {
-	tag, exact, err := p.gitserverClient.Tags(ctx, p.store, index.RepositoryID, index.Commit)
-	if err != nil {
-		return err
-	}
-	if !exact {
-		tag = fmt.Sprintf("%s-%s", tag, index.Commit[:12])
-	}
-
-	args := []string{
-		"--repositoryRoot=.",
-		fmt.Sprintf("--moduleVersion=%s", tag),
-	}
-
-	return command(repoDir, "lsif-go", args...)
+	return command(repoDir, index.Indexer, index.IndexerArgs...)
 }
 
 func (p *processor) upload(ctx context.Context, repoDir string, index store.Index) error {
@@ -72,7 +59,7 @@ func (p *processor) upload(ctx context.Context, repoDir string, index store.Inde
 		Repo:                repoName,
 		Commit:              index.Commit,
 		Root:                "",
-		Indexer:             "lsif-go",
+		Indexer:             index.Indexer,
 		File:                filepath.Join(repoDir, "dump.lsif"),
 		MaxPayloadSizeBytes: 100 * 1000 * 1000, // 100Mb
 		MaxRetries:          10,
@@ -0,0 +1,118 @@
+package inference
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// IndexJob describes the indexer binary and the arguments used to produce an LSIF
+// dump named dump.lsif at the root of a repository.
+type IndexJob struct {
+	Indexer   string
+	Arguments []string
+}
+
+// recognizer determines whether or not a repository can be indexed by a particular
+// indexer by looking for one of a set of marker files at the root of the repository.
+type recognizer struct {
+	language  string
+	indexer   string
+	filenames []string
+	arguments func(version string) []string
+}
+
+// recognizers is the ordered list of supported indexers. When a repository matches
+// multiple recognizers, an index job will be created for each match.
+var recognizers = []recognizer{
+	{
+		language:  "go",
+		indexer:   "lsif-go",
+		filenames: []string{"go.mod"},
+		arguments: func(version string) []string {
+			return []string{"--repositoryRoot=.", fmt.Sprintf("--moduleVersion=%s", version)}
+		},
+	},
+	{
+		language:  "typescript",
+		indexer:   "lsif-tsc",
+		filenames: []string{"tsconfig.json"},
+		arguments: func(version string) []string {
+			return []string{"-p", ".", "--out=dump.lsif"}
+		},
+	},
+	{
+		language:  "java",
+		indexer:   "lsif-java",
+		filenames: []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+		arguments: func(version string) []string {
+			return []string{"--projectRoot=.", "--out=dump.lsif"}
+		},
+	},
+	{
+		language:  "python",
+		indexer:   "lsif-py",
+		filenames: []string{"setup.py", "pyproject.toml", "requirements.txt"},
+		arguments: func(version string) []string {
+			return []string{".", "--file=dump.lsif"}
+		},
+	},
+}
+
+// Languages returns the names of the languages with a supported indexer that are detected
+// from the given set of paths at the root of a repository.
+func Languages(paths []string) []string {
+	var languages []string
+	for _, r := range recognizers {
+		if r.matches(paths) {
+			languages = append(languages, r.language)
+		}
+	}
+
+	return languages
+}
+
+// InferIndexJobs returns an index job for each supported indexer that can index a repository
+// with the given set of paths at its root. The given version is used as the module version of
+// indexers that require one.
+func InferIndexJobs(paths []string, version string) []IndexJob {
+	var jobs []IndexJob
+	for _, r := range recognizers {
+		if r.matches(paths) {
+			jobs = append(jobs, IndexJob{Indexer: r.indexer, Arguments: r.arguments(version)})
+		}
+	}
+
+	return jobs
+}
+
+// OverrideIndexJob returns the index job for an explicitly configured indexer. If the given
+// arguments are nil and the indexer is supported, the default arguments for that indexer are
+// used.
+func OverrideIndexJob(indexer string, arguments []string, version string) IndexJob {
+	if arguments == nil {
+		for _, r := range recognizers {
+			if r.indexer == indexer {
+				arguments = r.arguments(version)
+				break
+			}
+		}
+	}
+
+	return IndexJob{Indexer: indexer, Arguments: arguments}
+}
+
+func (r recognizer) matches(paths []string) bool {
+	for _, path := range paths {
+		if filepath.Dir(path) != "." {
+			continue
+		}
+
+		for _, filename := range r.filenames {
+			if path == filename {
+				return true
+			}
+		}
+	}
+
+	return false
+}
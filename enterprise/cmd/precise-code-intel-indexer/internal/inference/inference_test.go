@@ -0,0 +1,57 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLanguages(t *testing.T) {
+	testCases := []struct {
+		paths     []string
+		languages []string
+	}{
+		{[]string{"README.md"}, nil},
+		{[]string{"go.mod", "go.sum", "main.go"}, []string{"go"}},
+		{[]string{"cmd/go.mod"}, nil},
+		{[]string{"tsconfig.json", "package.json"}, []string{"typescript"}},
+		{[]string{"build.gradle", "src"}, []string{"java"}},
+		{[]string{"pom.xml", "setup.py", "go.mod"}, []string{"go", "java", "python"}},
+	}
+
+	for _, testCase := range testCases {
+		if diff := cmp.Diff(testCase.languages, Languages(testCase.paths)); diff != "" {
+			t.Errorf("unexpected languages for %v (-want +got):\n%s", testCase.paths, diff)
+		}
+	}
+}
+
+func TestInferIndexJobs(t *testing.T) {
+	jobs := InferIndexJobs([]string{"go.mod", "tsconfig.json", "README.md"}, "v1.2.3")
+
+	expected := []IndexJob{
+		{Indexer: "lsif-go", Arguments: []string{"--repositoryRoot=.", "--moduleVersion=v1.2.3"}},
+		{Indexer: "lsif-tsc", Arguments: []string{"-p", ".", "--out=dump.lsif"}},
+	}
+	if diff := cmp.Diff(expected, jobs); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverrideIndexJob(t *testing.T) {
+	testCases := []struct {
+		indexer   string
+		arguments []string
+		expected  IndexJob
+	}{
+		{"lsif-go", nil, IndexJob{Indexer: "lsif-go", Arguments: []string{"--repositoryRoot=.", "--moduleVersion=v1.2.3"}}},
+		{"lsif-go", []string{"--noContents"}, IndexJob{Indexer: "lsif-go", Arguments: []string{"--noContents"}}},
+		{"lsif-cpp", nil, IndexJob{Indexer: "lsif-cpp"}},
+	}
+
+	for _, testCase := range testCases {
+		if diff := cmp.Diff(testCase.expected, OverrideIndexJob(testCase.indexer, testCase.arguments, "v1.2.3")); diff != "" {
+			t.Errorf("unexpected index job for %s (-want +got):\n%s", testCase.indexer, diff)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
@@ -111,6 +113,11 @@ func (s *Scheduler) queueIndex(ctx context.Context, indexableRepository store.In
 		return nil
 	}
 
+	jobs, err := s.inferIndexJobs(ctx, indexableRepository, commit)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return errors.Wrap(err, "store.Transact")
@@ -119,13 +126,25 @@ func (s *Scheduler) queueIndex(ctx context.Context, indexableRepository store.In
 		err = tx.Done(err)
 	}()
 
-	id, err := tx.InsertIndex(ctx, store.Index{
-		Commit:       commit,
-		RepositoryID: indexableRepository.RepositoryID,
-		State:        "queued",
-	})
-	if err != nil {
-		return errors.Wrap(err, "store.QueueIndex")
+	for _, job := range jobs {
+		id, err := tx.InsertIndex(ctx, store.Index{
+			Commit:       commit,
+			RepositoryID: indexableRepository.RepositoryID,
+			State:        "queued",
+			Indexer:      job.Indexer,
+			IndexerArgs:  job.Arguments,
+		})
+		if err != nil {
+			return errors.Wrap(err, "store.QueueIndex")
+		}
+
+		log15.Info(
+			"Enqueued index",
+			"id", id,
+			"repository_id", indexableRepository.RepositoryID,
+			"commit", commit,
+			"indexer", job.Indexer,
+		)
 	}
 
 	now := time.Now().UTC()
@@ -138,16 +157,33 @@ func (s *Scheduler) queueIndex(ctx context.Context, indexableRepository store.In
 		return errors.Wrap(err, "store.UpdateIndexableRepository")
 	}
 
-	log15.Info(
-		"Enqueued index",
-		"id", id,
-		"repository_id", indexableRepository.RepositoryID,
-		"commit", commit,
-	)
-
 	return nil
 }
 
+// inferIndexJobs determines the indexers to run on the given commit of the repository. If the
+// indexable repository record has an explicit indexer, only that indexer is used. Otherwise, an
+// index job is created for each language detected from the files at the root of the repository.
+func (s *Scheduler) inferIndexJobs(ctx context.Context, indexableRepository store.IndexableRepository, commit string) ([]inference.IndexJob, error) {
+	tag, exact, err := s.gitserverClient.Tags(ctx, s.store, indexableRepository.RepositoryID, commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.Tags")
+	}
+	if !exact {
+		tag = fmt.Sprintf("%s-%s", tag, commit[:12])
+	}
+
+	if indexableRepository.Indexer != nil {
+		return []inference.IndexJob{inference.OverrideIndexJob(*indexableRepository.Indexer, indexableRepository.IndexerArgs, tag)}, nil
+	}
+
+	children, err := s.gitserverClient.DirectoryChildren(ctx, s.store, indexableRepository.RepositoryID, commit, []string{""})
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.DirectoryChildren")
+	}
+
+	return inference.InferIndexJobs(children[""], tag), nil
+}
+
 func isRepoNotExist(err error) bool {
 	for err != nil {
 		if vcs.IsRepoNotExist(err) {
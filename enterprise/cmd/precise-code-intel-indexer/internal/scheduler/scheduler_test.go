@@ -25,6 +25,8 @@ func TestMain(m *testing.M) {
 }
 
 func TestUpdate(t *testing.T) {
+	overrideIndexer := "lsif-java"
+
 	mockStore := storemocks.NewMockStore()
 	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
 	mockStore.IndexableRepositoriesFunc.SetDefaultReturn([]store.IndexableRepository{
@@ -32,6 +34,7 @@ func TestUpdate(t *testing.T) {
 		{RepositoryID: 2},
 		{RepositoryID: 3},
 		{RepositoryID: 4},
+		{RepositoryID: 6, Indexer: &overrideIndexer},
 	}, nil)
 	mockStore.IsQueuedFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string) (bool, error) {
 		return repositoryID%2 != 0, nil
@@ -41,6 +44,13 @@ func TestUpdate(t *testing.T) {
 	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
 		return fmt.Sprintf("c%d", repositoryID), nil
 	})
+	mockGitserverClient.TagsFunc.SetDefaultReturn("v1.0.0", true, nil)
+	mockGitserverClient.DirectoryChildrenFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit string, dirnames []string) (map[string][]string, error) {
+		if repositoryID == 4 {
+			return map[string][]string{"": {"go.mod", "tsconfig.json"}}, nil
+		}
+		return map[string][]string{"": {"go.mod"}}, nil
+	})
 
 	scheduler := &Scheduler{
 		store:           mockStore,
@@ -52,8 +62,8 @@ func TestUpdate(t *testing.T) {
 		t.Fatalf("unexpected error performing update: %s", err)
 	}
 
-	if len(mockStore.IsQueuedFunc.History()) != 4 {
-		t.Errorf("unexpected number of calls to IsQueued. want=%d have=%d", 4, len(mockStore.IsQueuedFunc.History()))
+	if len(mockStore.IsQueuedFunc.History()) != 5 {
+		t.Errorf("unexpected number of calls to IsQueued. want=%d have=%d", 5, len(mockStore.IsQueuedFunc.History()))
 	} else {
 		var commits []string
 		for _, call := range mockStore.IsQueuedFunc.History() {
@@ -61,29 +71,37 @@ func TestUpdate(t *testing.T) {
 		}
 		sort.Strings(commits)
 
-		if diff := cmp.Diff([]string{"c1", "c2", "c3", "c4"}, commits); diff != "" {
+		if diff := cmp.Diff([]string{"c1", "c2", "c3", "c4", "c6"}, commits); diff != "" {
 			t.Errorf("unexpected commits (-want +got):\n%s", diff)
 		}
 	}
 
-	if len(mockStore.InsertIndexFunc.History()) != 2 {
-		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 2, len(mockStore.InsertIndexFunc.History()))
+	if len(mockStore.InsertIndexFunc.History()) != 4 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 4, len(mockStore.InsertIndexFunc.History()))
 	} else {
-		indexCommits := map[int]string{}
+		var indexes []store.Index
 		for _, call := range mockStore.InsertIndexFunc.History() {
-			indexCommits[call.Arg1.RepositoryID] = call.Arg1.Commit
+			indexes = append(indexes, call.Arg1)
 		}
+		sort.Slice(indexes, func(i, j int) bool {
+			if indexes[i].RepositoryID == indexes[j].RepositoryID {
+				return indexes[i].Indexer < indexes[j].Indexer
+			}
+			return indexes[i].RepositoryID < indexes[j].RepositoryID
+		})
 
-		expectedIndexCommits := map[int]string{
-			2: "c2",
-			4: "c4",
+		expectedIndexes := []store.Index{
+			{Commit: "c2", RepositoryID: 2, State: "queued", Indexer: "lsif-go", IndexerArgs: []string{"--repositoryRoot=.", "--moduleVersion=v1.0.0"}},
+			{Commit: "c4", RepositoryID: 4, State: "queued", Indexer: "lsif-go", IndexerArgs: []string{"--repositoryRoot=.", "--moduleVersion=v1.0.0"}},
+			{Commit: "c4", RepositoryID: 4, State: "queued", Indexer: "lsif-tsc", IndexerArgs: []string{"-p", ".", "--out=dump.lsif"}},
+			{Commit: "c6", RepositoryID: 6, State: "queued", Indexer: "lsif-java", IndexerArgs: []string{"--projectRoot=.", "--out=dump.lsif"}},
 		}
-		if diff := cmp.Diff(expectedIndexCommits, indexCommits); diff != "" {
+		if diff := cmp.Diff(expectedIndexes, indexes); diff != "" {
 			t.Errorf("unexpected indexes (-want +got):\n%s", diff)
 		}
 	}
 
-	if len(mockStore.UpdateIndexableRepositoryFunc.History()) != 2 {
-		t.Errorf("unexpected number of calls to UpdateIndexableRepository. want=%d have=%d", 2, len(mockStore.UpdateIndexableRepositoryFunc.History()))
+	if len(mockStore.UpdateIndexableRepositoryFunc.History()) != 3 {
+		t.Errorf("unexpected number of calls to UpdateIndexableRepository. want=%d have=%d", 3, len(mockStore.UpdateIndexableRepositoryFunc.History()))
 	}
 }
@@ -25,9 +25,9 @@ func canonicalize(state *State) {
 // canonicalizeDocuments determines if multiple documents are defined with the same URI. This can
 // happen in some indexers (such as lsif-tsc) that index dependent projects into the same index
 // as the target project. For each set of documents that share a path, we choose one document to
-// be the canonical representative and merge the contains, definition, and reference data into the
-// unique canonical document. This function guarantees that duplicate document IDs are removed from
-// the correlation state.
+// be the canonical representative and merge the contains, definition, reference, type definition,
+// and implementation data into the unique canonical document. This function guarantees that
+// duplicate document IDs are removed from the correlation state.
 func canonicalizeDocuments(state *State) {
 	documentIDs := map[string][]int{}
 	for documentID, doc := range state.DocumentData {
@@ -46,6 +46,8 @@ func canonicalizeDocuments(state *State) {
 			// Move definition/reference data into the canonical document
 			canonicalizeDocumentsInDefinitionReferences(state, state.DefinitionData, documentID, canonicalID)
 			canonicalizeDocumentsInDefinitionReferences(state, state.ReferenceData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.TypeDefinitionData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.ImplementationData, documentID, canonicalID)
 
 			// Remove non-canonical document
 			delete(state.DocumentData, documentID)
@@ -191,6 +193,12 @@ func mergeNextResultSetData(item, nextItem lsif.ResultSet) lsif.ResultSet {
 	if item.ReferenceResultID == 0 {
 		item = item.SetReferenceResultID(nextItem.ReferenceResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
@@ -209,6 +217,12 @@ func mergeNextRangeData(item lsif.Range, nextItem lsif.ResultSet) lsif.Range {
 	if item.ReferenceResultID == 0 {
 		item = item.SetReferenceResultID(nextItem.ReferenceResultID)
 	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
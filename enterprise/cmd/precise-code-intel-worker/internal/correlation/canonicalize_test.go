@@ -12,24 +12,28 @@ func TestCanonicalizeDocuments(t *testing.T) {
 	state := &State{
 		DocumentData: map[int]lsif.Document{
 			1001: {
-				URI:         "main.go",
-				Contains:    datastructures.IDSetWith(3001),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "main.go",
+				Contains:        datastructures.IDSetWith(3001),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1002: {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSetWith(3002),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.go",
+				Contains:        datastructures.IDSetWith(3002),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1003: {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSetWith(3003),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "bar.go",
+				Contains:        datastructures.IDSetWith(3003),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1004: {
-				URI:         "main.go",
-				Contains:    datastructures.IDSetWith(3004),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "main.go",
+				Contains:        datastructures.IDSetWith(3004),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		DefinitionData: map[int]datastructures.DefaultIDSetMap{
@@ -56,19 +60,22 @@ func TestCanonicalizeDocuments(t *testing.T) {
 	expectedState := &State{
 		DocumentData: map[int]lsif.Document{
 			1001: {
-				URI:         "main.go",
-				Contains:    datastructures.IDSetWith(3001, 3004),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "main.go",
+				Contains:        datastructures.IDSetWith(3001, 3004),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1002: {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSetWith(3002),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.go",
+				Contains:        datastructures.IDSetWith(3002),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1003: {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSetWith(3003),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "bar.go",
+				Contains:        datastructures.IDSetWith(3003),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		DefinitionData: map[int]datastructures.DefaultIDSetMap{
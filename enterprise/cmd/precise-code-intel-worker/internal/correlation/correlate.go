@@ -131,16 +131,19 @@ func correlateElement(state *wrappedState, element lsif.Element) error {
 }
 
 var vertexHandlers = map[string]func(state *wrappedState, element lsif.Element) error{
-	"metaData":           correlateMetaData,
-	"document":           correlateDocument,
-	"range":              correlateRange,
-	"resultSet":          correlateResultSet,
-	"definitionResult":   correlateDefinitionResult,
-	"referenceResult":    correlateReferenceResult,
-	"hoverResult":        correlateHoverResult,
-	"moniker":            correlateMoniker,
-	"packageInformation": correlatePackageInformation,
-	"diagnosticResult":   correlateDiagnosticResult,
+	"metaData":             correlateMetaData,
+	"document":             correlateDocument,
+	"range":                correlateRange,
+	"resultSet":            correlateResultSet,
+	"definitionResult":     correlateDefinitionResult,
+	"referenceResult":      correlateReferenceResult,
+	"typeDefinitionResult": correlateTypeDefinitionResult,
+	"implementationResult": correlateImplementationResult,
+	"hoverResult":          correlateHoverResult,
+	"moniker":              correlateMoniker,
+	"packageInformation":   correlatePackageInformation,
+	"diagnosticResult":     correlateDiagnosticResult,
+	"documentSymbolResult": correlateDocumentSymbolResult,
 }
 
 // correlateElement maps a single vertex element into the correlation state.
@@ -160,16 +163,19 @@ func correlateVertex(state *wrappedState, element lsif.Element) error {
 }
 
 var edgeHandlers = map[string]func(state *wrappedState, id int, edge lsif.Edge) error{
-	"contains":                correlateContainsEdge,
-	"next":                    correlateNextEdge,
-	"item":                    correlateItemEdge,
-	"textDocument/definition": correlateTextDocumentDefinitionEdge,
-	"textDocument/references": correlateTextDocumentReferencesEdge,
-	"textDocument/hover":      correlateTextDocumentHoverEdge,
-	"moniker":                 correlateMonikerEdge,
-	"nextMoniker":             correlateNextMonikerEdge,
-	"packageInformation":      correlatePackageInformationEdge,
-	"textDocument/diagnostic": correlateDiagnosticEdge,
+	"contains":                    correlateContainsEdge,
+	"next":                        correlateNextEdge,
+	"item":                        correlateItemEdge,
+	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
+	"textDocument/references":     correlateTextDocumentReferencesEdge,
+	"textDocument/typeDefinition": correlateTextDocumentTypeDefinitionEdge,
+	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/hover":          correlateTextDocumentHoverEdge,
+	"moniker":                     correlateMonikerEdge,
+	"nextMoniker":                 correlateNextMonikerEdge,
+	"packageInformation":          correlatePackageInformationEdge,
+	"textDocument/diagnostic":     correlateDiagnosticEdge,
+	"textDocument/documentSymbol": correlateDocumentSymbolEdge,
 }
 
 // correlateElement maps a single edge element into the correlation state.
@@ -263,6 +269,16 @@ func correlateReferenceResult(state *wrappedState, element lsif.Element) error {
 	return nil
 }
 
+func correlateTypeDefinitionResult(state *wrappedState, element lsif.Element) error {
+	state.TypeDefinitionData[element.ID] = datastructures.DefaultIDSetMap{}
+	return nil
+}
+
+func correlateImplementationResult(state *wrappedState, element lsif.Element) error {
+	state.ImplementationData[element.ID] = datastructures.DefaultIDSetMap{}
+	return nil
+}
+
 func correlateHoverResult(state *wrappedState, element lsif.Element) error {
 	payload, ok := element.Payload.(string)
 	if !ok {
@@ -303,6 +319,16 @@ func correlateDiagnosticResult(state *wrappedState, element lsif.Element) error
 	return nil
 }
 
+func correlateDocumentSymbolResult(state *wrappedState, element lsif.Element) error {
+	payload, ok := element.Payload.(lsif.DocumentSymbolResult)
+	if !ok {
+		return ErrUnexpectedPayload
+	}
+
+	state.DocumentSymbolResults[element.ID] = payload
+	return nil
+}
+
 func correlateContainsEdge(state *wrappedState, id int, edge lsif.Edge) error {
 	document, ok := state.DocumentData[edge.OutV]
 	if !ok {
@@ -366,6 +392,32 @@ func correlateItemEdge(state *wrappedState, id int, edge lsif.Edge) error {
 		return nil
 	}
 
+	if documentMap, ok := state.TypeDefinitionData[edge.OutV]; ok {
+		for _, inV := range edge.InVs {
+			if _, ok := state.RangeData[inV]; !ok {
+				return malformedDump(id, edge.InV, "range")
+			}
+
+			// Link type definition data to the range defining the type
+			documentMap.GetOrCreate(edge.Document).Add(inV)
+		}
+
+		return nil
+	}
+
+	if documentMap, ok := state.ImplementationData[edge.OutV]; ok {
+		for _, inV := range edge.InVs {
+			if _, ok := state.RangeData[inV]; !ok {
+				return malformedDump(id, edge.InV, "range")
+			}
+
+			// Link implementation data to an implementing range
+			documentMap.GetOrCreate(edge.Document).Add(inV)
+		}
+
+		return nil
+	}
+
 	if !state.unsupportedVertices.Contains(edge.OutV) {
 		return malformedDump(id, edge.OutV, "vertex")
 	}
@@ -404,6 +456,36 @@ func correlateTextDocumentReferencesEdge(state *wrappedState, id int, edge lsif.
 	return nil
 }
 
+func correlateTextDocumentTypeDefinitionEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	if _, ok := state.TypeDefinitionData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "typeDefinitionResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
+func correlateTextDocumentImplementationEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	if _, ok := state.ImplementationData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "implementationResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
 func correlateTextDocumentHoverEdge(state *wrappedState, id int, edge lsif.Edge) error {
 	if _, ok := state.HoverData[edge.InV]; !ok {
 		return malformedDump(id, edge.InV, "hoverResult")
@@ -484,3 +566,17 @@ func correlateDiagnosticEdge(state *wrappedState, id int, edge lsif.Edge) error
 	document.Diagnostics.Add(edge.InV)
 	return nil
 }
+
+func correlateDocumentSymbolEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	document, ok := state.DocumentData[edge.OutV]
+	if !ok {
+		return malformedDump(id, edge.OutV, "document")
+	}
+
+	if _, ok := state.DocumentSymbolResults[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "documentSymbolResult")
+	}
+
+	document.DocumentSymbols.Add(edge.InV)
+	return nil
+}
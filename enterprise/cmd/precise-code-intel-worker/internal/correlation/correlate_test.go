@@ -26,14 +26,16 @@ func TestCorrelate(t *testing.T) {
 		ProjectRoot: "file:///test/root",
 		DocumentData: map[int]lsif.Document{
 			2: {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSetWith(4, 5, 6),
-				Diagnostics: datastructures.IDSetWith(49),
+				URI:             "foo.go",
+				Contains:        datastructures.IDSetWith(4, 5, 6),
+				Diagnostics:     datastructures.IDSetWith(49),
+				DocumentSymbols: datastructures.IDSetWith(51),
 			},
 			3: {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSetWith(7, 8, 9),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "bar.go",
+				Contains:        datastructures.IDSetWith(7, 8, 9),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		RangeData: map[int]lsif.Range{
@@ -105,6 +107,8 @@ func TestCorrelate(t *testing.T) {
 			14: {2: datastructures.IDSetWith(4, 5)},
 			15: {},
 		},
+		TypeDefinitionData: map[int]datastructures.DefaultIDSetMap{},
+		ImplementationData: map[int]datastructures.DefaultIDSetMap{},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -155,6 +159,29 @@ func TestCorrelate(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[int]lsif.DocumentSymbolResult{
+			51: {
+				Result: []lsif.DocumentSymbol{
+					{
+						RangeID: 4,
+						Children: []lsif.DocumentSymbol{
+							{
+								Name:                    "bar",
+								Kind:                    13,
+								StartLine:               2,
+								StartCharacter:          1,
+								EndLine:                 2,
+								EndCharacter:            10,
+								SelectionStartLine:      2,
+								SelectionStartCharacter: 1,
+								SelectionEndLine:        2,
+								SelectionEndCharacter:   4,
+							},
+						},
+					},
+				},
+			},
+		},
 		NextData: map[int]int{
 			9:  10,
 			10: 11,
@@ -192,19 +219,23 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		ProjectRoot: "file:///test/root/",
 		DocumentData: map[int]lsif.Document{
 			2: {
-				URI:         "foo.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		RangeData:              map[int]lsif.Range{},
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		Diagnostics:            map[int]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[int]lsif.DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -233,19 +264,23 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		ProjectRoot: "file:///__w/sourcegraph/sourcegraph/shared/",
 		DocumentData: map[int]lsif.Document{
 			2: {
-				URI:         "../node_modules/@types/history/index.d.ts",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "../node_modules/@types/history/index.d.ts",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		RangeData:              map[int]lsif.Range{},
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		Diagnostics:            map[int]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[int]lsif.DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -21,6 +21,7 @@ type GroupedBundleData struct {
 	ResultChunks      map[int]types.ResultChunkData
 	Definitions       []types.MonikerLocations
 	References        []types.MonikerLocations
+	Implementations   []types.MonikerLocations
 	Packages          []types.Package
 	PackageReferences []types.PackageReference
 }
@@ -30,7 +31,7 @@ const ResultsPerResultChunk = 500
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(state *State, dumpID int) (*GroupedBundleData, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.TypeDefinitionData) + len(state.ImplementationData)
 	numResultChunks := int(math.Min(
 		MaxNumResultChunks,
 		math.Max(
@@ -44,6 +45,7 @@ func groupBundleData(state *State, dumpID int) (*GroupedBundleData, error) {
 	resultChunks := serializeResultChunks(state, numResultChunks)
 	definitionRows := gatherMonikersLocations(state, state.DefinitionData, getDefinitionResultID)
 	referenceRows := gatherMonikersLocations(state, state.ReferenceData, getReferenceResultID)
+	implementationRows := gatherMonikersLocations(state, state.ImplementationData, getImplementationResultID)
 	packages := gatherPackages(state, dumpID)
 	packageReferences, err := gatherPackageReferences(state, dumpID)
 	if err != nil {
@@ -56,6 +58,7 @@ func groupBundleData(state *State, dumpID int) (*GroupedBundleData, error) {
 		ResultChunks:      resultChunks,
 		Definitions:       definitionRows,
 		References:        referenceRows,
+		Implementations:   implementationRows,
 		Packages:          packages,
 		PackageReferences: packageReferences,
 	}, nil
@@ -81,6 +84,7 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 		Monikers:           map[types.ID]types.MonikerData{},
 		PackageInformation: map[types.ID]types.PackageInformationData{},
 		Diagnostics:        []types.DiagnosticData{},
+		Symbols:            []types.SymbolData{},
 	}
 
 	doc.Contains.Each(func(rangeID int) {
@@ -93,14 +97,16 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 		})
 
 		document.Ranges[toID(k)] = types.RangeData{
-			StartLine:          v.StartLine,
-			StartCharacter:     v.StartCharacter,
-			EndLine:            v.EndLine,
-			EndCharacter:       v.EndCharacter,
-			DefinitionResultID: toID(v.DefinitionResultID),
-			ReferenceResultID:  toID(v.ReferenceResultID),
-			HoverResultID:      toID(v.HoverResultID),
-			MonikerIDs:         monikerIDs,
+			StartLine:              v.StartLine,
+			StartCharacter:         v.StartCharacter,
+			EndLine:                v.EndLine,
+			EndCharacter:           v.EndCharacter,
+			DefinitionResultID:     toID(v.DefinitionResultID),
+			ReferenceResultID:      toID(v.ReferenceResultID),
+			TypeDefinitionResultID: toID(v.TypeDefinitionResultID),
+			ImplementationResultID: toID(v.ImplementationResultID),
+			HoverResultID:          toID(v.HoverResultID),
+			MonikerIDs:             monikerIDs,
 		}
 
 		if v.HoverResultID != 0 {
@@ -142,9 +148,60 @@ func serializeDocument(state *State, doc lsif.Document) types.DocumentData {
 		}
 	})
 
+	doc.DocumentSymbols.Each(func(documentSymbolResultID int) {
+		document.Symbols = append(document.Symbols, serializeSymbols(state, state.DocumentSymbolResults[documentSymbolResultID].Result)...)
+	})
+
 	return document
 }
 
+// serializeSymbols converts a document symbol tree into its serialized form. Range-based
+// symbols are resolved through the tag of the range vertex they reference. Symbols whose
+// range is missing or untagged are skipped along with their children.
+func serializeSymbols(state *State, symbols []lsif.DocumentSymbol) []types.SymbolData {
+	serialized := make([]types.SymbolData, 0, len(symbols))
+	for _, symbol := range symbols {
+		if symbol.RangeID != 0 {
+			r, ok := state.RangeData[symbol.RangeID]
+			if !ok || r.Tag == nil {
+				continue
+			}
+
+			symbol = lsif.DocumentSymbol{
+				Name:                    r.Tag.Text,
+				Detail:                  r.Tag.Detail,
+				Kind:                    r.Tag.Kind,
+				StartLine:               r.Tag.FullStartLine,
+				StartCharacter:          r.Tag.FullStartCharacter,
+				EndLine:                 r.Tag.FullEndLine,
+				EndCharacter:            r.Tag.FullEndCharacter,
+				SelectionStartLine:      r.StartLine,
+				SelectionStartCharacter: r.StartCharacter,
+				SelectionEndLine:        r.EndLine,
+				SelectionEndCharacter:   r.EndCharacter,
+				Children:                symbol.Children,
+			}
+		}
+
+		serialized = append(serialized, types.SymbolData{
+			Name:                    symbol.Name,
+			Detail:                  symbol.Detail,
+			Kind:                    symbol.Kind,
+			StartLine:               symbol.StartLine,
+			StartCharacter:          symbol.StartCharacter,
+			EndLine:                 symbol.EndLine,
+			EndCharacter:            symbol.EndCharacter,
+			SelectionStartLine:      symbol.SelectionStartLine,
+			SelectionStartCharacter: symbol.SelectionStartCharacter,
+			SelectionEndLine:        symbol.SelectionEndLine,
+			SelectionEndCharacter:   symbol.SelectionEndCharacter,
+			Children:                serializeSymbols(state, symbol.Children),
+		})
+	}
+
+	return serialized
+}
+
 func serializeResultChunks(state *State, numResultChunks int) map[int]types.ResultChunkData {
 	resultChunks := make([]types.ResultChunkData, 0, numResultChunks)
 	for i := 0; i < numResultChunks; i++ {
@@ -156,6 +213,8 @@ func serializeResultChunks(state *State, numResultChunks int) map[int]types.Resu
 
 	addToChunk(state, resultChunks, state.DefinitionData)
 	addToChunk(state, resultChunks, state.ReferenceData)
+	addToChunk(state, resultChunks, state.TypeDefinitionData)
+	addToChunk(state, resultChunks, state.ImplementationData)
 
 	out := make(map[int]types.ResultChunkData, len(resultChunks))
 	for id, resultChunk := range resultChunks {
@@ -195,8 +254,9 @@ func addToChunk(state *State, resultChunks []types.ResultChunkData, data map[int
 }
 
 var (
-	getDefinitionResultID = func(r lsif.Range) int { return r.DefinitionResultID }
-	getReferenceResultID  = func(r lsif.Range) int { return r.ReferenceResultID }
+	getDefinitionResultID     = func(r lsif.Range) int { return r.DefinitionResultID }
+	getReferenceResultID      = func(r lsif.Range) int { return r.ReferenceResultID }
+	getImplementationResultID = func(r lsif.Range) int { return r.ImplementationResultID }
 )
 
 func gatherMonikersLocations(state *State, data map[int]datastructures.DefaultIDSetMap, getResultID func(r lsif.Range) int) []types.MonikerLocations {
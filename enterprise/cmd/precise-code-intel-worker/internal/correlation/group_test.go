@@ -16,19 +16,22 @@ func TestGroupBundleData(t *testing.T) {
 	state := &State{
 		DocumentData: map[int]lsif.Document{
 			1001: {
-				URI:         "foo.go",
-				Contains:    datastructures.IDSetWith(2001, 2002, 2003),
-				Diagnostics: datastructures.IDSetWith(1001, 1002),
+				URI:             "foo.go",
+				Contains:        datastructures.IDSetWith(2001, 2002, 2003),
+				Diagnostics:     datastructures.IDSetWith(1001, 1002),
+				DocumentSymbols: datastructures.IDSetWith(6001),
 			},
 			1002: {
-				URI:         "bar.go",
-				Contains:    datastructures.IDSetWith(2004, 2005, 2006),
-				Diagnostics: datastructures.IDSetWith(1003),
+				URI:             "bar.go",
+				Contains:        datastructures.IDSetWith(2004, 2005, 2006),
+				Diagnostics:     datastructures.IDSetWith(1003),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1003: {
-				URI:         "baz.go",
-				Contains:    datastructures.IDSetWith(2007, 2008, 2009),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "baz.go",
+				Contains:        datastructures.IDSetWith(2007, 2008, 2009),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		RangeData: map[int]lsif.Range{
@@ -40,6 +43,16 @@ func TestGroupBundleData(t *testing.T) {
 				DefinitionResultID: 3001,
 				ReferenceResultID:  0,
 				MonikerIDs:         datastructures.IDSetWith(4001, 4002),
+				Tag: &lsif.RangeTag{
+					Type:               "definition",
+					Text:               "foo",
+					Kind:               12,
+					Detail:             "func foo()",
+					FullStartLine:      1,
+					FullStartCharacter: 0,
+					FullEndLine:        9,
+					FullEndCharacter:   1,
+				},
 			},
 			2002: {
 				StartLine:          2,
@@ -187,6 +200,31 @@ func TestGroupBundleData(t *testing.T) {
 				Version: "1.2.3",
 			},
 		},
+		DocumentSymbolResults: map[int]lsif.DocumentSymbolResult{
+			6001: {
+				Result: []lsif.DocumentSymbol{
+					{
+						RangeID: 2001,
+						Children: []lsif.DocumentSymbol{
+							{
+								Name:                    "bar",
+								Kind:                    13,
+								StartLine:               2,
+								StartCharacter:          1,
+								EndLine:                 2,
+								EndCharacter:            10,
+								SelectionStartLine:      2,
+								SelectionStartCharacter: 1,
+								SelectionEndLine:        2,
+								SelectionEndCharacter:   4,
+							},
+						},
+					},
+					// untagged range is skipped
+					{RangeID: 2002},
+				},
+			},
+		},
 		Diagnostics: map[int]lsif.DiagnosticResult{
 			1001: {
 				Result: []lsif.Diagnostic{
@@ -354,6 +392,36 @@ func TestGroupBundleData(t *testing.T) {
 						EndCharacter:   24,
 					},
 				},
+				Symbols: []types.SymbolData{
+					{
+						Name:                    "foo",
+						Detail:                  "func foo()",
+						Kind:                    12,
+						StartLine:               1,
+						StartCharacter:          0,
+						EndLine:                 9,
+						EndCharacter:            1,
+						SelectionStartLine:      1,
+						SelectionStartCharacter: 2,
+						SelectionEndLine:        3,
+						SelectionEndCharacter:   4,
+						Children: []types.SymbolData{
+							{
+								Name:                    "bar",
+								Kind:                    13,
+								StartLine:               2,
+								StartCharacter:          1,
+								EndLine:                 2,
+								EndCharacter:            10,
+								SelectionStartLine:      2,
+								SelectionStartCharacter: 1,
+								SelectionEndLine:        2,
+								SelectionEndCharacter:   4,
+								Children:                []types.SymbolData{},
+							},
+						},
+					},
+				},
 			},
 			"bar.go": {
 				Ranges: map[types.ID]types.RangeData{
@@ -413,6 +481,7 @@ func TestGroupBundleData(t *testing.T) {
 						EndCharacter:   44,
 					},
 				},
+				Symbols: []types.SymbolData{},
 			},
 			"baz.go": {
 				Ranges: map[types.ID]types.RangeData{
@@ -451,6 +520,7 @@ func TestGroupBundleData(t *testing.T) {
 				Monikers:           map[types.ID]types.MonikerData{},
 				PackageInformation: map[types.ID]types.PackageInformationData{},
 				Diagnostics:        []types.DiagnosticData{},
+				Symbols:            []types.SymbolData{},
 			},
 		},
 		ResultChunks: map[int]types.ResultChunkData{
@@ -539,6 +609,7 @@ func TestGroupBundleData(t *testing.T) {
 				},
 			},
 		},
+		Implementations: []types.MonikerLocations{},
 		Packages: []types.Package{
 			{DumpID: 42, Scheme: "scheme C", Name: "pkg B", Version: "1.2.3"},
 		},
@@ -22,114 +22,207 @@ type MetaData struct {
 }
 
 type Document struct {
-	URI         string
-	Contains    *datastructures.IDSet
-	Diagnostics *datastructures.IDSet
+	URI             string
+	Contains        *datastructures.IDSet
+	Diagnostics     *datastructures.IDSet
+	DocumentSymbols *datastructures.IDSet
 }
 
 type Range struct {
-	StartLine          int
-	StartCharacter     int
-	EndLine            int
-	EndCharacter       int
-	DefinitionResultID int
-	ReferenceResultID  int
-	HoverResultID      int
-	MonikerIDs         *datastructures.IDSet
+	StartLine              int
+	StartCharacter         int
+	EndLine                int
+	EndCharacter           int
+	DefinitionResultID     int
+	ReferenceResultID      int
+	TypeDefinitionResultID int
+	ImplementationResultID int
+	HoverResultID          int
+	MonikerIDs             *datastructures.IDSet
+	Tag                    *RangeTag
 }
 
 func (d Range) SetDefinitionResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetReferenceResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
+	}
+}
+
+func (d Range) SetTypeDefinitionResultID(id int) Range {
+	return Range{
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: id,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
+	}
+}
+
+func (d Range) SetImplementationResultID(id int) Range {
+	return Range{
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: id,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetHoverResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
-		MonikerIDs:         d.MonikerIDs,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          id,
+		MonikerIDs:             d.MonikerIDs,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetMonikerIDs(ids *datastructures.IDSet) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         ids,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             ids,
+		Tag:                    d.Tag,
 	}
 }
 
+// RangeTag carries the declaration metadata attached to a range vertex. Tagged
+// ranges are referred to by range-based document symbol results.
+type RangeTag struct {
+	Type               string
+	Text               string
+	Kind               int
+	Detail             string
+	FullStartLine      int
+	FullStartCharacter int
+	FullEndLine        int
+	FullEndCharacter   int
+}
+
 type ResultSet struct {
-	DefinitionResultID int
-	ReferenceResultID  int
-	HoverResultID      int
-	MonikerIDs         *datastructures.IDSet
+	DefinitionResultID     int
+	ReferenceResultID      int
+	TypeDefinitionResultID int
+	ImplementationResultID int
+	HoverResultID          int
+	MonikerIDs             *datastructures.IDSet
 }
 
 func (d ResultSet) SetDefinitionResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetReferenceResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+	}
+}
+
+func (d ResultSet) SetTypeDefinitionResultID(id int) ResultSet {
+	return ResultSet{
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: id,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
+	}
+}
+
+func (d ResultSet) SetImplementationResultID(id int) ResultSet {
+	return ResultSet{
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: id,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetHoverResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
-		MonikerIDs:         d.MonikerIDs,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          id,
+		MonikerIDs:             d.MonikerIDs,
 	}
 }
 
 func (d ResultSet) SetMonikerIDs(ids *datastructures.IDSet) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
-		MonikerIDs:         ids,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		HoverResultID:          d.HoverResultID,
+		MonikerIDs:             ids,
 	}
 }
 
@@ -168,3 +261,26 @@ type Diagnostic struct {
 	EndLine        int
 	EndCharacter   int
 }
+
+type DocumentSymbolResult struct {
+	Result []DocumentSymbol
+}
+
+// DocumentSymbol is a node of a document symbol tree. A symbol either refers to a
+// tagged range vertex by identifier (when RangeID is non-zero), or carries its own
+// name, kind, and ranges inline.
+type DocumentSymbol struct {
+	RangeID                 int
+	Name                    string
+	Detail                  string
+	Kind                    int
+	StartLine               int
+	StartCharacter          int
+	EndLine                 int
+	EndCharacter            int
+	SelectionStartLine      int
+	SelectionStartCharacter int
+	SelectionEndLine        int
+	SelectionEndCharacter   int
+	Children                []DocumentSymbol
+}
@@ -36,7 +36,10 @@ func unmarshalElement(interner *Interner, line []byte) (_ Element, err error) {
 	if payload.Type == "edge" {
 		element.Payload, err = unmarshalEdge(interner, line)
 	} else if payload.Type == "vertex" {
-		if unmarshaler, ok := vertexUnmarshalers[payload.Label]; ok {
+		if payload.Label == "documentSymbolResult" {
+			// Range-based document symbols refer to other vertices by identifier
+			element.Payload, err = unmarshalDocumentSymbolResult(interner, line)
+		} else if unmarshaler, ok := vertexUnmarshalers[payload.Label]; ok {
 			element.Payload, err = unmarshaler(line)
 		}
 	}
@@ -120,9 +123,10 @@ func unmarshalDocument(line []byte) (interface{}, error) {
 	}
 
 	return Document{
-		URI:         payload.URI,
-		Contains:    datastructures.NewIDSet(),
-		Diagnostics: datastructures.NewIDSet(),
+		URI:             payload.URI,
+		Contains:        datastructures.NewIDSet(),
+		Diagnostics:     datastructures.NewIDSet(),
+		DocumentSymbols: datastructures.NewIDSet(),
 	}, nil
 }
 
@@ -131,20 +135,47 @@ func unmarshalRange(line []byte) (interface{}, error) {
 		Line      int `json:"line"`
 		Character int `json:"character"`
 	}
+	type _range struct {
+		Start _position `json:"start"`
+		End   _position `json:"end"`
+	}
+	type _tag struct {
+		Type      string `json:"type"`
+		Text      string `json:"text"`
+		Kind      int    `json:"kind"`
+		Detail    string `json:"detail"`
+		FullRange _range `json:"fullRange"`
+	}
 	var payload struct {
 		Start _position `json:"start"`
 		End   _position `json:"end"`
+		Tag   *_tag     `json:"tag"`
 	}
 	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
 		return nil, err
 	}
 
+	var tag *RangeTag
+	if payload.Tag != nil {
+		tag = &RangeTag{
+			Type:               payload.Tag.Type,
+			Text:               payload.Tag.Text,
+			Kind:               payload.Tag.Kind,
+			Detail:             payload.Tag.Detail,
+			FullStartLine:      payload.Tag.FullRange.Start.Line,
+			FullStartCharacter: payload.Tag.FullRange.Start.Character,
+			FullEndLine:        payload.Tag.FullRange.End.Line,
+			FullEndCharacter:   payload.Tag.FullRange.End.Character,
+		}
+	}
+
 	return Range{
 		StartLine:      payload.Start.Line,
 		StartCharacter: payload.Start.Character,
 		EndLine:        payload.End.Line,
 		EndCharacter:   payload.End.Character,
 		MonikerIDs:     datastructures.NewIDSet(),
+		Tag:            tag,
 	}, nil
 }
 
@@ -292,6 +323,80 @@ func unmarshalDiagnosticResult(line []byte) (interface{}, error) {
 	return DiagnosticResult{Result: diagnostics}, nil
 }
 
+type _documentSymbol struct {
+	ID             json.RawMessage   `json:"id"`
+	Name           string            `json:"name"`
+	Detail         string            `json:"detail"`
+	Kind           int               `json:"kind"`
+	Range          _symbolRange      `json:"range"`
+	SelectionRange _symbolRange      `json:"selectionRange"`
+	Children       []_documentSymbol `json:"children"`
+}
+
+type _symbolRange struct {
+	Start struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"start"`
+	End struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"end"`
+}
+
+// unmarshalDocumentSymbolResult unmarshals a documentSymbolResult vertex. The result
+// may be a list of LSP document symbols or a list of range-based document symbols,
+// which refer to tagged range vertices by identifier.
+func unmarshalDocumentSymbolResult(interner *Interner, line []byte) (interface{}, error) {
+	var payload struct {
+		Result []_documentSymbol `json:"result"`
+	}
+	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
+		return nil, err
+	}
+
+	symbols, err := convertDocumentSymbols(interner, payload.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return DocumentSymbolResult{Result: symbols}, nil
+}
+
+func convertDocumentSymbols(interner *Interner, payload []_documentSymbol) ([]DocumentSymbol, error) {
+	var symbols []DocumentSymbol
+	for _, symbol := range payload {
+		// Inline symbols have no identifier and intern to zero
+		rangeID, err := internRaw(interner, symbol.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := convertDocumentSymbols(interner, symbol.Children)
+		if err != nil {
+			return nil, err
+		}
+
+		symbols = append(symbols, DocumentSymbol{
+			RangeID:                 rangeID,
+			Name:                    symbol.Name,
+			Detail:                  symbol.Detail,
+			Kind:                    symbol.Kind,
+			StartLine:               symbol.Range.Start.Line,
+			StartCharacter:          symbol.Range.Start.Character,
+			EndLine:                 symbol.Range.End.Line,
+			EndCharacter:            symbol.Range.End.Character,
+			SelectionStartLine:      symbol.SelectionRange.Start.Line,
+			SelectionStartCharacter: symbol.SelectionRange.Start.Character,
+			SelectionEndLine:        symbol.SelectionRange.End.Line,
+			SelectionEndCharacter:   symbol.SelectionRange.End.Character,
+			Children:                children,
+		})
+	}
+
+	return symbols, nil
+}
+
 type StringOrInt string
 
 func (id *StringOrInt) UnmarshalJSON(raw []byte) error {
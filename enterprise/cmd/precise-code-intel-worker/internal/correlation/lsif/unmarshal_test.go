@@ -96,9 +96,10 @@ func TestUnmarshalDocument(t *testing.T) {
 	}
 
 	expectedDocument := Document{
-		URI:         "file:///test/root/foo.go",
-		Contains:    datastructures.NewIDSet(),
-		Diagnostics: datastructures.NewIDSet(),
+		URI:             "file:///test/root/foo.go",
+		Contains:        datastructures.NewIDSet(),
+		Diagnostics:     datastructures.NewIDSet(),
+		DocumentSymbols: datastructures.NewIDSet(),
 	}
 	if diff := cmp.Diff(expectedDocument, document, datastructures.IDSetComparer); diff != "" {
 		t.Errorf("unexpected document (-want +got):\n%s", diff)
@@ -126,6 +127,34 @@ func TestUnmarshalRange(t *testing.T) {
 	}
 }
 
+func TestUnmarshalRangeTag(t *testing.T) {
+	r, err := unmarshalRange([]byte(`{"id": "04", "type": "vertex", "label": "range", "start": {"line": 1, "character": 5}, "end": {"line": 1, "character": 8}, "tag": {"type": "definition", "text": "foo", "kind": 12, "detail": "func foo()", "fullRange": {"start": {"line": 1, "character": 0}, "end": {"line": 3, "character": 1}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling range data: %s", err)
+	}
+
+	expectedRange := Range{
+		StartLine:      1,
+		StartCharacter: 5,
+		EndLine:        1,
+		EndCharacter:   8,
+		MonikerIDs:     datastructures.NewIDSet(),
+		Tag: &RangeTag{
+			Type:               "definition",
+			Text:               "foo",
+			Kind:               12,
+			Detail:             "func foo()",
+			FullStartLine:      1,
+			FullStartCharacter: 0,
+			FullEndLine:        3,
+			FullEndCharacter:   1,
+		},
+	}
+	if diff := cmp.Diff(expectedRange, r, datastructures.IDSetComparer); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+}
+
 func TestUnmarshalHover(t *testing.T) {
 	testCases := []struct {
 		contents      string
@@ -220,3 +249,67 @@ func TestUnmarshalDiagnosticResult(t *testing.T) {
 		t.Errorf("unexpected diagnostic result (-want +got):\n%s", diff)
 	}
 }
+
+func TestUnmarshalDocumentSymbolResult(t *testing.T) {
+	documentSymbolResult, err := unmarshalDocumentSymbolResult(NewInterner(), []byte(`{"id": 19, "type": "vertex", "label": "documentSymbolResult", "result": [{"name": "Foo", "detail": "struct", "kind": 23, "range": {"start": {"line": 1, "character": 0}, "end": {"line": 4, "character": 1}}, "selectionRange": {"start": {"line": 1, "character": 5}, "end": {"line": 1, "character": 8}}, "children": [{"name": "bar", "kind": 8, "range": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 8}}, "selectionRange": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 4}}}]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling document symbol result data: %s", err)
+	}
+
+	expectedDocumentSymbolResult := DocumentSymbolResult{
+		Result: []DocumentSymbol{
+			{
+				Name:                    "Foo",
+				Detail:                  "struct",
+				Kind:                    23,
+				StartLine:               1,
+				StartCharacter:          0,
+				EndLine:                 4,
+				EndCharacter:            1,
+				SelectionStartLine:      1,
+				SelectionStartCharacter: 5,
+				SelectionEndLine:        1,
+				SelectionEndCharacter:   8,
+				Children: []DocumentSymbol{
+					{
+						Name:                    "bar",
+						Kind:                    8,
+						StartLine:               2,
+						StartCharacter:          1,
+						EndLine:                 2,
+						EndCharacter:            8,
+						SelectionStartLine:      2,
+						SelectionStartCharacter: 1,
+						SelectionEndLine:        2,
+						SelectionEndCharacter:   4,
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDocumentSymbolResult, documentSymbolResult); diff != "" {
+		t.Errorf("unexpected document symbol result (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalDocumentSymbolResultRangeBased(t *testing.T) {
+	documentSymbolResult, err := unmarshalDocumentSymbolResult(NewInterner(), []byte(`{"id": 19, "type": "vertex", "label": "documentSymbolResult", "result": [{"id": 7, "children": [{"id": 8}, {"id": 9}]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling document symbol result data: %s", err)
+	}
+
+	expectedDocumentSymbolResult := DocumentSymbolResult{
+		Result: []DocumentSymbol{
+			{
+				RangeID: 7,
+				Children: []DocumentSymbol{
+					{RangeID: 8},
+					{RangeID: 9},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDocumentSymbolResult, documentSymbolResult); diff != "" {
+		t.Errorf("unexpected document symbol result (-want +got):\n%s", diff)
+	}
+}
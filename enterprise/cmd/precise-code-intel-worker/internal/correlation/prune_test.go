@@ -27,29 +27,34 @@ func TestPrune(t *testing.T) {
 	state := &State{
 		DocumentData: map[int]lsif.Document{
 			1001: {
-				URI:         "foo.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1002: {
-				URI:         "bar.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "bar.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1003: {
-				URI:         "sub/baz.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "sub/baz.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1004: {
-				URI:         "foo.generated.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.generated.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1005: {
-				URI:         "foo.generated.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.generated.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		DefinitionData: map[int]datastructures.DefaultIDSetMap{
@@ -79,19 +84,22 @@ func TestPrune(t *testing.T) {
 	expectedState := &State{
 		DocumentData: map[int]lsif.Document{
 			1001: {
-				URI:         "foo.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "foo.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1002: {
-				URI:         "bar.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "bar.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 			1003: {
-				URI:         "sub/baz.go",
-				Contains:    datastructures.NewIDSet(),
-				Diagnostics: datastructures.NewIDSet(),
+				URI:             "sub/baz.go",
+				Contains:        datastructures.NewIDSet(),
+				Diagnostics:     datastructures.NewIDSet(),
+				DocumentSymbols: datastructures.NewIDSet(),
 			},
 		},
 		DefinitionData: map[int]datastructures.DefaultIDSetMap{
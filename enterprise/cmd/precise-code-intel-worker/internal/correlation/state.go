@@ -14,10 +14,13 @@ type State struct {
 	ResultSetData          map[int]lsif.ResultSet
 	DefinitionData         map[int]datastructures.DefaultIDSetMap
 	ReferenceData          map[int]datastructures.DefaultIDSetMap
+	TypeDefinitionData     map[int]datastructures.DefaultIDSetMap
+	ImplementationData     map[int]datastructures.DefaultIDSetMap
 	HoverData              map[int]string
 	MonikerData            map[int]lsif.Moniker
 	PackageInformationData map[int]lsif.PackageInformation
 	Diagnostics            map[int]lsif.DiagnosticResult
+	DocumentSymbolResults  map[int]lsif.DocumentSymbolResult
 	NextData               map[int]int                  // maps vertices related via next edges
 	ImportedMonikers       *datastructures.IDSet        // moniker ids that have kind "import"
 	ExportedMonikers       *datastructures.IDSet        // moniker ids that have kind "export"
@@ -33,10 +36,13 @@ func newState() *State {
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		Diagnostics:            map[int]lsif.DiagnosticResult{},
+		DocumentSymbolResults:  map[int]lsif.DocumentSymbolResult{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -47,8 +47,9 @@ type requestMeta struct {
 type syncRequest struct {
 	*requestMeta
 
-	acquired bool // Whether the request has been acquired
-	index    int  // The index in the heap
+	acquired bool         // Whether the request has been acquired
+	requeue  *requestMeta // The high priority request enqueued while being acquired
+	index    int          // The index in the heap
 }
 
 // requestQueueKey is the key type for index in a requestQueue.
@@ -93,6 +94,12 @@ func notify(ch chan struct{}) {
 // If the sync request is already in the queue and it isn't yet acquired,
 // the request is updated.
 //
+// If the sync request is already acquired and the given priority is high, the
+// request is put back to the queue with the given metadata once the current
+// processing is done. This is because the processing might have started before
+// the change that triggered the new request (e.g. a webhook event), and the
+// change would otherwise be missed until the next periodic sync.
+//
 // If the given priority is higher than the one in the queue,
 // the sync request's position in the queue is updated accordingly.
 func (q *requestQueue) enqueue(meta *requestMeta) (updated bool) {
@@ -116,8 +123,17 @@ func (q *requestQueue) enqueue(meta *requestMeta) (updated bool) {
 		return false
 	}
 
-	if request.acquired || request.Priority >= meta.Priority {
-		// Request is acquired and in processing, or is already in the queue with at least as good priority.
+	if request.acquired {
+		if meta.Priority != priorityHigh {
+			// Request is acquired and in processing.
+			return false
+		}
+		request.requeue = meta
+		return true
+	}
+
+	if request.Priority >= meta.Priority {
+		// Request is already in the queue with at least as good priority.
 		return false
 	}
 
@@ -128,7 +144,8 @@ func (q *requestQueue) enqueue(meta *requestMeta) (updated bool) {
 }
 
 // remove removes the sync request from the queue if the request.acquired matches the
-// acquired argument.
+// acquired argument. A request that was enqueued again while being acquired is put
+// back to the queue instead of being removed.
 func (q *requestQueue) remove(typ requestType, id int32, acquired bool) (removed bool) {
 	if id == 0 {
 		return false
@@ -143,6 +160,11 @@ func (q *requestQueue) remove(typ requestType, id int32, acquired bool) (removed
 	}
 	request := q.index[key]
 	if request != nil && request.acquired == acquired {
+		if request.requeue != nil {
+			q.putBack(request)
+			return false
+		}
+
 		heap.Remove(q, request.index)
 		return true
 	}
@@ -187,10 +209,26 @@ func (q *requestQueue) release(typ requestType, id int32) {
 		return
 	}
 
+	if request.requeue != nil {
+		q.putBack(request)
+		return
+	}
+
 	request.acquired = false
 	heap.Fix(q, request.index)
 }
 
+// putBack puts the acquired sync request back to the queue with the metadata of
+// the request that was enqueued while being acquired. It must be called with the
+// mutex held.
+func (q *requestQueue) putBack(request *syncRequest) {
+	request.requestMeta = request.requeue
+	request.requeue = nil
+	request.acquired = false
+	heap.Fix(q, request.index)
+	notify(q.notifyEnqueue)
+}
+
 // The following methods implement heap.Interface based on the priority queue example:
 // https://golang.org/pkg/container/heap/#example__priorityQueue
 // These methods are not safe for concurrent use. Therefore, it is the caller's
@@ -508,6 +508,59 @@ func Test_requestQueue_release(t *testing.T) {
 	}
 }
 
+func Test_requestQueue_requeue(t *testing.T) {
+	lowUser1 := &requestMeta{Priority: priorityLow, Type: requestTypeUser, ID: 1}
+	highUser1 := &requestMeta{Priority: priorityHigh, Type: requestTypeUser, ID: 1}
+
+	q := newRequestQueue()
+	q.enqueue(lowUser1)
+	r := q.acquireNext()
+	<-q.notifyEnqueue
+
+	// Low priority request is dropped while the request is being processed
+	if q.enqueue(lowUser1) {
+		t.Fatal("want not updated but got updated")
+	}
+	// High priority request is kept for later
+	if !q.enqueue(highUser1) {
+		t.Fatal("want updated but got not updated")
+	}
+
+	expHeap := []*syncRequest{
+		{requestMeta: lowUser1, acquired: true, requeue: highUser1, index: 0},
+	}
+	if diff := cmp.Diff(expHeap, q.heap, cmpOpts); diff != "" {
+		t.Fatalf("heap: %v", diff)
+	}
+
+	// Finishing the processing puts the request back to the queue
+	if q.remove(r.Type, r.ID, true) {
+		t.Fatal("want not removed but got removed")
+	}
+
+	expHeap = []*syncRequest{
+		{requestMeta: highUser1, acquired: false, index: 0},
+	}
+	if diff := cmp.Diff(expHeap, q.heap, cmpOpts); diff != "" {
+		t.Fatalf("heap: %v", diff)
+	}
+
+	select {
+	case <-q.notifyEnqueue:
+	default:
+		t.Fatal("want notification but got none")
+	}
+
+	// The request is removed once processed again
+	r = q.acquireNext()
+	if !q.remove(r.Type, r.ID, true) {
+		t.Fatal("want removed but got not removed")
+	}
+	if len(q.heap) != 0 {
+		t.Fatalf("want empty heap but got %d requests", len(q.heap))
+	}
+}
+
 func Test_requestQueue_Less(t *testing.T) {
 	q := newRequestQueue()
 
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
 
 	ossAuthz "github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
@@ -25,7 +27,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 func main() {
@@ -57,6 +61,19 @@ func enterpriseInit(
 	sourcer := repos.NewSourcer(cf)
 	go campaigns.RunWorkers(ctx, campaignsStore, clock, gitserver.DefaultClient, sourcer, 5*time.Second)
 
+	// Set up the changeset bulk operation worker and resetter
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+	bulkOperationMetrics := campaigns.NewBulkOperationMetrics(observationContext)
+	go campaigns.NewBulkOperationWorker(ctx, campaignsStore, sourcer, bulkOperationMetrics).Start()
+	go campaigns.NewBulkOperationResetter(campaignsStore, bulkOperationMetrics).Start()
+
+	// Set up the per-campaign changeset metrics
+	go campaigns.RunCampaignMetricsReporter(ctx, campaignsStore, 1*time.Minute)
+
 	// Set up expired spec deletion
 	go func() {
 		for {
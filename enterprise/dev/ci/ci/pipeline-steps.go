@@ -22,6 +22,7 @@ var allDockerImages = []string{
 	"precise-code-intel-bundle-manager",
 	"precise-code-intel-worker",
 	"precise-code-intel-indexer",
+	"campaign-executor",
 
 	// Images under docker-images/
 	"cadvisor",
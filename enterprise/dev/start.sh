@@ -39,7 +39,7 @@ export PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL=http://localhost:3187
 export PRECISE_CODE_INTEL_BUNDLE_DIR=$HOME/.sourcegraph/lsif-storage
 
 export WATCH_ADDITIONAL_GO_DIRS="enterprise/cmd enterprise/dev enterprise/internal"
-export ENTERPRISE_ONLY_COMMANDS=" precise-code-intel-bundle-manager precise-code-intel-indexer precise-code-intel-worker "
+export ENTERPRISE_ONLY_COMMANDS=" campaign-executor precise-code-intel-bundle-manager precise-code-intel-indexer precise-code-intel-worker "
 export ENTERPRISE_COMMANDS="frontend repo-updater ${ENTERPRISE_ONLY_COMMANDS}"
 export ENTERPRISE=1
 export PROCFILE=enterprise/dev/Procfile
@@ -0,0 +1,83 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// autoMergeChangeset merges the given changeset on the code host if it was
+// created by a campaign whose changeset template has autoMerge enabled and
+// it is open, its checks have passed and it has been approved.
+//
+// It returns true if the changeset was merged. Errors are logged and not
+// returned, since a changeset that can't be merged yet (because of branch
+// protection rules, for example) shouldn't make the sync fail.
+func autoMergeChangeset(ctx context.Context, store SyncStore, source repos.ChangesetSource, c *repos.Changeset) bool {
+	if !isAutoMergeCandidate(c.Changeset) {
+		return false
+	}
+
+	enabled, err := autoMergeEnabled(ctx, store, c.Changeset)
+	if err != nil {
+		log15.Warn("checking whether changeset should be auto-merged", "changeset_id", c.Changeset.ID, "err", err)
+		return false
+	}
+	if !enabled {
+		return false
+	}
+
+	if err := source.MergeChangeset(ctx, c); err != nil {
+		log15.Warn("auto-merging changeset", "changeset_id", c.Changeset.ID, "err", err)
+		return false
+	}
+
+	return true
+}
+
+// isAutoMergeCandidate returns true if the changeset is in a state in which
+// it can be auto-merged: open, not a draft, passing checks, approved and not
+// conflicting with its base branch.
+func isAutoMergeCandidate(c *campaigns.Changeset) bool {
+	return c.CreatedByCampaign &&
+		!c.IsDeleted() &&
+		c.ExternalState == campaigns.ChangesetExternalStateOpen &&
+		c.ExternalCheckState == campaigns.ChangesetCheckStatePassed &&
+		c.ExternalReviewState == campaigns.ChangesetReviewStateApproved &&
+		c.ExternalMergeableState != campaigns.ChangesetMergeableStateConflicting
+}
+
+// autoMergeEnabled returns true if one of the open campaigns the changeset
+// belongs to has autoMerge enabled in the changeset template of its spec.
+func autoMergeEnabled(ctx context.Context, store SyncStore, c *campaigns.Changeset) (bool, error) {
+	cs, _, err := store.ListCampaigns(ctx, ListCampaignsOpts{
+		ChangesetID: c.ID,
+		State:       campaigns.CampaignStateOpen,
+		Limit:       -1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, campaign := range cs {
+		if campaign.CampaignSpecID == 0 {
+			continue
+		}
+
+		spec, err := store.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: campaign.CampaignSpecID})
+		if err != nil {
+			if err == ErrNoResults {
+				continue
+			}
+			return false, err
+		}
+
+		if spec.Spec.ChangesetTemplate.AutoMerge {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
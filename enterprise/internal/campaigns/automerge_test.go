@@ -0,0 +1,135 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestAutoMergeChangeset(t *testing.T) {
+	mergeable := func() *campaigns.Changeset {
+		return &campaigns.Changeset{
+			ID:                  1,
+			CreatedByCampaign:   true,
+			ExternalState:       campaigns.ChangesetExternalStateOpen,
+			ExternalCheckState:  campaigns.ChangesetCheckStatePassed,
+			ExternalReviewState: campaigns.ChangesetReviewStateApproved,
+		}
+	}
+
+	storeWithAutoMerge := func(autoMerge bool) MockSyncStore {
+		return MockSyncStore{
+			listCampaigns: func(ctx context.Context, opts ListCampaignsOpts) ([]*campaigns.Campaign, int64, error) {
+				if opts.ChangesetID != 1 || opts.State != campaigns.CampaignStateOpen {
+					return nil, 0, errors.Errorf("unexpected opts: %+v", opts)
+				}
+				return []*campaigns.Campaign{{ID: 1}, {ID: 2, CampaignSpecID: 3}}, 0, nil
+			},
+			getCampaignSpec: func(ctx context.Context, opts GetCampaignSpecOpts) (*campaigns.CampaignSpec, error) {
+				if opts.ID != 3 {
+					return nil, ErrNoResults
+				}
+				return &campaigns.CampaignSpec{
+					ID: 3,
+					Spec: campaigns.CampaignSpecFields{
+						ChangesetTemplate: campaigns.ChangesetTemplate{AutoMerge: autoMerge},
+					},
+				}, nil
+			},
+		}
+	}
+
+	tcs := []struct {
+		name       string
+		changeset  func() *campaigns.Changeset
+		store      MockSyncStore
+		sourceErr  error
+		wantMerged bool
+	}{
+		{
+			name:       "mergeable and autoMerge enabled",
+			changeset:  mergeable,
+			store:      storeWithAutoMerge(true),
+			wantMerged: true,
+		},
+		{
+			name:      "mergeable and autoMerge disabled",
+			changeset: mergeable,
+			store:     storeWithAutoMerge(false),
+		},
+		{
+			name: "draft",
+			changeset: func() *campaigns.Changeset {
+				c := mergeable()
+				c.ExternalState = campaigns.ChangesetExternalStateDraft
+				return c
+			},
+			store: storeWithAutoMerge(true),
+		},
+		{
+			name: "checks pending",
+			changeset: func() *campaigns.Changeset {
+				c := mergeable()
+				c.ExternalCheckState = campaigns.ChangesetCheckStatePending
+				return c
+			},
+			store: storeWithAutoMerge(true),
+		},
+		{
+			name: "changes requested",
+			changeset: func() *campaigns.Changeset {
+				c := mergeable()
+				c.ExternalReviewState = campaigns.ChangesetReviewStateChangesRequested
+				return c
+			},
+			store: storeWithAutoMerge(true),
+		},
+		{
+			name: "conflicting",
+			changeset: func() *campaigns.Changeset {
+				c := mergeable()
+				c.ExternalMergeableState = campaigns.ChangesetMergeableStateConflicting
+				return c
+			},
+			store: storeWithAutoMerge(true),
+		},
+		{
+			name: "not created by campaign",
+			changeset: func() *campaigns.Changeset {
+				c := mergeable()
+				c.CreatedByCampaign = false
+				return c
+			},
+			store: storeWithAutoMerge(true),
+		},
+		{
+			name:      "merge fails",
+			changeset: mergeable,
+			store:     storeWithAutoMerge(true),
+			sourceErr: errors.New("branch protection rules not satisfied"),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			source := &ct.FakeChangesetSource{Err: tc.sourceErr}
+			c := &repos.Changeset{Changeset: tc.changeset()}
+
+			if have, want := autoMergeChangeset(context.Background(), tc.store, source, c), tc.wantMerged; have != want {
+				t.Fatalf("wrong result. have=%t, want=%t", have, want)
+			}
+
+			wantMergedCount := 0
+			if tc.wantMerged {
+				wantMergedCount = 1
+			}
+			if have, want := len(source.MergedChangesets), wantMergedCount; have != want {
+				t.Fatalf("wrong number of merged changesets. have=%d, want=%d", have, want)
+			}
+		})
+	}
+}
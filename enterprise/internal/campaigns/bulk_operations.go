@@ -0,0 +1,358 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// ErrBulkOperationCommentBlank is returned by CreateChangesetBulkOperation if
+// a comment operation is created without a comment.
+var ErrBulkOperationCommentBlank = errors.New("comment cannot be blank")
+
+// ErrBulkOperationNoChangesets is returned by CreateChangesetBulkOperation if
+// none of the changesets of the campaign match the given filters.
+var ErrBulkOperationNoChangesets = errors.New("no changesets match the given filters")
+
+// CreateChangesetBulkOperationOpts captures the options for creating a
+// ChangesetBulkOperation. The filters select which changesets of the campaign
+// the operation runs on. Unset filters match all changesets.
+type CreateChangesetBulkOperationOpts struct {
+	CampaignID int64
+	Operation  campaigns.ChangesetBulkOperationType
+	Comment    string
+
+	ExternalState       *campaigns.ChangesetExternalState
+	ExternalReviewState *campaigns.ChangesetReviewState
+	ExternalCheckState  *campaigns.ChangesetCheckState
+	RepoIDs             []api.RepoID
+}
+
+func (o CreateChangesetBulkOperationOpts) String() string {
+	return fmt.Sprintf("CampaignID %d, Operation %s", o.CampaignID, o.Operation)
+}
+
+// CreateChangesetBulkOperation creates a ChangesetBulkOperation and enqueues
+// a ChangesetBulkOperationJob for each changeset of the campaign that matches
+// the filters and is in a repository the actor in the context can access.
+func (s *Service) CreateChangesetBulkOperation(ctx context.Context, opts CreateChangesetBulkOperationOpts) (op *campaigns.ChangesetBulkOperation, err error) {
+	tr, ctx := trace.New(ctx, "Service.CreateChangesetBulkOperation", opts.String())
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !opts.Operation.Valid() {
+		return nil, errors.Errorf("invalid bulk operation %q", opts.Operation)
+	}
+
+	if opts.Operation == campaigns.ChangesetBulkOperationComment && strings.TrimSpace(opts.Comment) == "" {
+		return nil, ErrBulkOperationCommentBlank
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: opts.CampaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the author of the campaign and site admins can run
+	// bulk operations on its changesets.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.AuthorID); err != nil {
+		return nil, err
+	}
+
+	cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID:          campaign.ID,
+		Limit:               -1,
+		WithoutDeleted:      true,
+		ExternalState:       opts.ExternalState,
+		ExternalReviewState: opts.ExternalReviewState,
+		ExternalCheckState:  opts.ExternalCheckState,
+		RepoIDs:             opts.RepoIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to. We don't
+	// touch changesets in those repositories.
+	rs, err := db.Repos.GetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	accessible := make(map[api.RepoID]struct{}, len(rs))
+	for _, r := range rs {
+		accessible[r.ID] = struct{}{}
+	}
+
+	var changesetIDs []int64
+	for _, c := range cs {
+		if _, ok := accessible[c.RepoID]; ok {
+			changesetIDs = append(changesetIDs, c.ID)
+		}
+	}
+
+	if len(changesetIDs) == 0 {
+		return nil, ErrBulkOperationNoChangesets
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	op = &campaigns.ChangesetBulkOperation{
+		CampaignID: campaign.ID,
+		UserID:     actor.FromContext(ctx).UID,
+		Operation:  opts.Operation,
+		Comment:    opts.Comment,
+	}
+	if err := tx.CreateChangesetBulkOperation(ctx, op); err != nil {
+		return nil, err
+	}
+
+	for _, id := range changesetIDs {
+		job := &campaigns.ChangesetBulkOperationJob{
+			BulkOperationID: op.ID,
+			ChangesetID:     id,
+		}
+		if err := tx.CreateChangesetBulkOperationJob(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+// BulkOperationMetrics are the metrics of the worker and resetter returned by
+// NewBulkOperationWorker and NewBulkOperationResetter.
+type BulkOperationMetrics struct {
+	Worker   workerutil.WorkerMetrics
+	Resetter workerutil.ResetterMetrics
+}
+
+// NewBulkOperationMetrics creates and registers the metrics of the bulk
+// operation worker and resetter.
+func NewBulkOperationMetrics(observationContext *observation.Context) BulkOperationMetrics {
+	handleMetrics := metrics.NewOperationMetrics(
+		observationContext.Registerer,
+		"changeset_bulk_operation_job_handler",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of results returned"),
+	)
+
+	resets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_changeset_bulk_operation_job_queue_reset_total",
+		Help: "Total number of changeset bulk operation jobs put back into queued state",
+	})
+	observationContext.Registerer.MustRegister(resets)
+
+	resetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_changeset_bulk_operation_job_queue_max_resets_total",
+		Help: "Total number of changeset bulk operation jobs that exceed the max number of resets",
+	})
+	observationContext.Registerer.MustRegister(resetFailures)
+
+	resetErrors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_changeset_bulk_operation_job_queue_reset_errors_total",
+		Help: "Total number of errors when running the changeset bulk operation job resetter",
+	})
+	observationContext.Registerer.MustRegister(resetErrors)
+
+	return BulkOperationMetrics{
+		Worker: workerutil.WorkerMetrics{
+			HandleOperation: observationContext.Operation(observation.Op{
+				Name:         "BulkOperationHandler.Handle",
+				MetricLabels: []string{"handle"},
+				Metrics:      handleMetrics,
+			}),
+		},
+		Resetter: workerutil.ResetterMetrics{
+			RecordResets:        resets,
+			RecordResetFailures: resetFailures,
+			Errors:              resetErrors,
+		},
+	}
+}
+
+const (
+	bulkOperationNumHandlers   = 4
+	bulkOperationPollInterval  = 1 * time.Second
+	bulkOperationResetInterval = 1 * time.Minute
+)
+
+// NewBulkOperationWorker returns a workerutil.Worker that executes queued
+// ChangesetBulkOperationJobs through the ChangesetSources of the code hosts.
+func NewBulkOperationWorker(ctx context.Context, s *Store, sourcer repos.Sourcer, metrics BulkOperationMetrics) *workerutil.Worker {
+	rootContext := actor.WithActor(ctx, &actor.Actor{Internal: true})
+
+	h := &bulkOperationHandler{store: s, sourcer: sourcer}
+
+	return workerutil.NewWorker(rootContext, WorkerutilChangesetBulkOperationJobStore(s), workerutil.WorkerOptions{
+		Name:        "changeset bulk operation worker",
+		Handler:     h,
+		NumHandlers: bulkOperationNumHandlers,
+		Interval:    bulkOperationPollInterval,
+		Metrics:     metrics.Worker,
+	})
+}
+
+// NewBulkOperationResetter returns a workerutil.Resetter that puts
+// ChangesetBulkOperationJobs whose worker died back into the queue.
+func NewBulkOperationResetter(s *Store, metrics BulkOperationMetrics) *workerutil.Resetter {
+	return workerutil.NewResetter(WorkerutilChangesetBulkOperationJobStore(s), workerutil.ResetterOptions{
+		Name:     "changeset bulk operation job resetter",
+		Interval: bulkOperationResetInterval,
+		Metrics:  metrics.Resetter,
+	})
+}
+
+type bulkOperationHandler struct {
+	store   *Store
+	sourcer repos.Sourcer
+}
+
+var _ workerutil.Handler = &bulkOperationHandler{}
+
+// Handle runs the bulk operation of the job on its changeset. A returned
+// error marks the job as errored and becomes its failure message.
+func (h *bulkOperationHandler) Handle(ctx context.Context, tx workerutil.Store, record workerutil.Record) error {
+	job := record.(*campaigns.ChangesetBulkOperationJob)
+	store := h.store.With(tx)
+
+	op, err := store.GetChangesetBulkOperation(ctx, GetChangesetBulkOperationOpts{ID: job.BulkOperationID})
+	if err != nil {
+		return errors.Wrap(err, "loading bulk operation")
+	}
+
+	c, err := store.GetChangeset(ctx, GetChangesetOpts{ID: job.ChangesetID})
+	if err != nil {
+		return errors.Wrap(err, "loading changeset")
+	}
+
+	return runBulkOperation(ctx, store, h.sourcer, op, c)
+}
+
+// runBulkOperation runs the given operation on the changeset on its code host
+// and then syncs the changeset, so that its new state is stored right away.
+func runBulkOperation(ctx context.Context, store *Store, sourcer repos.Sourcer, op *campaigns.ChangesetBulkOperation, c *campaigns.Changeset) error {
+	reposStore := repos.NewDBStore(store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, nil, sourcer, c)
+	if err != nil {
+		return err
+	}
+
+	var (
+		source    repos.ChangesetSource
+		changeset *repos.Changeset
+	)
+	for _, group := range bySource {
+		if len(group.Changesets) > 0 {
+			source, changeset = group.ChangesetSource, group.Changesets[0]
+		}
+	}
+	if changeset == nil {
+		return errors.New("no code host connection found for the repository of the changeset")
+	}
+
+	switch op.Operation {
+	case campaigns.ChangesetBulkOperationComment:
+		if err := source.CreateComment(ctx, changeset, op.Comment); err != nil {
+			return errors.Wrap(err, "commenting on changeset")
+		}
+
+	case campaigns.ChangesetBulkOperationClose:
+		if !c.IsOpen() {
+			return errors.New("changeset is not open")
+		}
+		if err := source.CloseChangeset(ctx, changeset); err != nil {
+			return errors.Wrap(err, "closing changeset")
+		}
+
+	case campaigns.ChangesetBulkOperationRepublish:
+		if err := republishChangeset(ctx, store, source, op.CampaignID, changeset); err != nil {
+			return err
+		}
+
+	case campaigns.ChangesetBulkOperationSync:
+		// Syncing is done below for all operations.
+
+	default:
+		return errors.Errorf("unknown bulk operation %q", op.Operation)
+	}
+
+	return syncChangesetsWithSources(ctx, store, bySource)
+}
+
+// republishChangeset updates the title, body and base branch of a changeset
+// created by the campaign with the values in the changeset spec of the
+// current campaign spec that has the same repository and head branch.
+func republishChangeset(ctx context.Context, store *Store, source repos.ChangesetSource, campaignID int64, c *repos.Changeset) error {
+	if !c.Changeset.CreatedByCampaign {
+		return errors.New("only changesets created by a campaign can be republished")
+	}
+	if !c.Changeset.IsOpen() {
+		return errors.New("changeset is not open")
+	}
+
+	campaign, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return errors.Wrap(err, "loading campaign")
+	}
+	if campaign.CampaignSpecID == 0 {
+		return errors.New("campaign has no campaign spec")
+	}
+
+	headRef, err := c.Changeset.HeadRef()
+	if err != nil {
+		return err
+	}
+
+	specs, _, err := store.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{
+		CampaignSpecID: campaign.CampaignSpecID,
+		Limit:          -1,
+	})
+	if err != nil {
+		return errors.Wrap(err, "loading changeset specs")
+	}
+
+	for _, spec := range specs {
+		if spec.RepoID != c.Changeset.RepoID || spec.Spec.IsExisting() {
+			continue
+		}
+		if git.AbbreviateRef(spec.Spec.HeadRef) != git.AbbreviateRef(headRef) {
+			continue
+		}
+
+		c.Title = spec.Spec.Title
+		c.Body = spec.Spec.Body
+		c.BaseRef = git.EnsureRefPrefix(spec.Spec.BaseRef)
+		c.HeadRef = git.EnsureRefPrefix(spec.Spec.HeadRef)
+
+		if err := source.UpdateChangeset(ctx, c); err != nil {
+			return errors.Wrap(err, "updating changeset")
+		}
+		return nil
+	}
+
+	return errors.New("no changeset spec for the changeset found in the current campaign spec")
+}
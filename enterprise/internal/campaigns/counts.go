@@ -120,3 +120,79 @@ func generateTimestamps(start, end time.Time) []time.Time {
 
 	return ts
 }
+
+// ChangesetTimeline captures the points in time at which a Changeset reached
+// the milestones tracked by the burndown chart.
+type ChangesetTimeline struct {
+	ChangesetID int64
+
+	OpenedAt time.Time
+	// FirstReviewedAt is the time of the first review that approved the
+	// changeset or requested changes. It's zero if the changeset was never
+	// reviewed.
+	FirstReviewedAt time.Time
+	// MergedAt is zero if the changeset hasn't been merged.
+	MergedAt time.Time
+	// ClosedAt is the time the changeset was last closed without being
+	// merged. It's zero if the changeset isn't closed.
+	ClosedAt time.Time
+}
+
+// TimeToMerge returns the duration between the changeset being opened and
+// merged, or zero if it hasn't been merged.
+func (t *ChangesetTimeline) TimeToMerge() time.Duration {
+	if t.MergedAt.IsZero() {
+		return 0
+	}
+	return t.MergedAt.Sub(t.OpenedAt)
+}
+
+// CalcTimelines calculates a ChangesetTimeline for each of the given
+// Changesets from their ChangesetEvents. The timelines are returned in the
+// order of the Changesets.
+func CalcTimelines(cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) ([]*ChangesetTimeline, error) {
+	events := ChangesetEvents(es)
+	sort.Sort(events)
+
+	byChangesetID := make(map[int64]ChangesetEvents)
+	for _, e := range events {
+		id := e.Changeset()
+		byChangesetID[id] = append(byChangesetID[id], e)
+	}
+
+	timelines := make([]*ChangesetTimeline, 0, len(cs))
+	for _, c := range cs {
+		history, err := computeHistory(c, byChangesetID[c.ID])
+		if err != nil {
+			return timelines, err
+		}
+
+		t := &ChangesetTimeline{ChangesetID: c.ID}
+		for i, s := range history {
+			if i == 0 {
+				t.OpenedAt = s.t
+			}
+
+			if t.FirstReviewedAt.IsZero() &&
+				(s.reviewState == campaigns.ChangesetReviewStateApproved ||
+					s.reviewState == campaigns.ChangesetReviewStateChangesRequested) {
+				t.FirstReviewedAt = s.t
+			}
+
+			switch s.externalState {
+			case campaigns.ChangesetExternalStateMerged:
+				if t.MergedAt.IsZero() {
+					t.MergedAt = s.t
+				}
+			case campaigns.ChangesetExternalStateClosed:
+				t.ClosedAt = s.t
+			default:
+				t.ClosedAt = time.Time{}
+			}
+		}
+
+		timelines = append(timelines, t)
+	}
+
+	return timelines, nil
+}
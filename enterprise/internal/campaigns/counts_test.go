@@ -1224,6 +1224,85 @@ func TestCalcCounts(t *testing.T) {
 	}
 }
 
+func TestCalcTimelines(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name       string
+		changesets []*campaigns.Changeset
+		events     []*campaigns.ChangesetEvent
+		want       []*ChangesetTimeline
+	}{
+		{
+			name: "open",
+			changesets: []*campaigns.Changeset{
+				ghChangeset(1, daysAgo(2)),
+			},
+			want: []*ChangesetTimeline{
+				{ChangesetID: 1, OpenedAt: daysAgo(2)},
+			},
+		},
+		{
+			name: "reviewed and merged",
+			changesets: []*campaigns.Changeset{
+				ghChangeset(1, daysAgo(5)),
+			},
+			events: []*campaigns.ChangesetEvent{
+				ghReview(1, daysAgo(4), "user1", "CHANGES_REQUESTED"),
+				ghReview(1, daysAgo(3), "user1", "APPROVED"),
+				event(t, daysAgo(1), campaigns.ChangesetEventKindGitHubMerged, 1),
+			},
+			want: []*ChangesetTimeline{
+				{ChangesetID: 1, OpenedAt: daysAgo(5), FirstReviewedAt: daysAgo(4), MergedAt: daysAgo(1)},
+			},
+		},
+		{
+			name: "closed, reopened and closed",
+			changesets: []*campaigns.Changeset{
+				ghChangeset(1, daysAgo(5)),
+				ghChangeset(2, daysAgo(4)),
+			},
+			events: []*campaigns.ChangesetEvent{
+				event(t, daysAgo(3), campaigns.ChangesetEventKindGitHubClosed, 1),
+				event(t, daysAgo(2), campaigns.ChangesetEventKindGitHubReopened, 1),
+				event(t, daysAgo(1), campaigns.ChangesetEventKindGitHubClosed, 1),
+				event(t, daysAgo(3), campaigns.ChangesetEventKindGitHubClosed, 2),
+				event(t, daysAgo(2), campaigns.ChangesetEventKindGitHubReopened, 2),
+			},
+			want: []*ChangesetTimeline{
+				{ChangesetID: 1, OpenedAt: daysAgo(5), ClosedAt: daysAgo(1)},
+				{ChangesetID: 2, OpenedAt: daysAgo(4)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := CalcTimelines(tc.changesets, tc.events...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Errorf("wrong timelines calculated. diff=%s", diff)
+			}
+		})
+	}
+
+	t.Run("TimeToMerge", func(t *testing.T) {
+		tl := &ChangesetTimeline{OpenedAt: daysAgo(3), MergedAt: daysAgo(1)}
+		if have, want := tl.TimeToMerge(), now.Sub(daysAgo(2)); have != want {
+			t.Errorf("wrong time to merge. want=%s, have=%s", want, have)
+		}
+
+		tl.MergedAt = time.Time{}
+		if have := tl.TimeToMerge(); have != 0 {
+			t.Errorf("wrong time to merge for unmerged changeset. want=0, have=%s", have)
+		}
+	})
+}
+
 func ghChangeset(id int64, t time.Time) *campaigns.Changeset {
 	return &campaigns.Changeset{ID: id, Metadata: &github.PullRequest{CreatedAt: t}}
 }
@@ -0,0 +1,371 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// ExportHandler serves the burndown time series and the per-changeset
+// history of a campaign as CSV (the default) or as JSON if the "format" query
+// parameter is "json".
+//
+// It expects the mux variables "Campaign" (the GraphQL ID of the campaign)
+// and "Export" (either "burndown" or "changesets").
+type ExportHandler struct {
+	store *Store
+	now   func() time.Time
+}
+
+// NewExportHandler returns a new ExportHandler.
+func NewExportHandler(store *Store, now func() time.Time) *ExportHandler {
+	return &ExportHandler{store: store, now: now}
+}
+
+// BurndownExportRow is a row of the burndown export. It holds the changeset
+// counts of the campaign at a point in time.
+type BurndownExportRow struct {
+	Date                 time.Time `json:"date"`
+	Total                int32     `json:"total"`
+	Merged               int32     `json:"merged"`
+	Closed               int32     `json:"closed"`
+	Open                 int32     `json:"open"`
+	OpenApproved         int32     `json:"openApproved"`
+	OpenChangesRequested int32     `json:"openChangesRequested"`
+	OpenPending          int32     `json:"openPending"`
+}
+
+var burndownExportHeader = []string{
+	"date", "total", "merged", "closed", "open", "open_approved", "open_changes_requested", "open_pending",
+}
+
+func (r *BurndownExportRow) csvRecord() []string {
+	return []string{
+		formatExportTime(r.Date),
+		strconv.Itoa(int(r.Total)),
+		strconv.Itoa(int(r.Merged)),
+		strconv.Itoa(int(r.Closed)),
+		strconv.Itoa(int(r.Open)),
+		strconv.Itoa(int(r.OpenApproved)),
+		strconv.Itoa(int(r.OpenChangesRequested)),
+		strconv.Itoa(int(r.OpenPending)),
+	}
+}
+
+// ChangesetExportRow is a row of the changesets export. It holds the current
+// state and the history of a changeset of the campaign.
+type ChangesetExportRow struct {
+	ChangesetID        int64      `json:"changesetID"`
+	Repository         string     `json:"repository"`
+	ExternalID         string     `json:"externalID"`
+	URL                string     `json:"url"`
+	Title              string     `json:"title"`
+	State              string     `json:"state"`
+	ReviewState        string     `json:"reviewState"`
+	CheckState         string     `json:"checkState"`
+	OpenedAt           time.Time  `json:"openedAt"`
+	FirstReviewedAt    *time.Time `json:"firstReviewedAt"`
+	MergedAt           *time.Time `json:"mergedAt"`
+	ClosedAt           *time.Time `json:"closedAt"`
+	TimeToMergeSeconds *int64     `json:"timeToMergeSeconds"`
+}
+
+var changesetExportHeader = []string{
+	"changeset_id", "repository", "external_id", "url", "title", "state", "review_state", "check_state",
+	"opened_at", "first_reviewed_at", "merged_at", "closed_at", "time_to_merge_seconds",
+}
+
+func (r *ChangesetExportRow) csvRecord() []string {
+	formatOptional := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return formatExportTime(*t)
+	}
+
+	var timeToMerge string
+	if r.TimeToMergeSeconds != nil {
+		timeToMerge = strconv.FormatInt(*r.TimeToMergeSeconds, 10)
+	}
+
+	return []string{
+		strconv.FormatInt(r.ChangesetID, 10),
+		r.Repository,
+		r.ExternalID,
+		r.URL,
+		r.Title,
+		r.State,
+		r.ReviewState,
+		r.CheckState,
+		formatExportTime(r.OpenedAt),
+		formatOptional(r.FirstReviewedAt),
+		formatOptional(r.MergedAt),
+		formatOptional(r.ClosedAt),
+		timeToMerge,
+	}
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
+	if !conf.CampaignsReadAccessEnabled() {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+			respond(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	vars := mux.Vars(r)
+
+	campaignID, err := campaigns.UnmarshalCampaignID(graphql.ID(vars["Campaign"]))
+	if err != nil || campaignID == 0 {
+		respond(w, http.StatusBadRequest, errors.New("invalid campaign ID"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respond(w, http.StatusBadRequest, errors.Errorf("unsupported format %q", format))
+		return
+	}
+
+	campaign, err := h.store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		if err == ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("campaign not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch vars["Export"] {
+	case "burndown":
+		start, end, err := h.burndownTimeframe(r, campaign)
+		if err != nil {
+			respond(w, http.StatusBadRequest, err)
+			return
+		}
+
+		rows, err := h.burndownRows(ctx, campaign, start, end)
+		if err != nil {
+			respond(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if format == "json" {
+			respond(w, http.StatusOK, rows)
+			return
+		}
+
+		records := make([][]string, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, row.csvRecord())
+		}
+		writeCSV(w, fmt.Sprintf("campaign-%d-burndown.csv", campaign.ID), burndownExportHeader, records)
+
+	case "changesets":
+		rows, err := h.changesetRows(ctx, campaign)
+		if err != nil {
+			respond(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if format == "json" {
+			respond(w, http.StatusOK, rows)
+			return
+		}
+
+		records := make([][]string, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, row.csvRecord())
+		}
+		writeCSV(w, fmt.Sprintf("campaign-%d-changesets.csv", campaign.ID), changesetExportHeader, records)
+
+	default:
+		respond(w, http.StatusNotFound, errors.Errorf("unknown export %q", vars["Export"]))
+	}
+}
+
+// burndownTimeframe returns the timeframe of the burndown export from the
+// "from" and "to" query parameters. The defaults are the same as the ones of
+// the burndown chart: from the creation of the campaign, but at least a week,
+// until now.
+func (h *ExportHandler) burndownTimeframe(r *http.Request, campaign *campaigns.Campaign) (start, end time.Time, err error) {
+	now := h.now().UTC()
+
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	start = campaign.CreatedAt.UTC()
+	if start.After(weekAgo) {
+		start = weekAgo
+	}
+	if from := r.URL.Query().Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return start, end, errors.Wrap(err, "parsing from")
+		}
+		start = t.UTC()
+	}
+
+	end = now
+	if to := r.URL.Query().Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return start, end, errors.Wrap(err, "parsing to")
+		}
+		if t.Before(end) {
+			end = t.UTC()
+		}
+	}
+
+	return start, end, nil
+}
+
+func (h *ExportHandler) burndownRows(ctx context.Context, campaign *campaigns.Campaign, start, end time.Time) ([]*BurndownExportRow, error) {
+	cs, _, err := h.store.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaign.ID, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	es, _, err := h.store.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := CalcCounts(start, end, cs, es...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*BurndownExportRow, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, &BurndownExportRow{
+			Date:                 c.Time,
+			Total:                c.Total,
+			Merged:               c.Merged,
+			Closed:               c.Closed,
+			Open:                 c.Open,
+			OpenApproved:         c.OpenApproved,
+			OpenChangesRequested: c.OpenChangesRequested,
+			OpenPending:          c.OpenPending,
+		})
+	}
+
+	return rows, nil
+}
+
+func (h *ExportHandler) changesetRows(ctx context.Context, campaign *campaigns.Campaign) ([]*ChangesetExportRow, error) {
+	cs, _, err := h.store.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaign.ID, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to. The
+	// changesets in those repositories are left out of the export.
+	rs, err := db.Repos.GetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	repoNames := make(map[api.RepoID]string, len(rs))
+	for _, r := range rs {
+		repoNames[r.ID] = string(r.Name)
+	}
+
+	cs = cs.Filter(func(c *campaigns.Changeset) bool {
+		_, ok := repoNames[c.RepoID]
+		return ok
+	})
+
+	es, _, err := h.store.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	timelines, err := CalcTimelines(cs, es...)
+	if err != nil {
+		return nil, err
+	}
+
+	optionalTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+
+	rows := make([]*ChangesetExportRow, 0, len(cs))
+	for i, c := range cs {
+		title, err := c.Title()
+		if err != nil {
+			return nil, err
+		}
+
+		url, err := c.URL()
+		if err != nil {
+			return nil, err
+		}
+
+		tl := timelines[i]
+		row := &ChangesetExportRow{
+			ChangesetID:     c.ID,
+			Repository:      repoNames[c.RepoID],
+			ExternalID:      c.ExternalID,
+			URL:             url,
+			Title:           title,
+			State:           string(c.ExternalState),
+			ReviewState:     string(c.ExternalReviewState),
+			CheckState:      string(c.ExternalCheckState),
+			OpenedAt:        tl.OpenedAt,
+			FirstReviewedAt: optionalTime(tl.FirstReviewedAt),
+			MergedAt:        optionalTime(tl.MergedAt),
+			ClosedAt:        optionalTime(tl.ClosedAt),
+		}
+		if !tl.MergedAt.IsZero() {
+			seconds := int64(tl.TimeToMerge() / time.Second)
+			row.TimeToMergeSeconds = &seconds
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func writeCSV(w http.ResponseWriter, filename string, header []string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		log15.Error("failed to write CSV header", "error", err)
+		return
+	}
+	if err := cw.WriteAll(records); err != nil {
+		log15.Error("failed to write CSV records", "error", err)
+	}
+}
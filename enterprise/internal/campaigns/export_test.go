@@ -0,0 +1,201 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExportHandler(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	readAccessEnabled := false
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CampaignsReadAccessEnabled: &readAccessEnabled,
+	}})
+	defer conf.Mock(nil)
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	clock := func() time.Time { return now }
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	admin := createTestUser(ctx, t)
+	if !admin.SiteAdmin {
+		t.Fatal("admin is not a site-admin")
+	}
+
+	user := createTestUser(ctx, t)
+	if user.SiteAdmin {
+		t.Fatal("user is admin, want non-admin")
+	}
+
+	var rs []*repos.Repo
+	for i := 0; i < 2; i++ {
+		rs = append(rs, testRepo(i, extsvc.TypeGitHub))
+	}
+
+	reposStore := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
+	if err := reposStore.UpsertRepos(ctx, rs...); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	campaign := testCampaign(admin.ID)
+	if err := store.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	merged := testChangeset(rs[0].ID, campaign.ID, 1, campaigns.ChangesetExternalStateMerged)
+	merged.Metadata = &github.PullRequest{
+		Title:     "Merged PR",
+		URL:       "https://github.com/sourcegraph/sourcegraph/pull/1",
+		State:     "MERGED",
+		CreatedAt: daysAgo(3),
+	}
+	hidden := testChangeset(rs[1].ID, campaign.ID, 2, campaigns.ChangesetExternalStateOpen)
+	hidden.Metadata = &github.PullRequest{
+		Title:     "Hidden PR",
+		State:     "OPEN",
+		CreatedAt: daysAgo(2),
+	}
+	if err := store.CreateChangesets(ctx, merged, hidden); err != nil {
+		t.Fatal(err)
+	}
+
+	reviewed := ghReview(merged.ID, daysAgo(2), "reviewer", "APPROVED")
+	reviewed.Key = "review"
+	mergedEvent := &campaigns.ChangesetEvent{
+		ChangesetID: merged.ID,
+		Kind:        campaigns.ChangesetEventKindGitHubMerged,
+		Key:         "merged",
+		Metadata:    &github.MergedEvent{CreatedAt: daysAgo(1)},
+	}
+	if err := store.UpsertChangesetEvents(ctx, reviewed, mergedEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	// The repository of hidden is filtered out by the authzFilter
+	ct.AuthzFilterRepos(t, rs[1].ID)
+
+	router := mux.NewRouter()
+	router.Path("/campaigns/{Campaign}/export/{Export:burndown|changesets}").Handler(NewExportHandler(store, clock))
+
+	serve := func(t *testing.T, userID int32, path string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := httptest.NewRequest("GET", path, nil)
+		req = req.WithContext(actor.WithActor(context.Background(), actor.FromUser(userID)))
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	campaignPath := fmt.Sprintf("/campaigns/%s/export", campaigns.MarshalCampaignID(campaign.ID))
+
+	t.Run("burndown CSV", func(t *testing.T) {
+		rec := serve(t, admin.ID, campaignPath+"/burndown?from="+daysAgo(3).Format(time.RFC3339))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("wrong status code %d: %s", rec.Code, rec.Body.String())
+		}
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		date := func(days int) string { return daysAgo(days).Format(time.RFC3339) }
+		want := [][]string{
+			burndownExportHeader,
+			{date(3), "1", "0", "0", "1", "0", "0", "1"},
+			{date(2), "2", "0", "0", "2", "1", "0", "1"},
+			{date(1), "2", "1", "0", "1", "0", "0", "1"},
+			{date(0), "2", "1", "0", "1", "0", "0", "1"},
+		}
+		if diff := cmp.Diff(records, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("changesets JSON", func(t *testing.T) {
+		rec := serve(t, admin.ID, campaignPath+"/changesets?format=json")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("wrong status code %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var have []*ChangesetExportRow
+		if err := json.NewDecoder(rec.Body).Decode(&have); err != nil {
+			t.Fatal(err)
+		}
+
+		reviewedAt, mergedAt := daysAgo(2), daysAgo(1)
+		timeToMerge := int64((2 * 24 * time.Hour) / time.Second)
+
+		// The changeset in the hidden repository is left out
+		want := []*ChangesetExportRow{
+			{
+				ChangesetID:        merged.ID,
+				Repository:         string(rs[0].Name),
+				ExternalID:         merged.ExternalID,
+				URL:                "https://github.com/sourcegraph/sourcegraph/pull/1",
+				Title:              "Merged PR",
+				State:              "MERGED",
+				OpenedAt:           daysAgo(3),
+				FirstReviewedAt:    &reviewedAt,
+				MergedAt:           &mergedAt,
+				TimeToMergeSeconds: &timeToMerge,
+			},
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		rec := serve(t, admin.ID, campaignPath+"/changesets?format=xml")
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("wrong status code %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("unknown campaign", func(t *testing.T) {
+		path := fmt.Sprintf("/campaigns/%s/export/burndown", campaigns.MarshalCampaignID(campaign.ID+1000))
+		rec := serve(t, admin.ID, path)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("wrong status code %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("non-admin without read access", func(t *testing.T) {
+		rec := serve(t, user.ID, campaignPath+"/burndown")
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("wrong status code %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -29,6 +29,8 @@ func TestIntegration(t *testing.T) {
 		t.Run("ListChangesetSyncData", storeTest(db, testStoreListChangesetSyncData))
 		t.Run("CampaignSpecs", storeTest(db, testStoreCampaignSpecs))
 		t.Run("ChangesetSpecs", storeTest(db, testStoreChangesetSpecs))
+		t.Run("CampaignSpecJobs", storeTest(db, testStoreCampaignSpecJobs))
+		t.Run("ChangesetBulkOperations", storeTest(db, testStoreChangesetBulkOperations))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
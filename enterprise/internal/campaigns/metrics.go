@@ -0,0 +1,63 @@
+package campaigns
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var campaignMetrics = struct {
+	changesets *prometheus.GaugeVec
+}{}
+
+func init() {
+	campaignMetrics.changesets = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_campaigns_changesets",
+		Help: "The number of changesets of each open campaign by state (open, merged, closed, failing)",
+	}, []string{"campaign_id", "campaign_name", "state"})
+}
+
+// RunCampaignMetricsReporter periodically updates the per-campaign changeset
+// gauges from the database. It is long running and is expected to be launched
+// once at startup.
+func RunCampaignMetricsReporter(ctx context.Context, s *Store, interval time.Duration) {
+	for {
+		if err := reportCampaignMetrics(ctx, s); err != nil {
+			log15.Error("Reporting campaign metrics", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func reportCampaignMetrics(ctx context.Context, s *Store) error {
+	stats, err := s.ListCampaignChangesetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Reset the gauges so that closed and deleted campaigns are dropped.
+	campaignMetrics.changesets.Reset()
+
+	for _, st := range stats {
+		id := strconv.FormatInt(st.CampaignID, 10)
+		for state, n := range map[string]int32{
+			"open":    st.Open,
+			"merged":  st.Merged,
+			"closed":  st.Closed,
+			"failing": st.Failing,
+		} {
+			campaignMetrics.changesets.WithLabelValues(id, st.CampaignName, state).Set(float64(n))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// ErrRebaseNotOpen is returned by RebaseChangeset if the changeset is not
+// open on the code host.
+var ErrRebaseNotOpen = errors.New("only open changesets can be rebased")
+
+// ErrRebaseNotCreatedByCampaign is returned by RebaseChangeset if the
+// changeset was not created by a campaign, since we'd otherwise overwrite
+// a branch we don't own.
+var ErrRebaseNotCreatedByCampaign = errors.New("only changesets created by a campaign can be rebased")
+
+// ErrRebaseUpToDate is returned by RebaseChangeset if the head branch of
+// the changeset already contains the latest revision of its base branch.
+var ErrRebaseUpToDate = errors.New("changeset is already up to date with its base branch")
+
+// ErrRebaseConflict is returned by RebaseChangeset if the changes of the
+// changeset can't be applied cleanly on top of its base branch.
+type ErrRebaseConflict struct {
+	Err error
+}
+
+func (e ErrRebaseConflict) Error() string {
+	return fmt.Sprintf("changes conflict with the base branch and need to be resolved manually: %s", e.Err)
+}
+
+// RebaseChangeset loads the given changeset from the database, checks
+// whether the actor in the context has permission to modify it and then
+// re-applies its changes on top of the latest revision of its base branch.
+// The resulting commit is force-pushed to the head branch of the changeset.
+//
+// All commits on the head branch are squashed into a single commit that
+// reuses the message and author of the head commit.
+func (s *Service) RebaseChangeset(ctx context.Context, id int64) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d", id)
+	tr, ctx := trace.New(ctx, "service.RebaseChangeset", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changeset, err = s.getChangesetWithAdminRights(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !changeset.CreatedByCampaign {
+		return nil, ErrRebaseNotCreatedByCampaign
+	}
+	if !changeset.IsOpen() {
+		return nil, ErrRebaseNotOpen
+	}
+
+	repo, err := changesetGitserverRepo(ctx, changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rebaseChangeset(ctx, s.gitClient, *repo, changeset); err != nil {
+		return nil, err
+	}
+
+	reposStore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, s.cf, s.sourcer, changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syncChangesetsWithSources(ctx, s.store, bySource); err != nil {
+		return nil, err
+	}
+
+	return changeset, nil
+}
+
+// rebaseChangeset computes the diff between the merge-base and the head of
+// the changeset and commits it on top of the latest revision of the base
+// branch, replacing the head branch on the code host.
+func rebaseChangeset(ctx context.Context, gitClient GitserverClient, repo gitserver.Repo, c *campaigns.Changeset) error {
+	baseRef, err := c.BaseRef()
+	if err != nil {
+		return err
+	}
+	headRef, err := c.HeadRef()
+	if err != nil {
+		return err
+	}
+	headOid, err := c.HeadRefOid()
+	if err != nil {
+		return err
+	}
+	if baseRef == "" || headRef == "" || headOid == "" {
+		return errors.New("changeset is missing base or head ref")
+	}
+
+	latestBase, err := git.ResolveRevision(ctx, repo, nil, baseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+
+	head, err := git.GetCommit(ctx, repo, nil, api.CommitID(headOid), git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "loading head commit")
+	}
+
+	mergeBase, err := git.MergeBase(ctx, repo, latestBase, head.ID)
+	if err != nil {
+		return errors.Wrap(err, "computing merge-base")
+	}
+	if mergeBase == latestBase {
+		return ErrRebaseUpToDate
+	}
+
+	rc, err := git.ExecReader(ctx, repo, []string{"diff", "--full-index", string(mergeBase), string(head.ID)})
+	if err != nil {
+		return errors.Wrap(err, "computing changeset diff")
+	}
+	defer rc.Close()
+
+	patch, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return errors.Wrap(err, "reading changeset diff")
+	}
+
+	committer := head.Author
+	if head.Committer != nil {
+		committer = *head.Committer
+	}
+
+	_, err = gitClient.CreateCommitFromPatch(ctx, protocol.CreateCommitFromPatchRequest{
+		Repo:       repo.Name,
+		BaseCommit: latestBase,
+		Patch:      string(patch),
+		TargetRef:  headRef,
+		CommitInfo: protocol.PatchCommitInfo{
+			Message:        head.Message,
+			AuthorName:     head.Author.Name,
+			AuthorEmail:    head.Author.Email,
+			CommitterName:  committer.Name,
+			CommitterEmail: committer.Email,
+			Date:           head.Author.Date,
+		},
+		Push: true,
+		// The diff contains full blob IDs, which allows git to fall back to a
+		// three-way merge if the base branch changed the same files.
+		GitApplyArgs: []string{"--3way"},
+	})
+	if err != nil {
+		var patchErr *protocol.CreateCommitFromPatchError
+		if errors.As(err, &patchErr) && strings.HasPrefix(patchErr.Command, "git apply") {
+			return ErrRebaseConflict{Err: err}
+		}
+		return errors.Wrap(err, "pushing rebased changeset")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package campaigns
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestRebaseChangeset(t *testing.T) {
+	const (
+		oldBase    = api.CommitID("0000000000000000000000000000000000000001")
+		latestBase = api.CommitID("0000000000000000000000000000000000000002")
+		head       = api.CommitID("0000000000000000000000000000000000000003")
+		patch      = "diff --git a/README.md b/README.md\n"
+	)
+
+	repo := gitserver.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	changeset := &campaigns.Changeset{
+		ExternalServiceType: extsvc.TypeGitHub,
+		ExternalState:       campaigns.ChangesetExternalStateOpen,
+		Metadata: &github.PullRequest{
+			BaseRefName: "master",
+			HeadRefName: "campaigns/test",
+			HeadRefOid:  string(head),
+		},
+	}
+
+	mockGit := func(t *testing.T, mergeBase api.CommitID) {
+		git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+			if spec != "refs/heads/master" {
+				t.Fatalf("wrong spec resolved: %q", spec)
+			}
+			return latestBase, nil
+		}
+		git.Mocks.GetCommit = func(id api.CommitID) (*git.Commit, error) {
+			return &git.Commit{
+				ID:      id,
+				Author:  git.Signature{Name: "Mary Mc", Email: "mary@example.com"},
+				Message: "Update README",
+			}, nil
+		}
+		git.Mocks.MergeBase = func(repo gitserver.Repo, a, b api.CommitID) (api.CommitID, error) {
+			return mergeBase, nil
+		}
+		git.Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+			want := []string{"diff", "--full-index", string(mergeBase), string(head)}
+			if strings.Join(args, " ") != strings.Join(want, " ") {
+				t.Fatalf("wrong args. have=%v, want=%v", args, want)
+			}
+			return ioutil.NopCloser(strings.NewReader(patch)), nil
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockGit(t, oldBase)
+		defer git.ResetMocks()
+
+		client := &ct.FakeGitserverClient{Response: "refs/heads/campaigns/test"}
+		if err := rebaseChangeset(context.Background(), client, repo, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := len(client.Requests), 1; have != want {
+			t.Fatalf("wrong number of requests. have=%d, want=%d", have, want)
+		}
+
+		req := client.Requests[0]
+		if req.BaseCommit != latestBase {
+			t.Errorf("wrong base commit. have=%s, want=%s", req.BaseCommit, latestBase)
+		}
+		if req.TargetRef != "refs/heads/campaigns/test" {
+			t.Errorf("wrong target ref: %s", req.TargetRef)
+		}
+		if req.Patch != patch {
+			t.Errorf("wrong patch: %q", req.Patch)
+		}
+		if !req.Push {
+			t.Errorf("rebased commit not pushed")
+		}
+		if req.CommitInfo.Message != "Update README" || req.CommitInfo.AuthorEmail != "mary@example.com" {
+			t.Errorf("wrong commit info: %+v", req.CommitInfo)
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		mockGit(t, latestBase)
+		defer git.ResetMocks()
+
+		client := &ct.FakeGitserverClient{}
+		if have, want := rebaseChangeset(context.Background(), client, repo, changeset), ErrRebaseUpToDate; have != want {
+			t.Fatalf("wrong error. have=%v, want=%v", have, want)
+		}
+		if len(client.Requests) != 0 {
+			t.Fatalf("unexpected requests: %+v", client.Requests)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		mockGit(t, oldBase)
+		defer git.ResetMocks()
+
+		client := &ct.FakeGitserverClient{ResponseErr: &protocol.CreateCommitFromPatchError{
+			Command:       "git apply --cached --3way",
+			InternalError: "gitserver: applying patch",
+		}}
+		err := rebaseChangeset(context.Background(), client, repo, changeset)
+		if _, ok := err.(ErrRebaseConflict); !ok {
+			t.Fatalf("wrong error. have=%v, want ErrRebaseConflict", err)
+		}
+	})
+
+	t.Run("push fails", func(t *testing.T) {
+		mockGit(t, oldBase)
+		defer git.ResetMocks()
+
+		client := &ct.FakeGitserverClient{ResponseErr: errors.New("connection refused")}
+		err := rebaseChangeset(context.Background(), client, repo, changeset)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if _, ok := err.(ErrRebaseConflict); ok {
+			t.Fatalf("push error reported as conflict: %v", err)
+		}
+	})
+}
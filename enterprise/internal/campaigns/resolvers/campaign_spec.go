@@ -65,6 +65,26 @@ func (r *campaignSpecResolver) ChangesetSpecs(ctx context.Context, args *graphql
 	}, nil
 }
 
+func (r *campaignSpecResolver) Jobs(ctx context.Context, args *graphqlbackend.CampaignSpecJobsConnectionArgs) (graphqlbackend.CampaignSpecJobConnectionResolver, error) {
+	opts := ee.ListCampaignSpecJobsOpts{CampaignSpecID: r.campaignSpec.ID}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &campaignSpecJobConnectionResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		opts:        opts,
+	}, nil
+}
+
 func (r *campaignSpecResolver) Description() graphqlbackend.CampaignDescriptionResolver {
 	return &campaignDescriptionResolver{
 		name:        r.campaignSpec.Spec.Name,
@@ -0,0 +1,202 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func marshalCampaignSpecJobID(id int64) graphql.ID {
+	return relay.MarshalID("CampaignSpecJob", id)
+}
+
+func unmarshalCampaignSpecJobID(id graphql.ID) (campaignSpecJobID int64, err error) {
+	err = relay.UnmarshalSpec(id, &campaignSpecJobID)
+	return
+}
+
+var _ graphqlbackend.CampaignSpecJobResolver = &campaignSpecJobResolver{}
+
+type campaignSpecJobResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	job *campaigns.CampaignSpecJob
+
+	// repo is nil if the viewer can't access the repository of the job.
+	repo *types.Repo
+}
+
+func (r *campaignSpecJobResolver) ID() graphql.ID {
+	return marshalCampaignSpecJobID(r.job.ID)
+}
+
+func (r *campaignSpecJobResolver) Repository() *graphqlbackend.RepositoryResolver {
+	if r.repo == nil {
+		return nil
+	}
+	return graphqlbackend.NewRepositoryResolver(r.repo)
+}
+
+func (r *campaignSpecJobResolver) State() string {
+	return strings.ToUpper(string(r.job.State))
+}
+
+func (r *campaignSpecJobResolver) FailureMessage() *string {
+	if r.job.FailureMessage == "" {
+		return nil
+	}
+	return &r.job.FailureMessage
+}
+
+func (r *campaignSpecJobResolver) NumFailures() int32 {
+	return r.job.NumFailures
+}
+
+func (r *campaignSpecJobResolver) Log() string {
+	// 🚨 SECURITY: The log contains the output of the steps, which may include
+	// the contents of the repository.
+	if r.repo == nil {
+		return ""
+	}
+	return r.job.Log
+}
+
+func (r *campaignSpecJobResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.job.StartedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.StartedAt}
+}
+
+func (r *campaignSpecJobResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.FinishedAt}
+}
+
+func (r *campaignSpecJobResolver) ChangesetSpec(ctx context.Context) (graphqlbackend.ChangesetSpecResolver, error) {
+	if r.job.ChangesetSpecID == 0 {
+		return nil, nil
+	}
+
+	spec, err := r.store.GetChangesetSpec(ctx, ee.GetChangesetSpecOpts{ID: r.job.ChangesetSpecID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &changesetSpecResolver{
+		store:         r.store,
+		httpFactory:   r.httpFactory,
+		changesetSpec: spec,
+
+		preloadedRepo:        r.repo,
+		attemptedPreloadRepo: true,
+		repoCtx:              ctx,
+	}, nil
+}
+
+var _ graphqlbackend.CampaignSpecJobConnectionResolver = &campaignSpecJobConnectionResolver{}
+
+type campaignSpecJobConnectionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	opts ee.ListCampaignSpecJobsOpts
+
+	// Cache results because they are used by multiple fields
+	once      sync.Once
+	jobs      []*campaigns.CampaignSpecJob
+	reposByID map[api.RepoID]*types.Repo
+	next      int64
+	err       error
+}
+
+func (r *campaignSpecJobConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountCampaignSpecJobs(ctx, ee.CountCampaignSpecJobsOpts{
+		CampaignSpecID: r.opts.CampaignSpecID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *campaignSpecJobConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, _, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *campaignSpecJobConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignSpecJobResolver, error) {
+	jobs, reposByID, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignSpecJobResolver, 0, len(jobs))
+	for _, j := range jobs {
+		// If it's not in reposByID the repository was filtered out by the
+		// authz-filter, and the resolver hides the repository and the log.
+		resolvers = append(resolvers, &campaignSpecJobResolver{
+			store:       r.store,
+			httpFactory: r.httpFactory,
+			job:         j,
+			repo:        reposByID[j.RepoID],
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *campaignSpecJobConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignSpecJob, map[api.RepoID]*types.Repo, int64, error) {
+	r.once.Do(func() {
+		r.jobs, r.next, r.err = r.store.ListCampaignSpecJobs(ctx, r.opts)
+		if r.err != nil {
+			return
+		}
+
+		repoIDs := make([]api.RepoID, len(r.jobs))
+		for i, j := range r.jobs {
+			repoIDs[i] = j.RepoID
+		}
+
+		// 🚨 SECURITY: db.Repos.GetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		rs, err := db.Repos.GetByIDs(ctx, repoIDs...)
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		r.reposByID = make(map[api.RepoID]*types.Repo, len(rs))
+		for _, repo := range rs {
+			r.reposByID[repo.ID] = repo
+		}
+	})
+
+	return r.jobs, r.reposByID, r.next, r.err
+}
@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"path"
+	"strconv"
 	"sync"
 	"time"
 
@@ -225,3 +226,31 @@ func (r *campaignResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffSt
 
 	return totalStat, nil
 }
+
+func (r *campaignResolver) BulkOperations(
+	ctx context.Context,
+	args *graphqlbackend.ChangesetBulkOperationsConnectionArgs,
+) (graphqlbackend.ChangesetBulkOperationConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access changesets.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := ee.ListChangesetBulkOperationsOpts{CampaignID: r.Campaign.ID}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &changesetBulkOperationConnectionResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		opts:        opts,
+	}, nil
+}
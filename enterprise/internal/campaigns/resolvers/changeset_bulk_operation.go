@@ -0,0 +1,308 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func marshalChangesetBulkOperationID(id int64) graphql.ID {
+	return relay.MarshalID("ChangesetBulkOperation", id)
+}
+
+func marshalChangesetBulkOperationJobID(id int64) graphql.ID {
+	return relay.MarshalID("ChangesetBulkOperationJob", id)
+}
+
+var _ graphqlbackend.ChangesetBulkOperationResolver = &changesetBulkOperationResolver{}
+
+type changesetBulkOperationResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	operation *campaigns.ChangesetBulkOperation
+}
+
+func (r *changesetBulkOperationResolver) ID() graphql.ID {
+	return marshalChangesetBulkOperationID(r.operation.ID)
+}
+
+func (r *changesetBulkOperationResolver) Operation() string {
+	return string(r.operation.Operation)
+}
+
+func (r *changesetBulkOperationResolver) Comment() *string {
+	if r.operation.Comment == "" {
+		return nil
+	}
+	return &r.operation.Comment
+}
+
+func (r *changesetBulkOperationResolver) Initiator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return graphqlbackend.UserByIDInt32(ctx, r.operation.UserID)
+}
+
+func (r *changesetBulkOperationResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.operation.CreatedAt}
+}
+
+func (r *changesetBulkOperationResolver) Jobs(ctx context.Context, args *graphqlbackend.ChangesetBulkOperationJobsConnectionArgs) (graphqlbackend.ChangesetBulkOperationJobConnectionResolver, error) {
+	opts := ee.ListChangesetBulkOperationJobsOpts{BulkOperationID: r.operation.ID}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+	if args.State != nil {
+		state := campaigns.ChangesetBulkOperationJobState(strings.ToLower(*args.State))
+		if !state.Valid() {
+			return nil, errors.New("changeset bulk operation job state not valid")
+		}
+		opts.State = state
+	}
+
+	return &changesetBulkOperationJobConnectionResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		opts:        opts,
+	}, nil
+}
+
+var _ graphqlbackend.ChangesetBulkOperationConnectionResolver = &changesetBulkOperationConnectionResolver{}
+
+type changesetBulkOperationConnectionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	opts ee.ListChangesetBulkOperationsOpts
+
+	// Cache results because they are used by multiple fields
+	once       sync.Once
+	operations []*campaigns.ChangesetBulkOperation
+	next       int64
+	err        error
+}
+
+func (r *changesetBulkOperationConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountChangesetBulkOperations(ctx, ee.CountChangesetBulkOperationsOpts{
+		CampaignID: r.opts.CampaignID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *changesetBulkOperationConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *changesetBulkOperationConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.ChangesetBulkOperationResolver, error) {
+	operations, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetBulkOperationResolver, 0, len(operations))
+	for _, o := range operations {
+		resolvers = append(resolvers, &changesetBulkOperationResolver{
+			store:       r.store,
+			httpFactory: r.httpFactory,
+			operation:   o,
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *changesetBulkOperationConnectionResolver) compute(ctx context.Context) ([]*campaigns.ChangesetBulkOperation, int64, error) {
+	r.once.Do(func() {
+		r.operations, r.next, r.err = r.store.ListChangesetBulkOperations(ctx, r.opts)
+	})
+
+	return r.operations, r.next, r.err
+}
+
+var _ graphqlbackend.ChangesetBulkOperationJobResolver = &changesetBulkOperationJobResolver{}
+
+type changesetBulkOperationJobResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	job       *campaigns.ChangesetBulkOperationJob
+	changeset *campaigns.Changeset
+
+	// repo is nil if the viewer can't access the repository of the changeset.
+	repo *types.Repo
+}
+
+func (r *changesetBulkOperationJobResolver) ID() graphql.ID {
+	return marshalChangesetBulkOperationJobID(r.job.ID)
+}
+
+func (r *changesetBulkOperationJobResolver) Changeset() graphqlbackend.ChangesetResolver {
+	// If the repo is nil, the changesetResolver resolves to a hidden
+	// changeset that doesn't reveal any information.
+	return &changesetResolver{
+		store:                r.store,
+		httpFactory:          r.httpFactory,
+		changeset:            r.changeset,
+		preloadedRepo:        r.repo,
+		attemptedPreloadRepo: true,
+	}
+}
+
+func (r *changesetBulkOperationJobResolver) State() string {
+	return strings.ToUpper(string(r.job.State))
+}
+
+func (r *changesetBulkOperationJobResolver) FailureMessage() *string {
+	// 🚨 SECURITY: The failure message is returned by the code host and may
+	// contain information about the repository.
+	if r.repo == nil || r.job.FailureMessage == "" {
+		return nil
+	}
+	return &r.job.FailureMessage
+}
+
+func (r *changesetBulkOperationJobResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.job.FinishedAt}
+}
+
+var _ graphqlbackend.ChangesetBulkOperationJobConnectionResolver = &changesetBulkOperationJobConnectionResolver{}
+
+type changesetBulkOperationJobConnectionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	opts ee.ListChangesetBulkOperationJobsOpts
+
+	// Cache results because they are used by multiple fields
+	once       sync.Once
+	jobs       []*campaigns.ChangesetBulkOperationJob
+	changesets map[int64]*campaigns.Changeset
+	reposByID  map[api.RepoID]*types.Repo
+	next       int64
+	err        error
+}
+
+func (r *changesetBulkOperationJobConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountChangesetBulkOperationJobs(ctx, ee.CountChangesetBulkOperationJobsOpts{
+		BulkOperationID: r.opts.BulkOperationID,
+		State:           r.opts.State,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *changesetBulkOperationJobConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	if err := r.compute(ctx); err != nil {
+		return nil, err
+	}
+
+	if r.next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(r.next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *changesetBulkOperationJobConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.ChangesetBulkOperationJobResolver, error) {
+	if err := r.compute(ctx); err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetBulkOperationJobResolver, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		c, ok := r.changesets[j.ChangesetID]
+		if !ok {
+			continue
+		}
+
+		// If it's not in reposByID the repository was filtered out by the
+		// authz-filter, and the resolver hides the changeset and the
+		// failure message.
+		resolvers = append(resolvers, &changesetBulkOperationJobResolver{
+			store:       r.store,
+			httpFactory: r.httpFactory,
+			job:         j,
+			changeset:   c,
+			repo:        r.reposByID[c.RepoID],
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *changesetBulkOperationJobConnectionResolver) compute(ctx context.Context) error {
+	r.once.Do(func() {
+		r.jobs, r.next, r.err = r.store.ListChangesetBulkOperationJobs(ctx, r.opts)
+		if r.err != nil || len(r.jobs) == 0 {
+			return
+		}
+
+		ids := make([]int64, len(r.jobs))
+		for i, j := range r.jobs {
+			ids[i] = j.ChangesetID
+		}
+
+		cs, _, err := r.store.ListChangesets(ctx, ee.ListChangesetsOpts{IDs: ids, Limit: -1})
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		r.changesets = make(map[int64]*campaigns.Changeset, len(cs))
+		for _, c := range cs {
+			r.changesets[c.ID] = c
+		}
+
+		// 🚨 SECURITY: db.Repos.GetByIDs uses the authzFilter under the hood and
+		// filters out repositories that the user doesn't have access to.
+		rs, err := db.Repos.GetByIDs(ctx, cs.RepoIDs()...)
+		if err != nil {
+			r.err = err
+			return
+		}
+
+		r.reposByID = make(map[api.RepoID]*types.Repo, len(rs))
+		for _, repo := range rs {
+			r.reposByID[repo.ID] = repo
+		}
+	})
+
+	return r.err
+}
@@ -174,7 +174,8 @@ func (r *changesetDescriptionResolver) HeadRepository() *graphqlbackend.Reposito
 func (r *changesetDescriptionResolver) HeadRef() string { return r.desc.HeadRef }
 func (r *changesetDescriptionResolver) Title() string   { return r.desc.Title }
 func (r *changesetDescriptionResolver) Body() string    { return r.desc.Body }
-func (r *changesetDescriptionResolver) Published() bool { return r.desc.Published }
+func (r *changesetDescriptionResolver) Published() bool { return !r.desc.Published.False() }
+func (r *changesetDescriptionResolver) Draft() bool     { return r.desc.Published.Draft() }
 
 func (r *changesetDescriptionResolver) Diff(ctx context.Context) (graphqlbackend.PreviewRepositoryComparisonResolver, error) {
 	diff, err := r.desc.Diff()
@@ -432,6 +432,14 @@ func (r *changesetResolver) CheckState() *campaigns.ChangesetCheckState {
 	return &state
 }
 
+func (r *changesetResolver) MergeableState() *campaigns.ChangesetMergeableState {
+	state := r.changeset.ExternalMergeableState
+	if state == "" {
+		return nil
+	}
+	return &state
+}
+
 func (r *changesetResolver) Error() *string {
 	// TODO: Implement.
 	return nil
@@ -487,7 +495,7 @@ func (r *changesetResolver) Diff(ctx context.Context) (graphqlbackend.Repository
 
 	// Only return diffs for open changesets, otherwise we can't guarantee that
 	// we have the refs on gitserver
-	if r.changeset.ExternalState != campaigns.ChangesetExternalStateOpen {
+	if !r.changeset.IsOpen() {
 		return nil, nil
 	}
 
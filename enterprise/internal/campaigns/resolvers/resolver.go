@@ -273,6 +273,91 @@ func (r *Resolver) CreateCampaignSpec(ctx context.Context, args *graphqlbackend.
 	return specResolver, nil
 }
 
+func (r *Resolver) ExecuteCampaignSpec(ctx context.Context, args *graphqlbackend.ExecuteCampaignSpecArgs) (graphqlbackend.CampaignSpecResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.ExecuteCampaignSpec", fmt.Sprintf("Namespace %s, Spec %q", args.Namespace, args.CampaignSpec))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	user, err := db.Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+
+	// 🚨 SECURITY: Only site admins may create campaign specs for now.
+	if !user.SiteAdmin {
+		return nil, backend.ErrMustBeSiteAdmin
+	}
+
+	opts := ee.ExecuteCampaignSpecOpts{RawSpec: args.CampaignSpec}
+
+	switch relay.UnmarshalKind(args.Namespace) {
+	case "User":
+		err = relay.UnmarshalSpec(args.Namespace, &opts.NamespaceUserID)
+	case "Org":
+		err = relay.UnmarshalSpec(args.Namespace, &opts.NamespaceOrgID)
+	default:
+		err = errors.Errorf("Invalid namespace %q", args.Namespace)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	campaignSpec, err := svc.ExecuteCampaignSpec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecResolver{
+		store:        r.store,
+		httpFactory:  r.httpFactory,
+		campaignSpec: campaignSpec,
+	}, nil
+}
+
+func (r *Resolver) RetryCampaignSpecJob(ctx context.Context, args *graphqlbackend.RetryCampaignSpecJobArgs) (graphqlbackend.CampaignSpecJobResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.RetryCampaignSpecJob", fmt.Sprintf("CampaignSpecJob: %q", args.CampaignSpecJob))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	jobID, err := unmarshalCampaignSpecJobID(args.CampaignSpecJob)
+	if err != nil {
+		return nil, err
+	}
+
+	if jobID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: RetryCampaignSpecJob checks whether the current user is
+	// authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	job, err := svc.RetryCampaignSpecJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// returns an error if the user doesn't have access to the repository.
+	repo, err := db.Repos.Get(ctx, job.RepoID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+
+	return &campaignSpecJobResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		job:         job,
+		repo:        repo,
+	}, nil
+}
+
 func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend.CreateChangesetSpecArgs) (graphqlbackend.ChangesetSpecResolver, error) {
 	var err error
 	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetSpec", "")
@@ -507,6 +592,156 @@ func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncC
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) UndraftChangeset(ctx context.Context, args *graphqlbackend.UndraftChangesetArgs) (_ graphqlbackend.ExternalChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UndraftChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: UndraftChangeset checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changeset, err := svc.UndraftChangeset(ctx, changesetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetResolver{
+		store:                r.store,
+		httpFactory:          r.httpFactory,
+		changeset:            changeset,
+		attemptedPreloadRepo: true,
+		preloadedRepo:        repo,
+	}, nil
+}
+
+func (r *Resolver) RebaseChangeset(ctx context.Context, args *graphqlbackend.RebaseChangesetArgs) (_ graphqlbackend.ExternalChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RebaseChangeset", fmt.Sprintf("Changeset: %q", args.Changeset))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: RebaseChangeset checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	changeset, err := svc.RebaseChangeset(ctx, changesetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetResolver{
+		store:                r.store,
+		httpFactory:          r.httpFactory,
+		changeset:            changeset,
+		attemptedPreloadRepo: true,
+		preloadedRepo:        repo,
+	}, nil
+}
+
+func (r *Resolver) CreateChangesetBulkOperation(ctx context.Context, args *graphqlbackend.CreateChangesetBulkOperationArgs) (_ graphqlbackend.ChangesetBulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetBulkOperation", fmt.Sprintf("Campaign: %q, Operation: %q", args.Campaign, args.Operation))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	opts := ee.CreateChangesetBulkOperationOpts{
+		CampaignID: campaignID,
+		Operation:  args.Operation,
+	}
+	if args.Comment != nil {
+		opts.Comment = *args.Comment
+	}
+
+	if args.ExternalState != nil {
+		state := *args.ExternalState
+		if !state.Valid() {
+			return nil, errors.New("changeset external state not valid")
+		}
+		opts.ExternalState = &state
+	}
+	if args.ReviewState != nil {
+		state := *args.ReviewState
+		if !state.Valid() {
+			return nil, errors.New("changeset review state not valid")
+		}
+		opts.ExternalReviewState = &state
+	}
+	if args.CheckState != nil {
+		state := *args.CheckState
+		if !state.Valid() {
+			return nil, errors.New("changeset check state not valid")
+		}
+		opts.ExternalCheckState = &state
+	}
+
+	if args.Repositories != nil {
+		for _, id := range *args.Repositories {
+			repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+			if err != nil {
+				return nil, err
+			}
+			opts.RepoIDs = append(opts.RepoIDs, repoID)
+		}
+	}
+
+	// 🚨 SECURITY: CreateChangesetBulkOperation checks whether the current
+	// user is authorized and only enqueues jobs for changesets in
+	// repositories the user can access.
+	svc := ee.NewService(r.store, r.httpFactory)
+	op, err := svc.CreateChangesetBulkOperation(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changesetBulkOperationResolver{
+		store:       r.store,
+		httpFactory: r.httpFactory,
+		operation:   op,
+	}, nil
+}
+
 func parseCampaignState(s *string) (campaigns.CampaignState, error) {
 	if s == nil {
 		return campaigns.CampaignStateAny, nil
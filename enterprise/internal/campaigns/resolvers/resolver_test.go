@@ -1096,7 +1096,7 @@ func TestApplyCampaign(t *testing.T) {
 				Commit: campaigns.CommitTemplate{
 					Message: "Add hello world",
 				},
-				Published: false,
+				Published: campaigns.PublishedValue{Val: false},
 			},
 		},
 		UserID:          userID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -17,6 +18,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -31,7 +34,7 @@ func NewService(store *Store, cf *httpcli.Factory) *Service {
 // NewServiceWithClock returns a Service the given clock used
 // to generate timestamps.
 func NewServiceWithClock(store *Store, cf *httpcli.Factory, clock func() time.Time) *Service {
-	svc := &Service{store: store, cf: cf, clock: clock}
+	svc := &Service{store: store, cf: cf, clock: clock, gitClient: gitserver.DefaultClient}
 
 	return svc
 }
@@ -40,7 +43,8 @@ type Service struct {
 	store *Store
 	cf    *httpcli.Factory
 
-	sourcer repos.Sourcer
+	sourcer   repos.Sourcer
+	gitClient GitserverClient
 
 	clock func() time.Time
 }
@@ -188,13 +192,216 @@ func (s *Service) CreateChangesetSpec(ctx context.Context, rawSpec string, userI
 
 	// 🚨 SECURITY: We use db.Repos.Get to check whether the user has access to
 	// the repository or not.
-	if _, err = db.Repos.Get(ctx, spec.RepoID); err != nil {
+	repo, err := db.Repos.Get(ctx, spec.RepoID)
+	if err != nil {
 		return nil, err
 	}
 
+	if spec.Spec.Published.Draft() && !supportsDrafts(repo.ExternalRepo.ServiceType) {
+		return nil, ErrDraftsNotSupported
+	}
+
 	return spec, s.store.CreateChangesetSpec(ctx, spec)
 }
 
+type ExecuteCampaignSpecOpts struct {
+	RawSpec string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ErrNoSteps is returned by ExecuteCampaignSpec if the campaign spec doesn't
+// have any steps to execute.
+var ErrNoSteps = errors.New("campaign spec doesn't have any steps to execute")
+
+// ErrNoRepositories is returned by ExecuteCampaignSpec if the "on" attribute
+// of the campaign spec doesn't match any repositories.
+var ErrNoRepositories = errors.New("campaign spec doesn't match any repositories")
+
+// ExecuteCampaignSpec creates a CampaignSpec without any ChangesetSpecs and
+// enqueues a CampaignSpecJob for every repository matched by its "on"
+// attribute. The jobs are executed by the campaign executor, which runs the
+// steps of the spec and attaches the resulting ChangesetSpecs to it.
+func (s *Service) ExecuteCampaignSpec(ctx context.Context, opts ExecuteCampaignSpecOpts) (spec *campaigns.CampaignSpec, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.ExecuteCampaignSpec", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	spec, err = campaigns.NewCampaignSpecFromRaw(opts.RawSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spec.Spec.Steps) == 0 {
+		return nil, ErrNoSteps
+	}
+
+	// Check whether the current user has access to either one of the namespaces.
+	err = checkNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	spec.NamespaceOrgID = opts.NamespaceOrgID
+	spec.NamespaceUserID = opts.NamespaceUserID
+	spec.UserID = actor.UID
+
+	// 🚨 SECURITY: The repositories are resolved with the permissions of the
+	// current user. The executor doesn't check them again.
+	repoIDs, err := resolveRepositoriesOn(ctx, spec.Spec.On)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repoIDs) == 0 {
+		return nil, ErrNoRepositories
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	if err := tx.CreateCampaignSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	for _, id := range repoIDs {
+		job := &campaigns.CampaignSpecJob{CampaignSpecID: spec.ID, RepoID: id}
+		if err := tx.CreateCampaignSpecJob(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return spec, nil
+}
+
+// ErrRetryCampaignSpecJob is returned by RetryCampaignSpecJob if the
+// CampaignSpecJob hasn't errored.
+var ErrRetryCampaignSpecJob = errors.New("only errored campaign spec jobs can be retried")
+
+// RetryCampaignSpecJob puts the errored CampaignSpecJob with the given ID back
+// into the queue and resets its failure count.
+func (s *Service) RetryCampaignSpecJob(ctx context.Context, id int64) (job *campaigns.CampaignSpecJob, err error) {
+	traceTitle := fmt.Sprintf("campaignSpecJobID: %d", id)
+	tr, ctx := trace.New(ctx, "service.RetryCampaignSpecJob", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	job, err = tx.GetCampaignSpecJob(ctx, GetCampaignSpecJobOpts{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: job.CampaignSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of the campaign spec can
+	// retry its jobs.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, spec.UserID); err != nil {
+		return nil, err
+	}
+
+	if job.State != campaigns.CampaignSpecJobStateErrored {
+		return nil, ErrRetryCampaignSpecJob
+	}
+
+	job.State = campaigns.CampaignSpecJobStateQueued
+	job.FailureMessage = ""
+	job.ProcessAfter = time.Time{}
+	job.NumResets = 0
+	job.NumFailures = 0
+
+	return job, tx.UpdateCampaignSpecJob(ctx, job)
+}
+
+// resolveRepositoriesOn returns the IDs of the repositories matched by the
+// given "on" attributes of a campaign spec, in the order they were matched and
+// without duplicates. Repositories the actor in ctx can't access are omitted
+// from search results; referencing one by name is an error.
+func resolveRepositoriesOn(ctx context.Context, on []campaigns.CampaignSpecOn) ([]api.RepoID, error) {
+	var (
+		ids  []api.RepoID
+		seen = map[api.RepoID]bool{}
+	)
+
+	add := func(id api.RepoID) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, o := range on {
+		if o.Repository != "" {
+			// 🚨 SECURITY: db.Repos.GetByName uses the authzFilter under the
+			// hood and returns a not found error for inaccessible repositories.
+			repo, err := db.Repos.GetByName(ctx, api.RepoName(o.Repository))
+			if err != nil {
+				return nil, err
+			}
+			add(repo.ID)
+			continue
+		}
+
+		repos, err := searchRepositories(ctx, o.RepositoriesMatchingQuery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving repositoriesMatchingQuery %q", o.RepositoriesMatchingQuery)
+		}
+		for _, repo := range repos {
+			add(repo.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// searchRepositories returns the repositories that have results for the given
+// search query, like src-cli does when it executes a campaign spec.
+func searchRepositories(ctx context.Context, query string) ([]*types.Repo, error) {
+	if !strings.Contains(query, "count:") {
+		query += " count:999999"
+	}
+
+	search, err := graphqlbackend.NewSearchImplementer(ctx, &graphqlbackend.SearchArgs{
+		Version: "V2",
+		Query:   query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []*types.Repo
+	for _, r := range results.Results() {
+		if repo, ok := r.ToRepository(); ok {
+			repos = append(repos, repo.Type())
+		} else if fm, ok := r.ToFileMatch(); ok {
+			repos = append(repos, fm.Repository().Type())
+		}
+	}
+
+	return repos, nil
+}
+
 // changesetSpecNotFoundErr is returned by CreateCampaignSpec if a
 // ChangesetSpec with the given RandID doesn't exist.
 // It fulfills the interface required by errcode.IsNotFound.
@@ -251,6 +458,24 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		return nil, err
 	}
 
+	// The changeset specs of an executed campaign spec are only complete once
+	// all of its jobs have completed.
+	for _, state := range []campaigns.CampaignSpecJobState{
+		campaigns.CampaignSpecJobStateQueued,
+		campaigns.CampaignSpecJobStateProcessing,
+	} {
+		count, err := tx.CountCampaignSpecJobs(ctx, CountCampaignSpecJobsOpts{
+			CampaignSpecID: campaignSpec.ID,
+			State:          state,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, ErrApplyCampaignSpecExecuting
+		}
+	}
+
 	getOpts := GetCampaignOpts{
 		CampaignSpecName: campaignSpec.Spec.Name,
 		NamespaceUserID:  campaignSpec.NamespaceUserID,
@@ -360,6 +585,10 @@ func (s *Service) MoveCampaign(ctx context.Context, opts MoveCampaignOpts) (camp
 	return campaign, tx.UpdateCampaign(ctx, campaign)
 }
 
+// ErrApplyCampaignSpecExecuting is returned by ApplyCampaign if jobs of the
+// CampaignSpec are still queued or processing.
+var ErrApplyCampaignSpecExecuting = errors.New("cannot apply a campaign spec while its steps are being executed")
+
 // ErrEnsureCampaignFailed is returned by ApplyCampaign when a ensureCampaignID
 // is provided but a campaign with the name specified the campaignSpec exists
 // in the given namespace but has a different ID.
@@ -487,7 +716,7 @@ func (s *Service) DeleteCampaign(ctx context.Context, id int64) (err error) {
 // CloseOpenChangesets closes the given Changesets on their respective codehosts and syncs them.
 func (s *Service) CloseOpenChangesets(ctx context.Context, cs campaigns.Changesets) (err error) {
 	cs = cs.Filter(func(c *campaigns.Changeset) bool {
-		return c.ExternalState == campaigns.ChangesetExternalStateOpen
+		return c.IsOpen()
 	})
 
 	if len(cs) == 0 {
@@ -542,21 +771,105 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 		tr.Finish()
 	}()
 
+	if _, err := s.getChangesetWithAdminRights(ctx, id); err != nil {
+		return err
+	}
+
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{id}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ErrChangesetNotDraft is returned by UndraftChangeset if the changeset is
+// not a draft on the code host.
+var ErrChangesetNotDraft = errors.New("only draft changesets can be marked as ready for review")
+
+// ErrDraftsNotSupported is returned by CreateChangesetSpec and
+// UndraftChangeset if the code host of the changeset doesn't support drafts.
+var ErrDraftsNotSupported = errors.New("code host doesn't support draft changesets")
+
+// supportsDrafts returns whether changesets on code hosts of the given
+// service type can be created as drafts.
+func supportsDrafts(serviceType string) bool {
+	return serviceType == extsvc.TypeGitHub || serviceType == extsvc.TypeGitLab
+}
+
+// UndraftChangeset loads the given changeset from the database, checks
+// whether the actor in the context has permission to modify it and then
+// marks the draft changeset as ready for review on the code host.
+func (s *Service) UndraftChangeset(ctx context.Context, id int64) (changeset *campaigns.Changeset, err error) {
+	traceTitle := fmt.Sprintf("changeset: %d", id)
+	tr, ctx := trace.New(ctx, "service.UndraftChangeset", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	changeset, err = s.getChangesetWithAdminRights(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeset.ExternalState != campaigns.ChangesetExternalStateDraft {
+		return nil, ErrChangesetNotDraft
+	}
+
+	reposStore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, s.cf, s.sourcer, changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range bySource {
+		source, ok := group.ChangesetSource.(repos.DraftChangesetSource)
+		if !ok {
+			return nil, ErrDraftsNotSupported
+		}
+
+		for _, c := range group.Changesets {
+			if err := source.UndraftChangeset(ctx, c); err != nil {
+				return nil, errors.Wrap(err, "marking changeset as ready for review")
+			}
+		}
+	}
+
+	if err := syncChangesetsWithSources(ctx, s.store, bySource); err != nil {
+		return nil, err
+	}
+
+	return changeset, nil
+}
+
+// getChangesetWithAdminRights loads the given changeset from the database
+// and checks whether the actor in the context has access to its repository
+// and admin rights for one of the campaigns it belongs to.
+func (s *Service) getChangesetWithAdminRights(ctx context.Context, id int64) (*campaigns.Changeset, error) {
 	// Check for existence of changeset so we don't swallow that error.
 	changeset, err := s.store.GetChangeset(ctx, GetChangesetOpts{ID: id})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 🚨 SECURITY: We use db.Repos.Get to check whether the user has access to
 	// the repository or not.
 	if _, err = db.Repos.Get(ctx, changeset.RepoID); err != nil {
-		return err
+		return nil, err
 	}
 
-	campaigns, _, err := s.store.ListCampaigns(ctx, ListCampaignsOpts{ChangesetID: id})
+	cs, _, err := s.store.ListCampaigns(ctx, ListCampaignsOpts{ChangesetID: id})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Changesets that don't belong to any campaign can only be modified by
+	// site admins.
+	if len(cs) == 0 {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+			return nil, err
+		}
+		return changeset, nil
 	}
 
 	// Check whether the user has admin rights for one of the campaigns.
@@ -565,7 +878,7 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 		hasAdminRights bool
 	)
 
-	for _, c := range campaigns {
+	for _, c := range cs {
 		err := backend.CheckSiteAdminOrSameUser(ctx, c.AuthorID)
 		if err != nil {
 			authErr = err
@@ -576,14 +889,10 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 	}
 
 	if !hasAdminRights {
-		return authErr
+		return nil, authErr
 	}
 
-	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{id}); err != nil {
-		return err
-	}
-
-	return nil
+	return changeset, nil
 }
 
 // ErrCampaignNameBlank is returned by CreateCampaign or UpdateCampaign if the
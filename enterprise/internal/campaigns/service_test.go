@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -154,6 +156,24 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("UndraftChangeset", func(t *testing.T) {
+				_, err = svc.UndraftChangeset(currentUserCtx, changeset.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("RebaseChangeset", func(t *testing.T) {
+				_, err = svc.RebaseChangeset(currentUserCtx, changeset.ID)
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("CreateChangesetBulkOperation", func(t *testing.T) {
+				_, err = svc.CreateChangesetBulkOperation(currentUserCtx, CreateChangesetBulkOperationOpts{
+					CampaignID: campaign.ID,
+					Operation:  campaigns.ChangesetBulkOperationSync,
+				})
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("CloseCampaign", func(t *testing.T) {
 				_, err = svc.CloseCampaign(currentUserCtx, campaign.ID, false)
 				tc.assertFunc(t, err)
@@ -365,6 +385,233 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("UndraftChangeset", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		draft := testChangeset(rs[0].ID, campaign.ID, 171819, campaigns.ChangesetExternalStateOpen)
+		draft.Metadata.(*github.PullRequest).IsDraft = true
+		draft.ExternalState = campaigns.ChangesetExternalStateDraft
+		open := testChangeset(rs[0].ID, campaign.ID, 192021, campaigns.ChangesetExternalStateOpen)
+		if err = store.CreateChangesets(ctx, draft, open); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{draft.ID, open.ID}
+		if err = store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		fakeSource := &ct.FakeChangesetSource{Err: nil}
+		svc := NewServiceWithClock(store, cf, clock)
+		svc.sourcer = repos.NewFakeSourcer(nil, fakeSource)
+
+		if _, err := svc.UndraftChangeset(ctx, open.ID); err != ErrChangesetNotDraft {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrChangesetNotDraft, err)
+		}
+
+		if _, err := svc.UndraftChangeset(ctx, draft.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := len(fakeSource.UndraftedChangesets), 1; have != want {
+			t.Fatalf("UndraftedChangesets has wrong length. want=%d, have=%d", want, have)
+		}
+
+		if have, want := fakeSource.UndraftedChangesets[0].Changeset.ID, draft.ID; have != want {
+			t.Fatalf("wrong changeset undrafted. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("RebaseChangeset", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		imported := testChangeset(rs[0].ID, campaign.ID, 222324, campaigns.ChangesetExternalStateOpen)
+		closed := testChangeset(rs[0].ID, campaign.ID, 252627, campaigns.ChangesetExternalStateClosed)
+		closed.CreatedByCampaign = true
+		if err = store.CreateChangesets(ctx, imported, closed); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{imported.ID, closed.ID}
+		if err = store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		gitClient := &ct.FakeGitserverClient{}
+		svc := NewServiceWithClock(store, cf, clock)
+		svc.gitClient = gitClient
+
+		if _, err := svc.RebaseChangeset(ctx, imported.ID); err != ErrRebaseNotCreatedByCampaign {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrRebaseNotCreatedByCampaign, err)
+		}
+
+		if _, err := svc.RebaseChangeset(ctx, closed.ID); err != ErrRebaseNotOpen {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrRebaseNotOpen, err)
+		}
+
+		if len(gitClient.Requests) != 0 {
+			t.Fatalf("unexpected gitserver requests: %+v", gitClient.Requests)
+		}
+	})
+
+	t.Run("CreateChangesetBulkOperation", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		open1 := testChangeset(rs[0].ID, campaign.ID, 282930, campaigns.ChangesetExternalStateOpen)
+		open2 := testChangeset(rs[1].ID, campaign.ID, 313233, campaigns.ChangesetExternalStateOpen)
+		closed := testChangeset(rs[2].ID, campaign.ID, 343536, campaigns.ChangesetExternalStateClosed)
+		if err = store.CreateChangesets(ctx, open1, open2, closed); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{open1.ID, open2.ID, closed.ID}
+		if err = store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		svc := NewServiceWithClock(store, cf, clock)
+
+		openState := campaigns.ChangesetExternalStateOpen
+
+		t.Run("blank comment", func(t *testing.T) {
+			_, err := svc.CreateChangesetBulkOperation(ctx, CreateChangesetBulkOperationOpts{
+				CampaignID: campaign.ID,
+				Operation:  campaigns.ChangesetBulkOperationComment,
+				Comment:    "  ",
+			})
+			if err != ErrBulkOperationCommentBlank {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrBulkOperationCommentBlank, err)
+			}
+		})
+
+		t.Run("no matching changesets", func(t *testing.T) {
+			_, err := svc.CreateChangesetBulkOperation(ctx, CreateChangesetBulkOperationOpts{
+				CampaignID: campaign.ID,
+				Operation:  campaigns.ChangesetBulkOperationClose,
+				RepoIDs:    []api.RepoID{rs[3].ID},
+			})
+			if err != ErrBulkOperationNoChangesets {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrBulkOperationNoChangesets, err)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			// Repo of open2 filtered out by authzFilter
+			ct.AuthzFilterRepos(t, open2.RepoID)
+
+			op, err := svc.CreateChangesetBulkOperation(ctx, CreateChangesetBulkOperationOpts{
+				CampaignID:    campaign.ID,
+				Operation:     campaigns.ChangesetBulkOperationComment,
+				Comment:       "Please take a look",
+				ExternalState: &openState,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := op.UserID, admin.ID; have != want {
+				t.Fatalf("wrong UserID. want=%d, have=%d", want, have)
+			}
+
+			jobs, _, err := store.ListChangesetBulkOperationJobs(ctx, ListChangesetBulkOperationJobsOpts{
+				BulkOperationID: op.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Only open1 matches the filter and is accessible
+			if have, want := len(jobs), 1; have != want {
+				t.Fatalf("wrong number of jobs. want=%d, have=%d", want, have)
+			}
+			if have, want := jobs[0].ChangesetID, open1.ID; have != want {
+				t.Fatalf("wrong changeset. want=%d, have=%d", want, have)
+			}
+			if have, want := jobs[0].State, campaigns.ChangesetBulkOperationJobStateQueued; have != want {
+				t.Fatalf("wrong state. want=%q, have=%q", want, have)
+			}
+		})
+	})
+
+	t.Run("RunBulkOperation", func(t *testing.T) {
+		campaign := testCampaign(admin.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		open := testChangeset(rs[0].ID, campaign.ID, 373839, campaigns.ChangesetExternalStateOpen)
+		closed := testChangeset(rs[0].ID, campaign.ID, 404142, campaigns.ChangesetExternalStateClosed)
+		if err = store.CreateChangesets(ctx, open, closed); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign.ChangesetIDs = []int64{open.ID, closed.ID}
+		if err = store.UpdateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("comment", func(t *testing.T) {
+			fakeSource := &ct.FakeChangesetSource{Svc: ext, FakeMetadata: open.Metadata}
+			op := &campaigns.ChangesetBulkOperation{
+				CampaignID: campaign.ID,
+				Operation:  campaigns.ChangesetBulkOperationComment,
+				Comment:    "Please take a look",
+			}
+
+			if err := runBulkOperation(ctx, store, repos.NewFakeSourcer(nil, fakeSource), op, open); err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := fakeSource.Comments, []string{op.Comment}; !reflect.DeepEqual(have, want) {
+				t.Fatalf("wrong comments. want=%v, have=%v", want, have)
+			}
+			if have, want := fakeSource.CommentedChangesets[0].Changeset.ID, open.ID; have != want {
+				t.Fatalf("wrong changeset commented. want=%d, have=%d", want, have)
+			}
+		})
+
+		t.Run("close not open", func(t *testing.T) {
+			fakeSource := &ct.FakeChangesetSource{Svc: ext, FakeMetadata: closed.Metadata}
+			op := &campaigns.ChangesetBulkOperation{
+				CampaignID: campaign.ID,
+				Operation:  campaigns.ChangesetBulkOperationClose,
+			}
+
+			if err := runBulkOperation(ctx, store, repos.NewFakeSourcer(nil, fakeSource), op, closed); err == nil {
+				t.Fatal("expected error. got none")
+			}
+
+			if len(fakeSource.ClosedChangesets) != 0 {
+				t.Fatalf("unexpected closed changesets: %+v", fakeSource.ClosedChangesets)
+			}
+		})
+
+		t.Run("republish imported changeset", func(t *testing.T) {
+			fakeSource := &ct.FakeChangesetSource{Svc: ext, FakeMetadata: open.Metadata}
+			op := &campaigns.ChangesetBulkOperation{
+				CampaignID: campaign.ID,
+				Operation:  campaigns.ChangesetBulkOperationRepublish,
+			}
+
+			if err := runBulkOperation(ctx, store, repos.NewFakeSourcer(nil, fakeSource), op, open); err == nil {
+				t.Fatal("expected error. got none")
+			}
+
+			if len(fakeSource.UpdatedChangesets) != 0 {
+				t.Fatalf("unexpected updated changesets: %+v", fakeSource.UpdatedChangesets)
+			}
+		})
+	})
+
 	t.Run("CreateCampaignSpec", func(t *testing.T) {
 		svc := NewServiceWithClock(store, cf, clock)
 
@@ -565,6 +812,26 @@ func TestService(t *testing.T) {
 			}
 		})
 
+		t.Run("draft", func(t *testing.T) {
+			draft := func(repo *repos.Repo) string {
+				raw := ct.NewRawChangesetSpecGitBranch(graphqlbackend.MarshalRepositoryID(repo.ID), "d34db33f")
+				return strings.Replace(raw, `"published": false`, `"published": "draft"`, 1)
+			}
+
+			spec, err := svc.CreateChangesetSpec(ctx, draft(repo), admin.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !spec.Spec.Published.Draft() {
+				t.Fatalf("spec is not a draft: %+v", spec.Spec.Published)
+			}
+
+			// AWS CodeCommit doesn't support drafts.
+			if _, err := svc.CreateChangesetSpec(ctx, draft(rs[awsCodeCommitRepoID]), admin.ID); err != ErrDraftsNotSupported {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrDraftsNotSupported, err)
+			}
+		})
+
 		t.Run("invalid raw spec", func(t *testing.T) {
 			invalidRaw := `{"externalComputer": "beepboop"}`
 			_, err := svc.CreateChangesetSpec(ctx, invalidRaw, admin.ID)
@@ -845,6 +1112,153 @@ func TestService(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("ExecuteCampaignSpec", func(t *testing.T) {
+		svc := NewServiceWithClock(store, cf, clock)
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		rawSpec := func(on, steps string) string {
+			return fmt.Sprintf(`{
+				"name": "my-campaign",
+				"on": %s,
+				"steps": %s,
+				"changesetTemplate": {
+					"title": "Hello there",
+					"body": "This is the body",
+					"branch": "my-branch",
+					"commit": {"message": "commit message"},
+					"published": false
+				}
+			}`, on, steps)
+		}
+		steps := `[{"run": "echo hello > hello.txt", "container": "alpine:3"}]`
+
+		t.Run("success", func(t *testing.T) {
+			on := fmt.Sprintf(`[{"repository": %q}, {"repository": %q}, {"repository": %q}]`, rs[0].Name, rs[1].Name, rs[0].Name)
+			opts := ExecuteCampaignSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec:         rawSpec(on, steps),
+			}
+
+			spec, err := svc.ExecuteCampaignSpec(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := spec.UserID, admin.ID; have != want {
+				t.Fatalf("UserID is %d, want %d", have, want)
+			}
+
+			jobs, _, err := store.ListCampaignSpecJobs(ctx, ListCampaignSpecJobsOpts{CampaignSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have []api.RepoID
+			for _, j := range jobs {
+				if j.State != campaigns.CampaignSpecJobStateQueued {
+					t.Fatalf("job has state %q, want queued", j.State)
+				}
+				have = append(have, j.RepoID)
+			}
+
+			want := []api.RepoID{rs[0].ID, rs[1].ID}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("wrong repositories (-want +got):\n%s", diff)
+			}
+
+			_, err = svc.ApplyCampaign(adminCtx, ApplyCampaignOpts{CampaignSpecRandID: spec.RandID})
+			if err != ErrApplyCampaignSpecExecuting {
+				t.Fatalf("expected %s error but got %v", ErrApplyCampaignSpecExecuting, err)
+			}
+
+			for _, j := range jobs {
+				j.State = campaigns.CampaignSpecJobStateCompleted
+				if err := store.UpdateCampaignSpecJob(ctx, j); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := svc.ApplyCampaign(adminCtx, ApplyCampaignOpts{CampaignSpecRandID: spec.RandID}); err != nil {
+				t.Fatalf("applying campaign spec with completed jobs failed: %s", err)
+			}
+		})
+
+		t.Run("no steps", func(t *testing.T) {
+			on := fmt.Sprintf(`[{"repository": %q}]`, rs[0].Name)
+			opts := ExecuteCampaignSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec:         rawSpec(on, `[]`),
+			}
+
+			if _, err := svc.ExecuteCampaignSpec(adminCtx, opts); err != ErrNoSteps {
+				t.Fatalf("expected %s error but got %v", ErrNoSteps, err)
+			}
+		})
+
+		t.Run("missing repository permissions", func(t *testing.T) {
+			ct.AuthzFilterRepos(t, rs[0].ID)
+
+			on := fmt.Sprintf(`[{"repository": %q}]`, rs[0].Name)
+			opts := ExecuteCampaignSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec:         rawSpec(on, steps),
+			}
+
+			if _, err := svc.ExecuteCampaignSpec(adminCtx, opts); !errcode.IsNotFound(err) {
+				t.Fatalf("expected not-found error but got %v", err)
+			}
+		})
+	})
+
+	t.Run("RetryCampaignSpecJob", func(t *testing.T) {
+		svc := NewServiceWithClock(store, cf, clock)
+
+		spec := &campaigns.CampaignSpec{UserID: admin.ID, NamespaceUserID: admin.ID}
+		if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		job := &campaigns.CampaignSpecJob{
+			CampaignSpecID: spec.ID,
+			RepoID:         rs[0].ID,
+			State:          campaigns.CampaignSpecJobStateErrored,
+			FailureMessage: "step 1 failed",
+			NumFailures:    3,
+		}
+		if err := store.CreateCampaignSpecJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("user is not admin and not creator", func(t *testing.T) {
+			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+			if _, err := svc.RetryCampaignSpecJob(userCtx, job.ID); !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %v", err)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+			retried, err := svc.RetryCampaignSpecJob(adminCtx, job.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := retried.State, campaigns.CampaignSpecJobStateQueued; have != want {
+				t.Fatalf("job has state %q, want %q", have, want)
+			}
+			if retried.FailureMessage != "" || retried.NumFailures != 0 {
+				t.Fatalf("job failure not reset: %+v", retried)
+			}
+		})
+
+		t.Run("job not errored", func(t *testing.T) {
+			adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+			if _, err := svc.RetryCampaignSpecJob(adminCtx, job.ID); err != ErrRetryCampaignSpecJob {
+				t.Fatalf("expected %s error but got %v", ErrRetryCampaignSpecJob, err)
+			}
+		})
+	})
 }
 
 var testUser = db.NewUser{
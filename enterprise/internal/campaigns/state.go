@@ -48,11 +48,13 @@ func SetDerivedState(ctx context.Context, c *campaigns.Changeset, es []*campaign
 		c.ExternalReviewState = state
 	}
 
+	c.ExternalMergeableState = computeMergeableState(c)
+
 	// If the changeset was "complete" (that is, not open) the last time we
 	// synced, and it's still complete, then we don't need to do any further
 	// work: the diffstat should still be correct, and this way we don't need to
 	// rely on gitserver having the head OID still available.
-	if c.SyncState.IsComplete && c.ExternalState != campaigns.ChangesetExternalStateOpen {
+	if c.SyncState.IsComplete && !c.IsOpen() {
 		return
 	}
 
@@ -91,6 +93,47 @@ func SetDerivedState(ctx context.Context, c *campaigns.Changeset, es []*campaign
 	}
 }
 
+// computeMergeableState computes whether the changeset can be merged into its
+// base branch without conflicts, based on what the code host reported the
+// last time it was synced. Only open changesets can be mergeable or
+// conflicting.
+func computeMergeableState(c *campaigns.Changeset) campaigns.ChangesetMergeableState {
+	if !c.IsOpen() {
+		return campaigns.ChangesetMergeableStateUnknown
+	}
+
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		switch m.Mergeable {
+		case "MERGEABLE":
+			return campaigns.ChangesetMergeableStateMergeable
+		case "CONFLICTING":
+			return campaigns.ChangesetMergeableStateConflicting
+		}
+
+	case *gitlab.MergeRequest:
+		switch m.MergeStatus {
+		case gitlab.MergeStatusCanBeMerged:
+			return campaigns.ChangesetMergeableStateMergeable
+		case gitlab.MergeStatusCannotBeMerged:
+			return campaigns.ChangesetMergeableStateConflicting
+		}
+
+	case *bitbucketserver.PullRequest:
+		if m.MergeStatus == nil {
+			break
+		}
+		switch m.MergeStatus.Outcome {
+		case bitbucketserver.MergeOutcomeClean:
+			return campaigns.ChangesetMergeableStateMergeable
+		case bitbucketserver.MergeOutcomeConflicted:
+			return campaigns.ChangesetMergeableStateConflicting
+		}
+	}
+
+	return campaigns.ChangesetMergeableStateUnknown
+}
+
 // computeCheckState computes the overall check state based on the current
 // synced check state and any webhook events that have arrived after the most
 // recent sync.
@@ -103,7 +146,7 @@ func computeCheckState(c *campaigns.Changeset, events ChangesetEvents) campaigns
 		return computeBitbucketBuildStatus(c.UpdatedAt, m, events)
 
 	case *gitlab.MergeRequest:
-		return computeGitLabCheckState(m)
+		return computeGitLabCheckState(c.UpdatedAt, m, events)
 	}
 
 	return campaigns.ChangesetCheckStateUnknown
@@ -119,6 +162,11 @@ func computeExternalState(c *campaigns.Changeset, history []changesetStatesAtTim
 	if c.UpdatedAt.After(newestDataPoint.t) {
 		return computeSingleChangesetExternalState(c)
 	}
+	// The history doesn't track whether a changeset is a draft, so we take
+	// that from the metadata.
+	if newestDataPoint.externalState == campaigns.ChangesetExternalStateOpen && c.IsDraft() {
+		return campaigns.ChangesetExternalStateDraft, nil
+	}
 	return newestDataPoint.externalState, nil
 }
 
@@ -352,15 +400,26 @@ func parseGithubCheckSuiteState(status, conclusion string) campaigns.ChangesetCh
 	return campaigns.ChangesetCheckStateUnknown
 }
 
-func computeGitLabCheckState(mr *gitlab.MergeRequest) campaigns.ChangesetCheckState {
+func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*campaigns.ChangesetEvent) campaigns.ChangesetCheckState {
 	// GitLab pipelines aren't tied to commits in the same way that GitHub
-	// checks are. In the (current) absence of webhooks, the process here is
-	// pretty straightforward: the latest pipeline wins. They _should_ be in
-	// descending order, but we'll sort them just to be sure.
+	// checks are, so the process here is pretty straightforward: the latest
+	// pipeline wins.
+	//
+	// Pipelines from the last sync are updated with any pipeline webhook
+	// events that have arrived since.
+	pipelines := make([]*gitlab.Pipeline, len(mr.Pipelines))
+	copy(pipelines, mr.Pipelines)
+	for _, e := range events {
+		p, ok := e.Metadata.(*gitlab.Pipeline)
+		if !ok || !p.UpdatedAt.After(lastSynced) {
+			continue
+		}
+		pipelines = upsertGitLabPipeline(pipelines, p)
+	}
 
 	// First up, a special case: if there are no pipelines, we'll try to use
 	// HeadPipeline. If that's empty, then we'll shrug and say we don't know.
-	if len(mr.Pipelines) == 0 {
+	if len(pipelines) == 0 {
 		if mr.HeadPipeline != nil {
 			return parseGitLabPipelineStatus(mr.HeadPipeline.Status)
 		}
@@ -368,16 +427,25 @@ func computeGitLabCheckState(mr *gitlab.MergeRequest) campaigns.ChangesetCheckSt
 	}
 
 	// Sort into descending order so that the pipeline at index 0 is the latest.
-	pipelines := mr.Pipelines
 	sort.Slice(pipelines, func(i, j int) bool {
 		return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt)
 	})
 
-	// TODO: after webhooks, look at changeset events.
-
 	return parseGitLabPipelineStatus(pipelines[0].Status)
 }
 
+// upsertGitLabPipeline replaces the pipeline with the same ID as p in
+// pipelines, or appends p if there is none.
+func upsertGitLabPipeline(pipelines []*gitlab.Pipeline, p *gitlab.Pipeline) []*gitlab.Pipeline {
+	for i := range pipelines {
+		if pipelines[i].ID == p.ID {
+			pipelines[i] = p
+			return pipelines
+		}
+	}
+	return append(pipelines, p)
+}
+
 func parseGitLabPipelineStatus(status gitlab.PipelineStatus) campaigns.ChangesetCheckState {
 	switch status {
 	case gitlab.PipelineStatusSuccess:
@@ -408,7 +476,6 @@ func computeSingleChangesetExternalState(c *campaigns.Changeset) (s campaigns.Ch
 			s = campaigns.ChangesetExternalState(m.State)
 		}
 	case *gitlab.MergeRequest:
-		// TODO: implement webhook support
 		switch m.State {
 		case gitlab.MergeRequestStateClosed, gitlab.MergeRequestStateLocked:
 			s = campaigns.ChangesetExternalStateClosed
@@ -427,6 +494,10 @@ func computeSingleChangesetExternalState(c *campaigns.Changeset) (s campaigns.Ch
 		return "", errors.Errorf("changeset state %q invalid", s)
 	}
 
+	if s == campaigns.ChangesetExternalStateOpen && c.IsDraft() {
+		s = campaigns.ChangesetExternalStateDraft
+	}
+
 	return s, nil
 }
 
@@ -264,9 +264,19 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 }
 
 func TestComputeGitLabCheckState(t *testing.T) {
+	lastSynced := time.Unix(20, 0)
+	pipelineEvent := func(p *gitlab.Pipeline) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			Kind:     cmpgn.ChangesetEventKindGitLabPipeline,
+			Key:      p.Key(),
+			Metadata: p,
+		}
+	}
+
 	for name, tc := range map[string]struct {
-		mr   *gitlab.MergeRequest
-		want cmpgn.ChangesetCheckState
+		mr     *gitlab.MergeRequest
+		events []*cmpgn.ChangesetEvent
+		want   cmpgn.ChangesetCheckState
 	}{
 		"no pipelines at all": {
 			mr:   &gitlab.MergeRequest{},
@@ -330,9 +340,72 @@ func TestComputeGitLabCheckState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetCheckStatePassed,
 		},
+		"webhook updates a synced pipeline": {
+			mr: &gitlab.MergeRequest{
+				Pipelines: []*gitlab.Pipeline{
+					{
+						ID:        1,
+						CreatedAt: time.Unix(10, 0),
+						UpdatedAt: time.Unix(10, 0),
+						Status:    gitlab.PipelineStatusPending,
+					},
+				},
+			},
+			events: []*cmpgn.ChangesetEvent{
+				pipelineEvent(&gitlab.Pipeline{
+					ID:        1,
+					CreatedAt: time.Unix(10, 0),
+					UpdatedAt: time.Unix(30, 0),
+					Status:    gitlab.PipelineStatusSuccess,
+				}),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		"webhook adds a new pipeline": {
+			mr: &gitlab.MergeRequest{
+				Pipelines: []*gitlab.Pipeline{
+					{
+						ID:        1,
+						CreatedAt: time.Unix(10, 0),
+						UpdatedAt: time.Unix(10, 0),
+						Status:    gitlab.PipelineStatusSuccess,
+					},
+				},
+			},
+			events: []*cmpgn.ChangesetEvent{
+				pipelineEvent(&gitlab.Pipeline{
+					ID:        2,
+					CreatedAt: time.Unix(25, 0),
+					UpdatedAt: time.Unix(30, 0),
+					Status:    gitlab.PipelineStatusFailed,
+				}),
+			},
+			want: cmpgn.ChangesetCheckStateFailed,
+		},
+		"pipeline events older than the last sync are ignored": {
+			mr: &gitlab.MergeRequest{
+				Pipelines: []*gitlab.Pipeline{
+					{
+						ID:        1,
+						CreatedAt: time.Unix(10, 0),
+						UpdatedAt: time.Unix(15, 0),
+						Status:    gitlab.PipelineStatusSuccess,
+					},
+				},
+			},
+			events: []*cmpgn.ChangesetEvent{
+				pipelineEvent(&gitlab.Pipeline{
+					ID:        1,
+					CreatedAt: time.Unix(10, 0),
+					UpdatedAt: time.Unix(12, 0),
+					Status:    gitlab.PipelineStatusPending,
+				}),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
-			have := computeGitLabCheckState(tc.mr)
+			have := computeGitLabCheckState(lastSynced, tc.mr, tc.events)
 			if have != tc.want {
 				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
 			}
@@ -525,6 +598,28 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetExternalStateDeleted,
 		},
+		{
+			name:      "github - draft, no events",
+			changeset: setDraft(githubChangeset(daysAgo(10), "OPEN")),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "github - draft, changeset older than events",
+			changeset: setDraft(githubChangeset(daysAgo(10), "OPEN")),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: campaigns.ChangesetExternalStateOpen},
+			},
+			want: cmpgn.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "github - draft, changeset older than closing event",
+			changeset: setDraft(githubChangeset(daysAgo(10), "OPEN")),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: campaigns.ChangesetExternalStateClosed},
+			},
+			want: cmpgn.ChangesetExternalStateClosed,
+		},
 		{
 			name:      "bitbucketserver - no events",
 			changeset: bitbucketChangeset(daysAgo(10), "OPEN", "NEEDS_WORK"),
@@ -561,6 +656,12 @@ func TestComputeExternalState(t *testing.T) {
 			history:   []changesetStatesAtTime{},
 			want:      cmpgn.ChangesetExternalStateOpen,
 		},
+		{
+			name:      "gitlab - no events, work in progress",
+			changeset: setDraft(gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetExternalStateDraft,
+		},
 		{
 			name:      "gitlab - no events, closed",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateClosed, nil),
@@ -613,6 +714,102 @@ func TestComputeExternalState(t *testing.T) {
 	}
 }
 
+func TestComputeMergeableState(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	open := func(c *campaigns.Changeset) *campaigns.Changeset {
+		c.ExternalState = campaigns.ChangesetExternalStateOpen
+		return c
+	}
+
+	githubMergeable := func(mergeable string) *campaigns.Changeset {
+		c := open(githubChangeset(now, "OPEN"))
+		c.Metadata.(*github.PullRequest).Mergeable = mergeable
+		return c
+	}
+
+	gitLabMergeable := func(status gitlab.MergeStatus) *campaigns.Changeset {
+		c := open(gitLabChangeset(now, gitlab.MergeRequestStateOpened, nil))
+		c.Metadata.(*gitlab.MergeRequest).MergeStatus = status
+		return c
+	}
+
+	bitbucketMergeable := func(status *bitbucketserver.MergeStatus) *campaigns.Changeset {
+		c := open(bitbucketChangeset(now, "OPEN", "NEEDS_WORK"))
+		c.Metadata.(*bitbucketserver.PullRequest).MergeStatus = status
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		changeset *campaigns.Changeset
+		want      cmpgn.ChangesetMergeableState
+	}{
+		{
+			name:      "github - mergeable",
+			changeset: githubMergeable("MERGEABLE"),
+			want:      cmpgn.ChangesetMergeableStateMergeable,
+		},
+		{
+			name:      "github - conflicting",
+			changeset: githubMergeable("CONFLICTING"),
+			want:      cmpgn.ChangesetMergeableStateConflicting,
+		},
+		{
+			name:      "github - unknown",
+			changeset: githubMergeable("UNKNOWN"),
+			want:      cmpgn.ChangesetMergeableStateUnknown,
+		},
+		{
+			name: "github - closed",
+			changeset: func() *campaigns.Changeset {
+				c := githubMergeable("CONFLICTING")
+				c.ExternalState = campaigns.ChangesetExternalStateClosed
+				return c
+			}(),
+			want: cmpgn.ChangesetMergeableStateUnknown,
+		},
+		{
+			name:      "gitlab - can be merged",
+			changeset: gitLabMergeable(gitlab.MergeStatusCanBeMerged),
+			want:      cmpgn.ChangesetMergeableStateMergeable,
+		},
+		{
+			name:      "gitlab - cannot be merged",
+			changeset: gitLabMergeable(gitlab.MergeStatusCannotBeMerged),
+			want:      cmpgn.ChangesetMergeableStateConflicting,
+		},
+		{
+			name:      "gitlab - checking",
+			changeset: gitLabMergeable(gitlab.MergeStatusChecking),
+			want:      cmpgn.ChangesetMergeableStateUnknown,
+		},
+		{
+			name:      "bitbucketserver - clean",
+			changeset: bitbucketMergeable(&bitbucketserver.MergeStatus{Outcome: bitbucketserver.MergeOutcomeClean}),
+			want:      cmpgn.ChangesetMergeableStateMergeable,
+		},
+		{
+			name:      "bitbucketserver - conflicted",
+			changeset: bitbucketMergeable(&bitbucketserver.MergeStatus{Outcome: bitbucketserver.MergeOutcomeConflicted}),
+			want:      cmpgn.ChangesetMergeableStateConflicting,
+		},
+		{
+			name:      "bitbucketserver - no merge status",
+			changeset: bitbucketMergeable(nil),
+			want:      cmpgn.ChangesetMergeableStateUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have, want := computeMergeableState(tc.changeset), tc.want; have != want {
+				t.Errorf("wrong mergeable state. have=%s, want=%s", have, want)
+			}
+		})
+	}
+}
+
 func TestComputeLabels(t *testing.T) {
 	now := time.Now()
 	labelEvent := func(name string, kind cmpgn.ChangesetEventKind, when time.Time) *cmpgn.ChangesetEvent {
@@ -754,3 +951,13 @@ func setDeletedAt(c *campaigns.Changeset, deletedAt time.Time) *campaigns.Change
 	c.ExternalDeletedAt = deletedAt
 	return c
 }
+
+func setDraft(c *campaigns.Changeset) *campaigns.Changeset {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		m.IsDraft = true
+	case *gitlab.MergeRequest:
+		m.WorkInProgress = true
+	}
+	return c
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/segmentio/fasthash/fnv1"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -48,6 +49,13 @@ func NewStoreWithClock(db dbutil.DB, clock func() time.Time) *Store {
 // Clock returns the clock used by the Store.
 func (s *Store) Clock() func() time.Time { return s.now }
 
+// With returns a Store that shares the database handle (and therefore the
+// transaction) of the given basestore.ShareableStore, such as the
+// workerutil.Store passed to the handler of a workerutil.Worker.
+func (s *Store) With(other basestore.ShareableStore) *Store {
+	return &Store{db: other.Handle().DB(), now: s.now}
+}
+
 // Transact returns a Store whose methods operate within the context of a transaction.
 // This method will return an error if the underlying DB cannot be interface upgraded
 // to a TxBeginner.
@@ -221,6 +229,7 @@ WITH batch AS (
       external_state        text,
       external_review_state text,
       external_check_state  text,
+      external_mergeable_state text,
       created_by_campaign   boolean,
       added_to_campaign     boolean,
       diff_stat_added       integer,
@@ -250,6 +259,7 @@ changed AS (
     external_state,
     external_review_state,
     external_check_state,
+    external_mergeable_state,
     created_by_campaign,
     added_to_campaign,
     diff_stat_added,
@@ -271,6 +281,7 @@ changed AS (
     external_state,
     external_review_state,
     external_check_state,
+    external_mergeable_state,
     created_by_campaign,
     added_to_campaign,
     diff_stat_added,
@@ -301,6 +312,7 @@ SELECT
   COALESCE(changed.external_state, existing.external_state) AS external_state,
   COALESCE(changed.external_review_state, existing.external_review_state) AS external_review_state,
   COALESCE(changed.external_check_state, existing.external_check_state) AS external_check_state,
+  COALESCE(changed.external_mergeable_state, existing.external_mergeable_state) AS external_mergeable_state,
   COALESCE(changed.created_by_campaign, existing.created_by_campaign) AS created_by_campaign,
   COALESCE(changed.added_to_campaign, existing.added_to_campaign) AS added_to_campaign,
   COALESCE(changed.diff_stat_added, existing.diff_stat_added) AS diff_stat_added,
@@ -331,26 +343,27 @@ func (s *Store) createChangesetsQuery(cs []*campaigns.Changeset) (*sqlf.Query, e
 
 func batchChangesetsQuery(fmtstr string, cs []*campaigns.Changeset) (*sqlf.Query, error) {
 	type record struct {
-		ID                  int64                             `json:"id"`
-		RepoID              api.RepoID                        `json:"repo_id"`
-		CreatedAt           time.Time                         `json:"created_at"`
-		UpdatedAt           time.Time                         `json:"updated_at"`
-		Metadata            json.RawMessage                   `json:"metadata"`
-		CampaignIDs         json.RawMessage                   `json:"campaign_ids"`
-		ExternalID          string                            `json:"external_id"`
-		ExternalServiceType string                            `json:"external_service_type"`
-		ExternalBranch      string                            `json:"external_branch"`
-		ExternalDeletedAt   *time.Time                        `json:"external_deleted_at"`
-		ExternalUpdatedAt   *time.Time                        `json:"external_updated_at"`
-		ExternalState       *campaigns.ChangesetExternalState `json:"external_state"`
-		ExternalReviewState *campaigns.ChangesetReviewState   `json:"external_review_state"`
-		ExternalCheckState  *campaigns.ChangesetCheckState    `json:"external_check_state"`
-		CreatedByCampaign   bool                              `json:"created_by_campaign"`
-		AddedToCampaign     bool                              `json:"added_to_campaign"`
-		DiffStatAdded       *int32                            `json:"diff_stat_added"`
-		DiffStatChanged     *int32                            `json:"diff_stat_changed"`
-		DiffStatDeleted     *int32                            `json:"diff_stat_deleted"`
-		SyncState           json.RawMessage                   `json:"sync_state"`
+		ID                     int64                              `json:"id"`
+		RepoID                 api.RepoID                         `json:"repo_id"`
+		CreatedAt              time.Time                          `json:"created_at"`
+		UpdatedAt              time.Time                          `json:"updated_at"`
+		Metadata               json.RawMessage                    `json:"metadata"`
+		CampaignIDs            json.RawMessage                    `json:"campaign_ids"`
+		ExternalID             string                             `json:"external_id"`
+		ExternalServiceType    string                             `json:"external_service_type"`
+		ExternalBranch         string                             `json:"external_branch"`
+		ExternalDeletedAt      *time.Time                         `json:"external_deleted_at"`
+		ExternalUpdatedAt      *time.Time                         `json:"external_updated_at"`
+		ExternalState          *campaigns.ChangesetExternalState  `json:"external_state"`
+		ExternalReviewState    *campaigns.ChangesetReviewState    `json:"external_review_state"`
+		ExternalCheckState     *campaigns.ChangesetCheckState     `json:"external_check_state"`
+		ExternalMergeableState *campaigns.ChangesetMergeableState `json:"external_mergeable_state"`
+		CreatedByCampaign      bool                               `json:"created_by_campaign"`
+		AddedToCampaign        bool                               `json:"added_to_campaign"`
+		DiffStatAdded          *int32                             `json:"diff_stat_added"`
+		DiffStatChanged        *int32                             `json:"diff_stat_changed"`
+		DiffStatDeleted        *int32                             `json:"diff_stat_deleted"`
+		SyncState              json.RawMessage                    `json:"sync_state"`
 	}
 
 	records := make([]record, 0, len(cs))
@@ -399,6 +412,9 @@ func batchChangesetsQuery(fmtstr string, cs []*campaigns.Changeset) (*sqlf.Query
 		if len(c.ExternalCheckState) > 0 {
 			r.ExternalCheckState = &c.ExternalCheckState
 		}
+		if len(c.ExternalMergeableState) > 0 {
+			r.ExternalMergeableState = &c.ExternalMergeableState
+		}
 
 		records = append(records, r)
 	}
@@ -520,6 +536,7 @@ SELECT
   changesets.external_state,
   changesets.external_review_state,
   changesets.external_check_state,
+  changesets.external_mergeable_state,
   changesets.created_by_campaign,
   changesets.added_to_campaign,
   changesets.diff_stat_added,
@@ -621,6 +638,65 @@ func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
 	return sqlf.Sprintf(fmtString, sqlf.Join(preds, "\n AND"))
 }
 
+// CampaignChangesetStats are the number of changesets of an open campaign
+// in the states tracked by the campaign metrics.
+type CampaignChangesetStats struct {
+	CampaignID   int64
+	CampaignName string
+
+	// Open includes draft changesets.
+	Open   int32
+	Merged int32
+	Closed int32
+	// Failing are the open changesets whose checks failed.
+	Failing int32
+}
+
+// ListCampaignChangesetStats returns the CampaignChangesetStats of all open
+// campaigns, ignoring changesets that were deleted on the code host.
+func (s *Store) ListCampaignChangesetStats(ctx context.Context) ([]*CampaignChangesetStats, error) {
+	q := sqlf.Sprintf(listCampaignChangesetStatsQueryFmtstr)
+
+	stats := make([]*CampaignChangesetStats, 0)
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var st CampaignChangesetStats
+		if err = sc.Scan(
+			&st.CampaignID,
+			&st.CampaignName,
+			&st.Open,
+			&st.Merged,
+			&st.Closed,
+			&st.Failing,
+		); err != nil {
+			return 0, 0, err
+		}
+		stats = append(stats, &st)
+		return st.CampaignID, 1, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+var listCampaignChangesetStatsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaignChangesetStats
+SELECT
+  campaigns.id,
+  campaigns.name,
+  COUNT(changesets.id) FILTER (WHERE changesets.external_state IN ('OPEN', 'DRAFT')),
+  COUNT(changesets.id) FILTER (WHERE changesets.external_state = 'MERGED'),
+  COUNT(changesets.id) FILTER (WHERE changesets.external_state = 'CLOSED'),
+  COUNT(changesets.id) FILTER (WHERE changesets.external_state IN ('OPEN', 'DRAFT') AND changesets.external_check_state = 'FAILED')
+FROM campaigns
+LEFT JOIN changesets
+  ON changesets.campaign_ids ? campaigns.id::TEXT
+  AND changesets.external_deleted_at IS NULL
+WHERE campaigns.closed_at IS NULL
+GROUP BY campaigns.id
+ORDER BY campaigns.id ASC
+`
+
 // ListChangesetsOpts captures the query options needed for
 // listing changesets.
 type ListChangesetsOpts struct {
@@ -632,6 +708,7 @@ type ListChangesetsOpts struct {
 	ExternalState        *campaigns.ChangesetExternalState
 	ExternalReviewState  *campaigns.ChangesetReviewState
 	ExternalCheckState   *campaigns.ChangesetCheckState
+	RepoIDs              []api.RepoID
 	OnlyWithoutDiffStats bool
 }
 
@@ -674,6 +751,7 @@ SELECT
   changesets.external_state,
   changesets.external_review_state,
   changesets.external_check_state,
+  changesets.external_mergeable_state,
   changesets.created_by_campaign,
   changesets.added_to_campaign,
   changesets.diff_stat_added,
@@ -732,6 +810,14 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
 
+	if len(opts.RepoIDs) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.RepoIDs))
+		for _, id := range opts.RepoIDs {
+			ids = append(ids, sqlf.Sprintf("%d", id))
+		}
+		preds = append(preds, sqlf.Sprintf("changesets.repo_id IN (%s)", sqlf.Join(ids, ",")))
+	}
+
 	if opts.OnlyWithoutDiffStats {
 		preds = append(preds, sqlf.Sprintf("(changesets.diff_stat_added IS NULL OR changesets.diff_stat_changed IS NULL OR changesets.diff_stat_deleted IS NULL)"))
 	}
@@ -775,6 +861,7 @@ changed AS (
     external_state        = batch.external_state,
     external_review_state = batch.external_review_state,
     external_check_state  = batch.external_check_state,
+    external_mergeable_state = batch.external_mergeable_state,
     created_by_campaign   = batch.created_by_campaign,
     added_to_campaign     = batch.added_to_campaign,
     diff_stat_added       = batch.diff_stat_added,
@@ -803,6 +890,7 @@ SELECT
   changed.external_state,
   changed.external_review_state,
   changed.external_check_state,
+  changed.external_mergeable_state,
   changed.created_by_campaign,
   changed.added_to_campaign,
   changed.diff_stat_added,
@@ -1625,9 +1713,10 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 	var metadata, syncState json.RawMessage
 
 	var (
-		externalState       string
-		externalReviewState string
-		externalCheckState  string
+		externalState          string
+		externalReviewState    string
+		externalCheckState     string
+		externalMergeableState string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1644,6 +1733,7 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		&dbutil.NullString{S: &externalState},
 		&dbutil.NullString{S: &externalReviewState},
 		&dbutil.NullString{S: &externalCheckState},
+		&dbutil.NullString{S: &externalMergeableState},
 		&t.CreatedByCampaign,
 		&t.AddedToCampaign,
 		&t.DiffStatAdded,
@@ -1658,6 +1748,7 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 	t.ExternalState = campaigns.ChangesetExternalState(externalState)
 	t.ExternalReviewState = campaigns.ChangesetReviewState(externalReviewState)
 	t.ExternalCheckState = campaigns.ChangesetCheckState(externalCheckState)
+	t.ExternalMergeableState = campaigns.ChangesetMergeableState(externalMergeableState)
 
 	switch t.ExternalServiceType {
 	case extsvc.TypeGitHub:
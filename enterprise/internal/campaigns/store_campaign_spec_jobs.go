@@ -0,0 +1,350 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// CampaignSpecJobStalledMaxAge is the maximum duration a CampaignSpecJob can
+// stay in the processing state without being locked by an executor before it
+// is reset.
+const CampaignSpecJobStalledMaxAge = 5 * time.Second
+
+// CampaignSpecJobMaxNumResets is the maximum number of times a
+// CampaignSpecJob is reset after its executor died before it is marked as
+// errored.
+const CampaignSpecJobMaxNumResets = 3
+
+const campaignSpecJobInsertCols = `
+  campaign_spec_id,
+  repo_id,
+  state,
+  failure_message,
+  started_at,
+  finished_at,
+  process_after,
+  num_resets,
+  num_failures,
+  log,
+  changeset_spec_id,
+  created_at,
+  updated_at
+`
+const campaignSpecJobInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`
+
+const campaignSpecJobCols = `
+  id,` + campaignSpecJobInsertCols
+
+// campaignSpecJobColumns are the columns selected by the workerutil.Store
+// returned by WorkerutilCampaignSpecJobStore.
+var campaignSpecJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("campaign_spec_id"),
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("log"),
+	sqlf.Sprintf("changeset_spec_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// WorkerutilCampaignSpecJobStore returns a workerutil.Store that dequeues
+// CampaignSpecJobs in the order they were created.
+func WorkerutilCampaignSpecJobStore(s *Store) workerutil.Store {
+	return workerutil.NewStore(basestore.NewHandleWithDB(s.db), workerutil.StoreOptions{
+		TableName:         "campaign_spec_jobs",
+		ColumnExpressions: campaignSpecJobColumns,
+		Scan:              scanFirstCampaignSpecJobRecord,
+		OrderByExpression: sqlf.Sprintf("id"),
+		StalledMaxAge:     CampaignSpecJobStalledMaxAge,
+		MaxNumResets:      CampaignSpecJobMaxNumResets,
+	})
+}
+
+// CreateCampaignSpecJob creates the given CampaignSpecJob.
+func (s *Store) CreateCampaignSpecJob(ctx context.Context, j *campaigns.CampaignSpecJob) error {
+	q := s.createCampaignSpecJobQuery(j)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignSpecJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var createCampaignSpecJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_jobs.go:CreateCampaignSpecJob
+INSERT INTO campaign_spec_jobs (` + campaignSpecJobInsertCols + `)
+VALUES ` + campaignSpecJobInsertColsFmt + `
+RETURNING ` + campaignSpecJobCols + `;`
+
+func (s *Store) createCampaignSpecJobQuery(j *campaigns.CampaignSpecJob) *sqlf.Query {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	if j.State == "" {
+		j.State = campaigns.CampaignSpecJobStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createCampaignSpecJobQueryFmtstr,
+		j.CampaignSpecID,
+		j.RepoID,
+		j.State,
+		nullStringColumn(j.FailureMessage),
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		nullTimeColumn(j.ProcessAfter),
+		j.NumResets,
+		j.NumFailures,
+		j.Log,
+		nullInt64Column(j.ChangesetSpecID),
+		j.CreatedAt,
+		j.UpdatedAt,
+	)
+}
+
+// UpdateCampaignSpecJob updates the given CampaignSpecJob.
+func (s *Store) UpdateCampaignSpecJob(ctx context.Context, j *campaigns.CampaignSpecJob) error {
+	q := s.updateCampaignSpecJobQuery(j)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignSpecJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var updateCampaignSpecJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_jobs.go:UpdateCampaignSpecJob
+UPDATE campaign_spec_jobs
+SET (` + campaignSpecJobInsertCols + `) = ` + campaignSpecJobInsertColsFmt + `
+WHERE id = %s
+RETURNING ` + campaignSpecJobCols
+
+func (s *Store) updateCampaignSpecJobQuery(j *campaigns.CampaignSpecJob) *sqlf.Query {
+	j.UpdatedAt = s.now()
+
+	return sqlf.Sprintf(
+		updateCampaignSpecJobQueryFmtstr,
+		j.CampaignSpecID,
+		j.RepoID,
+		j.State,
+		nullStringColumn(j.FailureMessage),
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		nullTimeColumn(j.ProcessAfter),
+		j.NumResets,
+		j.NumFailures,
+		j.Log,
+		nullInt64Column(j.ChangesetSpecID),
+		j.CreatedAt,
+		j.UpdatedAt,
+		j.ID,
+	)
+}
+
+// GetCampaignSpecJobOpts captures the query options needed for getting a
+// CampaignSpecJob.
+type GetCampaignSpecJobOpts struct {
+	ID int64
+}
+
+// GetCampaignSpecJob gets a CampaignSpecJob matching the given options.
+func (s *Store) GetCampaignSpecJob(ctx context.Context, opts GetCampaignSpecJobOpts) (*campaigns.CampaignSpecJob, error) {
+	q := getCampaignSpecJobQuery(&opts)
+
+	var j campaigns.CampaignSpecJob
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanCampaignSpecJob(&j, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var getCampaignSpecJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_jobs.go:GetCampaignSpecJob
+SELECT ` + campaignSpecJobCols + `
+FROM campaign_spec_jobs
+WHERE %s
+LIMIT 1
+`
+
+func getCampaignSpecJobQuery(opts *GetCampaignSpecJobOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id = %s", opts.ID),
+	}
+
+	return sqlf.Sprintf(getCampaignSpecJobQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// CountCampaignSpecJobsOpts captures the query options needed for counting
+// CampaignSpecJobs.
+type CountCampaignSpecJobsOpts struct {
+	CampaignSpecID int64
+	State          campaigns.CampaignSpecJobState
+}
+
+// CountCampaignSpecJobs returns the number of CampaignSpecJobs in the
+// database.
+func (s *Store) CountCampaignSpecJobs(ctx context.Context, opts CountCampaignSpecJobsOpts) (count int64, _ error) {
+	q := countCampaignSpecJobsQuery(&opts)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countCampaignSpecJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_jobs.go:CountCampaignSpecJobs
+SELECT COUNT(id)
+FROM campaign_spec_jobs
+WHERE %s
+`
+
+func countCampaignSpecJobsQuery(opts *CountCampaignSpecJobsOpts) *sqlf.Query {
+	return sqlf.Sprintf(countCampaignSpecJobsQueryFmtstr, campaignSpecJobsPreds(opts.CampaignSpecID, opts.State))
+}
+
+// ListCampaignSpecJobsOpts captures the query options needed for listing
+// CampaignSpecJobs.
+type ListCampaignSpecJobsOpts struct {
+	Cursor         int64
+	Limit          int
+	CampaignSpecID int64
+	State          campaigns.CampaignSpecJobState
+}
+
+// ListCampaignSpecJobs lists CampaignSpecJobs with the given filters.
+func (s *Store) ListCampaignSpecJobs(ctx context.Context, opts ListCampaignSpecJobsOpts) (js []*campaigns.CampaignSpecJob, next int64, err error) {
+	q := listCampaignSpecJobsQuery(&opts)
+
+	js = make([]*campaigns.CampaignSpecJob, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.CampaignSpecJob
+		if err = scanCampaignSpecJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(js) == opts.Limit {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
+	}
+
+	return js, next, err
+}
+
+var listCampaignSpecJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_jobs.go:ListCampaignSpecJobs
+SELECT ` + campaignSpecJobCols + ` FROM campaign_spec_jobs
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignSpecJobsQuery(opts *ListCampaignSpecJobsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id >= %s", opts.Cursor),
+		campaignSpecJobsPreds(opts.CampaignSpecID, opts.State),
+	}
+
+	return sqlf.Sprintf(
+		listCampaignSpecJobsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func campaignSpecJobsPreds(campaignSpecID int64, state campaigns.CampaignSpecJobState) *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if campaignSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_spec_id = %s", campaignSpecID))
+	}
+
+	if state != "" {
+		preds = append(preds, sqlf.Sprintf("state = %s", state))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Join(preds, "\n AND ")
+}
+
+func scanCampaignSpecJob(j *campaigns.CampaignSpecJob, s scanner) error {
+	err := s.Scan(
+		&j.ID,
+		&j.CampaignSpecID,
+		&j.RepoID,
+		&j.State,
+		&dbutil.NullString{S: &j.FailureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.NumFailures,
+		&j.Log,
+		&dbutil.NullInt64{N: &j.ChangesetSpecID},
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+
+	return errors.Wrap(err, "scanning campaign spec job")
+}
+
+// scanFirstCampaignSpecJobRecord scans the first CampaignSpecJob of the given
+// rows. It implements workerutil.RecordScanFn.
+func scanFirstCampaignSpecJobRecord(rows *sql.Rows, err error) (_ workerutil.Record, exists bool, _ error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var j campaigns.CampaignSpecJob
+	_, count, err := scanAll(rows, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignSpecJob(&j, sc)
+		return j.ID, 1, err
+	})
+	if err != nil || count == 0 {
+		return nil, false, err
+	}
+
+	return &j, true, nil
+}
@@ -0,0 +1,187 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreCampaignSpecJobs(t *testing.T, ctx context.Context, s *Store, rs repos.Store, clock clock) {
+	repo := testRepo(1, extsvc.TypeGitHub)
+	if err := rs.UpsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	campaignSpec := &cmpgn.CampaignSpec{UserID: 1234, NamespaceUserID: 1234}
+	if err := s.CreateCampaignSpec(ctx, campaignSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := make([]*cmpgn.CampaignSpecJob, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(jobs); i++ {
+			j := &cmpgn.CampaignSpecJob{
+				CampaignSpecID: campaignSpec.ID,
+				RepoID:         repo.ID,
+			}
+
+			if i == cap(jobs)-1 {
+				j.CampaignSpecID = campaignSpec.ID + 1
+			}
+
+			want := j.Clone()
+			have := j
+
+			if err := s.CreateCampaignSpecJob(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.State = cmpgn.CampaignSpecJobStateQueued
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			jobs = append(jobs, j)
+		}
+	})
+
+	if len(jobs) != cap(jobs) {
+		t.Fatalf("jobs is empty. creation failed")
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountCampaignSpecJobs(ctx, CountCampaignSpecJobsOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, int64(len(jobs)); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		t.Run("WithCampaignSpecID", func(t *testing.T) {
+			count, err := s.CountCampaignSpecJobs(ctx, CountCampaignSpecJobsOpts{CampaignSpecID: campaignSpec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := count, int64(len(jobs)-1); have != want {
+				t.Fatalf("have count: %d, want: %d", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("NoLimit", func(t *testing.T) {
+			have, next, err := s.ListCampaignSpecJobs(ctx, ListCampaignSpecJobsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next != 0 {
+				t.Fatalf("have next %d, want 0", next)
+			}
+
+			if diff := cmp.Diff(have, jobs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimitAndCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(jobs); i++ {
+				opts := ListCampaignSpecJobsOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListCampaignSpecJobs(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := jobs[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+
+		t.Run("WithCampaignSpecID", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecJobs(ctx, ListCampaignSpecJobsOpts{CampaignSpecID: campaignSpec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, jobs[:len(jobs)-1]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithState", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecJobs(ctx, ListCampaignSpecJobsOpts{State: cmpgn.CampaignSpecJobStateErrored})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(have) != 0 {
+				t.Fatalf("listed %d jobs, want: 0", len(have))
+			}
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		for _, j := range jobs {
+			j.State = cmpgn.CampaignSpecJobStateErrored
+			j.FailureMessage = "step 1 failed"
+			j.NumFailures = 3
+			j.Log = "--- step 1: exit 1\n"
+
+			clock.add(1 * time.Second)
+
+			want := j
+			want.UpdatedAt = clock.now()
+
+			have := j.Clone()
+			if err := s.UpdateCampaignSpecJob(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		want := jobs[1]
+
+		have, err := s.GetCampaignSpecJob(ctx, GetCampaignSpecJobOpts{ID: want.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetCampaignSpecJob(ctx, GetCampaignSpecJobOpts{ID: 0xdeadbeef})
+			if want := ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+}
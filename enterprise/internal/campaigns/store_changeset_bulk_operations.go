@@ -0,0 +1,466 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// ChangesetBulkOperationJobStalledMaxAge is the maximum duration a
+// ChangesetBulkOperationJob can stay in the processing state without being
+// locked by a worker before it is reset.
+const ChangesetBulkOperationJobStalledMaxAge = 5 * time.Second
+
+// ChangesetBulkOperationJobMaxNumResets is the maximum number of times a
+// ChangesetBulkOperationJob is reset after its worker died before it is
+// marked as errored.
+const ChangesetBulkOperationJobMaxNumResets = 3
+
+const changesetBulkOperationInsertCols = `
+  campaign_id,
+  user_id,
+  operation,
+  comment,
+  created_at,
+  updated_at
+`
+const changesetBulkOperationInsertColsFmt = `(%s, %s, %s, %s, %s, %s)`
+
+const changesetBulkOperationCols = `
+  id,` + changesetBulkOperationInsertCols
+
+// CreateChangesetBulkOperation creates the given ChangesetBulkOperation.
+func (s *Store) CreateChangesetBulkOperation(ctx context.Context, o *campaigns.ChangesetBulkOperation) error {
+	q := s.createChangesetBulkOperationQuery(o)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkOperation(o, sc)
+		return o.ID, 1, err
+	})
+}
+
+var createChangesetBulkOperationQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:CreateChangesetBulkOperation
+INSERT INTO changeset_bulk_operations (` + changesetBulkOperationInsertCols + `)
+VALUES ` + changesetBulkOperationInsertColsFmt + `
+RETURNING ` + changesetBulkOperationCols + `;`
+
+func (s *Store) createChangesetBulkOperationQuery(o *campaigns.ChangesetBulkOperation) *sqlf.Query {
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = s.now()
+	}
+
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = o.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createChangesetBulkOperationQueryFmtstr,
+		o.CampaignID,
+		o.UserID,
+		o.Operation,
+		o.Comment,
+		o.CreatedAt,
+		o.UpdatedAt,
+	)
+}
+
+// GetChangesetBulkOperationOpts captures the query options needed for
+// getting a ChangesetBulkOperation.
+type GetChangesetBulkOperationOpts struct {
+	ID int64
+}
+
+// GetChangesetBulkOperation gets a ChangesetBulkOperation matching the given
+// options.
+func (s *Store) GetChangesetBulkOperation(ctx context.Context, opts GetChangesetBulkOperationOpts) (*campaigns.ChangesetBulkOperation, error) {
+	q := getChangesetBulkOperationQuery(&opts)
+
+	var o campaigns.ChangesetBulkOperation
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanChangesetBulkOperation(&o, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if o.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &o, nil
+}
+
+var getChangesetBulkOperationQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:GetChangesetBulkOperation
+SELECT ` + changesetBulkOperationCols + `
+FROM changeset_bulk_operations
+WHERE %s
+LIMIT 1
+`
+
+func getChangesetBulkOperationQuery(opts *GetChangesetBulkOperationOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id = %s", opts.ID),
+	}
+
+	return sqlf.Sprintf(getChangesetBulkOperationQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// CountChangesetBulkOperationsOpts captures the query options needed for
+// counting ChangesetBulkOperations.
+type CountChangesetBulkOperationsOpts struct {
+	CampaignID int64
+}
+
+// CountChangesetBulkOperations returns the number of ChangesetBulkOperations
+// in the database.
+func (s *Store) CountChangesetBulkOperations(ctx context.Context, opts CountChangesetBulkOperationsOpts) (count int64, _ error) {
+	q := countChangesetBulkOperationsQuery(&opts)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countChangesetBulkOperationsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:CountChangesetBulkOperations
+SELECT COUNT(id)
+FROM changeset_bulk_operations
+WHERE %s
+`
+
+func countChangesetBulkOperationsQuery(opts *CountChangesetBulkOperationsOpts) *sqlf.Query {
+	return sqlf.Sprintf(countChangesetBulkOperationsQueryFmtstr, changesetBulkOperationsPreds(opts.CampaignID))
+}
+
+// ListChangesetBulkOperationsOpts captures the query options needed for
+// listing ChangesetBulkOperations.
+type ListChangesetBulkOperationsOpts struct {
+	Cursor     int64
+	Limit      int
+	CampaignID int64
+}
+
+// ListChangesetBulkOperations lists ChangesetBulkOperations with the given
+// filters, most recent first.
+func (s *Store) ListChangesetBulkOperations(ctx context.Context, opts ListChangesetBulkOperationsOpts) (ops []*campaigns.ChangesetBulkOperation, next int64, err error) {
+	q := listChangesetBulkOperationsQuery(&opts)
+
+	ops = make([]*campaigns.ChangesetBulkOperation, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var o campaigns.ChangesetBulkOperation
+		if err = scanChangesetBulkOperation(&o, sc); err != nil {
+			return 0, 0, err
+		}
+		ops = append(ops, &o)
+		return o.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(ops) == opts.Limit {
+		next = ops[len(ops)-1].ID
+		ops = ops[:len(ops)-1]
+	}
+
+	return ops, next, err
+}
+
+var listChangesetBulkOperationsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:ListChangesetBulkOperations
+SELECT ` + changesetBulkOperationCols + ` FROM changeset_bulk_operations
+WHERE %s
+ORDER BY id DESC
+`
+
+func listChangesetBulkOperationsQuery(opts *ListChangesetBulkOperationsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		changesetBulkOperationsPreds(opts.CampaignID),
+	}
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id <= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetBulkOperationsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func changesetBulkOperationsPreds(campaignID int64) *sqlf.Query {
+	if campaignID != 0 {
+		return sqlf.Sprintf("campaign_id = %s", campaignID)
+	}
+	return sqlf.Sprintf("TRUE")
+}
+
+func scanChangesetBulkOperation(o *campaigns.ChangesetBulkOperation, s scanner) error {
+	err := s.Scan(
+		&o.ID,
+		&o.CampaignID,
+		&o.UserID,
+		&o.Operation,
+		&o.Comment,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+	)
+
+	return errors.Wrap(err, "scanning changeset bulk operation")
+}
+
+const changesetBulkOperationJobInsertCols = `
+  bulk_operation_id,
+  changeset_id,
+  state,
+  failure_message,
+  started_at,
+  finished_at,
+  process_after,
+  num_resets,
+  num_failures,
+  created_at,
+  updated_at
+`
+const changesetBulkOperationJobInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`
+
+const changesetBulkOperationJobCols = `
+  id,` + changesetBulkOperationJobInsertCols
+
+// changesetBulkOperationJobColumns are the columns selected by the
+// workerutil.Store returned by WorkerutilChangesetBulkOperationJobStore.
+var changesetBulkOperationJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("bulk_operation_id"),
+	sqlf.Sprintf("changeset_id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// WorkerutilChangesetBulkOperationJobStore returns a workerutil.Store that
+// dequeues ChangesetBulkOperationJobs in the order they were created.
+func WorkerutilChangesetBulkOperationJobStore(s *Store) workerutil.Store {
+	return workerutil.NewStore(basestore.NewHandleWithDB(s.db), workerutil.StoreOptions{
+		TableName:         "changeset_bulk_operation_jobs",
+		ColumnExpressions: changesetBulkOperationJobColumns,
+		Scan:              scanFirstChangesetBulkOperationJobRecord,
+		OrderByExpression: sqlf.Sprintf("id"),
+		StalledMaxAge:     ChangesetBulkOperationJobStalledMaxAge,
+		MaxNumResets:      ChangesetBulkOperationJobMaxNumResets,
+	})
+}
+
+// CreateChangesetBulkOperationJob creates the given ChangesetBulkOperationJob.
+func (s *Store) CreateChangesetBulkOperationJob(ctx context.Context, j *campaigns.ChangesetBulkOperationJob) error {
+	q := s.createChangesetBulkOperationJobQuery(j)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkOperationJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var createChangesetBulkOperationJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:CreateChangesetBulkOperationJob
+INSERT INTO changeset_bulk_operation_jobs (` + changesetBulkOperationJobInsertCols + `)
+VALUES ` + changesetBulkOperationJobInsertColsFmt + `
+RETURNING ` + changesetBulkOperationJobCols + `;`
+
+func (s *Store) createChangesetBulkOperationJobQuery(j *campaigns.ChangesetBulkOperationJob) *sqlf.Query {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	if j.State == "" {
+		j.State = campaigns.ChangesetBulkOperationJobStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createChangesetBulkOperationJobQueryFmtstr,
+		j.BulkOperationID,
+		j.ChangesetID,
+		j.State,
+		nullStringColumn(j.FailureMessage),
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		nullTimeColumn(j.ProcessAfter),
+		j.NumResets,
+		j.NumFailures,
+		j.CreatedAt,
+		j.UpdatedAt,
+	)
+}
+
+// CountChangesetBulkOperationJobsOpts captures the query options needed for
+// counting ChangesetBulkOperationJobs.
+type CountChangesetBulkOperationJobsOpts struct {
+	BulkOperationID int64
+	State           campaigns.ChangesetBulkOperationJobState
+}
+
+// CountChangesetBulkOperationJobs returns the number of
+// ChangesetBulkOperationJobs in the database.
+func (s *Store) CountChangesetBulkOperationJobs(ctx context.Context, opts CountChangesetBulkOperationJobsOpts) (count int64, _ error) {
+	q := countChangesetBulkOperationJobsQuery(&opts)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countChangesetBulkOperationJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:CountChangesetBulkOperationJobs
+SELECT COUNT(id)
+FROM changeset_bulk_operation_jobs
+WHERE %s
+`
+
+func countChangesetBulkOperationJobsQuery(opts *CountChangesetBulkOperationJobsOpts) *sqlf.Query {
+	return sqlf.Sprintf(countChangesetBulkOperationJobsQueryFmtstr, changesetBulkOperationJobsPreds(opts.BulkOperationID, opts.State))
+}
+
+// ListChangesetBulkOperationJobsOpts captures the query options needed for
+// listing ChangesetBulkOperationJobs.
+type ListChangesetBulkOperationJobsOpts struct {
+	Cursor          int64
+	Limit           int
+	BulkOperationID int64
+	State           campaigns.ChangesetBulkOperationJobState
+}
+
+// ListChangesetBulkOperationJobs lists ChangesetBulkOperationJobs with the
+// given filters.
+func (s *Store) ListChangesetBulkOperationJobs(ctx context.Context, opts ListChangesetBulkOperationJobsOpts) (js []*campaigns.ChangesetBulkOperationJob, next int64, err error) {
+	q := listChangesetBulkOperationJobsQuery(&opts)
+
+	js = make([]*campaigns.ChangesetBulkOperationJob, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.ChangesetBulkOperationJob
+		if err = scanChangesetBulkOperationJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(js) == opts.Limit {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
+	}
+
+	return js, next, err
+}
+
+var listChangesetBulkOperationJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_bulk_operations.go:ListChangesetBulkOperationJobs
+SELECT ` + changesetBulkOperationJobCols + ` FROM changeset_bulk_operation_jobs
+WHERE %s
+ORDER BY id ASC
+`
+
+func listChangesetBulkOperationJobsQuery(opts *ListChangesetBulkOperationJobsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id >= %s", opts.Cursor),
+		changesetBulkOperationJobsPreds(opts.BulkOperationID, opts.State),
+	}
+
+	return sqlf.Sprintf(
+		listChangesetBulkOperationJobsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func changesetBulkOperationJobsPreds(bulkOperationID int64, state campaigns.ChangesetBulkOperationJobState) *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if bulkOperationID != 0 {
+		preds = append(preds, sqlf.Sprintf("bulk_operation_id = %s", bulkOperationID))
+	}
+
+	if state != "" {
+		preds = append(preds, sqlf.Sprintf("state = %s", state))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Join(preds, "\n AND ")
+}
+
+func scanChangesetBulkOperationJob(j *campaigns.ChangesetBulkOperationJob, s scanner) error {
+	err := s.Scan(
+		&j.ID,
+		&j.BulkOperationID,
+		&j.ChangesetID,
+		&j.State,
+		&dbutil.NullString{S: &j.FailureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.NumFailures,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+
+	return errors.Wrap(err, "scanning changeset bulk operation job")
+}
+
+// scanFirstChangesetBulkOperationJobRecord scans the first
+// ChangesetBulkOperationJob of the given rows. It implements
+// workerutil.RecordScanFn.
+func scanFirstChangesetBulkOperationJobRecord(rows *sql.Rows, err error) (_ workerutil.Record, exists bool, _ error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var j campaigns.ChangesetBulkOperationJob
+	_, count, err := scanAll(rows, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkOperationJob(&j, sc)
+		return j.ID, 1, err
+	})
+	if err != nil || count == 0 {
+		return nil, false, err
+	}
+
+	return &j, true, nil
+}
@@ -0,0 +1,258 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreChangesetBulkOperations(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	campaignID := int64(4321)
+
+	ops := make([]*cmpgn.ChangesetBulkOperation, 0, 3)
+	jobs := make([]*cmpgn.ChangesetBulkOperationJob, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(ops); i++ {
+			o := &cmpgn.ChangesetBulkOperation{
+				CampaignID: campaignID,
+				UserID:     1234,
+				Operation:  cmpgn.ChangesetBulkOperationComment,
+				Comment:    "Please review",
+			}
+
+			if i == cap(ops)-1 {
+				o.CampaignID = campaignID + 1
+				o.Operation = cmpgn.ChangesetBulkOperationClose
+				o.Comment = ""
+			}
+
+			want := o.Clone()
+			have := o
+
+			if err := s.CreateChangesetBulkOperation(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			ops = append(ops, o)
+		}
+
+		for i := 0; i < cap(jobs); i++ {
+			j := &cmpgn.ChangesetBulkOperationJob{
+				BulkOperationID: ops[0].ID,
+				ChangesetID:     int64(i + 1),
+			}
+
+			if i == cap(jobs)-1 {
+				j.BulkOperationID = ops[1].ID
+			}
+
+			want := j.Clone()
+			have := j
+
+			if err := s.CreateChangesetBulkOperationJob(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.State = cmpgn.ChangesetBulkOperationJobStateQueued
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			jobs = append(jobs, j)
+		}
+	})
+
+	if len(ops) != cap(ops) || len(jobs) != cap(jobs) {
+		t.Fatalf("ops or jobs are empty. creation failed")
+	}
+
+	t.Run("GetOperation", func(t *testing.T) {
+		want := ops[1]
+
+		have, err := s.GetChangesetBulkOperation(ctx, GetChangesetBulkOperationOpts{ID: want.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetChangesetBulkOperation(ctx, GetChangesetBulkOperationOpts{ID: 0xdeadbeef})
+			if want := ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("CountOperations", func(t *testing.T) {
+		count, err := s.CountChangesetBulkOperations(ctx, CountChangesetBulkOperationsOpts{CampaignID: campaignID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, int64(len(ops)-1); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+	})
+
+	t.Run("ListOperations", func(t *testing.T) {
+		// Bulk operations are listed most recent first.
+		reversed := make([]*cmpgn.ChangesetBulkOperation, 0, len(ops))
+		for i := len(ops) - 1; i >= 0; i-- {
+			reversed = append(reversed, ops[i])
+		}
+
+		t.Run("NoLimit", func(t *testing.T) {
+			have, next, err := s.ListChangesetBulkOperations(ctx, ListChangesetBulkOperationsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next != 0 {
+				t.Fatalf("have next %d, want 0", next)
+			}
+
+			if diff := cmp.Diff(have, reversed); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimitAndCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(reversed); i++ {
+				opts := ListChangesetBulkOperationsOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListChangesetBulkOperations(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := reversed[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+
+		t.Run("WithCampaignID", func(t *testing.T) {
+			have, _, err := s.ListChangesetBulkOperations(ctx, ListChangesetBulkOperationsOpts{CampaignID: campaignID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, reversed[1:]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("CountJobs", func(t *testing.T) {
+		count, err := s.CountChangesetBulkOperationJobs(ctx, CountChangesetBulkOperationJobsOpts{BulkOperationID: ops[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := count, int64(len(jobs)-1); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		t.Run("WithState", func(t *testing.T) {
+			count, err := s.CountChangesetBulkOperationJobs(ctx, CountChangesetBulkOperationJobsOpts{
+				State: cmpgn.ChangesetBulkOperationJobStateErrored,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != 0 {
+				t.Fatalf("have count: %d, want: 0", count)
+			}
+		})
+	})
+
+	t.Run("ListJobs", func(t *testing.T) {
+		t.Run("NoLimit", func(t *testing.T) {
+			have, next, err := s.ListChangesetBulkOperationJobs(ctx, ListChangesetBulkOperationJobsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next != 0 {
+				t.Fatalf("have next %d, want 0", next)
+			}
+
+			if diff := cmp.Diff(have, jobs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimitAndCursor", func(t *testing.T) {
+			var cursor int64
+			for i := 1; i <= len(jobs); i++ {
+				opts := ListChangesetBulkOperationJobsOpts{Cursor: cursor, Limit: 1}
+				have, next, err := s.ListChangesetBulkOperationJobs(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := jobs[i-1 : i]
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+
+				cursor = next
+			}
+		})
+
+		t.Run("WithBulkOperationID", func(t *testing.T) {
+			have, _, err := s.ListChangesetBulkOperationJobs(ctx, ListChangesetBulkOperationJobsOpts{BulkOperationID: ops[0].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, jobs[:len(jobs)-1]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithState", func(t *testing.T) {
+			have, _, err := s.ListChangesetBulkOperationJobs(ctx, ListChangesetBulkOperationJobsOpts{
+				State: cmpgn.ChangesetBulkOperationJobStateQueued,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, jobs); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+}
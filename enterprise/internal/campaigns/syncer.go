@@ -279,6 +279,8 @@ type SyncStore interface {
 	ListChangesets(context.Context, ListChangesetsOpts) (campaigns.Changesets, int64, error)
 	UpdateChangesets(ctx context.Context, cs ...*campaigns.Changeset) error
 	UpsertChangesetEvents(ctx context.Context, cs ...*campaigns.ChangesetEvent) error
+	ListCampaigns(context.Context, ListCampaignsOpts) ([]*campaigns.Campaign, int64, error)
+	GetCampaignSpec(context.Context, GetCampaignSpecOpts) (*campaigns.CampaignSpec, error)
 	Transact(context.Context) (*Store, error)
 }
 
@@ -562,6 +564,11 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 			csEvents := c.Events()
 			SetDerivedState(ctx, c.Changeset, csEvents)
 
+			if autoMergeChangeset(ctx, store, s.ChangesetSource, c) {
+				csEvents = c.Events()
+				SetDerivedState(ctx, c.Changeset, csEvents)
+			}
+
 			// Deduplicate events per changeset based on their Kind+Key to avoid
 			// conflicts when inserting into database.
 			uniqueEvents := make(map[string]struct{}, len(csEvents))
@@ -508,6 +508,8 @@ type MockSyncStore struct {
 	listChangesets        func(context.Context, ListChangesetsOpts) (campaigns.Changesets, int64, error)
 	updateChangesets      func(context.Context, ...*campaigns.Changeset) error
 	upsertChangesetEvents func(context.Context, ...*campaigns.ChangesetEvent) error
+	listCampaigns         func(context.Context, ListCampaignsOpts) ([]*campaigns.Campaign, int64, error)
+	getCampaignSpec       func(context.Context, GetCampaignSpecOpts) (*campaigns.CampaignSpec, error)
 	transact              func(context.Context) (*Store, error)
 }
 
@@ -531,6 +533,14 @@ func (m MockSyncStore) UpsertChangesetEvents(ctx context.Context, cs ...*campaig
 	return m.upsertChangesetEvents(ctx, cs...)
 }
 
+func (m MockSyncStore) ListCampaigns(ctx context.Context, opts ListCampaignsOpts) ([]*campaigns.Campaign, int64, error) {
+	return m.listCampaigns(ctx, opts)
+}
+
+func (m MockSyncStore) GetCampaignSpec(ctx context.Context, opts GetCampaignSpecOpts) (*campaigns.CampaignSpec, error) {
+	return m.getCampaignSpec(ctx, opts)
+}
+
 func (m MockSyncStore) Transact(ctx context.Context) (*Store, error) {
 	return m.transact(ctx)
 }
@@ -35,8 +35,28 @@ type FakeChangesetSource struct {
 
 	// LoadedChangesets contains the changesets that were passed to LoadChangesets
 	LoadedChangesets []*repos.Changeset
+
+	// CreatedDraftChangesets contains the changesets that were passed to CreateDraftChangeset
+	CreatedDraftChangesets []*repos.Changeset
+
+	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
+	UndraftedChangesets []*repos.Changeset
+
+	// MergedChangesets contains the changesets that were passed to MergeChangeset
+	MergedChangesets []*repos.Changeset
+
+	// UpdatedChangesets contains the changesets that were passed to UpdateChangeset
+	UpdatedChangesets []*repos.Changeset
+
+	// CommentedChangesets contains the changesets that were passed to
+	// CreateComment, Comments the corresponding comment bodies.
+	CommentedChangesets []*repos.Changeset
+	Comments            []string
 }
 
+var _ repos.ChangesetSource = &FakeChangesetSource{}
+var _ repos.DraftChangesetSource = &FakeChangesetSource{}
+
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
 	if s.Err != nil {
 		return s.ChangesetExists, s.Err
@@ -57,6 +77,23 @@ func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Chan
 	return s.ChangesetExists, s.Err
 }
 
+func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
+	exists, err := s.CreateChangeset(ctx, c)
+	if err != nil {
+		return exists, err
+	}
+	s.CreatedDraftChangesets = append(s.CreatedDraftChangesets, c)
+	return exists, nil
+}
+
+func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *repos.Changeset) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.UndraftedChangesets = append(s.UndraftedChangesets, c)
+	return nil
+}
+
 func (s *FakeChangesetSource) UpdateChangeset(ctx context.Context, c *repos.Changeset) error {
 	if s.Err != nil {
 		return s.Err
@@ -66,6 +103,7 @@ func (s *FakeChangesetSource) UpdateChangeset(ctx context.Context, c *repos.Chan
 		return fmt.Errorf("wrong BaseRef. want=%s, have=%s", s.WantBaseRef, c.BaseRef)
 	}
 
+	s.UpdatedChangesets = append(s.UpdatedChangesets, c)
 	return c.SetMetadata(s.FakeMetadata)
 }
 
@@ -93,13 +131,34 @@ func (s *FakeChangesetSource) CloseChangeset(ctx context.Context, c *repos.Chang
 	return nil
 }
 
+func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *repos.Changeset) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.MergedChangesets = append(s.MergedChangesets, c)
+	return nil
+}
+
+func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *repos.Changeset, body string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.CommentedChangesets = append(s.CommentedChangesets, c)
+	s.Comments = append(s.Comments, body)
+	return nil
+}
+
 // FakeGitserverClient is a test implementation of the GitserverClient
 // interface required by ExecChangesetJob.
 type FakeGitserverClient struct {
 	Response    string
 	ResponseErr error
+
+	// Requests contains the requests passed to CreateCommitFromPatch.
+	Requests []protocol.CreateCommitFromPatchRequest
 }
 
 func (f *FakeGitserverClient) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+	f.Requests = append(f.Requests, req)
 	return f.Response, f.ResponseErr
 }
@@ -2,6 +2,7 @@ package campaigns
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -78,6 +80,8 @@ func extractExternalServiceID(extSvc *repos.ExternalService) (string, error) {
 		serviceID = c.Url
 	case *schema.BitbucketServerConnection:
 		serviceID = c.Url
+	case *schema.GitLabConnection:
+		serviceID = c.Url
 	}
 	if serviceID == "" {
 		return "", errors.New("could not determine service id")
@@ -167,6 +171,9 @@ func (h Webhook) upsertChangesetEvent(
 		ChangesetIDs: []int64{cs.ID},
 		Limit:        -1,
 	})
+	if err != nil {
+		return err
+	}
 	SetDerivedState(ctx, cs, events)
 	if err := tx.UpdateChangesets(ctx, cs); err != nil {
 		return err
@@ -903,6 +910,165 @@ func (h *BitbucketServerWebhook) convertEvent(theirs interface{}) (prs []PR, our
 	return
 }
 
+// GitLabWebhook receives GitLab merge request, note and pipeline webhook
+// events that are relevant to campaigns, normalizes those events into
+// ChangesetEvents and upserts them to the database.
+type GitLabWebhook struct {
+	*Webhook
+}
+
+func NewGitLabWebhook(store *Store, repos repos.Store, now func() time.Time) *GitLabWebhook {
+	return &GitLabWebhook{&Webhook{store, repos, now, extsvc.TypeGitLab}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, httpErr := h.parseEvent(r)
+	if httpErr != nil {
+		respond(w, httpErr.code, httpErr)
+		return
+	}
+	if e == nil {
+		respond(w, http.StatusOK, nil) // Not an event we care about
+		return
+	}
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	pr, ev := h.convertEvent(e)
+	if pr == (PR{}) || ev == nil {
+		respond(w, http.StatusOK, nil) // Nothing to do
+		return
+	}
+
+	if err := h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+	}
+}
+
+// parseEvent authenticates the request and parses its event. The returned
+// event is nil for event types that aren't relevant to campaigns, since
+// GitLab webhooks are often set up to deliver other events, too.
+func (h *GitLabWebhook) parseEvent(r *http.Request) (interface{}, *repos.ExternalService, *httpError) {
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	rawID := r.FormValue(extsvc.IDParam)
+	var externalServiceID int64
+	if rawID != "" {
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	// 🚨 SECURITY: Try to authenticate the request with any of the stored secrets
+	// in GitLab external services config. GitLab sends the secret as is in the
+	// X-Gitlab-Token header. If there are no secrets or no secret matches the
+	// token, we return a 401 to the client.
+	args := repos.StoreListExternalServicesArgs{Kinds: []string{extsvc.KindGitLab}}
+	if externalServiceID != 0 {
+		args.IDs = append(args.IDs, externalServiceID)
+	}
+	es, err := h.Repos.ListExternalServices(r.Context(), args)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	token := []byte(r.Header.Get("X-Gitlab-Token"))
+
+	var extSvc *repos.ExternalService
+	for _, e := range es {
+		if len(token) == 0 {
+			break
+		}
+
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.GitLabConnection)
+		if !ok {
+			continue
+		}
+
+		for _, hook := range con.Webhooks {
+			if hook.Secret == "" {
+				continue
+			}
+			if subtle.ConstantTimeCompare(token, []byte(hook.Secret)) == 1 {
+				extSvc = e
+				break
+			}
+		}
+		if extSvc != nil {
+			break
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, nil}
+	}
+
+	e, err := gitlab.ParseWebhookEvent(gitlab.WebhookEventType(r), payload)
+	if err != nil {
+		if errors.Cause(err) == gitlab.ErrUnknownWebhookEvent {
+			return nil, extSvc, nil
+		}
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+// convertEvent returns the merge request affected by the GitLab webhook event
+// and the ChangesetEvent metadata the event corresponds to. ev is nil if the
+// event doesn't correspond to any, e.g. a regular comment or an update to the
+// description of a merge request.
+func (h *GitLabWebhook) convertEvent(theirs interface{}) (pr PR, ev keyer) {
+	log15.Debug("GitLab webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *gitlab.MergeRequestEvent:
+		pr = gitLabPR(&e.ObjectAttributes.WebhookMergeRequest, e.Project)
+		if s := e.ToStateEvent(); s != nil {
+			return pr, s.(keyer)
+		}
+		if r := e.ToReview(); r != nil {
+			return pr, r.(keyer)
+		}
+
+	case *gitlab.NoteEvent:
+		if e.MergeRequest == nil {
+			return PR{}, nil
+		}
+		pr = gitLabPR(e.MergeRequest, e.Project)
+		if r := e.ToNote().ToReview(); r != nil {
+			return pr, r.(keyer)
+		}
+
+	case *gitlab.PipelineEvent:
+		if e.MergeRequest == nil {
+			return PR{}, nil
+		}
+		return gitLabPR(e.MergeRequest, e.Project), e.ToPipeline(h.Now())
+	}
+
+	return pr, nil
+}
+
+// gitLabPR returns the PR of the merge request, which belongs to the
+// repository of its target project.
+func gitLabPR(mr *gitlab.WebhookMergeRequest, project gitlab.WebhookProject) PR {
+	projectID := mr.TargetProjectID
+	if projectID == 0 {
+		projectID = project.ID
+	}
+	return PR{ID: int64(mr.IID), RepoExternalID: strconv.Itoa(projectID)}
+}
+
 type httpError struct {
 	code int
 	err  error
@@ -25,6 +25,7 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
@@ -447,3 +448,71 @@ func marshalJSON(t testing.TB, v interface{}) string {
 
 	return string(bs)
 }
+
+func TestGitLabWebhookConvertEvent(t *testing.T) {
+	now := time.Date(2020, 8, 5, 12, 0, 0, 0, time.UTC)
+	h := NewGitLabWebhook(nil, nil, func() time.Time { return now })
+	user := gitlab.User{ID: 1, Username: "alice"}
+	mr := gitlab.WebhookMergeRequest{ID: 99, IID: 3, TargetProjectID: 14}
+	wantPR := PR{ID: 3, RepoExternalID: "14"}
+
+	mergeRequestEvent := func(action gitlab.MergeRequestAction) *gitlab.MergeRequestEvent {
+		e := &gitlab.MergeRequestEvent{User: user}
+		e.ObjectAttributes.WebhookMergeRequest = mr
+		e.ObjectAttributes.Action = action
+		e.ObjectAttributes.UpdatedAt = gitlab.WebhookTime{Time: now}
+		return e
+	}
+	noteEvent := func(body string, system bool) *gitlab.NoteEvent {
+		e := &gitlab.NoteEvent{User: user, MergeRequest: &mr}
+		e.ObjectAttributes.ID = 7
+		e.ObjectAttributes.Note = body
+		e.ObjectAttributes.System = system
+		e.ObjectAttributes.CreatedAt = gitlab.WebhookTime{Time: now}
+		return e
+	}
+	pipelineEvent := func(mr *gitlab.WebhookMergeRequest) *gitlab.PipelineEvent {
+		e := &gitlab.PipelineEvent{MergeRequest: mr}
+		e.ObjectAttributes.ID = 31
+		e.ObjectAttributes.Status = gitlab.PipelineStatusRunning
+		return e
+	}
+
+	for name, tc := range map[string]struct {
+		event    interface{}
+		wantPR   PR
+		wantKind campaigns.ChangesetEventKind
+	}{
+		"closed":            {event: mergeRequestEvent(gitlab.MergeRequestActionClose), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabClosed},
+		"merged":            {event: mergeRequestEvent(gitlab.MergeRequestActionMerge), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabMerged},
+		"reopened":          {event: mergeRequestEvent(gitlab.MergeRequestActionReopen), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabReopened},
+		"approved":          {event: mergeRequestEvent(gitlab.MergeRequestActionApproved), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabApproved},
+		"unapproval":        {event: mergeRequestEvent(gitlab.MergeRequestActionUnapproval), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabUnapproved},
+		"updated":           {event: mergeRequestEvent(gitlab.MergeRequestActionUpdate), wantPR: wantPR},
+		"approval note":     {event: noteEvent("approved this merge request", true), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabApproved},
+		"comment":           {event: noteEvent("approved this merge request", false), wantPR: wantPR},
+		"pipeline":          {event: pipelineEvent(&mr), wantPR: wantPR, wantKind: campaigns.ChangesetEventKindGitLabPipeline},
+		"branch pipeline":   {event: pipelineEvent(nil)},
+		"unsupported event": {event: struct{}{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr, ev := h.convertEvent(tc.event)
+			if pr != tc.wantPR {
+				t.Errorf("unexpected PR: have %+v, want %+v", pr, tc.wantPR)
+			}
+
+			if tc.wantKind == "" {
+				if ev != nil {
+					t.Errorf("unexpected event %+v", ev)
+				}
+				return
+			}
+			if ev == nil {
+				t.Fatal("unexpected nil event")
+			}
+			if have := campaigns.ChangesetEventKindFor(ev); have != tc.wantKind {
+				t.Errorf("unexpected kind: have %q, want %q", have, tc.wantKind)
+			}
+		})
+	}
+}
@@ -29,11 +29,21 @@ type CodeIntelAPI interface {
 	// This may include references from other dumps and repositories.
 	References(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
 
+	// TypeDefinitions returns the list of source locations that define the type of the symbol at the given position.
+	TypeDefinitions(ctx context.Context, file string, line, character, uploadID int) ([]ResolvedLocation, error)
+
+	// Implementations returns the list of source locations that implement the symbol at the given position.
+	// This may include implementations from other dumps and repositories.
+	Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
+
 	// Hover returns the hover text and range for the symbol at the given position.
 	Hover(ctx context.Context, file string, line, character, uploadID int) (string, bundles.Range, bool, error)
 
 	// Diagnostics returns the diagnostics for documents with the given path prefix.
 	Diagnostics(ctx context.Context, prefix string, uploadID, limit, offset int) ([]ResolvedDiagnostic, int, error)
+
+	// DocumentSymbols returns the symbol tree of the given file.
+	DocumentSymbols(ctx context.Context, file string, uploadID int) ([]bundles.DocumentSymbol, error)
 }
 
 type codeIntelAPI struct {
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+)
+
+// DocumentSymbols returns the symbol tree of the given file. If the dump's bundle does not exist,
+// an empty tree is returned.
+func (api *codeIntelAPI) DocumentSymbols(ctx context.Context, file string, uploadID int) ([]bundles.DocumentSymbol, error) {
+	dump, exists, err := api.store.GetDumpByID(ctx, uploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetDumpByID")
+	}
+	if !exists {
+		return nil, ErrMissingDump
+	}
+
+	pathInBundle := strings.TrimPrefix(file, dump.Root)
+	bundleClient := api.bundleManagerClient.BundleClient(dump.ID)
+
+	symbols, err := bundleClient.DocumentSymbols(ctx, pathInBundle)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient.DocumentSymbols")
+	}
+
+	return symbols, nil
+}
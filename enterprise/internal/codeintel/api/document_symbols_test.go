@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+	mockGitserverClient := gitservermocks.NewMockClient()
+
+	symbols := []bundles.DocumentSymbol{
+		{
+			Name:           "Foo",
+			Kind:           23,
+			Range:          testRange1,
+			SelectionRange: testRange2,
+			Children: []bundles.DocumentSymbol{
+				{Name: "bar", Kind: 8, Range: testRange3, SelectionRange: testRange3},
+			},
+		},
+	}
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientDocumentSymbols(t, mockBundleClient, "main.go", symbols)
+
+	api := testAPI(mockStore, mockBundleManagerClient, mockGitserverClient)
+	documentSymbols, err := api.DocumentSymbols(context.Background(), "sub1/main.go", 42)
+	if err != nil {
+		t.Fatalf("expected error getting document symbols: %s", err)
+	}
+
+	if diff := cmp.Diff(symbols, documentSymbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestDocumentSymbolsUnknownDump(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockGitserverClient := gitservermocks.NewMockClient()
+	setMockStoreGetDumpByID(t, mockStore, nil)
+
+	api := testAPI(mockStore, mockBundleManagerClient, mockGitserverClient)
+	if _, err := api.DocumentSymbols(context.Background(), "sub1/main.go", 25); err != ErrMissingDump {
+		t.Fatalf("unexpected error getting document symbols. want=%q have=%q", ErrMissingDump, err)
+	}
+}
+
+func TestDocumentSymbolsMissingBundle(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+	mockGitserverClient := gitservermocks.NewMockClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	mockBundleClient.DocumentSymbolsFunc.SetDefaultReturn(nil, bundles.ErrNotFound)
+
+	api := testAPI(mockStore, mockBundleManagerClient, mockGitserverClient)
+	documentSymbols, err := api.DocumentSymbols(context.Background(), "sub1/main.go", 42)
+	if err != nil {
+		t.Fatalf("unexpected error getting document symbols: %s", err)
+	}
+	if len(documentSymbols) != 0 {
+		t.Errorf("unexpected document symbols. want=%d have=%d", 0, len(documentSymbols))
+	}
+}
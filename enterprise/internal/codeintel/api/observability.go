@@ -16,8 +16,11 @@ type ObservedCodeIntelAPI struct {
 	rangesOperation           *observation.Operation
 	definitionsOperation      *observation.Operation
 	referencesOperation       *observation.Operation
+	typeDefinitionsOperation  *observation.Operation
+	implementationsOperation  *observation.Operation
 	hoverOperation            *observation.Operation
 	diagnosticsOperation      *observation.Operation
+	documentSymbolsOperation  *observation.Operation
 }
 
 var _ CodeIntelAPI = &ObservedCodeIntelAPI{}
@@ -53,6 +56,16 @@ func NewObserved(codeIntelAPI CodeIntelAPI, observationContext *observation.Cont
 			MetricLabels: []string{"references"},
 			Metrics:      metrics,
 		}),
+		typeDefinitionsOperation: observationContext.Operation(observation.Op{
+			Name:         "CodeIntelAPI.TypeDefinitions",
+			MetricLabels: []string{"type_definitions"},
+			Metrics:      metrics,
+		}),
+		implementationsOperation: observationContext.Operation(observation.Op{
+			Name:         "CodeIntelAPI.Implementations",
+			MetricLabels: []string{"implementations"},
+			Metrics:      metrics,
+		}),
 		hoverOperation: observationContext.Operation(observation.Op{
 			Name:         "CodeIntelAPI.Hover",
 			MetricLabels: []string{"hover"},
@@ -63,6 +76,11 @@ func NewObserved(codeIntelAPI CodeIntelAPI, observationContext *observation.Cont
 			MetricLabels: []string{"diagnostics"},
 			Metrics:      metrics,
 		}),
+		documentSymbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "CodeIntelAPI.DocumentSymbols",
+			MetricLabels: []string{"document_symbols"},
+			Metrics:      metrics,
+		}),
 	}
 }
 
@@ -94,6 +112,20 @@ func (api *ObservedCodeIntelAPI) References(ctx context.Context, repositoryID in
 	return api.codeIntelAPI.References(ctx, repositoryID, commit, limit, cursor)
 }
 
+// TypeDefinitions calls into the inner CodeIntelAPI and registers the observed results.
+func (api *ObservedCodeIntelAPI) TypeDefinitions(ctx context.Context, file string, line, character, uploadID int) (typeDefinitions []ResolvedLocation, err error) {
+	ctx, endObservation := api.typeDefinitionsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(typeDefinitions)), observation.Args{}) }()
+	return api.codeIntelAPI.TypeDefinitions(ctx, file, line, character, uploadID)
+}
+
+// Implementations calls into the inner CodeIntelAPI and registers the observed results.
+func (api *ObservedCodeIntelAPI) Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) (implementations []ResolvedLocation, _ Cursor, _ bool, err error) {
+	ctx, endObservation := api.implementationsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(implementations)), observation.Args{}) }()
+	return api.codeIntelAPI.Implementations(ctx, repositoryID, commit, limit, cursor)
+}
+
 // Hover calls into the inner CodeIntelAPI and registers the observed results.
 func (api *ObservedCodeIntelAPI) Hover(ctx context.Context, file string, line, character, uploadID int) (_ string, _ bundles.Range, _ bool, err error) {
 	ctx, endObservation := api.hoverOperation.With(ctx, &err, observation.Args{})
@@ -107,3 +139,10 @@ func (api *ObservedCodeIntelAPI) Diagnostics(ctx context.Context, prefix string,
 	defer func() { endObservation(float64(len(diagnostics)), observation.Args{}) }()
 	return api.codeIntelAPI.Diagnostics(ctx, prefix, uploadID, limit, offset)
 }
+
+// DocumentSymbols calls into the inner CodeIntelAPI and registers the observed results.
+func (api *ObservedCodeIntelAPI) DocumentSymbols(ctx context.Context, file string, uploadID int) (symbols []bundles.DocumentSymbol, err error) {
+	ctx, endObservation := api.documentSymbolsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return api.codeIntelAPI.DocumentSymbols(ctx, file, uploadID)
+}
@@ -31,6 +31,7 @@ func (api *codeIntelAPI) References(ctx context.Context, repositoryID int, commi
 		bundleManagerClient: api.bundleManagerClient,
 		repositoryID:        repositoryID,
 		commit:              commit,
+		modelType:           "reference",
 		remoteDumpLimit:     RemoteDumpLimit,
 		limit:               limit,
 	}
@@ -38,11 +39,35 @@ func (api *codeIntelAPI) References(ctx context.Context, repositoryID int, commi
 	return rpr.resolvePage(ctx, cursor)
 }
 
+// Implementations returns the list of source locations that implement the symbol at the given position.
+// This may include implementations from other dumps and repositories.
+func (api *codeIntelAPI) Implementations(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
+	if limit <= 0 {
+		return nil, Cursor{}, false, ErrIllegalLimit
+	}
+
+	rpr := &ReferencePageResolver{
+		store:               api.store,
+		bundleManagerClient: api.bundleManagerClient,
+		repositoryID:        repositoryID,
+		commit:              commit,
+		modelType:           "implementation",
+		remoteDumpLimit:     RemoteDumpLimit,
+		limit:               limit,
+	}
+
+	return rpr.resolvePage(ctx, cursor)
+}
+
+// ReferencePageResolver pages through the locations attached to the symbol at a given position. The
+// model type is either "reference" or "implementation" and determines which set of graph edges and
+// which moniker tables of the current and remote bundles are queried.
 type ReferencePageResolver struct {
 	store               store.Store
 	bundleManagerClient bundles.BundleManagerClient
 	repositoryID        int
 	commit              string
+	modelType           string
 	remoteDumpLimit     int
 	limit               int
 }
@@ -98,13 +123,13 @@ func (s *ReferencePageResolver) handleSameDumpCursor(ctx context.Context, cursor
 	}
 	bundleClient := s.bundleManagerClient.BundleClient(dump.ID)
 
-	locations, err := bundleClient.References(ctx, cursor.Path, cursor.Line, cursor.Character)
+	locations, err := s.graphLocations(ctx, bundleClient, cursor.Path, cursor.Line, cursor.Character)
 	if err != nil {
 		if err == bundles.ErrNotFound {
 			log15.Warn("Bundle does not exist")
 			return nil, Cursor{}, false, nil
 		}
-		return nil, Cursor{}, false, pkgerrors.Wrap(err, "bundleClient.References")
+		return nil, Cursor{}, false, err
 	}
 
 	resolvedLocations := resolveLocationsWithDump(dump, sliceLocations(locations, cursor.SkipResults, cursor.SkipResults+s.limit))
@@ -147,13 +172,13 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 	// Get the references that we've seen from the graph-encoded portion of the bundle. We
 	// need to know what we've returned previously so that we can filter out duplicate locations
 	// that are also encoded as monikers.
-	previousLocations, err := bundleClient.References(ctx, cursor.Path, cursor.Line, cursor.Character)
+	previousLocations, err := s.graphLocations(ctx, bundleClient, cursor.Path, cursor.Line, cursor.Character)
 	if err != nil {
 		if err == bundles.ErrNotFound {
 			log15.Warn("Bundle does not exist")
 			return nil, Cursor{}, false, nil
 		}
-		return nil, Cursor{}, false, pkgerrors.Wrap(err, "bundleClient.References")
+		return nil, Cursor{}, false, err
 	}
 
 	hashes := map[string]struct{}{}
@@ -169,7 +194,7 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 	// the governing definition, and those may not be fully linked in the LSIF data. This
 	// method returns a cursor if there are reference rows remaining for a subsequent page.
 	for _, moniker := range cursor.Monikers {
-		results, count, err := bundleClient.MonikerResults(ctx, "reference", moniker.Scheme, moniker.Identifier, cursor.SkipResults, s.limit)
+		results, count, err := bundleClient.MonikerResults(ctx, s.modelType, moniker.Scheme, moniker.Identifier, cursor.SkipResults, s.limit)
 		if err != nil {
 			if err == bundles.ErrNotFound {
 				log15.Warn("Bundle does not exist")
@@ -254,7 +279,7 @@ func (s *ReferencePageResolver) handleDefinitionMonikersCursor(ctx context.Conte
 			continue
 		}
 
-		locations, count, err := lookupMoniker(s.store, s.bundleManagerClient, cursor.DumpID, cursor.Path, "reference", moniker, cursor.SkipResults, s.limit)
+		locations, count, err := lookupMoniker(s.store, s.bundleManagerClient, cursor.DumpID, cursor.Path, s.modelType, moniker, cursor.SkipResults, s.limit)
 		if err != nil {
 			return nil, Cursor{}, false, err
 		}
@@ -383,7 +408,7 @@ func (s *ReferencePageResolver) resolveLocationsViaReferencePager(ctx context.Co
 		}
 		bundleClient := s.bundleManagerClient.BundleClient(batchDumpID)
 
-		results, count, err := bundleClient.MonikerResults(ctx, "reference", scheme, identifier, cursor.SkipResultsInDump, limit)
+		results, count, err := bundleClient.MonikerResults(ctx, s.modelType, scheme, identifier, cursor.SkipResultsInDump, limit)
 		if err != nil {
 			if err == bundles.ErrNotFound {
 				log15.Warn("Bundle does not exist")
@@ -423,6 +448,24 @@ func (s *ReferencePageResolver) resolveLocationsViaReferencePager(ctx context.Co
 	return nil, Cursor{}, false, nil
 }
 
+// graphLocations returns the locations attached to the given position via the graph-encoded portion
+// of the bundle (references or implementations, depending on the model type of the resolver).
+func (s *ReferencePageResolver) graphLocations(ctx context.Context, bundleClient bundles.BundleClient, path string, line, character int) ([]bundles.Location, error) {
+	if s.modelType == "implementation" {
+		locations, err := bundleClient.Implementations(ctx, path, line, character)
+		if err != nil && err != bundles.ErrNotFound {
+			return nil, pkgerrors.Wrap(err, "bundleClient.Implementations")
+		}
+		return locations, err
+	}
+
+	locations, err := bundleClient.References(ctx, path, line, character)
+	if err != nil && err != bundles.ErrNotFound {
+		return nil, pkgerrors.Wrap(err, "bundleClient.References")
+	}
+	return locations, err
+}
+
 func hashLocation(location bundles.Location) string {
 	return fmt.Sprintf(
 		"%s:%d:%d:%d:%d",
@@ -38,6 +38,7 @@ func TestHandleSameDumpCursor(t *testing.T) {
 		bundleManagerClient: mockBundleManagerClient,
 		repositoryID:        100,
 		commit:              testCommit,
+		modelType:           "reference",
 		limit:               5,
 	}
 
@@ -123,6 +124,68 @@ func TestHandleSameDumpCursor(t *testing.T) {
 	})
 }
 
+func TestHandleSameDumpCursorImplementations(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientImplementations(t, mockBundleClient, "main.go", 23, 34, []bundles.Location{
+		{DumpID: 42, Path: "foo.go", Range: testRange1},
+		{DumpID: 42, Path: "bar.go", Range: testRange2},
+	})
+
+	rpr := &ReferencePageResolver{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+		repositoryID:        100,
+		commit:              testCommit,
+		modelType:           "implementation",
+		limit:               5,
+	}
+
+	implementations, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+		Phase:       "same-dump",
+		DumpID:      42,
+		Path:        "main.go",
+		Line:        23,
+		Character:   34,
+		Monikers:    []bundles.MonikerData{{Kind: "export", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	})
+	if err != nil {
+		t.Fatalf("expected error getting implementations: %s", err)
+	}
+
+	expectedImplementations := []ResolvedLocation{
+		{Dump: testDump1, Path: "sub1/foo.go", Range: testRange1},
+		{Dump: testDump1, Path: "sub1/bar.go", Range: testRange2},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	expectedNewCursor := Cursor{
+		Phase:       "same-dump-monikers",
+		DumpID:      42,
+		Path:        "main.go",
+		Line:        23,
+		Character:   34,
+		Monikers:    []bundles.MonikerData{{Kind: "export", Scheme: "gomod", Identifier: "pad"}},
+		SkipResults: 0,
+	}
+	if !hasNewCursor {
+		t.Errorf("expected new cursor")
+	} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+		t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+	}
+
+	if len(mockBundleClient.ReferencesFunc.History()) != 0 {
+		t.Errorf("unexpected call to References")
+	}
+}
+
 func TestHandleSameDumpMonikersCursor(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
@@ -141,6 +204,7 @@ func TestHandleSameDumpMonikersCursor(t *testing.T) {
 		bundleManagerClient: mockBundleManagerClient,
 		repositoryID:        100,
 		commit:              testCommit,
+		modelType:           "reference",
 		limit:               5,
 	}
 
@@ -249,6 +313,7 @@ func TestHandleDefinitionMonikersCursor(t *testing.T) {
 		bundleManagerClient: mockBundleManagerClient,
 		repositoryID:        100,
 		commit:              testCommit,
+		modelType:           "reference",
 		limit:               5,
 	}
 
@@ -375,6 +440,7 @@ func TestHandleSameRepoCursor(t *testing.T) {
 			bundleManagerClient: mockBundleManagerClient,
 			repositoryID:        100,
 			commit:              testCommit,
+			modelType:           "reference",
 			remoteDumpLimit:     5,
 			limit:               5,
 		}
@@ -440,6 +506,7 @@ func TestHandleSameRepoCursor(t *testing.T) {
 			bundleManagerClient: mockBundleManagerClient,
 			repositoryID:        100,
 			commit:              testCommit,
+			modelType:           "reference",
 			remoteDumpLimit:     5,
 			limit:               5,
 		}
@@ -506,6 +573,7 @@ func TestHandleSameRepoCursorMultipleDumpBatches(t *testing.T) {
 		bundleManagerClient: mockBundleManagerClient,
 		repositoryID:        100,
 		commit:              testCommit,
+		modelType:           "reference",
 		remoteDumpLimit:     2,
 		limit:               5,
 	}
@@ -591,6 +659,7 @@ func TestHandleRemoteRepoCursor(t *testing.T) {
 			bundleManagerClient: mockBundleManagerClient,
 			repositoryID:        100,
 			commit:              testCommit,
+			modelType:           "reference",
 			remoteDumpLimit:     5,
 			limit:               5,
 		}
@@ -656,6 +725,7 @@ func TestHandleRemoteRepoCursor(t *testing.T) {
 			bundleManagerClient: mockBundleManagerClient,
 			repositoryID:        100,
 			commit:              testCommit,
+			modelType:           "reference",
 			remoteDumpLimit:     5,
 			limit:               5,
 		}
@@ -711,6 +781,7 @@ func TestHandleRemoteRepoCursorMultipleDumpBatches(t *testing.T) {
 		bundleManagerClient: mockBundleManagerClient,
 		repositoryID:        100,
 		commit:              testCommit,
+		modelType:           "reference",
 		remoteDumpLimit:     2,
 		limit:               5,
 	}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the
+// given position. This may include remote definitions if the type is imported from another dump.
+func (api *codeIntelAPI) TypeDefinitions(ctx context.Context, file string, line, character, uploadID int) ([]ResolvedLocation, error) {
+	dump, exists, err := api.store.GetDumpByID(ctx, uploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetDumpByID")
+	}
+	if !exists {
+		return nil, ErrMissingDump
+	}
+
+	pathInBundle := strings.TrimPrefix(file, dump.Root)
+	bundleClient := api.bundleManagerClient.BundleClient(dump.ID)
+
+	locations, err := bundleClient.TypeDefinitions(ctx, pathInBundle, line, character)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient.TypeDefinitions")
+	}
+
+	var resolvedLocations []ResolvedLocation
+	for _, location := range locations {
+		remoteLocations, err := api.importedDefinitions(ctx, dump, bundleClient, location)
+		if err != nil {
+			return nil, err
+		}
+		if len(remoteLocations) > 0 {
+			resolvedLocations = append(resolvedLocations, remoteLocations...)
+			continue
+		}
+
+		resolvedLocations = append(resolvedLocations, resolveLocationsWithDump(dump, []bundles.Location{location})...)
+	}
+
+	return resolvedLocations, nil
+}
+
+// importedDefinitions returns the definitions of the symbol at the given location of the given dump
+// if that symbol is imported from another dump. A type definition result can only refer to ranges
+// within its own dump, so a type that is defined in a dependency is resolved by following the import
+// moniker attached to the range that refers to it.
+func (api *codeIntelAPI) importedDefinitions(ctx context.Context, dump store.Dump, bundleClient bundles.BundleClient, location bundles.Location) ([]ResolvedLocation, error) {
+	rangeMonikers, err := bundleClient.MonikersByPosition(ctx, location.Path, location.Range.Start.Line, location.Range.Start.Character)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient.MonikersByPosition")
+	}
+
+	for _, monikers := range rangeMonikers {
+		for _, moniker := range monikers {
+			if moniker.Kind != "import" {
+				continue
+			}
+
+			locations, _, err := lookupMoniker(api.store, api.bundleManagerClient, dump.ID, location.Path, "definition", moniker, 0, DefintionMonikersLimit)
+			if err != nil {
+				return nil, err
+			}
+			if len(locations) > 0 {
+				return locations, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
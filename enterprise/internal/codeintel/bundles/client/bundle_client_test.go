@@ -200,6 +200,64 @@ func TestReferences(t *testing.T) {
 	}
 }
 
+func TestTypeDefinitions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/typeDefinitions", map[string]string{
+			"path":      "main.go",
+			"line":      "10",
+			"character": "20",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "foo.go", "range": {"start": {"line": 1, "character": 2}, "end": {"line": 3, "character": 4}}},
+			{"path": "bar.go", "range": {"start": {"line": 5, "character": 6}, "end": {"line": 7, "character": 8}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	typeDefinitions, err := client.TypeDefinitions(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	} else if diff := cmp.Diff(expected, typeDefinitions); diff != "" {
+		t.Errorf("unexpected type definitions (-want +got):\n%s", diff)
+	}
+}
+
+func TestImplementations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/implementations", map[string]string{
+			"path":      "main.go",
+			"line":      "10",
+			"character": "20",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "foo.go", "range": {"start": {"line": 1, "character": 2}, "end": {"line": 3, "character": 4}}},
+			{"path": "bar.go", "range": {"start": {"line": 5, "character": 6}, "end": {"line": 7, "character": 8}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	implementations, err := client.Implementations(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	} else if diff := cmp.Diff(expected, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+}
+
 func TestHover(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/hover", map[string]string{
@@ -331,6 +389,59 @@ func TestDiagnostics(t *testing.T) {
 	}
 }
 
+func TestDocumentSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/documentSymbols", map[string]string{
+			"path": "main.go",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{
+				"name": "Foo",
+				"detail": "struct",
+				"kind": 23,
+				"range": {"start": {"line": 1, "character": 0}, "end": {"line": 4, "character": 1}},
+				"selectionRange": {"start": {"line": 1, "character": 5}, "end": {"line": 1, "character": 8}},
+				"children": [
+					{
+						"name": "bar",
+						"kind": 8,
+						"range": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 8}},
+						"selectionRange": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 4}}
+					}
+				]
+			}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []DocumentSymbol{
+		{
+			Name:           "Foo",
+			Detail:         "struct",
+			Kind:           23,
+			Range:          Range{Start: Position{1, 0}, End: Position{4, 1}},
+			SelectionRange: Range{Start: Position{1, 5}, End: Position{1, 8}},
+			Children: []DocumentSymbol{
+				{
+					Name:           "bar",
+					Kind:           8,
+					Range:          Range{Start: Position{2, 1}, End: Position{2, 8}},
+					SelectionRange: Range{Start: Position{2, 1}, End: Position{2, 4}},
+				},
+			},
+		},
+	}
+
+	client := &bundleClientImpl{base: &bundleManagerClientImpl{bundleManagerURL: ts.URL}, bundleID: 42}
+	symbols, err := client.DocumentSymbols(context.Background(), "main.go")
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	} else if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
 func TestMonikersByPosition(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/monikersByPosition", map[string]string{
@@ -19,6 +19,9 @@ type MockBundleClient struct {
 	// DiagnosticsFunc is an instance of a mock function object controlling
 	// the behavior of the method Diagnostics.
 	DiagnosticsFunc *BundleClientDiagnosticsFunc
+	// DocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentSymbols.
+	DocumentSymbolsFunc *BundleClientDocumentSymbolsFunc
 	// ExistsFunc is an instance of a mock function object controlling the
 	// behavior of the method Exists.
 	ExistsFunc *BundleClientExistsFunc
@@ -28,6 +31,9 @@ type MockBundleClient struct {
 	// IDFunc is an instance of a mock function object controlling the
 	// behavior of the method ID.
 	IDFunc *BundleClientIDFunc
+	// ImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method Implementations.
+	ImplementationsFunc *BundleClientImplementationsFunc
 	// MonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikerResults.
 	MonikerResultsFunc *BundleClientMonikerResultsFunc
@@ -43,6 +49,9 @@ type MockBundleClient struct {
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *BundleClientReferencesFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *BundleClientTypeDefinitionsFunc
 }
 
 // NewMockBundleClient creates a new mock of the BundleClient interface. All
@@ -59,6 +68,11 @@ func NewMockBundleClient() *MockBundleClient {
 				return nil, 0, nil
 			},
 		},
+		DocumentSymbolsFunc: &BundleClientDocumentSymbolsFunc{
+			defaultHook: func(context.Context, string) ([]client.DocumentSymbol, error) {
+				return nil, nil
+			},
+		},
 		ExistsFunc: &BundleClientExistsFunc{
 			defaultHook: func(context.Context, string) (bool, error) {
 				return false, nil
@@ -74,6 +88,11 @@ func NewMockBundleClient() *MockBundleClient {
 				return 0
 			},
 		},
+		ImplementationsFunc: &BundleClientImplementationsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 		MonikerResultsFunc: &BundleClientMonikerResultsFunc{
 			defaultHook: func(context.Context, string, string, string, int, int) ([]client.Location, int, error) {
 				return nil, 0, nil
@@ -99,6 +118,11 @@ func NewMockBundleClient() *MockBundleClient {
 				return nil, nil
 			},
 		},
+		TypeDefinitionsFunc: &BundleClientTypeDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]client.Location, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -113,6 +137,9 @@ func NewMockBundleClientFrom(i client.BundleClient) *MockBundleClient {
 		DiagnosticsFunc: &BundleClientDiagnosticsFunc{
 			defaultHook: i.Diagnostics,
 		},
+		DocumentSymbolsFunc: &BundleClientDocumentSymbolsFunc{
+			defaultHook: i.DocumentSymbols,
+		},
 		ExistsFunc: &BundleClientExistsFunc{
 			defaultHook: i.Exists,
 		},
@@ -122,6 +149,9 @@ func NewMockBundleClientFrom(i client.BundleClient) *MockBundleClient {
 		IDFunc: &BundleClientIDFunc{
 			defaultHook: i.ID,
 		},
+		ImplementationsFunc: &BundleClientImplementationsFunc{
+			defaultHook: i.Implementations,
+		},
 		MonikerResultsFunc: &BundleClientMonikerResultsFunc{
 			defaultHook: i.MonikerResults,
 		},
@@ -137,6 +167,9 @@ func NewMockBundleClientFrom(i client.BundleClient) *MockBundleClient {
 		ReferencesFunc: &BundleClientReferencesFunc{
 			defaultHook: i.References,
 		},
+		TypeDefinitionsFunc: &BundleClientTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -373,6 +406,114 @@ func (c BundleClientDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// BundleClientDocumentSymbolsFunc describes the behavior when the
+// DocumentSymbols method of the parent MockBundleClient instance is invoked.
+type BundleClientDocumentSymbolsFunc struct {
+	defaultHook func(context.Context, string) ([]client.DocumentSymbol, error)
+	hooks       []func(context.Context, string) ([]client.DocumentSymbol, error)
+	history     []BundleClientDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockBundleClient) DocumentSymbols(v0 context.Context, v1 string) ([]client.DocumentSymbol, error) {
+	r0, r1 := m.DocumentSymbolsFunc.nextHook()(v0, v1)
+	m.DocumentSymbolsFunc.appendCall(BundleClientDocumentSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DocumentSymbols method
+// of the parent MockBundleClient instance is invoked and the hook queue is
+// empty.
+func (f *BundleClientDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context, string) ([]client.DocumentSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentSymbols method of the parent MockBundleClient instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *BundleClientDocumentSymbolsFunc) PushHook(hook func(context.Context, string) ([]client.DocumentSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientDocumentSymbolsFunc) SetDefaultReturn(r0 []client.DocumentSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]client.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientDocumentSymbolsFunc) PushReturn(r0 []client.DocumentSymbol, r1 error) {
+	f.PushHook(func(context.Context, string) ([]client.DocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *BundleClientDocumentSymbolsFunc) nextHook() func(context.Context, string) ([]client.DocumentSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientDocumentSymbolsFunc) appendCall(r0 BundleClientDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientDocumentSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientDocumentSymbolsFunc) History() []BundleClientDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientDocumentSymbolsFuncCall is an object that describes an
+// invocation of method DocumentSymbols on an instance of MockBundleClient.
+type BundleClientDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.DocumentSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c BundleClientDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BundleClientExistsFunc describes the behavior when the Exists method of
 // the parent MockBundleClient instance is invoked.
 type BundleClientExistsFunc struct {
@@ -700,6 +841,120 @@ func (c BundleClientIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// BundleClientImplementationsFunc describes the behavior when the
+// Implementations method of the parent MockBundleClient instance is invoked.
+type BundleClientImplementationsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []BundleClientImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// Implementations delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockBundleClient) Implementations(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.ImplementationsFunc.nextHook()(v0, v1, v2, v3)
+	m.ImplementationsFunc.appendCall(BundleClientImplementationsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Implementations method
+// of the parent MockBundleClient instance is invoked and the hook queue is
+// empty.
+func (f *BundleClientImplementationsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Implementations method of the parent MockBundleClient instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *BundleClientImplementationsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientImplementationsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientImplementationsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *BundleClientImplementationsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientImplementationsFunc) appendCall(r0 BundleClientImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientImplementationsFunc) History() []BundleClientImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientImplementationsFuncCall is an object that describes an
+// invocation of method Implementations on an instance of MockBundleClient.
+type BundleClientImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c BundleClientImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BundleClientMonikerResultsFunc describes the behavior when the
 // MonikerResults method of the parent MockBundleClient instance is invoked.
 type BundleClientMonikerResultsFunc struct {
@@ -1283,3 +1538,117 @@ func (c BundleClientReferencesFuncCall) Args() []interface{} {
 func (c BundleClientReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// BundleClientTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockBundleClient instance is invoked.
+type BundleClientTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]client.Location, error)
+	hooks       []func(context.Context, string, int, int) ([]client.Location, error)
+	history     []BundleClientTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockBundleClient) TypeDefinitions(v0 context.Context, v1 string, v2 int, v3 int) ([]client.Location, error) {
+	r0, r1 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3)
+	m.TypeDefinitionsFunc.appendCall(BundleClientTypeDefinitionsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions method
+// of the parent MockBundleClient instance is invoked and the hook queue is
+// empty.
+func (f *BundleClientTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockBundleClient instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *BundleClientTypeDefinitionsFunc) PushHook(hook func(context.Context, string, int, int) ([]client.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *BundleClientTypeDefinitionsFunc) SetDefaultReturn(r0 []client.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *BundleClientTypeDefinitionsFunc) PushReturn(r0 []client.Location, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]client.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *BundleClientTypeDefinitionsFunc) nextHook() func(context.Context, string, int, int) ([]client.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BundleClientTypeDefinitionsFunc) appendCall(r0 BundleClientTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of BundleClientTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *BundleClientTypeDefinitionsFunc) History() []BundleClientTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]BundleClientTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BundleClientTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockBundleClient.
+type BundleClientTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []client.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c BundleClientTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BundleClientTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -55,3 +55,14 @@ type CodeIntelligenceRange struct {
 	References  []Location `json:"references"`
 	HoverText   string     `json:"hoverText"`
 }
+
+// DocumentSymbol describes a symbol declared within a document. The children of a
+// symbol are the symbols lexically nested within it.
+type DocumentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail"`
+	Kind           int              `json:"kind"`
+	Range          Range            `json:"range"`
+	SelectionRange Range            `json:"selectionRange"`
+	Children       []DocumentSymbol `json:"children"`
+}
@@ -26,6 +26,9 @@ type MockReader struct {
 	// ReadDocumentFunc is an instance of a mock function object controlling
 	// the behavior of the method ReadDocument.
 	ReadDocumentFunc *ReaderReadDocumentFunc
+	// ReadImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReadImplementations.
+	ReadImplementationsFunc *ReaderReadImplementationsFunc
 	// ReadMetaFunc is an instance of a mock function object controlling the
 	// behavior of the method ReadMeta.
 	ReadMetaFunc *ReaderReadMetaFunc
@@ -61,6 +64,11 @@ func NewMockReader() *MockReader {
 				return types.DocumentData{}, false, nil
 			},
 		},
+		ReadImplementationsFunc: &ReaderReadImplementationsFunc{
+			defaultHook: func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 		ReadMetaFunc: &ReaderReadMetaFunc{
 			defaultHook: func(context.Context) (types.MetaData, error) {
 				return types.MetaData{}, nil
@@ -95,6 +103,9 @@ func NewMockReaderFrom(i persistence.Reader) *MockReader {
 		ReadDocumentFunc: &ReaderReadDocumentFunc{
 			defaultHook: i.ReadDocument,
 		},
+		ReadImplementationsFunc: &ReaderReadImplementationsFunc{
+			defaultHook: i.ReadImplementations,
+		},
 		ReadMetaFunc: &ReaderReadMetaFunc{
 			defaultHook: i.ReadMeta,
 		},
@@ -547,6 +558,126 @@ func (c ReaderReadDocumentFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ReaderReadImplementationsFunc describes the behavior when the
+// ReadImplementations method of the parent MockReader instance is invoked.
+type ReaderReadImplementationsFunc struct {
+	defaultHook func(context.Context, string, string, int, int) ([]types.Location, int, error)
+	hooks       []func(context.Context, string, string, int, int) ([]types.Location, int, error)
+	history     []ReaderReadImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReader) ReadImplementations(v0 context.Context, v1 string, v2 string, v3 int, v4 int) ([]types.Location, int, error) {
+	r0, r1, r2 := m.ReadImplementationsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ReadImplementationsFunc.appendCall(ReaderReadImplementationsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ReadImplementations
+// method of the parent MockReader instance is invoked and the hook queue is
+// empty.
+func (f *ReaderReadImplementationsFunc) SetDefaultHook(hook func(context.Context, string, string, int, int) ([]types.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadImplementations method of the parent MockReader instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReaderReadImplementationsFunc) PushHook(hook func(context.Context, string, string, int, int) ([]types.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ReaderReadImplementationsFunc) SetDefaultReturn(r0 []types.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ReaderReadImplementationsFunc) PushReturn(r0 []types.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ReaderReadImplementationsFunc) nextHook() func(context.Context, string, string, int, int) ([]types.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReaderReadImplementationsFunc) appendCall(r0 ReaderReadImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReaderReadImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *ReaderReadImplementationsFunc) History() []ReaderReadImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReaderReadImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReaderReadImplementationsFuncCall is an object that describes an invocation
+// of method ReadImplementations on an instance of MockReader.
+type ReaderReadImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c ReaderReadImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReaderReadImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ReaderReadMetaFunc describes the behavior when the ReadMeta method of the
 // parent MockReader instance is invoked.
 type ReaderReadMetaFunc struct {
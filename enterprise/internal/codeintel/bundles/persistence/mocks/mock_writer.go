@@ -23,6 +23,9 @@ type MockWriter struct {
 	// WriteDocumentsFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteDocuments.
 	WriteDocumentsFunc *WriterWriteDocumentsFunc
+	// WriteImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method WriteImplementations.
+	WriteImplementationsFunc *WriterWriteImplementationsFunc
 	// WriteMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method WriteMeta.
 	WriteMetaFunc *WriterWriteMetaFunc
@@ -53,6 +56,11 @@ func NewMockWriter() *MockWriter {
 				return nil
 			},
 		},
+		WriteImplementationsFunc: &WriterWriteImplementationsFunc{
+			defaultHook: func(context.Context, []types.MonikerLocations) error {
+				return nil
+			},
+		},
 		WriteMetaFunc: &WriterWriteMetaFunc{
 			defaultHook: func(context.Context, types.MetaData) error {
 				return nil
@@ -84,6 +92,9 @@ func NewMockWriterFrom(i persistence.Writer) *MockWriter {
 		WriteDocumentsFunc: &WriterWriteDocumentsFunc{
 			defaultHook: i.WriteDocuments,
 		},
+		WriteImplementationsFunc: &WriterWriteImplementationsFunc{
+			defaultHook: i.WriteImplementations,
+		},
 		WriteMetaFunc: &WriterWriteMetaFunc{
 			defaultHook: i.WriteMeta,
 		},
@@ -410,6 +421,111 @@ func (c WriterWriteDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// WriterWriteImplementationsFunc describes the behavior when the
+// WriteImplementations method of the parent MockWriter instance is invoked.
+type WriterWriteImplementationsFunc struct {
+	defaultHook func(context.Context, []types.MonikerLocations) error
+	hooks       []func(context.Context, []types.MonikerLocations) error
+	history     []WriterWriteImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWriter) WriteImplementations(v0 context.Context, v1 []types.MonikerLocations) error {
+	r0 := m.WriteImplementationsFunc.nextHook()(v0, v1)
+	m.WriteImplementationsFunc.appendCall(WriterWriteImplementationsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WriteImplementations
+// method of the parent MockWriter instance is invoked and the hook queue is
+// empty.
+func (f *WriterWriteImplementationsFunc) SetDefaultHook(hook func(context.Context, []types.MonikerLocations) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteImplementations method of the parent MockWriter instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WriterWriteImplementationsFunc) PushHook(hook func(context.Context, []types.MonikerLocations) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *WriterWriteImplementationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []types.MonikerLocations) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *WriterWriteImplementationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []types.MonikerLocations) error {
+		return r0
+	})
+}
+
+func (f *WriterWriteImplementationsFunc) nextHook() func(context.Context, []types.MonikerLocations) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WriterWriteImplementationsFunc) appendCall(r0 WriterWriteImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WriterWriteImplementationsFuncCall objects
+// describing the invocations of this function.
+func (f *WriterWriteImplementationsFunc) History() []WriterWriteImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]WriterWriteImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WriterWriteImplementationsFuncCall is an object that describes an invocation
+// of method WriteImplementations on an instance of MockWriter.
+type WriterWriteImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []types.MonikerLocations
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this invocation.
+func (c WriterWriteImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WriterWriteImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // WriterWriteMetaFunc describes the behavior when the WriteMeta method of
 // the parent MockWriter instance is invoked.
 type WriterWriteMetaFunc struct {
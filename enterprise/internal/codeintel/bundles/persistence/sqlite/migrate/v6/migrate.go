@@ -0,0 +1,15 @@
+package v6
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/serialization"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite/store"
+)
+
+// Migrate v6: Create an empty implementations table. Bundles written prior to this version
+// did not correlate implementation results, so there are no rows to populate.
+func Migrate(ctx context.Context, s *store.Store, serializer serialization.Serializer) error {
+	return s.Exec(ctx, sqlf.Sprintf(`CREATE TABLE IF NOT EXISTS "implementations" ("scheme" text NOT NULL, "identifier" text NOT NULL, "data" blob NOT NULL, PRIMARY KEY (scheme, identifier))`))
+}
@@ -16,6 +16,7 @@ type DocumentData struct {
 	Monikers           map[ID]MonikerData
 	PackageInformation map[ID]PackageInformationData
 	Diagnostics        []DiagnosticData
+	Symbols            []SymbolData
 }
 
 // RangeData represents a range vertex within an index. It contains the same relevant
@@ -23,14 +24,16 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine          int  // 0-indexed, inclusive
-	StartCharacter     int  // 0-indexed, inclusive
-	EndLine            int  // 0-indexed, inclusive
-	EndCharacter       int  // 0-indexed, inclusive
-	DefinitionResultID ID   // possibly empty
-	ReferenceResultID  ID   // possibly empty
-	HoverResultID      ID   // possibly empty
-	MonikerIDs         []ID // possibly empty
+	StartLine              int  // 0-indexed, inclusive
+	StartCharacter         int  // 0-indexed, inclusive
+	EndLine                int  // 0-indexed, inclusive
+	EndCharacter           int  // 0-indexed, inclusive
+	DefinitionResultID     ID   // possibly empty
+	ReferenceResultID      ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
+	ImplementationResultID ID   // possibly empty
+	HoverResultID          ID   // possibly empty
+	MonikerIDs             []ID // possibly empty
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
@@ -63,6 +66,23 @@ type DiagnosticData struct {
 	EndCharacter   int // 0-indexed, inclusive
 }
 
+// SymbolData represents a symbol declared within a document. Symbols form a tree that
+// mirrors the lexical nesting of declarations (e.g. methods within a class).
+type SymbolData struct {
+	Name                    string
+	Detail                  string
+	Kind                    int // LSP SymbolKind
+	StartLine               int // 0-indexed, inclusive
+	StartCharacter          int // 0-indexed, inclusive
+	EndLine                 int // 0-indexed, inclusive
+	EndCharacter            int // 0-indexed, inclusive
+	SelectionStartLine      int // 0-indexed, inclusive
+	SelectionStartCharacter int // 0-indexed, inclusive
+	SelectionEndLine        int // 0-indexed, inclusive
+	SelectionEndCharacter   int // 0-indexed, inclusive
+	Children                []SymbolData
+}
+
 // ResultChunkData represents a row of the resultChunk table. Each row is a subset
 // of definition and reference result data in the index. Results are inserted into
 // chunks based on the hash of their identifier, thus every chunk has a roughly
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+)
+
+type DocumentSymbolResolver struct {
+	symbol resolvers.AdjustedDocumentSymbol
+}
+
+func NewDocumentSymbolResolver(symbol resolvers.AdjustedDocumentSymbol) gql.DocumentSymbolResolver {
+	return &DocumentSymbolResolver{
+		symbol: symbol,
+	}
+}
+
+// NewDocumentSymbolResolvers creates a resolver for each of the given symbols.
+func NewDocumentSymbolResolvers(symbols []resolvers.AdjustedDocumentSymbol) []gql.DocumentSymbolResolver {
+	resolvers := make([]gql.DocumentSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, NewDocumentSymbolResolver(symbol))
+	}
+
+	return resolvers
+}
+
+func (r *DocumentSymbolResolver) Name() string    { return r.symbol.Name }
+func (r *DocumentSymbolResolver) Detail() *string { return strPtr(r.symbol.Detail) }
+func (r *DocumentSymbolResolver) Kind() string    { return toSymbolKind(r.symbol.Kind) }
+
+func (r *DocumentSymbolResolver) Range() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.symbol.Range))
+}
+
+func (r *DocumentSymbolResolver) SelectionRange() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.symbol.SelectionRange))
+}
+
+func (r *DocumentSymbolResolver) Children() []gql.DocumentSymbolResolver {
+	return NewDocumentSymbolResolvers(r.symbol.Children)
+}
+
+// toSymbolKind converts an LSP symbol kind into its GraphQL enum value.
+func toSymbolKind(kind int) string {
+	if kind == 0 {
+		return "UNKNOWN"
+	}
+
+	return strings.ToUpper(lsp.SymbolKind(kind).String())
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"sync"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -31,6 +33,9 @@ type cachedRepositoryResolver struct {
 	sync.RWMutex
 	resolver *gql.RepositoryResolver
 	children map[string]*cachedCommitResolver
+
+	// pathMatcher decides which paths of the repository the current user can read.
+	pathMatcher *authz.PathMatcher
 }
 
 type cachedCommitResolver struct {
@@ -77,6 +82,16 @@ func (r *CachedLocationResolver) Path(ctx context.Context, id api.RepoID, commit
 	return pathResolver, nil
 }
 
+// CanReadPath returns true if the current user can read the file at the given path of the repository
+// with the given identifier.
+func (r *CachedLocationResolver) CanReadPath(ctx context.Context, id api.RepoID, path string) (bool, error) {
+	cachedRepositoryResolver, err := r.cachedRepository(ctx, id)
+	if err != nil || cachedRepositoryResolver == nil {
+		return false, err
+	}
+	return cachedRepositoryResolver.pathMatcher.CanReadPath(path), nil
+}
+
 // cachedRepository resolves the repository with the given identifier if the resulting resolver does not
 // already exist in the cache. The cache is tested/populated with double-checked locking, which ensures
 // that the resolver is created exactly once per GraphQL request.
@@ -110,7 +125,11 @@ func (r *CachedLocationResolver) cachedRepository(ctx context.Context, id api.Re
 	// of a path may result in a nil dereference.
 	var cachedResolver *cachedRepositoryResolver
 	if resolver != nil {
-		cachedResolver = &cachedRepositoryResolver{resolver: resolver, children: map[string]*cachedCommitResolver{}}
+		pathMatcher, err := db.AuthzPathMatcher(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		cachedResolver = &cachedRepositoryResolver{resolver: resolver, children: map[string]*cachedCommitResolver{}, pathMatcher: pathMatcher}
 	}
 	r.children[id] = cachedResolver
 	return cachedResolver, nil
@@ -239,7 +258,7 @@ func (r *CachedLocationResolver) resolvePath(ctx context.Context, commitResolver
 
 // resolveLocations creates a slide of LocationResolvers for the given list of adjusted locations. The
 // resulting list may be smaller than the the input list as any locations with a commit not known by
-// gitserver will be skipped.
+// gitserver, or with a path the current user cannot read, will be skipped.
 func resolveLocations(ctx context.Context, locationResolver *CachedLocationResolver, locations []resolvers.AdjustedLocation) ([]gql.LocationResolver, error) {
 	resolvedLocations := make([]gql.LocationResolver, 0, len(locations))
 	for i := range locations {
@@ -258,8 +277,15 @@ func resolveLocations(ctx context.Context, locationResolver *CachedLocationResol
 }
 
 // resolveLocation creates a LocationResolver for the given adjusted location. This function may return a
-// nil resolver if the location's commit is not known by gitserver.
+// nil resolver if the location's commit is not known by gitserver, or if the current user cannot read the
+// location's path.
 func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolver, location resolvers.AdjustedLocation) (gql.LocationResolver, error) {
+	// 🚨 SECURITY: Skip locations in paths the user cannot read.
+	canRead, err := locationResolver.CanReadPath(ctx, api.RepoID(location.Dump.RepositoryID), location.Path)
+	if err != nil || !canRead {
+		return nil, err
+	}
+
 	treeResolver, err := locationResolver.Path(ctx, api.RepoID(location.Dump.RepositoryID), location.AdjustedCommit, location.Path)
 	if err != nil || treeResolver == nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
 )
 
@@ -130,6 +131,63 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestTypeDefinitions(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
+	if _, err := resolver.TypeDefinitions(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.TypeDefinitionsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.TypeDefinitionsFunc.History()))
+	}
+	if val := mockResolver.TypeDefinitionsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockResolver.TypeDefinitionsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+}
+
+func TestImplementations(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	offset := int32(25)
+	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
+
+	args := &gql.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &cursor,
+	}
+
+	if _, err := resolver.Implementations(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.ImplementationsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.ImplementationsFunc.History()))
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg3; val != 25 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 25, val)
+	}
+	if val := mockResolver.ImplementationsFunc.History()[0].Arg4; val != "test-cursor" {
+		t.Fatalf("unexpected cursor. want=%s have=%s", "test-cursor", val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockResolver := resolvermocks.NewMockQueryResolver()
 	mockResolver.HoverFunc.SetDefaultReturn("text", bundles.Range{}, true, nil)
@@ -205,3 +263,46 @@ func TestDiagnosticsDefaultIllegalLimit(t *testing.T) {
 		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalLimit, err)
 	}
 }
+
+func TestDocumentSymbols(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.DocumentSymbolsFunc.SetDefaultReturn([]resolvers.AdjustedDocumentSymbol{
+		{
+			Name: "Foo",
+			Kind: 5,
+			Children: []resolvers.AdjustedDocumentSymbol{
+				{Name: "bar", Detail: "func()", Kind: 6},
+			},
+		},
+	}, nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	symbols, err := resolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(symbols) != 1 {
+		t.Fatalf("unexpected symbol count. want=%d have=%d", 1, len(symbols))
+	}
+	if name := symbols[0].Name(); name != "Foo" {
+		t.Errorf("unexpected name. want=%s have=%s", "Foo", name)
+	}
+	if kind := symbols[0].Kind(); kind != "CLASS" {
+		t.Errorf("unexpected kind. want=%s have=%s", "CLASS", kind)
+	}
+	if detail := symbols[0].Detail(); detail != nil {
+		t.Errorf("unexpected detail. want=nil have=%s", *detail)
+	}
+
+	children := symbols[0].Children()
+	if len(children) != 1 {
+		t.Fatalf("unexpected child count. want=%d have=%d", 1, len(children))
+	}
+	if kind := children[0].Kind(); kind != "METHOD" {
+		t.Errorf("unexpected kind. want=%s have=%s", "METHOD", kind)
+	}
+	if detail := children[0].Detail(); detail == nil || *detail != "func()" {
+		t.Errorf("unexpected detail. want=%s have=%v", "func()", detail)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
@@ -123,6 +124,36 @@ func (r *Resolver) DeleteLSIFIndex(ctx context.Context, id graphql.ID) (*gql.Emp
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) UpdateLSIFIndexerOverride(ctx context.Context, args *gql.UpdateLSIFIndexerOverrideArgs) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may configure auto-indexing for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if args.Indexer != nil && *args.Indexer == "" {
+		return nil, errors.New("indexer must not be empty")
+	}
+	if args.Indexer == nil && args.IndexerArgs != nil {
+		return nil, errors.New("indexerArgs requires an indexer")
+	}
+
+	repositoryID, err := resolveRepositoryID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexerArgs []string
+	if args.IndexerArgs != nil {
+		indexerArgs = *args.IndexerArgs
+	}
+
+	if err := r.resolver.UpdateIndexerOverride(ctx, repositoryID, args.Indexer, indexerArgs); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
 func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (gql.GitBlobLSIFDataResolver, error) {
 	resolver, err := r.resolver.QueryResolver(ctx, args)
 	if err != nil || resolver == nil {
@@ -132,6 +163,15 @@ func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDat
 	return NewQueryResolver(resolver, r.locationResolver), nil
 }
 
+func (r *Resolver) GitBlobDocumentSymbols(ctx context.Context, args *gql.GitBlobLSIFDataArgs) ([]gql.DocumentSymbolResolver, error) {
+	symbols, err := r.resolver.DocumentSymbols(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDocumentSymbolResolvers(symbols), nil
+}
+
 // makeGetUploadsOptions translates the given GraphQL arguments into options defined by the
 // store.GetUploads operations.
 func makeGetUploadsOptions(ctx context.Context, args *gql.LSIFRepositoryUploadsQueryArgs) (store.GetUploadsOptions, error) {
@@ -81,6 +81,78 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestUpdateLSIFIndexerOverride(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewResolver(mockResolver)
+
+	if _, err := resolver.UpdateLSIFIndexerOverride(context.Background(), &gql.UpdateLSIFIndexerOverrideArgs{
+		Repository:  gql.MarshalRepositoryID(50),
+		Indexer:     strPtr("lsif-java"),
+		IndexerArgs: &[]string{"index"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := resolver.UpdateLSIFIndexerOverride(context.Background(), &gql.UpdateLSIFIndexerOverrideArgs{
+		Repository: gql.MarshalRepositoryID(51),
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	history := mockResolver.UpdateIndexerOverrideFunc.History()
+	if len(history) != 2 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 2, len(history))
+	}
+	if diff := cmp.Diff([]interface{}{50, strPtr("lsif-java"), []string{"index"}}, history[0].Args()[1:]); diff != "" {
+		t.Errorf("unexpected arguments (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]interface{}{51, (*string)(nil), []string(nil)}, history[1].Args()[1:]); diff != "" {
+		t.Errorf("unexpected arguments (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateLSIFIndexerOverrideArgsWithoutIndexer(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).UpdateLSIFIndexerOverride(context.Background(), &gql.UpdateLSIFIndexerOverrideArgs{
+		Repository:  gql.MarshalRepositoryID(50),
+		IndexerArgs: &[]string{"index"},
+	}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(mockResolver.UpdateIndexerOverrideFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockResolver.UpdateIndexerOverrideFunc.History()))
+	}
+}
+
+func TestUpdateLSIFIndexerOverrideUnauthenticated(t *testing.T) {
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).UpdateLSIFIndexerOverride(context.Background(), &gql.UpdateLSIFIndexerOverrideArgs{
+		Repository: gql.MarshalRepositoryID(50),
+		Indexer:    strPtr("lsif-java"),
+	}); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		db.Mocks.Repos.Get = nil
@@ -21,6 +21,9 @@ type MockResolver struct {
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *ResolverDeleteUploadByIDFunc
+	// DocumentSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentSymbols.
+	DocumentSymbolsFunc *ResolverDocumentSymbolsFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
@@ -33,6 +36,9 @@ type MockResolver struct {
 	// QueryResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryResolver.
 	QueryResolverFunc *ResolverQueryResolverFunc
+	// UpdateIndexerOverrideFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIndexerOverride.
+	UpdateIndexerOverrideFunc *ResolverUpdateIndexerOverrideFunc
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
@@ -52,6 +58,11 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		DocumentSymbolsFunc: &ResolverDocumentSymbolsFunc{
+			defaultHook: func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error) {
+				return nil, nil
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (store.Index, bool, error) {
 				return store.Index{}, false, nil
@@ -72,6 +83,11 @@ func NewMockResolver() *MockResolver {
 				return nil, nil
 			},
 		},
+		UpdateIndexerOverrideFunc: &ResolverUpdateIndexerOverrideFunc{
+			defaultHook: func(context.Context, int, *string, []string) error {
+				return nil
+			},
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: func(store.GetUploadsOptions) *resolvers.UploadsResolver {
 				return nil
@@ -90,6 +106,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DocumentSymbolsFunc: &ResolverDocumentSymbolsFunc{
+			defaultHook: i.DocumentSymbols,
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -102,6 +121,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: i.QueryResolver,
 		},
+		UpdateIndexerOverrideFunc: &ResolverUpdateIndexerOverrideFunc{
+			defaultHook: i.UpdateIndexerOverride,
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
@@ -320,6 +342,115 @@ func (c ResolverDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverDocumentSymbolsFunc describes the behavior when the DocumentSymbols
+// method of the parent MockResolver instance is invoked.
+type ResolverDocumentSymbolsFunc struct {
+	defaultHook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error)
+	hooks       []func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error)
+	history     []ResolverDocumentSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentSymbols delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockResolver) DocumentSymbols(v0 context.Context, v1 *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error) {
+	r0, r1 := m.DocumentSymbolsFunc.nextHook()(v0, v1)
+	m.DocumentSymbolsFunc.appendCall(ResolverDocumentSymbolsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DocumentSymbols method
+// of the parent MockResolver instance is invoked and the hook queue is
+// empty.
+func (f *ResolverDocumentSymbolsFunc) SetDefaultHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentSymbols method of the parent MockResolver instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ResolverDocumentSymbolsFunc) PushHook(hook func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverDocumentSymbolsFunc) SetDefaultReturn(r0 []resolvers.AdjustedDocumentSymbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverDocumentSymbolsFunc) PushReturn(r0 []resolvers.AdjustedDocumentSymbol, r1 error) {
+	f.PushHook(func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverDocumentSymbolsFunc) nextHook() func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) ([]resolvers.AdjustedDocumentSymbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDocumentSymbolsFunc) appendCall(r0 ResolverDocumentSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverDocumentSymbolsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverDocumentSymbolsFunc) History() []ResolverDocumentSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDocumentSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDocumentSymbolsFuncCall is an object that describes an invocation
+// of method DocumentSymbols on an instance of MockResolver.
+type ResolverDocumentSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *graphqlbackend.GitBlobLSIFDataArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedDocumentSymbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDocumentSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDocumentSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetIndexByIDFunc struct {
@@ -759,6 +890,120 @@ func (c ResolverQueryResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverUpdateIndexerOverrideFunc describes the behavior when the
+// UpdateIndexerOverride method of the parent MockResolver instance is
+// invoked.
+type ResolverUpdateIndexerOverrideFunc struct {
+	defaultHook func(context.Context, int, *string, []string) error
+	hooks       []func(context.Context, int, *string, []string) error
+	history     []ResolverUpdateIndexerOverrideFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexerOverride delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) UpdateIndexerOverride(v0 context.Context, v1 int, v2 *string, v3 []string) error {
+	r0 := m.UpdateIndexerOverrideFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateIndexerOverrideFunc.appendCall(ResolverUpdateIndexerOverrideFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexerOverride method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverUpdateIndexerOverrideFunc) SetDefaultHook(hook func(context.Context, int, *string, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexerOverride method of the parent MockResolver instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverUpdateIndexerOverrideFunc) PushHook(hook func(context.Context, int, *string, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUpdateIndexerOverrideFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, *string, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUpdateIndexerOverrideFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, *string, []string) error {
+		return r0
+	})
+}
+
+func (f *ResolverUpdateIndexerOverrideFunc) nextHook() func(context.Context, int, *string, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUpdateIndexerOverrideFunc) appendCall(r0 ResolverUpdateIndexerOverrideFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUpdateIndexerOverrideFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverUpdateIndexerOverrideFunc) History() []ResolverUpdateIndexerOverrideFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUpdateIndexerOverrideFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUpdateIndexerOverrideFuncCall is an object that describes an
+// invocation of method UpdateIndexerOverride on an instance of
+// MockResolver.
+type ResolverUpdateIndexerOverrideFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUpdateIndexerOverrideFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUpdateIndexerOverrideFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverUploadConnectionResolverFunc describes the behavior when the
 // UploadConnectionResolver method of the parent MockResolver instance is
 // invoked.
@@ -27,6 +27,17 @@ type AdjustedDiagnostic struct {
 	AdjustedRange  bundles.Range
 }
 
+// AdjustedDocumentSymbol is similar to a bundles.DocumentSymbol, but with ranges adjusted for the
+// target commit (when the requested commit is not indexed).
+type AdjustedDocumentSymbol struct {
+	Name           string
+	Detail         string
+	Kind           int // LSP SymbolKind
+	Range          bundles.Range
+	SelectionRange bundles.Range
+	Children       []AdjustedDocumentSymbol
+}
+
 // AdjustedCodeIntelligenceRange is similar to a codeintelapi.CodeIntelligenceRange,
 // but with adjusted definition and reference locations.
 type AdjustedCodeIntelligenceRange struct {
@@ -44,8 +55,11 @@ type QueryResolver interface {
 	Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error)
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Hover(ctx context.Context, line, character int) (string, bundles.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
+	DocumentSymbols(ctx context.Context) ([]AdjustedDocumentSymbol, error)
 }
 
 type queryResolver struct {
@@ -165,6 +179,51 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 // This may include references from other dumps and repositories. If there are multiple bundles
 // associated with this resolver, results from all bundles will be concatenated and returned.
 func (r *queryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return r.pagedLocations(ctx, line, character, limit, rawCursor, r.codeIntelAPI.References)
+}
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the
+// given position. If there are multiple bundles associated with this resolver, the type definitions
+// from the first bundle with any results will be returned.
+func (r *queryResolver) TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error) {
+	position := bundles.Position{Line: line, Character: character}
+
+	for i := range r.uploads {
+		adjustedPath, adjustedPosition, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		locations, err := r.codeIntelAPI.TypeDefinitions(ctx, adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(locations) == 0 {
+			continue
+		}
+
+		return r.adjustLocations(ctx, locations)
+	}
+
+	return nil, nil
+}
+
+// Implementations returns the list of source locations that implement the symbol at the given position.
+// This may include implementations from other dumps and repositories. If there are multiple bundles
+// associated with this resolver, results from all bundles will be concatenated and returned.
+func (r *queryResolver) Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return r.pagedLocations(ctx, line, character, limit, rawCursor, r.codeIntelAPI.Implementations)
+}
+
+// pagedLocationsFunc is the signature shared by the paginated location methods of CodeIntelAPI.
+type pagedLocationsFunc func(ctx context.Context, repositoryID int, commit string, limit int, cursor codeintelapi.Cursor) ([]codeintelapi.ResolvedLocation, codeintelapi.Cursor, bool, error)
+
+// pagedLocations returns a page of locations from each bundle associated with this resolver using
+// the given paginated CodeIntelAPI method, along with a cursor for the next page of results.
+func (r *queryResolver) pagedLocations(ctx context.Context, line, character, limit int, rawCursor string, fn pagedLocationsFunc) ([]AdjustedLocation, string, error) {
 	position := bundles.Position{Line: line, Character: character}
 
 	// Decode a map of upload ids to the next url that serves
@@ -206,7 +265,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 			return nil, "", err
 		}
 
-		locations, newCursor, hasNewCursor, err := r.codeIntelAPI.References(ctx, r.repositoryID, r.commit, limit, cursor)
+		locations, newCursor, hasNewCursor, err := fn(ctx, r.repositoryID, r.commit, limit, cursor)
 		if err != nil {
 			return nil, "", err
 		}
@@ -322,6 +381,67 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedD
 	return adjustedDiagnostics, totalCount, nil
 }
 
+// DocumentSymbols returns the symbol tree of the document. If there are multiple bundles associated
+// with this resolver, the symbols from the first bundle with any results will be returned. If no
+// bundle can supply symbols for the document, the symbols reported by the symbols service are
+// returned instead.
+func (r *queryResolver) DocumentSymbols(ctx context.Context) ([]AdjustedDocumentSymbol, error) {
+	for i := range r.uploads {
+		adjustedPath, ok, err := r.positionAdjuster.AdjustPath(ctx, r.uploads[i].Commit, r.path, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		symbols, err := r.codeIntelAPI.DocumentSymbols(ctx, adjustedPath, r.uploads[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+
+		return r.adjustDocumentSymbols(ctx, r.uploads[i], adjustedPath, symbols)
+	}
+
+	return r.fallbackDocumentSymbols(ctx)
+}
+
+// adjustDocumentSymbols translates the ranges of the given symbol tree from the commit of
+// the given dump into the target commit.
+func (r *queryResolver) adjustDocumentSymbols(ctx context.Context, dump store.Dump, path string, symbols []bundles.DocumentSymbol) ([]AdjustedDocumentSymbol, error) {
+	adjustedSymbols := make([]AdjustedDocumentSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		_, adjustedRange, err := r.adjustRange(ctx, dump.RepositoryID, dump.Commit, path, symbol.Range)
+		if err != nil {
+			return nil, err
+		}
+
+		_, adjustedSelectionRange, err := r.adjustRange(ctx, dump.RepositoryID, dump.Commit, path, symbol.SelectionRange)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustedChildren, err := r.adjustDocumentSymbols(ctx, dump, path, symbol.Children)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustedSymbols = append(adjustedSymbols, AdjustedDocumentSymbol{
+			Name:           symbol.Name,
+			Detail:         symbol.Detail,
+			Kind:           symbol.Kind,
+			Range:          adjustedRange,
+			SelectionRange: adjustedSelectionRange,
+			Children:       adjustedChildren,
+		})
+	}
+
+	return adjustedSymbols, nil
+}
+
 // adjustLocations translates a list of resolved locations (relative to the indexed commit) into a list of
 // equivalent locations in the requested commit.
 func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeintelapi.ResolvedLocation) ([]AdjustedLocation, error) {
@@ -5,12 +5,15 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	codeintelapi "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/api"
 	apimocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/api/mocks"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
 	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
 )
 
 func TestRanges(t *testing.T) {
@@ -425,6 +428,158 @@ func TestReferences(t *testing.T) {
 	}
 }
 
+func TestTypeDefinitions(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// position can be translated for all dumps
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, true, nil)
+
+	mockCodeIntelAPI.TypeDefinitionsFunc.SetDefaultReturn([]codeintelapi.ResolvedLocation{
+		{
+			Dump: store.Dump{ID: 43, RepositoryID: 50},
+			Path: "p1.go",
+			Range: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+		},
+	}, nil)
+
+	// first requested dump (dump 42) has no type definitions
+	mockCodeIntelAPI.TypeDefinitionsFunc.PushReturn(nil, nil)
+
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+		return path, bundles.Range{
+			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
+			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
+		}, true, nil
+	})
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	typeDefinitions, err := queryResolver.TypeDefinitions(context.Background(), 10, 15)
+	if err != nil {
+		t.Fatalf("unexpected error resolving type definitions: %s", err)
+	}
+
+	expectedTypeDefinitions := []AdjustedLocation{
+		{
+			Dump:           store.Dump{ID: 43, RepositoryID: 50},
+			Path:           "p1.go",
+			AdjustedCommit: "deadbeef2",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 110, Character: 120},
+				End:   bundles.Position{Line: 130, Character: 140},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedTypeDefinitions, typeDefinitions); diff != "" {
+		t.Errorf("unexpected type definitions (-want +got):\n%s", diff)
+	}
+}
+
+func TestImplementations(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// position can be translated for all dumps
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("", bundles.Position{Line: 20, Character: 15}, true, nil)
+
+	cursorIn := codeintelapi.Cursor{Phase: "p1"}
+	cursorOut := codeintelapi.Cursor{Phase: "p2"}
+
+	mockCodeIntelAPI.ImplementationsFunc.SetDefaultReturn([]codeintelapi.ResolvedLocation{
+		{
+			Dump: store.Dump{ID: 42, RepositoryID: 50},
+			Path: "p1.go",
+			Range: bundles.Range{
+				Start: bundles.Position{Line: 11, Character: 12},
+				End:   bundles.Position{Line: 13, Character: 14},
+			},
+		},
+	}, cursorOut, true, nil)
+
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+		return path, bundles.Range{
+			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
+			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
+		}, true, nil
+	})
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	cursor, err := makeCursor(map[int]string{42: codeintelapi.EncodeCursor(cursorIn)})
+	if err != nil {
+		t.Fatalf("unexpected error creating cursor: %s", err)
+	}
+
+	implementations, nextCursor, err := queryResolver.Implementations(context.Background(), 10, 15, 3, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error resolving implementations: %s", err)
+	}
+
+	expectedCursor, err := makeCursor(map[int]string{42: codeintelapi.EncodeCursor(cursorOut)})
+	if err != nil {
+		t.Fatalf("unexpected error creating cursor: %s", err)
+	}
+
+	if nextCursor != expectedCursor {
+		t.Errorf("unexpected cursor. want=%q have=%q", expectedCursor, nextCursor)
+	}
+
+	expectedImplementations := []AdjustedLocation{
+		{
+			Dump:           store.Dump{ID: 42, RepositoryID: 50},
+			Path:           "p1.go",
+			AdjustedCommit: "deadbeef2",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 110, Character: 120},
+				End:   bundles.Position{Line: 130, Character: 140},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	if val := len(mockCodeIntelAPI.ImplementationsFunc.History()); val != 1 {
+		t.Errorf("unexpected call count. want=%d have=%d", 1, val)
+	} else if diff := cmp.Diff(cursorIn, mockCodeIntelAPI.ImplementationsFunc.History()[0].Arg4); diff != "" {
+		t.Errorf("unexpected cursor (-want +got):\n%s", diff)
+	}
+	if val := len(mockCodeIntelAPI.ReferencesFunc.History()); val != 0 {
+		t.Errorf("unexpected call count for References. want=%d have=%d", 0, val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
@@ -662,3 +817,157 @@ func TestDiagnostics(t *testing.T) {
 		t.Errorf("unexpected limit. want=%d have=%d", 0, val)
 	}
 }
+
+func TestDocumentSymbols(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// path can be translated for subsequent dumps
+	mockPositionAdjuster.AdjustPathFunc.SetDefaultReturn("/foo/bar.go", true, nil)
+
+	// first requested dump (dump 42) has no equivalent path
+	mockPositionAdjuster.AdjustPathFunc.PushReturn("", false, nil)
+
+	mockCodeIntelAPI.DocumentSymbolsFunc.SetDefaultReturn([]bundles.DocumentSymbol{
+		{
+			Name:           "Foo",
+			Kind:           23,
+			Range:          bundles.Range{Start: bundles.Position{Line: 1, Character: 0}, End: bundles.Position{Line: 4, Character: 1}},
+			SelectionRange: bundles.Range{Start: bundles.Position{Line: 1, Character: 5}, End: bundles.Position{Line: 1, Character: 8}},
+			Children: []bundles.DocumentSymbol{
+				{
+					Name:           "bar",
+					Kind:           8,
+					Range:          bundles.Range{Start: bundles.Position{Line: 2, Character: 1}, End: bundles.Position{Line: 2, Character: 8}},
+					SelectionRange: bundles.Range{Start: bundles.Position{Line: 2, Character: 1}, End: bundles.Position{Line: 2, Character: 4}},
+				},
+			},
+		},
+	}, nil)
+
+	// first requested dump (dump 43) has no symbols
+	mockCodeIntelAPI.DocumentSymbolsFunc.PushReturn(nil, nil)
+
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+		return path, bundles.Range{
+			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
+			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
+		}, true, nil
+	})
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 44, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	symbols, err := queryResolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error resolving document symbols: %s", err)
+	}
+
+	expectedSymbols := []AdjustedDocumentSymbol{
+		{
+			Name:           "Foo",
+			Kind:           23,
+			Range:          bundles.Range{Start: bundles.Position{Line: 10, Character: 0}, End: bundles.Position{Line: 40, Character: 10}},
+			SelectionRange: bundles.Range{Start: bundles.Position{Line: 10, Character: 50}, End: bundles.Position{Line: 10, Character: 80}},
+			Children: []AdjustedDocumentSymbol{
+				{
+					Name:           "bar",
+					Kind:           8,
+					Range:          bundles.Range{Start: bundles.Position{Line: 20, Character: 10}, End: bundles.Position{Line: 20, Character: 80}},
+					SelectionRange: bundles.Range{Start: bundles.Position{Line: 20, Character: 10}, End: bundles.Position{Line: 20, Character: 40}},
+					Children:       []AdjustedDocumentSymbol{},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if history := mockCodeIntelAPI.DocumentSymbolsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected call count. want=%d have=%d", 2, len(history))
+	}
+}
+
+func TestDocumentSymbolsFallback(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	mockStore.RepoNameFunc.SetDefaultReturn("github.com/test/test", nil)
+	mockPositionAdjuster.AdjustPathFunc.SetDefaultReturn("/foo/bar.go", true, nil)
+
+	var args search.SymbolsParameters
+	listTags = func(ctx context.Context, a search.SymbolsParameters) ([]protocol.Symbol, error) {
+		args = a
+		return []protocol.Symbol{
+			{Name: "Foo", Kind: "struct", Line: 2, Pattern: "/^type Foo struct {$/"},
+			{Name: "bar", Kind: "field", Line: 3, Parent: "Foo", Pattern: "/^\tbar int$/"},
+			{Name: "baz", Kind: "func", Line: 6, Signature: "()", Parent: "missing", Pattern: "/^func baz() {$/"},
+		}, nil
+	}
+	defer func() { listTags = backend.Symbols.ListTags }()
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	symbols, err := queryResolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error resolving document symbols: %s", err)
+	}
+
+	expectedArgs := search.SymbolsParameters{
+		Repo:            "github.com/test/test",
+		CommitID:        "deadbeef2",
+		IncludePatterns: []string{`^foo/bar\.go$`},
+		First:           MaxFallbackDocumentSymbols,
+	}
+	if diff := cmp.Diff(expectedArgs, args); diff != "" {
+		t.Errorf("unexpected symbols service arguments (-want +got):\n%s", diff)
+	}
+
+	fooRange := bundles.Range{Start: bundles.Position{Line: 1, Character: 5}, End: bundles.Position{Line: 1, Character: 8}}
+	barRange := bundles.Range{Start: bundles.Position{Line: 2, Character: 1}, End: bundles.Position{Line: 2, Character: 4}}
+	bazRange := bundles.Range{Start: bundles.Position{Line: 5, Character: 5}, End: bundles.Position{Line: 5, Character: 8}}
+
+	expectedSymbols := []AdjustedDocumentSymbol{
+		{
+			Name:           "Foo",
+			Kind:           23,
+			Range:          fooRange,
+			SelectionRange: fooRange,
+			Children: []AdjustedDocumentSymbol{
+				{Name: "bar", Kind: 8, Range: barRange, SelectionRange: barRange, Children: []AdjustedDocumentSymbol{}},
+			},
+		},
+		{Name: "baz", Detail: "()", Kind: 12, Range: bazRange, SelectionRange: bazRange, Children: []AdjustedDocumentSymbol{}},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
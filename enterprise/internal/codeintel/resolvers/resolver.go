@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -23,7 +24,9 @@ type Resolver interface {
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
 	DeleteUploadByID(ctx context.Context, uploadID int) error
 	DeleteIndexByID(ctx context.Context, id int) error
+	UpdateIndexerOverride(ctx context.Context, repositoryID int, indexer *string, indexerArgs []string) error
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
+	DocumentSymbols(ctx context.Context, args *gql.GitBlobLSIFDataArgs) ([]AdjustedDocumentSymbol, error)
 }
 
 type resolver struct {
@@ -69,6 +72,10 @@ func (r *resolver) DeleteIndexByID(ctx context.Context, id int) error {
 	return err
 }
 
+func (r *resolver) UpdateIndexerOverride(ctx context.Context, repositoryID int, indexer *string, indexerArgs []string) error {
+	return r.store.UpdateIndexableRepositoryIndexer(ctx, repositoryID, indexer, indexerArgs, time.Now().UTC())
+}
+
 // QueryResolver determines the set of dumps that can answer code intel queries for the
 // given repository, commit, and path, then constructs a new query resolver instance which
 // can be used to answer subsequent queries.
@@ -97,6 +104,21 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 	), nil
 }
 
+// DocumentSymbols returns the symbols declared in the given path. Symbols are read from the dumps
+// that can answer code intel queries for the path if there are any, and from the symbols service
+// otherwise.
+func (r *resolver) DocumentSymbols(ctx context.Context, args *gql.GitBlobLSIFDataArgs) ([]AdjustedDocumentSymbol, error) {
+	queryResolver, err := r.QueryResolver(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if queryResolver != nil {
+		return queryResolver.DocumentSymbols(ctx)
+	}
+
+	return fallbackDocumentSymbols(ctx, args.Repo.Name, string(args.Commit), args.Path)
+}
+
 // getTipCommit returns the head of the default branch for the given repository. This
 // is used to recalculate the set of visible dumps for a repository on dump deletion.
 func (r *resolver) getTipCommit(ctx context.Context, repositoryID int) (string, error) {
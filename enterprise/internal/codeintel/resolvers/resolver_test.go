@@ -4,12 +4,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	apimocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/api/mocks"
 	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
 	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
 )
 
 func TestQueryResolver(t *testing.T) {
@@ -33,3 +36,31 @@ func TestQueryResolver(t *testing.T) {
 		t.Errorf("expected nil-valued resolver")
 	}
 }
+
+func TestDocumentSymbolsWithoutDumps(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI() // returns no dumps
+
+	listTags = func(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+		if args.Repo != "github.com/test/test" || args.CommitID != "deadbeef" {
+			t.Errorf("unexpected symbols parameters: %+v", args)
+		}
+		return []protocol.Symbol{{Name: "main", Kind: "function", Line: 3}}, nil
+	}
+	defer func() { listTags = backend.Symbols.ListTags }()
+
+	resolver := NewResolver(mockStore, mockBundleManagerClient, mockCodeIntelAPI, nil)
+	symbols, err := resolver.DocumentSymbols(context.Background(), &gql.GitBlobLSIFDataArgs{
+		Repo:      &types.Repo{ID: 50, Name: "github.com/test/test"},
+		Commit:    api.CommitID("deadbeef"),
+		Path:      "main.go",
+		ExactPath: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(symbols) != 1 || symbols[0].Name != "main" {
+		t.Errorf("expected fallback document symbols. got=%v", symbols)
+	}
+}
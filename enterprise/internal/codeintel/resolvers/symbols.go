@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+// MaxFallbackDocumentSymbols is the maximum number of symbols requested from the symbols
+// service for a single document when no bundle can supply document symbols.
+const MaxFallbackDocumentSymbols = 1000
+
+// listTags is the symbols service query used for fallback document symbols. This is
+// replaced in tests.
+var listTags = backend.Symbols.ListTags
+
+// fallbackDocumentSymbols returns the symbols the symbols service (ctags) reports for the
+// document.
+func (r *queryResolver) fallbackDocumentSymbols(ctx context.Context) ([]AdjustedDocumentSymbol, error) {
+	repoName, err := r.store.RepoName(ctx, r.repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.RepoName")
+	}
+
+	return fallbackDocumentSymbols(ctx, api.RepoName(repoName), r.commit, r.path)
+}
+
+// fallbackDocumentSymbols returns the symbols the symbols service (ctags) reports for the
+// given document. Ctags symbols carry only a line and the name of their enclosing symbol,
+// so the ranges of the returned symbols span only the symbol name and the tree is
+// reconstructed from parent names.
+func fallbackDocumentSymbols(ctx context.Context, repoName api.RepoName, commit, path string) ([]AdjustedDocumentSymbol, error) {
+	symbols, err := listTags(ctx, search.SymbolsParameters{
+		Repo:            repoName,
+		CommitID:        api.CommitID(commit),
+		IncludePatterns: []string{"^" + regexp.QuoteMeta(path) + "$"},
+		First:           MaxFallbackDocumentSymbols,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "symbols.ListTags")
+	}
+
+	return ctagsSymbolTree(symbols), nil
+}
+
+// ctagsSymbolTree arranges the given flat list of ctags symbols into a tree. A symbol is
+// nested under the first symbol whose name matches its parent name. Symbols whose parent
+// cannot be found are placed at the root of the tree.
+func ctagsSymbolTree(symbols []protocol.Symbol) []AdjustedDocumentSymbol {
+	indexByName := map[string]int{}
+	for i, symbol := range symbols {
+		if _, ok := indexByName[symbol.Name]; !ok {
+			indexByName[symbol.Name] = i
+		}
+	}
+
+	var roots []int
+	children := map[int][]int{}
+	for i, symbol := range symbols {
+		if parent, ok := indexByName[symbol.Parent]; ok && symbol.Parent != "" && parent != i {
+			children[parent] = append(children[parent], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	var build func(indexes []int) []AdjustedDocumentSymbol
+	build = func(indexes []int) []AdjustedDocumentSymbol {
+		adjustedSymbols := make([]AdjustedDocumentSymbol, 0, len(indexes))
+		for _, i := range indexes {
+			lspRange := gql.SymbolRange(symbols[i])
+			rn := bundles.Range{
+				Start: bundles.Position{Line: lspRange.Start.Line, Character: lspRange.Start.Character},
+				End:   bundles.Position{Line: lspRange.End.Line, Character: lspRange.End.Character},
+			}
+
+			adjustedSymbols = append(adjustedSymbols, AdjustedDocumentSymbol{
+				Name:           symbols[i].Name,
+				Detail:         symbols[i].Signature,
+				Kind:           int(gql.CtagsKindToLSPSymbolKind(symbols[i].Kind)),
+				Range:          rn,
+				SelectionRange: rn,
+				Children:       build(children[i]),
+			})
+		}
+
+		return adjustedSymbols
+	}
+
+	return build(roots)
+}
@@ -138,8 +138,10 @@ func insertIndexes(t *testing.T, db *sql.DB, indexes ...Index) {
 				finished_at,
 				process_after,
 				num_resets,
-				repository_id
-			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+				repository_id,
+				indexer,
+				indexer_args
+			) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 		`,
 			index.ID,
 			index.Commit,
@@ -151,6 +153,8 @@ func insertIndexes(t *testing.T, db *sql.DB, indexes ...Index) {
 			index.ProcessAfter,
 			index.NumResets,
 			index.RepositoryID,
+			index.Indexer,
+			pq.Array(index.IndexerArgs),
 		)
 
 		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 )
 
 // IndexableRepository marks a repository for eligibility to be index automatically.
@@ -15,6 +16,8 @@ type IndexableRepository struct {
 	PreciseCount        int
 	LastIndexEnqueuedAt *time.Time
 	Enabled             *bool
+	Indexer             *string  // overrides the indexer inferred from the repository contents
+	IndexerArgs         []string // overrides the arguments of the inferred indexer
 }
 
 // UpdateableIndexableRepository is a version of IndexableRepository with pointer
@@ -25,6 +28,8 @@ type UpdateableIndexableRepository struct {
 	PreciseCount        *int
 	LastIndexEnqueuedAt *time.Time
 	Enabled             *bool
+	Indexer             *string
+	IndexerArgs         []string
 }
 
 // IndexableRepositoryQueryOptions controls the result filter for IndexableRepositories.
@@ -53,6 +58,8 @@ func scanIndexableRepositories(rows *sql.Rows, queryErr error) (_ []IndexableRep
 			&indexableRepository.PreciseCount,
 			&indexableRepository.LastIndexEnqueuedAt,
 			&indexableRepository.Enabled,
+			&indexableRepository.Indexer,
+			pq.Array(&indexableRepository.IndexerArgs),
 		); err != nil {
 			return nil, err
 		}
@@ -109,7 +116,9 @@ func (s *store) IndexableRepositories(ctx context.Context, opts IndexableReposit
 			search_count,
 			precise_count,
 			last_index_enqueued_at,
-			enabled
+			enabled,
+			indexer,
+			indexer_args
 		FROM lsif_indexable_repositories
 		WHERE enabled is not false AND (enabled is true OR (%s))
 		LIMIT %s
@@ -144,6 +153,12 @@ func (s *store) UpdateIndexableRepository(ctx context.Context, indexableReposito
 	if indexableRepository.Enabled != nil {
 		pairs = append(pairs, sqlf.Sprintf("enabled = %s", indexableRepository.Enabled))
 	}
+	if indexableRepository.Indexer != nil {
+		pairs = append(pairs, sqlf.Sprintf("indexer = %s", indexableRepository.Indexer))
+	}
+	if indexableRepository.IndexerArgs != nil {
+		pairs = append(pairs, sqlf.Sprintf("indexer_args = %s", pq.Array(indexableRepository.IndexerArgs)))
+	}
 	if len(pairs) == 0 {
 		return nil
 	}
@@ -155,6 +170,22 @@ func (s *store) UpdateIndexableRepository(ctx context.Context, indexableReposito
 	`, sqlf.Join(pairs, ","), now, indexableRepository.RepositoryID))
 }
 
+// UpdateIndexableRepositoryIndexer sets the indexer and indexer arguments that override the indexer inferred
+// from the contents of the given repository. A nil indexer removes the override. If the repository is not
+// already marked as indexable, a new record will be created.
+func (s *store) UpdateIndexableRepositoryIndexer(ctx context.Context, repositoryID int, indexer *string, indexerArgs []string, now time.Time) error {
+	if indexer == nil {
+		indexerArgs = nil
+	}
+
+	return s.queryForEffect(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_indexable_repositories (repository_id, indexer, indexer_args, last_updated_at)
+		VALUES (%s, %s, %s, %s)
+		ON CONFLICT (repository_id) DO UPDATE
+		SET indexer = EXCLUDED.indexer, indexer_args = EXCLUDED.indexer_args, last_updated_at = EXCLUDED.last_updated_at
+	`, repositoryID, indexer, pq.Array(indexerArgs), now))
+}
+
 // ResetIndexableRepositories zeroes the event counts for indexable repositories that have not been updated
 // since lastUpdatedBefore.
 func (s *store) ResetIndexableRepositories(ctx context.Context, lastUpdatedBefore time.Time) error {
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -228,6 +229,105 @@ func TestIndexableRepositoriesEnabled(t *testing.T) {
 	}
 }
 
+func TestIndexableRepositoriesIndexerOverride(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	updates := []UpdateableIndexableRepository{
+		{RepositoryID: 1, Enabled: boolptr(true)},
+		{RepositoryID: 2, Enabled: boolptr(true), Indexer: strptr("lsif-tsc")},
+		{RepositoryID: 3, Enabled: boolptr(true), Indexer: strptr("lsif-java"), IndexerArgs: []string{"index"}},
+		{RepositoryID: 3, IndexerArgs: []string{"index", "--build-tool=gradle"}},
+	}
+
+	for _, update := range updates {
+		if err := store.UpdateIndexableRepository(context.Background(), update, time.Now().UTC()); err != nil {
+			t.Fatalf("unexpected error while updating indexable repository: %s", err)
+		}
+	}
+
+	indexableRepositories, err := store.IndexableRepositories(context.Background(), IndexableRepositoryQueryOptions{
+		Limit: 50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while fetching indexable repository: %s", err)
+	}
+
+	expectedIndexableRepositories := []IndexableRepository{
+		{RepositoryID: 1, Enabled: boolptr(true)},
+		{RepositoryID: 2, Enabled: boolptr(true), Indexer: strptr("lsif-tsc")},
+		{RepositoryID: 3, Enabled: boolptr(true), Indexer: strptr("lsif-java"), IndexerArgs: []string{"index", "--build-tool=gradle"}},
+	}
+	if diff := cmp.Diff(expectedIndexableRepositories, indexableRepositories); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateIndexableRepositoryIndexer(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	if err := store.UpdateIndexableRepository(context.Background(), UpdateableIndexableRepository{RepositoryID: 1, Enabled: boolptr(true)}, time.Now().UTC()); err != nil {
+		t.Fatalf("unexpected error while updating indexable repository: %s", err)
+	}
+	if err := store.UpdateIndexableRepositoryIndexer(context.Background(), 1, strptr("lsif-java"), []string{"index"}, time.Now().UTC()); err != nil {
+		t.Fatalf("unexpected error while updating indexable repository indexer: %s", err)
+	}
+	if err := store.UpdateIndexableRepositoryIndexer(context.Background(), 2, strptr("lsif-tsc"), nil, time.Now().UTC()); err != nil {
+		t.Fatalf("unexpected error while updating indexable repository indexer: %s", err)
+	}
+	if err := store.UpdateIndexableRepository(context.Background(), UpdateableIndexableRepository{RepositoryID: 2, Enabled: boolptr(true)}, time.Now().UTC()); err != nil {
+		t.Fatalf("unexpected error while updating indexable repository: %s", err)
+	}
+
+	indexableRepositories, err := store.IndexableRepositories(context.Background(), IndexableRepositoryQueryOptions{
+		Limit: 50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while fetching indexable repository: %s", err)
+	}
+	sort.Slice(indexableRepositories, func(i, j int) bool {
+		return indexableRepositories[i].RepositoryID < indexableRepositories[j].RepositoryID
+	})
+
+	expectedIndexableRepositories := []IndexableRepository{
+		{RepositoryID: 1, Enabled: boolptr(true), Indexer: strptr("lsif-java"), IndexerArgs: []string{"index"}},
+		{RepositoryID: 2, Enabled: boolptr(true), Indexer: strptr("lsif-tsc")},
+	}
+	if diff := cmp.Diff(expectedIndexableRepositories, indexableRepositories); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+
+	// Removing the override also removes its arguments
+	if err := store.UpdateIndexableRepositoryIndexer(context.Background(), 1, nil, []string{"index"}, time.Now().UTC()); err != nil {
+		t.Fatalf("unexpected error while updating indexable repository indexer: %s", err)
+	}
+
+	indexableRepositories, err = store.IndexableRepositories(context.Background(), IndexableRepositoryQueryOptions{
+		Limit: 50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while fetching indexable repository: %s", err)
+	}
+	sort.Slice(indexableRepositories, func(i, j int) bool {
+		return indexableRepositories[i].RepositoryID < indexableRepositories[j].RepositoryID
+	})
+
+	expectedIndexableRepositories = []IndexableRepository{
+		{RepositoryID: 1, Enabled: boolptr(true)},
+		{RepositoryID: 2, Enabled: boolptr(true), Indexer: strptr("lsif-tsc")},
+	}
+	if diff := cmp.Diff(expectedIndexableRepositories, indexableRepositories); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
+
 func TestResetIndexableRepositories(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -284,3 +384,7 @@ func intptr(val int) *int {
 func boolptr(val bool) *bool {
 	return &val
 }
+
+func strptr(val string) *string {
+	return &val
+}
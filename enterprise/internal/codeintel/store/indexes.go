@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
@@ -23,6 +24,8 @@ type Index struct {
 	NumResets      int        `json:"numResets"`
 	RepositoryID   int        `json:"repositoryId"`
 	RepositoryName string     `json:"repositoryName"`
+	Indexer        string     `json:"indexer"`
+	IndexerArgs    []string   `json:"indexerArgs"`
 	Rank           *int       `json:"placeInQueue"`
 }
 
@@ -52,6 +55,8 @@ func scanIndexes(rows *sql.Rows, queryErr error) (_ []Index, err error) {
 			&index.NumResets,
 			&index.RepositoryID,
 			&index.RepositoryName,
+			&index.Indexer,
+			pq.Array(&index.IndexerArgs),
 			&index.Rank,
 		); err != nil {
 			return nil, err
@@ -97,6 +102,8 @@ func (s *store) GetIndexByID(ctx context.Context, id int) (Index, bool, error) {
 			u.num_resets,
 			u.repository_id,
 			u.repository_name,
+			u.indexer,
+			u.indexer_args,
 			s.rank
 		FROM lsif_indexes_with_repository_name u
 		LEFT JOIN (
@@ -164,6 +171,8 @@ func (s *store) GetIndexes(ctx context.Context, opts GetIndexesOptions) (_ []Ind
 				u.num_resets,
 				u.repository_id,
 				u.repository_name,
+				u.indexer,
+				u.indexer_args,
 				s.rank
 			FROM lsif_indexes_with_repository_name u
 			LEFT JOIN (
@@ -188,6 +197,7 @@ func makeIndexSearchCondition(term string) *sqlf.Query {
 		"(u.state)::text",
 		`u.repository_name`,
 		"u.commit",
+		"u.indexer",
 		"u.failure_message",
 	}
 
@@ -230,10 +240,12 @@ func (s *store) InsertIndex(ctx context.Context, index Index) (int, error) {
 			INSERT INTO lsif_indexes (
 				commit,
 				repository_id,
-				state
-			) VALUES (%s, %s, %s)
+				state,
+				indexer,
+				indexer_args
+			) VALUES (%s, %s, %s, %s, %s)
 			RETURNING id
-		`, index.Commit, index.RepositoryID, index.State),
+		`, index.Commit, index.RepositoryID, index.State, index.Indexer, pq.Array(index.IndexerArgs)),
 	))
 
 	return id, err
@@ -269,6 +281,8 @@ var indexColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.num_resets"),
 	sqlf.Sprintf("u.repository_id"),
 	sqlf.Sprintf(`u.repository_name`),
+	sqlf.Sprintf("u.indexer"),
+	sqlf.Sprintf("u.indexer_args"),
 	sqlf.Sprintf("NULL"),
 }
 
@@ -39,6 +39,8 @@ func TestGetIndexByID(t *testing.T) {
 		FinishedAt:     nil,
 		RepositoryID:   123,
 		RepositoryName: "n-123",
+		Indexer:        "lsif-go",
+		IndexerArgs:    []string{"--repositoryRoot=."},
 		Rank:           nil,
 	}
 
@@ -275,6 +277,8 @@ func TestInsertIndex(t *testing.T) {
 		Commit:       makeCommit(1),
 		State:        "queued",
 		RepositoryID: 50,
+		Indexer:      "lsif-tsc",
+		IndexerArgs:  []string{"-p", "."},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error enqueueing index: %s", err)
@@ -291,6 +295,8 @@ func TestInsertIndex(t *testing.T) {
 		FinishedAt:     nil,
 		RepositoryID:   50,
 		RepositoryName: "n-50",
+		Indexer:        "lsif-tsc",
+		IndexerArgs:    []string{"-p", "."},
 		Rank:           &rank,
 	}
 
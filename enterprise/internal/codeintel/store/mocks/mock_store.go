@@ -153,6 +153,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// UpdateIndexableRepository.
 	UpdateIndexableRepositoryFunc *StoreUpdateIndexableRepositoryFunc
+	// UpdateIndexableRepositoryIndexerFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpdateIndexableRepositoryIndexer.
+	UpdateIndexableRepositoryIndexerFunc *StoreUpdateIndexableRepositoryIndexerFunc
 	// UpdatePackageReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePackageReferences.
 	UpdatePackageReferencesFunc *StoreUpdatePackageReferencesFunc
@@ -388,6 +392,11 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
+		UpdateIndexableRepositoryIndexerFunc: &StoreUpdateIndexableRepositoryIndexerFunc{
+			defaultHook: func(context.Context, int, *string, []string, time.Time) error {
+				return nil
+			},
+		},
 		UpdatePackageReferencesFunc: &StoreUpdatePackageReferencesFunc{
 			defaultHook: func(context.Context, []types.PackageReference) error {
 				return nil
@@ -542,6 +551,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateIndexableRepositoryFunc: &StoreUpdateIndexableRepositoryFunc{
 			defaultHook: i.UpdateIndexableRepository,
 		},
+		UpdateIndexableRepositoryIndexerFunc: &StoreUpdateIndexableRepositoryIndexerFunc{
+			defaultHook: i.UpdateIndexableRepositoryIndexer,
+		},
 		UpdatePackageReferencesFunc: &StoreUpdatePackageReferencesFunc{
 			defaultHook: i.UpdatePackageReferences,
 		},
@@ -5400,6 +5412,125 @@ func (c StoreUpdateIndexableRepositoryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateIndexableRepositoryIndexerFunc describes the behavior when
+// the UpdateIndexableRepositoryIndexer method of the parent MockStore
+// instance is invoked.
+type StoreUpdateIndexableRepositoryIndexerFunc struct {
+	defaultHook func(context.Context, int, *string, []string, time.Time) error
+	hooks       []func(context.Context, int, *string, []string, time.Time) error
+	history     []StoreUpdateIndexableRepositoryIndexerFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexableRepositoryIndexer delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateIndexableRepositoryIndexer(v0 context.Context, v1 int, v2 *string, v3 []string, v4 time.Time) error {
+	r0 := m.UpdateIndexableRepositoryIndexerFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIndexableRepositoryIndexerFunc.appendCall(StoreUpdateIndexableRepositoryIndexerFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexableRepositoryIndexer method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) SetDefaultHook(hook func(context.Context, int, *string, []string, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexableRepositoryIndexer method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) PushHook(hook func(context.Context, int, *string, []string, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, *string, []string, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, *string, []string, time.Time) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) nextHook() func(context.Context, int, *string, []string, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) appendCall(r0 StoreUpdateIndexableRepositoryIndexerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreUpdateIndexableRepositoryIndexerFuncCall objects describing the
+// invocations of this function.
+func (f *StoreUpdateIndexableRepositoryIndexerFunc) History() []StoreUpdateIndexableRepositoryIndexerFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateIndexableRepositoryIndexerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateIndexableRepositoryIndexerFuncCall is an object that describes
+// an invocation of method UpdateIndexableRepositoryIndexer on an instance
+// of MockStore.
+type StoreUpdateIndexableRepositoryIndexerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateIndexableRepositoryIndexerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateIndexableRepositoryIndexerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdatePackageReferencesFunc describes the behavior when the
 // UpdatePackageReferences method of the parent MockStore instance is
 // invoked.
@@ -12,51 +12,52 @@ import (
 
 // An ObservedStore wraps another store with error logging, Prometheus metrics, and tracing.
 type ObservedStore struct {
-	store                                   Store
-	doneOperation                           *observation.Operation
-	getUploadByIDOperation                  *observation.Operation
-	getUploadsOperation                     *observation.Operation
-	queueSizeOperation                      *observation.Operation
-	insertUploadOperation                   *observation.Operation
-	addUploadPartOperation                  *observation.Operation
-	markQueuedOperation                     *observation.Operation
-	markCompleteOperation                   *observation.Operation
-	markErroredOperation                    *observation.Operation
-	dequeueOperation                        *observation.Operation
-	requeueOperation                        *observation.Operation
-	getStatesOperation                      *observation.Operation
-	deleteUploadByIDOperation               *observation.Operation
-	deleteUploadsWithoutRepositoryOperation *observation.Operation
-	resetStalledOperation                   *observation.Operation
-	getDumpByIDOperation                    *observation.Operation
-	findClosestDumpsOperation               *observation.Operation
-	deleteOldestDumpOperation               *observation.Operation
-	updateDumpsVisibleFromTipOperation      *observation.Operation
-	deleteOverlappingDumpsOperation         *observation.Operation
-	getPackageOperation                     *observation.Operation
-	updatePackagesOperation                 *observation.Operation
-	sameRepoPagerOperation                  *observation.Operation
-	updatePackageReferencesOperation        *observation.Operation
-	packageReferencePagerOperation          *observation.Operation
-	hasCommitOperation                      *observation.Operation
-	updateCommitsOperation                  *observation.Operation
-	indexableRepositoriesOperation          *observation.Operation
-	updateIndexableRepositoryOperation      *observation.Operation
-	resetIndexableRepositoriesOperation     *observation.Operation
-	getIndexByIDOperation                   *observation.Operation
-	getIndexesOperation                     *observation.Operation
-	indexQueueSizeOperation                 *observation.Operation
-	isQueuedOperation                       *observation.Operation
-	insertIndexOperation                    *observation.Operation
-	markIndexCompleteOperation              *observation.Operation
-	markIndexErroredOperation               *observation.Operation
-	dequeueIndexOperation                   *observation.Operation
-	requeueIndexOperation                   *observation.Operation
-	deleteIndexByIdOperation                *observation.Operation
-	deleteIndexesWithoutRepositoryOperation *observation.Operation
-	resetStalledIndexesOperation            *observation.Operation
-	repoUsageStatisticsOperation            *observation.Operation
-	repoNameOperation                       *observation.Operation
+	store                                     Store
+	doneOperation                             *observation.Operation
+	getUploadByIDOperation                    *observation.Operation
+	getUploadsOperation                       *observation.Operation
+	queueSizeOperation                        *observation.Operation
+	insertUploadOperation                     *observation.Operation
+	addUploadPartOperation                    *observation.Operation
+	markQueuedOperation                       *observation.Operation
+	markCompleteOperation                     *observation.Operation
+	markErroredOperation                      *observation.Operation
+	dequeueOperation                          *observation.Operation
+	requeueOperation                          *observation.Operation
+	getStatesOperation                        *observation.Operation
+	deleteUploadByIDOperation                 *observation.Operation
+	deleteUploadsWithoutRepositoryOperation   *observation.Operation
+	resetStalledOperation                     *observation.Operation
+	getDumpByIDOperation                      *observation.Operation
+	findClosestDumpsOperation                 *observation.Operation
+	deleteOldestDumpOperation                 *observation.Operation
+	updateDumpsVisibleFromTipOperation        *observation.Operation
+	deleteOverlappingDumpsOperation           *observation.Operation
+	getPackageOperation                       *observation.Operation
+	updatePackagesOperation                   *observation.Operation
+	sameRepoPagerOperation                    *observation.Operation
+	updatePackageReferencesOperation          *observation.Operation
+	packageReferencePagerOperation            *observation.Operation
+	hasCommitOperation                        *observation.Operation
+	updateCommitsOperation                    *observation.Operation
+	indexableRepositoriesOperation            *observation.Operation
+	updateIndexableRepositoryOperation        *observation.Operation
+	updateIndexableRepositoryIndexerOperation *observation.Operation
+	resetIndexableRepositoriesOperation       *observation.Operation
+	getIndexByIDOperation                     *observation.Operation
+	getIndexesOperation                       *observation.Operation
+	indexQueueSizeOperation                   *observation.Operation
+	isQueuedOperation                         *observation.Operation
+	insertIndexOperation                      *observation.Operation
+	markIndexCompleteOperation                *observation.Operation
+	markIndexErroredOperation                 *observation.Operation
+	dequeueIndexOperation                     *observation.Operation
+	requeueIndexOperation                     *observation.Operation
+	deleteIndexByIdOperation                  *observation.Operation
+	deleteIndexesWithoutRepositoryOperation   *observation.Operation
+	resetStalledIndexesOperation              *observation.Operation
+	repoUsageStatisticsOperation              *observation.Operation
+	repoNameOperation                         *observation.Operation
 }
 
 var _ Store = &ObservedStore{}
@@ -217,6 +218,11 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"update_indexable_repository"},
 			Metrics:      metrics,
 		}),
+		updateIndexableRepositoryIndexerOperation: observationContext.Operation(observation.Op{
+			Name:         "store.UpdateIndexableRepositoryIndexer",
+			MetricLabels: []string{"update_indexable_repository_indexer"},
+			Metrics:      metrics,
+		}),
 		resetIndexableRepositoriesOperation: observationContext.Operation(observation.Op{
 			Name:         "store.ResetIndexableRepositories",
 			MetricLabels: []string{"reset_indexable_repositories"},
@@ -302,51 +308,52 @@ func (s *ObservedStore) wrap(other Store) Store {
 	}
 
 	return &ObservedStore{
-		store:                                   other,
-		doneOperation:                           s.doneOperation,
-		getUploadByIDOperation:                  s.getUploadByIDOperation,
-		deleteUploadsWithoutRepositoryOperation: s.deleteUploadsWithoutRepositoryOperation,
-		getUploadsOperation:                     s.getUploadsOperation,
-		queueSizeOperation:                      s.queueSizeOperation,
-		insertUploadOperation:                   s.insertUploadOperation,
-		addUploadPartOperation:                  s.addUploadPartOperation,
-		markQueuedOperation:                     s.markQueuedOperation,
-		markCompleteOperation:                   s.markCompleteOperation,
-		markErroredOperation:                    s.markErroredOperation,
-		dequeueOperation:                        s.dequeueOperation,
-		requeueOperation:                        s.requeueOperation,
-		getStatesOperation:                      s.getStatesOperation,
-		deleteUploadByIDOperation:               s.deleteUploadByIDOperation,
-		resetStalledOperation:                   s.resetStalledOperation,
-		getDumpByIDOperation:                    s.getDumpByIDOperation,
-		findClosestDumpsOperation:               s.findClosestDumpsOperation,
-		deleteOldestDumpOperation:               s.deleteOldestDumpOperation,
-		updateDumpsVisibleFromTipOperation:      s.updateDumpsVisibleFromTipOperation,
-		deleteOverlappingDumpsOperation:         s.deleteOverlappingDumpsOperation,
-		getPackageOperation:                     s.getPackageOperation,
-		updatePackagesOperation:                 s.updatePackagesOperation,
-		sameRepoPagerOperation:                  s.sameRepoPagerOperation,
-		updatePackageReferencesOperation:        s.updatePackageReferencesOperation,
-		packageReferencePagerOperation:          s.packageReferencePagerOperation,
-		hasCommitOperation:                      s.hasCommitOperation,
-		updateCommitsOperation:                  s.updateCommitsOperation,
-		indexableRepositoriesOperation:          s.indexableRepositoriesOperation,
-		updateIndexableRepositoryOperation:      s.updateIndexableRepositoryOperation,
-		resetIndexableRepositoriesOperation:     s.resetIndexableRepositoriesOperation,
-		getIndexByIDOperation:                   s.getIndexByIDOperation,
-		getIndexesOperation:                     s.getIndexesOperation,
-		indexQueueSizeOperation:                 s.indexQueueSizeOperation,
-		isQueuedOperation:                       s.isQueuedOperation,
-		insertIndexOperation:                    s.insertIndexOperation,
-		markIndexCompleteOperation:              s.markIndexCompleteOperation,
-		markIndexErroredOperation:               s.markIndexErroredOperation,
-		dequeueIndexOperation:                   s.dequeueIndexOperation,
-		requeueIndexOperation:                   s.requeueIndexOperation,
-		deleteIndexByIdOperation:                s.deleteIndexByIdOperation,
-		deleteIndexesWithoutRepositoryOperation: s.deleteIndexesWithoutRepositoryOperation,
-		resetStalledIndexesOperation:            s.resetStalledIndexesOperation,
-		repoUsageStatisticsOperation:            s.repoUsageStatisticsOperation,
-		repoNameOperation:                       s.repoNameOperation,
+		store:                                     other,
+		doneOperation:                             s.doneOperation,
+		getUploadByIDOperation:                    s.getUploadByIDOperation,
+		deleteUploadsWithoutRepositoryOperation:   s.deleteUploadsWithoutRepositoryOperation,
+		getUploadsOperation:                       s.getUploadsOperation,
+		queueSizeOperation:                        s.queueSizeOperation,
+		insertUploadOperation:                     s.insertUploadOperation,
+		addUploadPartOperation:                    s.addUploadPartOperation,
+		markQueuedOperation:                       s.markQueuedOperation,
+		markCompleteOperation:                     s.markCompleteOperation,
+		markErroredOperation:                      s.markErroredOperation,
+		dequeueOperation:                          s.dequeueOperation,
+		requeueOperation:                          s.requeueOperation,
+		getStatesOperation:                        s.getStatesOperation,
+		deleteUploadByIDOperation:                 s.deleteUploadByIDOperation,
+		resetStalledOperation:                     s.resetStalledOperation,
+		getDumpByIDOperation:                      s.getDumpByIDOperation,
+		findClosestDumpsOperation:                 s.findClosestDumpsOperation,
+		deleteOldestDumpOperation:                 s.deleteOldestDumpOperation,
+		updateDumpsVisibleFromTipOperation:        s.updateDumpsVisibleFromTipOperation,
+		deleteOverlappingDumpsOperation:           s.deleteOverlappingDumpsOperation,
+		getPackageOperation:                       s.getPackageOperation,
+		updatePackagesOperation:                   s.updatePackagesOperation,
+		sameRepoPagerOperation:                    s.sameRepoPagerOperation,
+		updatePackageReferencesOperation:          s.updatePackageReferencesOperation,
+		packageReferencePagerOperation:            s.packageReferencePagerOperation,
+		hasCommitOperation:                        s.hasCommitOperation,
+		updateCommitsOperation:                    s.updateCommitsOperation,
+		indexableRepositoriesOperation:            s.indexableRepositoriesOperation,
+		updateIndexableRepositoryOperation:        s.updateIndexableRepositoryOperation,
+		updateIndexableRepositoryIndexerOperation: s.updateIndexableRepositoryIndexerOperation,
+		resetIndexableRepositoriesOperation:       s.resetIndexableRepositoriesOperation,
+		getIndexByIDOperation:                     s.getIndexByIDOperation,
+		getIndexesOperation:                       s.getIndexesOperation,
+		indexQueueSizeOperation:                   s.indexQueueSizeOperation,
+		isQueuedOperation:                         s.isQueuedOperation,
+		insertIndexOperation:                      s.insertIndexOperation,
+		markIndexCompleteOperation:                s.markIndexCompleteOperation,
+		markIndexErroredOperation:                 s.markIndexErroredOperation,
+		dequeueIndexOperation:                     s.dequeueIndexOperation,
+		requeueIndexOperation:                     s.requeueIndexOperation,
+		deleteIndexByIdOperation:                  s.deleteIndexByIdOperation,
+		deleteIndexesWithoutRepositoryOperation:   s.deleteIndexesWithoutRepositoryOperation,
+		resetStalledIndexesOperation:              s.resetStalledIndexesOperation,
+		repoUsageStatisticsOperation:              s.repoUsageStatisticsOperation,
+		repoNameOperation:                         s.repoNameOperation,
 	}
 }
 
@@ -589,6 +596,13 @@ func (s *ObservedStore) UpdateIndexableRepository(ctx context.Context, indexable
 	return s.store.UpdateIndexableRepository(ctx, indexableRepository, now)
 }
 
+// UpdateIndexableRepositoryIndexer calls into the inner store and registers the observed results.
+func (s *ObservedStore) UpdateIndexableRepositoryIndexer(ctx context.Context, repositoryID int, indexer *string, indexerArgs []string, now time.Time) (err error) {
+	ctx, endObservation := s.updateIndexableRepositoryIndexerOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.UpdateIndexableRepositoryIndexer(ctx, repositoryID, indexer, indexerArgs, now)
+}
+
 // ResetIndexableRepositories calls into the inner store and registers the observed results.
 func (s *ObservedStore) ResetIndexableRepositories(ctx context.Context, lastUpdatedBefore time.Time) (err error) {
 	ctx, endObservation := s.resetIndexableRepositoriesOperation.With(ctx, &err, observation.Args{})
@@ -133,6 +133,11 @@ type Store interface {
 	// already marked as indexable, a new record will be created.
 	UpdateIndexableRepository(ctx context.Context, indexableRepository UpdateableIndexableRepository, now time.Time) error
 
+	// UpdateIndexableRepositoryIndexer sets the indexer and indexer arguments that override the indexer inferred
+	// from the contents of the given repository. A nil indexer removes the override. If the repository is not
+	// already marked as indexable, a new record will be created.
+	UpdateIndexableRepositoryIndexer(ctx context.Context, repositoryID int, indexer *string, indexerArgs []string, now time.Time) error
+
 	// ResetIndexableRepositories zeroes the event counts for indexable repositories that have not been updated
 	// since lastUpdatedBefore.
 	ResetIndexableRepositories(ctx context.Context, lastUpdatedBefore time.Time) error
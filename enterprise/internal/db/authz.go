@@ -137,6 +137,27 @@ func (s *authzStore) AuthorizedRepos(ctx context.Context, args *db.AuthorizedRep
 	return filtered, nil
 }
 
+// SubRepoPermissions returns the sub-repository permissions of a user for a repository, which
+// implements the db.AuthzStore interface. It returns nil if the user's access to the repository
+// is not restricted to some paths.
+func (s *authzStore) SubRepoPermissions(ctx context.Context, args *db.SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error) {
+	if args.UserID <= 0 {
+		return nil, nil
+	}
+
+	p := &authz.SubRepoPermissions{
+		UserID: args.UserID,
+		RepoID: int32(args.RepoID),
+	}
+	if err := s.store.LoadSubRepoPermissions(ctx, p); err != nil {
+		if err == authz.ErrPermsNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
 // RevokeUserPermissions deletes both effective and pending permissions that could be related to a user,
 // which implements the db.AuthzStore interface. It proactively clean up left-over pending permissions to
 // prevent accidental reuse (i.e. another user with same username or email address(es) but not the same person).
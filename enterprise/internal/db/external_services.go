@@ -1,6 +1,8 @@
 package db
 
 import (
+	"github.com/sourcegraph/sourcegraph/internal/authz/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
@@ -21,5 +23,11 @@ func NewExternalServicesStore() *db.ExternalServicesStore {
 		BitbucketServerValidators: []func(*schema.BitbucketServerConnection) error{
 			bitbucketserver.ValidateAuthz,
 		},
+		BitbucketCloudValidators: []func(*schema.BitbucketCloudConnection) error{
+			bitbucketcloud.ValidateAuthz,
+		},
+		AWSCodeCommitValidators: []func(*schema.AWSCodeCommitConnection) error{
+			awscodecommit.ValidateAuthz,
+		},
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
@@ -24,7 +25,8 @@ var (
 
 // PermsStore is the unified interface for managing permissions explicitly in the database.
 // It is concurrency-safe and maintains data consistency over the 'user_permissions',
-// 'repo_permissions', 'user_pending_permissions', and 'repo_pending_permissions' tables.
+// 'repo_permissions', 'user_pending_permissions', 'repo_pending_permissions' and
+// 'sub_repo_permissions' tables.
 type PermsStore struct {
 	db    dbutil.DB
 	clock func() time.Time
@@ -495,6 +497,82 @@ DO UPDATE SET
 	), nil
 }
 
+// LoadSubRepoPermissions loads the stored sub-repository permissions of the user for the
+// repository into p. An ErrPermsNotFound is returned when the user's access to the repository
+// is not restricted to some paths.
+func (s *PermsStore) LoadSubRepoPermissions(ctx context.Context, p *authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.LoadSubRepoPermissions != nil {
+		return Mocks.Perms.LoadSubRepoPermissions(ctx, p)
+	}
+
+	ctx, save := s.observe(ctx, "LoadSubRepoPermissions", "")
+	defer func() { save(&err, p.TracingFields()...) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.LoadSubRepoPermissions
+SELECT path_includes, path_excludes, updated_at
+FROM sub_repo_permissions
+WHERE user_id = %s
+AND repo_id = %s
+`, p.UserID, p.RepoID)
+
+	return s.execute(ctx, q, pq.Array(&p.PathIncludes), pq.Array(&p.PathExcludes), &p.UpdatedAt)
+}
+
+// SetSubRepoPermissions stores the sub-repository permissions of the user for the repository,
+// replacing any previous ones. If p has neither includes nor excludes, the previous permissions
+// are deleted so that the user can read the whole repository again.
+//
+// The user's permission to read the repository itself is unaffected.
+func (s *PermsStore) SetSubRepoPermissions(ctx context.Context, p *authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.SetSubRepoPermissions != nil {
+		return Mocks.Perms.SetSubRepoPermissions(ctx, p)
+	}
+
+	ctx, save := s.observe(ctx, "SetSubRepoPermissions", "")
+	defer func() { save(&err, p.TracingFields()...) }()
+
+	if len(p.PathIncludes) == 0 && len(p.PathExcludes) == 0 {
+		q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetSubRepoPermissions
+DELETE FROM sub_repo_permissions
+WHERE user_id = %s
+AND repo_id = %s
+`, p.UserID, p.RepoID)
+		if err = s.execute(ctx, q); err != nil {
+			return errors.Wrap(err, "execute delete sub-repository permissions query")
+		}
+		return nil
+	}
+
+	p.UpdatedAt = s.clock()
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetSubRepoPermissions
+INSERT INTO sub_repo_permissions
+  (user_id, repo_id, path_includes, path_excludes, updated_at)
+VALUES
+  (%s, %s, %s, %s, %s)
+ON CONFLICT ON CONSTRAINT
+  sub_repo_permissions_pkey
+DO UPDATE SET
+  path_includes = excluded.path_includes,
+  path_excludes = excluded.path_excludes,
+  updated_at = excluded.updated_at
+`, p.UserID, p.RepoID, pq.Array(nonNilStrings(p.PathIncludes)), pq.Array(nonNilStrings(p.PathExcludes)), p.UpdatedAt.UTC())
+	if err = s.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute upsert sub-repository permissions query")
+	}
+	return nil
+}
+
+// nonNilStrings returns an empty slice for a nil ss, since the path columns are not nullable.
+func nonNilStrings(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
+
 // LoadUserPendingPermissions returns pending permissions found by given parameters.
 // An ErrPermsNotFound is returned when there are no pending permissions available.
 func (s *PermsStore) LoadUserPendingPermissions(ctx context.Context, p *authz.UserPendingPermissions) (err error) {
@@ -1179,6 +1257,9 @@ func (s *PermsStore) DeleteAllUserPermissions(ctx context.Context, userID int32)
 	if err = s.execute(ctx, sqlf.Sprintf(`DELETE FROM user_permissions WHERE user_id = %s`, userID)); err != nil {
 		return errors.Wrap(err, "execute delete user permissions query")
 	}
+	if err = s.execute(ctx, sqlf.Sprintf(`DELETE FROM sub_repo_permissions WHERE user_id = %s`, userID)); err != nil {
+		return errors.Wrap(err, "execute delete user sub-repository permissions query")
+	}
 
 	return nil
 }
@@ -15,6 +15,8 @@ type MockPerms struct {
 	SetUserPermissions           func(ctx context.Context, p *authz.UserPermissions) error
 	SetRepoPermissions           func(ctx context.Context, p *authz.RepoPermissions) error
 	SetRepoPendingPermissions    func(ctx context.Context, accounts *extsvc.Accounts, p *authz.RepoPermissions) error
+	LoadSubRepoPermissions       func(ctx context.Context, p *authz.SubRepoPermissions) error
+	SetSubRepoPermissions        func(ctx context.Context, p *authz.SubRepoPermissions) error
 	ListPendingUsers             func(ctx context.Context) ([]string, error)
 	ListExternalAccounts         func(ctx context.Context, userID int32) ([]*extsvc.Account, error)
 	GetUserIDsByExternalAccounts func(ctx context.Context, accounts *extsvc.Accounts) (map[string]int32, error)
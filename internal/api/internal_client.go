@@ -98,6 +98,9 @@ type ConfigSavedQuery struct {
 	UserID          *int32  `json:"userID"`
 	OrgID           *int32  `json:"orgID"`
 	SlackWebhookURL *string `json:"slackWebhookURL"`
+	NotifyWebhook   bool    `json:"notifyWebhook,omitempty"`
+	WebhookURL      *string `json:"webhookURL,omitempty"`
+	WebhookSecret   *string `json:"webhookSecret,omitempty"`
 }
 
 func (sq ConfigSavedQuery) Equals(other ConfigSavedQuery) bool {
@@ -148,6 +151,37 @@ type SavedQueryInfo struct {
 
 	// ExecDuration is the amount of time it took for the query to execute.
 	ExecDuration time.Duration
+
+	// Results is the result set of the last execution of the search query,
+	// which new results are diffed against to find the added and removed
+	// results. It is nil if the result set is unknown, or if new results are
+	// found with the after:"time" operator instead (for commit and diff
+	// searches).
+	Results *SavedQueryResults
+}
+
+// SavedQueryResults is the result set of an execution of a saved query.
+type SavedQueryResults struct {
+	// Fingerprint identifies the result set: result sets with the same
+	// entries have the same fingerprint.
+	Fingerprint string
+
+	// Entries are the file, line and commit matches of the result set, sorted
+	// by repository, path and commit.
+	Entries []SavedQueryResult
+
+	// LimitHit is whether the search hit a result limit, so that the result
+	// set is incomplete.
+	LimitHit bool
+}
+
+// SavedQueryResult is a single file, line or commit match of a saved query.
+type SavedQueryResult struct {
+	Repo       string `json:"repo"`
+	Path       string `json:"path,omitempty"`       // the path of file and line matches
+	Commit     string `json:"commit,omitempty"`     // the commit ID of commit matches
+	LineNumber int    `json:"lineNumber,omitempty"` // the 1-based line number of line matches
+	Preview    string `json:"preview,omitempty"`    // the line of line matches or the subject of commit matches
 }
 
 // SavedQueriesGetInfo gets the info from the DB for the given saved query. nil
@@ -170,6 +204,72 @@ func (c *internalClient) SavedQueriesDeleteInfo(ctx context.Context, query strin
 	return c.postInternal(ctx, "saved-queries/delete-info", query, nil)
 }
 
+// SavedQueryWebhookDelivery represents a delivery of the new results of a saved query to its
+// webhook.
+type SavedQueryWebhookDelivery struct {
+	SavedSearchID int32
+	URL           string
+	Payload       json.RawMessage
+
+	// Attempts is the number of attempts to deliver the payload, and StatusCode and Error
+	// describe the outcome of the last attempt.
+	Attempts   int32
+	StatusCode int32  // zero if no response was received
+	Error      string // empty if the delivery succeeded
+
+	CreatedAt   time.Time
+	DeliveredAt *time.Time // nil if the delivery failed
+}
+
+// SavedQueriesRecordWebhookDelivery records a delivery of the new results of a saved query to its
+// webhook in the DB.
+func (c *internalClient) SavedQueriesRecordWebhookDelivery(ctx context.Context, delivery *SavedQueryWebhookDelivery) error {
+	return c.postInternal(ctx, "saved-queries/record-webhook-delivery", delivery, nil)
+}
+
+// SavedQueryClaim is a request to claim the lease on running a saved query.
+type SavedQueryClaim struct {
+	Query  string
+	Runner string        // identifies the query runner replica that claims the lease
+	Lease  time.Duration // how long the lease is held, unless it is released earlier
+}
+
+// SavedQueriesClaim claims the lease on running the given saved query for the
+// runner, so that multiple query runner replicas don't run the same query at
+// the same time. It reports whether the lease was acquired, which is not the
+// case if another runner holds it.
+func (c *internalClient) SavedQueriesClaim(ctx context.Context, query, runner string, lease time.Duration) (bool, error) {
+	var claimed bool
+	err := c.postInternal(ctx, "saved-queries/claim", &SavedQueryClaim{Query: query, Runner: runner, Lease: lease}, &claimed)
+	return claimed, err
+}
+
+// SavedQueriesRelease releases the runner's lease on running the given saved
+// query.
+func (c *internalClient) SavedQueriesRelease(ctx context.Context, query, runner string) error {
+	return c.postInternal(ctx, "saved-queries/release", &SavedQueryClaim{Query: query, Runner: runner}, nil)
+}
+
+// SavedQueryRun represents a run of a saved query by a query runner replica.
+type SavedQueryRun struct {
+	Query  string
+	Runner string
+
+	// ScheduledAt is when the query was due to run, so StartedAt - ScheduledAt
+	// is how far the query runner lags behind.
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+
+	ResultCount int32  // the number of results, if the search succeeded
+	Error       string // empty if the search succeeded
+}
+
+// SavedQueriesRecordRun records a run of a saved query in the DB.
+func (c *internalClient) SavedQueriesRecordRun(ctx context.Context, run *SavedQueryRun) error {
+	return c.postInternal(ctx, "saved-queries/record-run", run, nil)
+}
+
 func (c *internalClient) SettingsGetForSubject(ctx context.Context, subject SettingsSubject) (parsed *schema.Settings, settings *Settings, err error) {
 	err = c.postInternal(ctx, "settings/get-for-subject", subject, &settings)
 	if err == nil {
@@ -292,8 +392,13 @@ func (c *internalClient) ExternalServiceConfigs(ctx context.Context, kind string
 	}, &result)
 }
 
+var MockExternalServicesList func(opts ExternalServicesListRequest) ([]*ExternalService, error)
+
 // ExternalServicesList returns all external services of the given kind.
 func (c *internalClient) ExternalServicesList(ctx context.Context, opts ExternalServicesListRequest) ([]*ExternalService, error) {
+	if MockExternalServicesList != nil {
+		return MockExternalServicesList(opts)
+	}
 	var extsvcs []*ExternalService
 	return extsvcs, c.postInternal(ctx, "external-services/list", &opts, &extsvcs)
 }
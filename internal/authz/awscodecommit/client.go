@@ -0,0 +1,44 @@
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+)
+
+// client defines the set of AWS API client methods used by the authz provider.
+//
+// NOTE: All methods are sorted in alphabetical order.
+type client interface {
+	CanGitPull(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error)
+	GetIAMUser(ctx context.Context, name string) (*awscodecommit.IAMUser, error)
+	ListIAMUsers(ctx context.Context, marker string) (users []*awscodecommit.IAMUser, nextMarker string, err error)
+	ListRepositories(ctx context.Context, nextToken string) (repos []*awscodecommit.Repository, nextNextToken string, err error)
+}
+
+var _ client = (*awscodecommit.Client)(nil)
+
+var _ client = (*mockClient)(nil)
+
+type mockClient struct {
+	MockCanGitPull       func(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error)
+	MockGetIAMUser       func(ctx context.Context, name string) (*awscodecommit.IAMUser, error)
+	MockListIAMUsers     func(ctx context.Context, marker string) ([]*awscodecommit.IAMUser, string, error)
+	MockListRepositories func(ctx context.Context, nextToken string) ([]*awscodecommit.Repository, string, error)
+}
+
+func (m *mockClient) CanGitPull(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error) {
+	return m.MockCanGitPull(ctx, principalARN, repoARNs)
+}
+
+func (m *mockClient) GetIAMUser(ctx context.Context, name string) (*awscodecommit.IAMUser, error) {
+	return m.MockGetIAMUser(ctx, name)
+}
+
+func (m *mockClient) ListIAMUsers(ctx context.Context, marker string) ([]*awscodecommit.IAMUser, string, error) {
+	return m.MockListIAMUsers(ctx, marker)
+}
+
+func (m *mockClient) ListRepositories(ctx context.Context, nextToken string) ([]*awscodecommit.Repository, string, error) {
+	return m.MockListRepositories(ctx, nextToken)
+}
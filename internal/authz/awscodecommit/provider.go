@@ -0,0 +1,213 @@
+// Package awscodecommit contains an authorization provider for AWS CodeCommit.
+package awscodecommit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// derived from AWS IAM. AWS CodeCommit does not support resource-based policies, so a user can
+// read a repository if and only if the IAM policies of the user (including the policies of the
+// user's groups and permissions boundary) allow the "codecommit:GitPull" action on it.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new AWS CodeCommit authorization provider that uses the given
+// awscodecommit.Client to evaluate the IAM policies of users. The serviceID is the
+// ExternalRepoSpec.ServiceID of the repositories, see awscodecommit.ServiceID. It assumes
+// usernames of Sourcegraph accounts match 1-1 with names of IAM users.
+func NewProvider(cli *awscodecommit.Client, serviceID, urn string) *Provider {
+	return &Provider{
+		urn:    urn,
+		client: cli,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   serviceID,
+			ServiceType: extsvc.TypeAWSCodeCommit,
+		},
+	}
+}
+
+// Validate validates that the Provider can list IAM users with the credentials it was
+// configured with.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, _, err := p.client.ListIAMUsers(ctx, ""); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the ARN prefix that identifies the AWS account and region this provider is
+// configured with, e.g. "arn:aws:codecommit:us-west-1:999999999999:".
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "awsCodeCommit".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It returns the IAM user whose name is the
+// username of the user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (acct *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "awscodecommit.authz.provider.FetchAccount", "")
+	defer func() {
+		tr.LogFields(
+			otlog.String("user.name", user.Username),
+			otlog.Int32("user.id", user.ID),
+		)
+
+		if err != nil {
+			tr.SetError(err)
+		}
+
+		tr.Finish()
+	}()
+
+	iamUser, err := p.client.GetIAMUser(ctx, user.Username)
+	if awscodecommit.IsIAMNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(iamUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   iamUser.ID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID. All AWS CodeCommit repositories are private.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var user awscodecommit.IAMUser
+	if err := json.Unmarshal(*account.Data, &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	var repoIDs []extsvc.RepoID
+	var nextToken string
+	for {
+		repos, next, err := p.client.ListRepositories(ctx, nextToken)
+		if err != nil {
+			return repoIDs, err
+		}
+
+		arns := make([]string, 0, len(repos))
+		for _, r := range repos {
+			arns = append(arns, r.ARN)
+		}
+		allowed, err := p.client.CanGitPull(ctx, user.ARN, arns)
+		for _, r := range repos {
+			if allowed[r.ARN] {
+				repoIDs = append(repoIDs, extsvc.RepoID(r.ID))
+			}
+		}
+		if err != nil {
+			return repoIDs, err
+		}
+
+		if len(repos) == 0 || next == "" {
+			break // last page
+		}
+		nextToken = next
+	}
+
+	return repoIDs, nil
+}
+
+// FetchRepoPerms returns a list of IAM user IDs (on code host) who have read access to
+// the given repository on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes access granted by
+// the policies of the users' groups.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// The service ID is the ARN prefix of the repositories, and the URI is the repository name.
+	repoARN := repo.ServiceID + repo.URI
+
+	var userIDs []extsvc.AccountID
+	var marker string
+	for {
+		users, next, err := p.client.ListIAMUsers(ctx, marker)
+		if err != nil {
+			return userIDs, err
+		}
+
+		for _, u := range users {
+			allowed, err := p.client.CanGitPull(ctx, u.ARN, []string{repoARN})
+			if err != nil {
+				return userIDs, err
+			}
+			if allowed[repoARN] {
+				userIDs = append(userIDs, extsvc.AccountID(u.ID))
+			}
+		}
+
+		if next == "" {
+			break // last page
+		}
+		marker = next
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,155 @@
+package awscodecommit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+)
+
+const serviceID = "arn:aws:codecommit:us-west-1:999999999999:"
+
+func newTestProvider(cli client) *Provider {
+	p := NewProvider(nil, serviceID, "")
+	p.client = cli
+	return p
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(&mockClient{
+		MockGetIAMUser: func(ctx context.Context, name string) (*awscodecommit.IAMUser, error) {
+			if name != "alice" {
+				return nil, awserr.New("NoSuchEntity", "not found", nil)
+			}
+			return &awscodecommit.IAMUser{
+				ARN:  "arn:aws:iam::999999999999:user/alice",
+				ID:   "AIDAALICE",
+				Name: "alice",
+			}, nil
+		},
+	})
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeAWSCodeCommit,
+		ServiceID:   serviceID,
+		AccountID:   "AIDAALICE",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatal(diff)
+	}
+
+	acct, err = p.FetchAccount(context.Background(), &types.User{ID: 43, Username: "bob"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if acct != nil {
+		t.Fatalf("want no account but got %+v", acct)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p := newTestProvider(&mockClient{})
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeAWSCodeCommit,
+				ServiceID:   "arn:aws:codecommit:us-east-1:999999999999:",
+			},
+			AccountData: extsvc.AccountData{Data: new(json.RawMessage)},
+		})
+		want := fmt.Sprintf(`not a code host of the account: want %q but have "arn:aws:codecommit:us-east-1:999999999999:"`, serviceID)
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	repo := func(name string) *awscodecommit.Repository {
+		return &awscodecommit.Repository{ARN: serviceID + name, ID: "id-" + name, Name: name}
+	}
+	p := newTestProvider(&mockClient{
+		MockListRepositories: func(ctx context.Context, nextToken string) ([]*awscodecommit.Repository, string, error) {
+			switch nextToken {
+			case "":
+				return []*awscodecommit.Repository{repo("a"), repo("b")}, "2", nil
+			case "2":
+				return []*awscodecommit.Repository{repo("c")}, "", nil
+			}
+			return nil, "", fmt.Errorf("unexpected token %q", nextToken)
+		},
+		MockCanGitPull: func(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error) {
+			if want := "arn:aws:iam::999999999999:user/alice"; principalARN != want {
+				return nil, fmt.Errorf("principal: want %q but got %q", want, principalARN)
+			}
+			return map[string]bool{serviceID + "a": true, serviceID + "c": true}, nil
+		},
+	})
+
+	data := json.RawMessage(`{"ARN": "arn:aws:iam::999999999999:user/alice", "ID": "AIDAALICE", "Name": "alice"}`)
+	repoIDs, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeAWSCodeCommit,
+			ServiceID:   serviceID,
+			AccountID:   "AIDAALICE",
+		},
+		AccountData: extsvc.AccountData{Data: &data},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.RepoID{"id-a", "id-c"}
+	if diff := cmp.Diff(want, repoIDs); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	user := func(name string) *awscodecommit.IAMUser {
+		return &awscodecommit.IAMUser{ARN: "arn:aws:iam::999999999999:user/" + name, ID: "id-" + name, Name: name}
+	}
+	p := newTestProvider(&mockClient{
+		MockListIAMUsers: func(ctx context.Context, marker string) ([]*awscodecommit.IAMUser, string, error) {
+			switch marker {
+			case "":
+				return []*awscodecommit.IAMUser{user("alice"), user("bob")}, "2", nil
+			case "2":
+				return []*awscodecommit.IAMUser{user("carol")}, "", nil
+			}
+			return nil, "", fmt.Errorf("unexpected marker %q", marker)
+		},
+		MockCanGitPull: func(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error) {
+			if want := []string{serviceID + "myrepo"}; !cmp.Equal(want, repoARNs) {
+				return nil, fmt.Errorf("repos: want %q but got %q", want, repoARNs)
+			}
+			return map[string]bool{serviceID + "myrepo": principalARN != user("bob").ARN}, nil
+		},
+	})
+
+	accountIDs, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		URI: "myrepo",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "f001337a-3450-46fd-b7d2-650c0EXAMPLE",
+			ServiceType: extsvc.TypeAWSCodeCommit,
+			ServiceID:   serviceID,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.AccountID{"id-alice", "id-carol"}
+	if diff := cmp.Diff(want, accountIDs); diff != "" {
+		t.Fatal(diff)
+	}
+}
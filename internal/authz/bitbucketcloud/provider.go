@@ -0,0 +1,256 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the Bitbucket Cloud API. Permissions are read from the repository permissions of
+// workspaces, which include the permissions inherited from the workspace, its projects and groups.
+type Provider struct {
+	urn        string
+	client     client
+	codeHost   *extsvc.CodeHost
+	workspaces []string
+	pageSize   int // Page size to use in paginated requests.
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses the given
+// bitbucketcloud.Client to read the permissions of the given workspaces. It assumes usernames
+// of Sourcegraph accounts match 1-1 with nicknames of Bitbucket Cloud accounts.
+func NewProvider(cli *bitbucketcloud.Client, baseURL *url.URL, urn string, workspaces []string) *Provider {
+	return &Provider{
+		urn:        urn,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		workspaces: workspaces,
+		pageSize:   100,
+	}
+}
+
+// Validate validates that the Provider can read the repository permissions of its workspaces
+// with the app password it was configured with.
+func (p *Provider) Validate() (problems []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, workspace := range p.workspaces {
+		_, _, err := p.client.WorkspaceRepoPermissions(ctx, &bitbucketcloud.PageToken{Pagelen: 1}, workspace, "")
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("cannot read repository permissions of workspace %q (the app password user must be an administrator of it): %s", workspace, err))
+		}
+	}
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance this provider is
+// configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It returns the Bitbucket Cloud account
+// whose nickname is the username of the user among the members of the provider's workspaces.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (acct *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "bitbucketcloud.authz.provider.FetchAccount", "")
+	defer func() {
+		tr.LogFields(
+			otlog.String("user.name", user.Username),
+			otlog.Int32("user.id", user.ID),
+		)
+
+		if err != nil {
+			tr.SetError(err)
+		}
+
+		tr.Finish()
+	}()
+
+	bitbucketUser, err := p.user(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	} else if bitbucketUser == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(bitbucketUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   bitbucketUser.UUID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID. The returned list only includes repositories of the
+// provider's workspaces, and may include public repositories.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var user bitbucketcloud.User
+	if err := json.Unmarshal(*account.Data, &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	query := fmt.Sprintf("user.uuid=%q", user.UUID)
+	repoIDs := make([]extsvc.RepoID, 0, p.pageSize)
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for {
+			perms, next, err := p.client.WorkspaceRepoPermissions(ctx, t, workspace, query)
+			if err != nil {
+				return repoIDs, err
+			}
+
+			for _, perm := range perms {
+				if perm.Repository != nil && canRead(perm.Permission) {
+					repoIDs = append(repoIDs, extsvc.RepoID(perm.Repository.UUID))
+				}
+			}
+
+			if !next.HasMore() {
+				break
+			}
+			t = next
+		}
+	}
+
+	return repoIDs, nil
+}
+
+// FetchRepoPerms returns a list of user UUIDs (on code host) who have read access to
+// the given repository on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both direct access
+// and inherited from the workspace, project and group membership.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// NOTE: We do not store port or scheme in our URI, so stripping the hostname alone is enough.
+	fullName := strings.TrimPrefix(repo.URI, p.codeHost.BaseURL.Hostname())
+	fullName = strings.TrimPrefix(fullName, "/")
+
+	i := strings.Index(fullName, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid Bitbucket Cloud repository name %q", fullName)
+	}
+	workspace, slug := fullName[:i], fullName[i+1:]
+
+	userIDs := make([]extsvc.AccountID, 0, p.pageSize)
+	t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+	for {
+		perms, next, err := p.client.RepoPermissions(ctx, t, workspace, slug)
+		if err != nil {
+			return userIDs, err
+		}
+
+		for _, perm := range perms {
+			if perm.User != nil && canRead(perm.Permission) {
+				userIDs = append(userIDs, extsvc.AccountID(perm.User.UUID))
+			}
+		}
+
+		if !next.HasMore() {
+			break
+		}
+		t = next
+	}
+
+	return userIDs, nil
+}
+
+// user returns the member of the provider's workspaces with the given nickname, or nil if there
+// is none.
+func (p *Provider) user(ctx context.Context, nickname string) (*bitbucketcloud.User, error) {
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for {
+			users, next, err := p.client.WorkspaceMembers(ctx, t, workspace)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, u := range users {
+				if u.Nickname == nickname {
+					return u, nil
+				}
+			}
+
+			if !next.HasMore() {
+				break
+			}
+			t = next
+		}
+	}
+
+	return nil, nil
+}
+
+// canRead reports whether the Bitbucket Cloud repository permission grants read access.
+func canRead(permission string) bool {
+	switch permission {
+	case "read", "write", "admin":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+func newTestProvider(cli client) *Provider {
+	p := NewProvider(nil, &url.URL{Scheme: "https", Host: "bitbucket.org"}, "", []string{"alice", "sglocal"})
+	p.client = cli
+	return p
+}
+
+// pages returns the number of the page requested with token from a paginated response of n pages,
+// and the token of the page after it.
+func pages(token *bitbucketcloud.PageToken, n int) (page int, next *bitbucketcloud.PageToken) {
+	page = 1
+	if token.HasMore() {
+		fmt.Sscanf(token.Next, "page=%d", &page)
+	}
+	next = &bitbucketcloud.PageToken{Page: page}
+	if page < n {
+		next.Next = fmt.Sprintf("page=%d", page+1)
+	}
+	return page, next
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(&mockClient{
+		MockWorkspaceMembers: func(ctx context.Context, token *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error) {
+			page, next := pages(token, 2)
+			if workspace != "sglocal" {
+				return nil, &bitbucketcloud.PageToken{}, nil
+			}
+			if page == 1 {
+				return []*bitbucketcloud.User{{UUID: "{1}", Nickname: "bob"}}, next, nil
+			}
+			return []*bitbucketcloud.User{{UUID: "{2}", Nickname: "carol"}}, next, nil
+		},
+	})
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "carol"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeBitbucketCloud,
+		ServiceID:   "https://bitbucket.org/",
+		AccountID:   "{2}",
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Fatal(diff)
+	}
+	if acct.UserID != 42 {
+		t.Fatalf("UserID: want 42 but got %d", acct.UserID)
+	}
+
+	acct, err = p.FetchAccount(context.Background(), &types.User{ID: 43, Username: "dave"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if acct != nil {
+		t.Fatalf("want no account but got %+v", acct)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p := newTestProvider(&mockClient{})
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitLab,
+				ServiceID:   "https://gitlab.com/",
+			},
+			AccountData: extsvc.AccountData{Data: new(json.RawMessage)},
+		})
+		want := `not a code host of the account: want "https://bitbucket.org/" but have "https://gitlab.com/"`
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	p := newTestProvider(&mockClient{
+		MockWorkspaceRepoPermissions: func(ctx context.Context, token *bitbucketcloud.PageToken, workspace, query string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if want := `user.uuid="{2}"`; query != want {
+				return nil, nil, fmt.Errorf("query: want %q but got %q", want, query)
+			}
+			page, next := pages(token, 2)
+			return []*bitbucketcloud.RepoPermission{
+				{Permission: "read", Repository: &bitbucketcloud.Repo{UUID: fmt.Sprintf("{%s-%d-read}", workspace, page)}},
+				{Permission: "none", Repository: &bitbucketcloud.Repo{UUID: fmt.Sprintf("{%s-%d-none}", workspace, page)}},
+			}, next, nil
+		},
+	})
+
+	data := json.RawMessage(`{"uuid": "{2}", "nickname": "carol"}`)
+	repoIDs, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+			AccountID:   "{2}",
+		},
+		AccountData: extsvc.AccountData{Data: &data},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.RepoID{"{alice-1-read}", "{alice-2-read}", "{sglocal-1-read}", "{sglocal-2-read}"}
+	if diff := cmp.Diff(want, repoIDs); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	t.Run("not the code host of the repo", func(t *testing.T) {
+		p := newTestProvider(&mockClient{})
+		_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+			URI: "gitlab.com/user/repo",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitLab,
+				ServiceID:   "https://gitlab.com/",
+			},
+		})
+		want := `not a code host of the repo: want "https://bitbucket.org/" but have "https://gitlab.com/"`
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	p := newTestProvider(&mockClient{
+		MockRepoPermissions: func(ctx context.Context, token *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if workspace != "sglocal" || repoSlug != "mux" {
+				return nil, nil, fmt.Errorf("unexpected repository %s/%s", workspace, repoSlug)
+			}
+			page, next := pages(token, 2)
+			return []*bitbucketcloud.RepoPermission{
+				{Permission: "admin", User: &bitbucketcloud.User{UUID: fmt.Sprintf("{%d-admin}", page)}},
+				{Permission: "write", User: &bitbucketcloud.User{UUID: fmt.Sprintf("{%d-write}", page)}},
+			}, next, nil
+		},
+	})
+
+	accountIDs, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		URI: "bitbucket.org/sglocal/mux",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "{e1e75436-05e6-4c38-8543-9c36ec26fad1}",
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []extsvc.AccountID{"{1-admin}", "{1-write}", "{2-admin}", "{2-write}"}
+	if diff := cmp.Diff(want, accountIDs); diff != "" {
+		t.Fatal(diff)
+	}
+}
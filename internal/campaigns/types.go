@@ -124,6 +124,7 @@ type ChangesetExternalState string
 // ChangesetExternalState constants.
 const (
 	ChangesetExternalStateOpen    ChangesetExternalState = "OPEN"
+	ChangesetExternalStateDraft   ChangesetExternalState = "DRAFT"
 	ChangesetExternalStateClosed  ChangesetExternalState = "CLOSED"
 	ChangesetExternalStateMerged  ChangesetExternalState = "MERGED"
 	ChangesetExternalStateDeleted ChangesetExternalState = "DELETED"
@@ -133,6 +134,7 @@ const (
 func (s ChangesetExternalState) Valid() bool {
 	switch s {
 	case ChangesetExternalStateOpen,
+		ChangesetExternalStateDraft,
 		ChangesetExternalStateClosed,
 		ChangesetExternalStateMerged,
 		ChangesetExternalStateDeleted:
@@ -207,6 +209,27 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetMergeableState constants.
+type ChangesetMergeableState string
+
+const (
+	ChangesetMergeableStateUnknown     ChangesetMergeableState = "UNKNOWN"
+	ChangesetMergeableStateMergeable   ChangesetMergeableState = "MERGEABLE"
+	ChangesetMergeableStateConflicting ChangesetMergeableState = "CONFLICTING"
+)
+
+// Valid returns true if the given Changeset mergeable state is valid.
+func (s ChangesetMergeableState) Valid() bool {
+	switch s {
+	case ChangesetMergeableStateUnknown,
+		ChangesetMergeableStateMergeable,
+		ChangesetMergeableStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
 // A Changeset is a changeset on a code host belonging to a Repository and many
 // Campaigns.
 type Changeset struct {
@@ -224,12 +247,15 @@ type Changeset struct {
 	ExternalState       ChangesetExternalState
 	ExternalReviewState ChangesetReviewState
 	ExternalCheckState  ChangesetCheckState
-	CreatedByCampaign   bool
-	AddedToCampaign     bool
-	DiffStatAdded       *int32
-	DiffStatChanged     *int32
-	DiffStatDeleted     *int32
-	SyncState           ChangesetSyncState
+	// ExternalMergeableState is whether the head branch of the changeset can
+	// be merged into the base branch without conflicts.
+	ExternalMergeableState ChangesetMergeableState
+	CreatedByCampaign      bool
+	AddedToCampaign        bool
+	DiffStatAdded          *int32
+	DiffStatChanged        *int32
+	DiffStatDeleted        *int32
+	SyncState              ChangesetSyncState
 }
 
 // Clone returns a clone of a Changeset.
@@ -362,6 +388,25 @@ func (c *Changeset) IsDeleted() bool {
 	return !c.ExternalDeletedAt.IsZero()
 }
 
+// IsOpen returns true when the ExternalState of the Changeset is open,
+// including drafts.
+func (c *Changeset) IsOpen() bool {
+	return c.ExternalState == ChangesetExternalStateOpen || c.ExternalState == ChangesetExternalStateDraft
+}
+
+// IsDraft returns true when the Changeset is an open draft on the code host,
+// that is a draft pull request on GitHub or a WIP merge request on GitLab.
+func (c *Changeset) IsDraft() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.IsDraft && m.State == string(ChangesetExternalStateOpen)
+	case *gitlab.MergeRequest:
+		return m.WorkInProgress && m.State == gitlab.MergeRequestStateOpened
+	default:
+		return false
+	}
+}
+
 // externalState of a Changeset based on the metadata.
 // It does NOT reflect the final calculated externalState, use `ExternalState` instead.
 func (c *Changeset) externalState() (s ChangesetExternalState, err error) {
@@ -397,6 +442,10 @@ func (c *Changeset) externalState() (s ChangesetExternalState, err error) {
 		return "", errors.Errorf("changeset state %q invalid", s)
 	}
 
+	if s == ChangesetExternalStateOpen && c.IsDraft() {
+		s = ChangesetExternalStateDraft
+	}
+
 	return s, nil
 }
 
@@ -855,6 +904,12 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		return e.CreatedAt
 	case *gitlab.ReviewUnapproved:
 		return e.CreatedAt
+	case *gitlab.MergeRequestClosedEvent:
+		return e.CreatedAt
+	case *gitlab.MergeRequestMergedEvent:
+		return e.CreatedAt
+	case *gitlab.MergeRequestReopenedEvent:
+		return e.CreatedAt
 	}
 
 	return t
@@ -1201,6 +1256,24 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *gitlab.Pipeline:
+		o := o.Metadata.(*gitlab.Pipeline)
+		// Pipelines change their status over time, and both syncs and
+		// webhooks deliver the whole pipeline.
+		*e = *o
+
+	case *gitlab.MergeRequestClosedEvent:
+		o := o.Metadata.(*gitlab.MergeRequestClosedEvent)
+		*e = *o
+
+	case *gitlab.MergeRequestMergedEvent:
+		o := o.Metadata.(*gitlab.MergeRequestMergedEvent)
+		*e = *o
+
+	case *gitlab.MergeRequestReopenedEvent:
+		o := o.Metadata.(*gitlab.MergeRequestReopenedEvent)
+		*e = *o
+
 	default:
 		panic(errors.Errorf("unknown changeset event metadata %T", e))
 	}
@@ -1340,6 +1413,12 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitLabApproved
 	case *gitlab.ReviewUnapproved:
 		return ChangesetEventKindGitLabUnapproved
+	case *gitlab.MergeRequestClosedEvent:
+		return ChangesetEventKindGitLabClosed
+	case *gitlab.MergeRequestMergedEvent:
+		return ChangesetEventKindGitLabMerged
+	case *gitlab.MergeRequestReopenedEvent:
+		return ChangesetEventKindGitLabReopened
 	default:
 		panic(errors.Errorf("unknown changeset event kind for %T", e))
 	}
@@ -1405,6 +1484,12 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 			return new(gitlab.Pipeline), nil
 		case ChangesetEventKindGitLabUnapproved:
 			return new(gitlab.ReviewUnapproved), nil
+		case ChangesetEventKindGitLabClosed:
+			return new(gitlab.MergeRequestClosedEvent), nil
+		case ChangesetEventKindGitLabMerged:
+			return new(gitlab.MergeRequestMergedEvent), nil
+		case ChangesetEventKindGitLabReopened:
+			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
@@ -1455,6 +1540,9 @@ const (
 	ChangesetEventKindGitLabApproved   ChangesetEventKind = "gitlab:approved"
 	ChangesetEventKindGitLabPipeline   ChangesetEventKind = "gitlab:pipeline"
 	ChangesetEventKindGitLabUnapproved ChangesetEventKind = "gitlab:unapproved"
+	ChangesetEventKindGitLabClosed     ChangesetEventKind = "gitlab:closed"
+	ChangesetEventKindGitLabMerged     ChangesetEventKind = "gitlab:merged"
+	ChangesetEventKindGitLabReopened   ChangesetEventKind = "gitlab:reopened"
 )
 
 // ChangesetSyncData represents data about the sync status of a changeset
@@ -1556,13 +1644,248 @@ type ChangesetTemplate struct {
 	Body      string         `json:"body"`
 	Branch    string         `json:"branch"`
 	Commit    CommitTemplate `json:"commit"`
-	Published bool           `json:"published"`
+	Published PublishedValue `json:"published"`
+	AutoMerge bool           `json:"autoMerge,omitempty"`
+}
+
+// PublishedValue is the publication state of a changeset in a campaign spec
+// or changeset spec: true, false or "draft".
+type PublishedValue struct {
+	Val interface{}
+}
+
+// publishedValueDraft is the value of a PublishedValue that publishes the
+// changeset as a draft.
+const publishedValueDraft = "draft"
+
+// True returns whether the changeset is published as a ready-for-review
+// changeset.
+func (p PublishedValue) True() bool {
+	v, ok := p.Val.(bool)
+	return ok && v
+}
+
+// False returns whether the changeset is not published. The zero value is
+// false.
+func (p PublishedValue) False() bool {
+	if p.Val == nil {
+		return true
+	}
+	v, ok := p.Val.(bool)
+	return ok && !v
+}
+
+// Draft returns whether the changeset is published as a draft.
+func (p PublishedValue) Draft() bool {
+	v, ok := p.Val.(string)
+	return ok && v == publishedValueDraft
+}
+
+// Valid returns whether the value is true, false or "draft".
+func (p PublishedValue) Valid() bool {
+	return p.True() || p.False() || p.Draft()
+}
+
+// Value returns the underlying value, which is false for the zero value.
+func (p PublishedValue) Value() interface{} {
+	if p.Val == nil {
+		return false
+	}
+	return p.Val
+}
+
+func (p PublishedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Value())
+}
+
+func (p *PublishedValue) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.Val); err != nil {
+		return err
+	}
+	if !p.Valid() {
+		return errors.Errorf("invalid published value %s: must be true, false or %q", b, publishedValueDraft)
+	}
+	return nil
 }
 
 type CommitTemplate struct {
 	Message string `json:"message"`
 }
 
+// CampaignSpecJobState defines the possible states of a CampaignSpecJob.
+type CampaignSpecJobState string
+
+// CampaignSpecJobState constants.
+const (
+	CampaignSpecJobStateQueued     CampaignSpecJobState = "queued"
+	CampaignSpecJobStateProcessing CampaignSpecJobState = "processing"
+	CampaignSpecJobStateCompleted  CampaignSpecJobState = "completed"
+	CampaignSpecJobStateErrored    CampaignSpecJobState = "errored"
+)
+
+// Valid returns true if the given CampaignSpecJobState is valid.
+func (s CampaignSpecJobState) Valid() bool {
+	switch s {
+	case CampaignSpecJobStateQueued,
+		CampaignSpecJobStateProcessing,
+		CampaignSpecJobStateCompleted,
+		CampaignSpecJobStateErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+// A CampaignSpecJob is the server-side execution of the steps of a
+// CampaignSpec in a single repository. A successful job produces at most one
+// ChangesetSpec, which is attached to the CampaignSpec.
+type CampaignSpecJob struct {
+	ID             int64
+	CampaignSpecID int64
+	RepoID         api.RepoID
+
+	State          CampaignSpecJobState
+	FailureMessage string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int32
+	NumFailures    int32
+
+	// Log is the combined output of the steps of the most recent attempt.
+	Log string
+
+	// ChangesetSpecID is zero if the job hasn't completed yet or if the steps
+	// didn't produce any changes.
+	ChangesetSpecID int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignSpecJob.
+func (j *CampaignSpecJob) Clone() *CampaignSpecJob {
+	jj := *j
+	return &jj
+}
+
+// RecordID returns the ID of the CampaignSpecJob. It implements
+// workerutil.Record.
+func (j *CampaignSpecJob) RecordID() int {
+	return int(j.ID)
+}
+
+// ChangesetBulkOperationType defines the operation a ChangesetBulkOperation
+// runs on each of its changesets.
+type ChangesetBulkOperationType string
+
+// ChangesetBulkOperationType constants.
+const (
+	// ChangesetBulkOperationComment posts a comment on the changeset.
+	ChangesetBulkOperationComment ChangesetBulkOperationType = "COMMENT"
+	// ChangesetBulkOperationClose closes the changeset on the code host.
+	ChangesetBulkOperationClose ChangesetBulkOperationType = "CLOSE"
+	// ChangesetBulkOperationSync reloads the changeset from the code host.
+	ChangesetBulkOperationSync ChangesetBulkOperationType = "SYNC"
+	// ChangesetBulkOperationRepublish updates the title, body and base
+	// branch of the changeset on the code host from the current spec of its
+	// campaign.
+	ChangesetBulkOperationRepublish ChangesetBulkOperationType = "REPUBLISH"
+)
+
+// Valid returns true if the given ChangesetBulkOperationType is valid.
+func (t ChangesetBulkOperationType) Valid() bool {
+	switch t {
+	case ChangesetBulkOperationComment,
+		ChangesetBulkOperationClose,
+		ChangesetBulkOperationSync,
+		ChangesetBulkOperationRepublish:
+		return true
+	default:
+		return false
+	}
+}
+
+// A ChangesetBulkOperation is an operation on a set of changesets of a
+// campaign, started by a user. It's executed as one
+// ChangesetBulkOperationJob per changeset.
+type ChangesetBulkOperation struct {
+	ID         int64
+	CampaignID int64
+	UserID     int32
+
+	Operation ChangesetBulkOperationType
+	// Comment is the body of the comment posted by
+	// ChangesetBulkOperationComment and empty otherwise.
+	Comment string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a ChangesetBulkOperation.
+func (o *ChangesetBulkOperation) Clone() *ChangesetBulkOperation {
+	oo := *o
+	return &oo
+}
+
+// ChangesetBulkOperationJobState defines the possible states of a
+// ChangesetBulkOperationJob.
+type ChangesetBulkOperationJobState string
+
+// ChangesetBulkOperationJobState constants.
+const (
+	ChangesetBulkOperationJobStateQueued     ChangesetBulkOperationJobState = "queued"
+	ChangesetBulkOperationJobStateProcessing ChangesetBulkOperationJobState = "processing"
+	ChangesetBulkOperationJobStateCompleted  ChangesetBulkOperationJobState = "completed"
+	ChangesetBulkOperationJobStateErrored    ChangesetBulkOperationJobState = "errored"
+)
+
+// Valid returns true if the given ChangesetBulkOperationJobState is valid.
+func (s ChangesetBulkOperationJobState) Valid() bool {
+	switch s {
+	case ChangesetBulkOperationJobStateQueued,
+		ChangesetBulkOperationJobStateProcessing,
+		ChangesetBulkOperationJobStateCompleted,
+		ChangesetBulkOperationJobStateErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+// A ChangesetBulkOperationJob is the execution of a ChangesetBulkOperation
+// on a single changeset. Its state and failure message are the result of the
+// operation for that changeset.
+type ChangesetBulkOperationJob struct {
+	ID              int64
+	BulkOperationID int64
+	ChangesetID     int64
+
+	State          ChangesetBulkOperationJobState
+	FailureMessage string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int32
+	NumFailures    int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a ChangesetBulkOperationJob.
+func (j *ChangesetBulkOperationJob) Clone() *ChangesetBulkOperationJob {
+	jj := *j
+	return &jj
+}
+
+// RecordID returns the ID of the ChangesetBulkOperationJob. It implements
+// workerutil.Record.
+func (j *ChangesetBulkOperationJob) RecordID() int {
+	return int(j.ID)
+}
+
 func NewChangesetSpecFromRaw(rawSpec string) (*ChangesetSpec, error) {
 	c := &ChangesetSpec{RawSpec: rawSpec}
 	err := c.UnmarshalValidate()
@@ -1702,7 +2025,7 @@ type ChangesetSpecDescription struct {
 
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
-	Published bool `json:"published,omitempty"`
+	Published PublishedValue `json:"published"`
 }
 
 // Type returns the ChangesetSpecDescriptionType of the ChangesetSpecDescription.
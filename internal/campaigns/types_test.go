@@ -1,6 +1,7 @@
 package campaigns
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -642,12 +643,33 @@ func TestChangeset_externalState(t *testing.T) {
 			},
 			want: ChangesetExternalStateOpen,
 		},
+		"GitHub: draft": {
+			meta: &github.PullRequest{
+				State:   "OPEN",
+				IsDraft: true,
+			},
+			want: ChangesetExternalStateDraft,
+		},
+		"GitHub: merged draft": {
+			meta: &github.PullRequest{
+				State:   "MERGED",
+				IsDraft: true,
+			},
+			want: ChangesetExternalStateMerged,
+		},
 		"GitLab: opened": {
 			meta: &gitlab.MergeRequest{
 				State: gitlab.MergeRequestStateOpened,
 			},
 			want: ChangesetExternalStateOpen,
 		},
+		"GitLab: work in progress": {
+			meta: &gitlab.MergeRequest{
+				State:          gitlab.MergeRequestStateOpened,
+				WorkInProgress: true,
+			},
+			want: ChangesetExternalStateDraft,
+		},
 		"GitLab: closed": {
 			meta: &gitlab.MergeRequest{
 				State: gitlab.MergeRequestStateClosed,
@@ -1182,3 +1204,54 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestPublishedValue(t *testing.T) {
+	for _, tc := range []struct {
+		json  string
+		val   PublishedValue
+		true  bool
+		false bool
+		draft bool
+	}{
+		{json: `true`, val: PublishedValue{Val: true}, true: true},
+		{json: `false`, val: PublishedValue{Val: false}, false: true},
+		{json: `"draft"`, val: PublishedValue{Val: "draft"}, draft: true},
+		{json: `null`, val: PublishedValue{}, false: true},
+	} {
+		t.Run(tc.json, func(t *testing.T) {
+			var have PublishedValue
+			if err := json.Unmarshal([]byte(tc.json), &have); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.val, have); diff != "" {
+				t.Fatalf("unexpected value (-want +got):\n%s", diff)
+			}
+
+			if have.True() != tc.true || have.False() != tc.false || have.Draft() != tc.draft {
+				t.Errorf("wrong state. have true=%t false=%t draft=%t", have.True(), have.False(), have.Draft())
+			}
+			if !have.Valid() {
+				t.Error("value is not valid")
+			}
+		})
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(PublishedValue{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := string(b), `false`; have != want {
+			t.Errorf("wrong JSON. have=%s, want=%s", have, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, in := range []string{`"yes"`, `1`, `{}`} {
+			var v PublishedValue
+			if err := json.Unmarshal([]byte(in), &v); err == nil {
+				t.Errorf("expected error for %s", in)
+			}
+		}
+	})
+}
@@ -283,6 +283,23 @@ func SearchSymbolsParallelism() int {
 	return val
 }
 
+// GitServerReplicationFactor returns the number of gitservers each repository
+// is cloned on. It returns 1, or the site config "gitServerReplicationFactor"
+// value if configured.
+func GitServerReplicationFactor() int {
+	val := Get().GitServerReplicationFactor
+	if val < 1 {
+		return 1
+	}
+	return val
+}
+
+// GitServerConsistentHashing returns true if repositories are assigned to
+// gitservers with consistent hashing.
+func GitServerConsistentHashing() bool {
+	return Get().GitServerConsistentHashing
+}
+
 func PermissionsBackgroundSyncEnabled() bool {
 	val := Get().PermissionsBackgroundSync
 	if val == nil {
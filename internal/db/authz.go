@@ -5,6 +5,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
@@ -42,6 +43,15 @@ type RevokeUserPermissionsArgs struct {
 	Accounts []*extsvc.Accounts
 }
 
+// SubRepoPermissionsArgs contains required arguments to look up which paths of a repository a
+// user is authorized to read.
+type SubRepoPermissionsArgs struct {
+	// The user whose authorization to read paths of the repository is being checked.
+	UserID int32
+	// The repository whose paths are being checked.
+	RepoID api.RepoID
+}
+
 // AuthzStore contains methods for manipulating user permissions.
 type AuthzStore interface {
 	// GrantPendingPermissions grants pending permissions for a user. It is a no-op in the OSS version.
@@ -53,6 +63,10 @@ type AuthzStore interface {
 	// RevokeUserPermissions deletes both effective and pending permissions that could be related to a user.
 	// It is a no-op in the OSS version.
 	RevokeUserPermissions(ctx context.Context, args *RevokeUserPermissionsArgs) error
+	// SubRepoPermissions returns the sub-repository permissions of a user for a repository. It
+	// returns nil if the user's access to the repository is not restricted to some paths.
+	// It is a no-op in the OSS version.
+	SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error)
 }
 
 // authzStore is a no-op placeholder for the OSS version.
@@ -78,3 +92,10 @@ func (*authzStore) RevokeUserPermissions(ctx context.Context, args *RevokeUserPe
 	}
 	return nil
 }
+
+func (*authzStore) SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error) {
+	if Mocks.Authz.SubRepoPermissions != nil {
+		return Mocks.Authz.SubRepoPermissions(ctx, args)
+	}
+	return nil, nil
+}
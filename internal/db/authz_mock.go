@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 )
 
@@ -10,4 +11,5 @@ type MockAuthz struct {
 	GrantPendingPermissions func(ctx context.Context, args *GrantPendingPermissionsArgs) error
 	AuthorizedRepos         func(ctx context.Context, args *AuthorizedReposArgs) ([]*types.Repo, error)
 	RevokeUserPermissions   func(ctx context.Context, args *RevokeUserPermissionsArgs) error
+	SubRepoPermissions      func(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error)
 }
@@ -30,6 +30,12 @@ func NewHandleWithDB(db dbutil.DB) *TransactableHandle {
 	return &TransactableHandle{db: db}
 }
 
+// DB returns the underlying database connection or transaction. It allows stores
+// that aren't built on top of basestore to share a handle, and its transaction.
+func (h *TransactableHandle) DB() dbutil.DB {
+	return h.db
+}
+
 // InTransaction returns true if the underlying database handle is in a transaction.
 func (h *TransactableHandle) InTransaction() bool {
 	_, ok := h.db.(dbutil.Tx)
@@ -31,6 +31,8 @@ type ExternalServicesStore struct {
 	GitHubValidators          []func(*schema.GitHubConnection) error
 	GitLabValidators          []func(*schema.GitLabConnection, []schema.AuthProviders) error
 	BitbucketServerValidators []func(*schema.BitbucketServerConnection) error
+	BitbucketCloudValidators  []func(*schema.BitbucketCloudConnection) error
+	AWSCodeCommitValidators   []func(*schema.AWSCodeCommitConnection) error
 }
 
 // ExternalServiceKinds contains a map of all supported kinds of
@@ -139,6 +141,13 @@ func (e *ExternalServicesStore) ValidateConfig(ctx context.Context, id int64, ki
 		}
 		err = e.validateBitbucketCloudConnection(ctx, id, &c)
 
+	case extsvc.KindAWSCodeCommit:
+		var c schema.AWSCodeCommitConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validateAWSCodeCommitConnection(&c)
+
 	case extsvc.KindOther:
 		var c schema.OtherExternalServiceConnection
 		if err = json.Unmarshal(normalized, &c); err != nil {
@@ -221,7 +230,22 @@ func (e *ExternalServicesStore) validateBitbucketServerConnection(ctx context.Co
 }
 
 func (e *ExternalServicesStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection) error {
-	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
+	err := new(multierror.Error)
+	for _, validate := range e.BitbucketCloudValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c))
+
+	return err.ErrorOrNil()
+}
+
+func (e *ExternalServicesStore) validateAWSCodeCommitConnection(c *schema.AWSCodeCommitConnection) error {
+	err := new(multierror.Error)
+	for _, validate := range e.AWSCodeCommitValidators {
+		err = multierror.Append(err, validate(c))
+	}
+	return err.ErrorOrNil()
 }
 
 func (e *ExternalServicesStore) validateDuplicateRateLimits(ctx context.Context, id int64, kind string, parsedConfig interface{}) error {
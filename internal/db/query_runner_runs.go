@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// queryRunnerRunRetention is how long the history of query runner runs is kept.
+const queryRunnerRunRetention = 7 * 24 * time.Hour
+
+// QueryRunnerRunListOptions specifies the options for listing query runner runs.
+type QueryRunnerRunListOptions struct {
+	// Query, if non-empty, only lists the runs of this saved search query.
+	Query string
+
+	*LimitOffset
+}
+
+type queryRunnerRuns struct{}
+
+// Create records a run of a saved search query, and deletes the runs older than
+// queryRunnerRunRetention.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to record runs.
+func (*queryRunnerRuns) Create(ctx context.Context, run *types.QueryRunnerRun) (*types.QueryRunnerRun, error) {
+	created := *run
+	q := sqlf.Sprintf(`INSERT INTO query_runner_runs(
+			query,
+			runner,
+			scheduled_at,
+			started_at,
+			finished_at,
+			result_count,
+			error
+		) VALUES(%s, %s, %s, %s, %s, %s, %s) RETURNING id`,
+		run.Query,
+		run.Runner,
+		run.ScheduledAt,
+		run.StartedAt,
+		run.FinishedAt,
+		run.ResultCount,
+		run.Error,
+	)
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&created.ID); err != nil {
+		return nil, err
+	}
+
+	q = sqlf.Sprintf("DELETE FROM query_runner_runs WHERE started_at < %s", run.StartedAt.Add(-queryRunnerRunRetention))
+	if _, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// List lists the query runner runs matching the options, most recent first.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure only users with
+// access to the saved searches can access the returned runs.
+func (*queryRunnerRuns) List(ctx context.Context, opt QueryRunnerRunListOptions) ([]*types.QueryRunnerRun, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opt.Query != "" {
+		conds = append(conds, sqlf.Sprintf("query=%s", opt.Query))
+	}
+
+	q := sqlf.Sprintf(`SELECT
+		id,
+		query,
+		runner,
+		scheduled_at,
+		started_at,
+		finished_at,
+		result_count,
+		error
+		FROM query_runner_runs
+		WHERE %s
+		ORDER BY started_at DESC, id DESC
+		%s`,
+		sqlf.Join(conds, "AND"),
+		opt.LimitOffset.SQL(),
+	)
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*types.QueryRunnerRun
+	for rows.Next() {
+		var r types.QueryRunnerRun
+		if err := rows.Scan(&r.ID, &r.Query, &r.Runner, &r.ScheduledAt, &r.StartedAt, &r.FinishedAt, &r.ResultCount, &r.Error); err != nil {
+			return nil, err
+		}
+		runs = append(runs, &r)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestQueryRunnerRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	errMsg := "search: timeout"
+	resultCount := int32(3)
+	expired := &types.QueryRunnerRun{
+		Query:       "type:diff foo",
+		Runner:      "query-runner-0",
+		ScheduledAt: now.Add(-queryRunnerRunRetention - time.Hour),
+		StartedAt:   now.Add(-queryRunnerRunRetention - time.Hour),
+		FinishedAt:  now.Add(-queryRunnerRunRetention - time.Hour),
+	}
+	failed := &types.QueryRunnerRun{
+		Query:       "type:diff foo",
+		Runner:      "query-runner-0",
+		ScheduledAt: now.Add(-2 * time.Minute),
+		StartedAt:   now.Add(-time.Minute),
+		FinishedAt:  now.Add(-time.Minute),
+		Error:       &errMsg,
+	}
+	succeeded := &types.QueryRunnerRun{
+		Query:       "type:diff foo",
+		Runner:      "query-runner-1",
+		ScheduledAt: now.Add(-time.Second),
+		StartedAt:   now,
+		FinishedAt:  now.Add(time.Second),
+		ResultCount: &resultCount,
+	}
+	other := &types.QueryRunnerRun{
+		Query:       "bar",
+		Runner:      "query-runner-1",
+		ScheduledAt: now,
+		StartedAt:   now,
+		FinishedAt:  now,
+		ResultCount: &resultCount,
+	}
+	for _, r := range []*types.QueryRunnerRun{expired, failed, succeeded, other} {
+		created, err := QueryRunnerRuns.Create(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.ID = created.ID
+	}
+
+	runs, err := QueryRunnerRuns.List(ctx, QueryRunnerRunListOptions{Query: "type:diff foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range runs {
+		r.ScheduledAt, r.StartedAt, r.FinishedAt = r.ScheduledAt.UTC(), r.StartedAt.UTC(), r.FinishedAt.UTC()
+	}
+	// The expired run is deleted when later runs are recorded.
+	if want := []*types.QueryRunnerRun{succeeded, failed}; !reflect.DeepEqual(runs, want) {
+		t.Errorf("got runs %+v, want %+v", runs, want)
+	}
+}
+
+func TestQueryRunnerState_Claim(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	claim := func(runner string, lease time.Duration, want bool) {
+		t.Helper()
+		claimed, err := QueryRunnerState.Claim(ctx, "foo", runner, lease)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if claimed != want {
+			t.Errorf("%s: got claimed %v, want %v", runner, claimed, want)
+		}
+	}
+
+	claim("a", time.Minute, true)
+	claim("b", time.Minute, false)
+	claim("a", time.Minute, true) // extends the lease
+
+	if err := QueryRunnerState.Release(ctx, "foo", "b"); err != nil {
+		t.Fatal(err)
+	}
+	claim("b", time.Minute, false) // b didn't hold the lease, so it wasn't released
+
+	if err := QueryRunnerState.Release(ctx, "foo", "a"); err != nil {
+		t.Fatal(err)
+	}
+	claim("b", -time.Minute, true) // acquire an already expired lease
+	claim("a", time.Minute, true)
+}
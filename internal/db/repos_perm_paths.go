@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+var MockAuthzPathMatcher func(ctx context.Context, repoID api.RepoID) (*authz.PathMatcher, error)
+
+// AuthzPathsMayBeRestricted reports whether the current actor may have sub-repository
+// permissions in any repository. If it returns false, AuthzPathMatcher returns nil for every
+// repository, so callers filtering many results can skip loading the matchers.
+func AuthzPathsMayBeRestricted(ctx context.Context) bool {
+	if MockAuthzPathMatcher != nil {
+		return true
+	}
+	return !isInternalActor(ctx) && actor.FromContext(ctx).IsAuthenticated()
+}
+
+// AuthzPathMatcher is the enforcement mechanism for sub-repository (path-level) permissions. It
+// returns the matcher of the paths of the repository that the currently authenticated user can
+// read, or nil if the user can read all paths. Callers must already have checked that the user can
+// read the repository itself, e.g. by getting it from db.Repos.
+//
+// 🚨 SECURITY: Every code path that returns paths, file contents or diffs of a repository to a
+// user must filter them with the returned matcher.
+func AuthzPathMatcher(ctx context.Context, repoID api.RepoID) (*authz.PathMatcher, error) {
+	if MockAuthzPathMatcher != nil {
+		return MockAuthzPathMatcher(ctx, repoID)
+	}
+
+	if isInternalActor(ctx) {
+		return nil, nil
+	}
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		// Sub-repository permissions are granted to users, anonymous users can
+		// read all paths of the repositories they can read.
+		return nil, nil
+	}
+
+	p, err := Authz.SubRepoPermissions(ctx, &SubRepoPermissionsArgs{
+		UserID: a.UID,
+		RepoID: repoID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "load sub-repository permissions")
+	}
+	if p == nil {
+		return nil, nil
+	}
+
+	// Like authzFilter, site admins can read everything. Only check this if the user is
+	// restricted, to avoid querying the user for every unrestricted repository.
+	currentUser, err := Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser.SiteAdmin {
+		return nil, nil
+	}
+
+	return authz.NewPathMatcher(p)
+}
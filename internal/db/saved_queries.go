@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 )
 
@@ -16,6 +18,7 @@ type SavedQueryInfo struct {
 	LastExecuted time.Time
 	LatestResult time.Time
 	ExecDuration time.Duration
+	Results      *api.SavedQueryResults
 }
 
 // Get gets the saved query information for the given query. nil
@@ -24,12 +27,15 @@ func (s *queryRunnerState) Get(ctx context.Context, query string) (*SavedQueryIn
 	info := &SavedQueryInfo{
 		Query: query,
 	}
-	var execDurationNs int64
+	var (
+		execDurationNs int64
+		results        []byte
+	)
 	err := dbconn.Global.QueryRowContext(
 		ctx,
-		"SELECT last_executed, latest_result, exec_duration_ns FROM query_runner_state WHERE query=$1",
+		"SELECT last_executed, latest_result, exec_duration_ns, results FROM query_runner_state WHERE query=$1",
 		query,
-	).Scan(&info.LastExecuted, &info.LatestResult, &execDurationNs)
+	).Scan(&info.LastExecuted, &info.LatestResult, &execDurationNs, &results)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -37,6 +43,11 @@ func (s *queryRunnerState) Get(ctx context.Context, query string) (*SavedQueryIn
 		return nil, errors.Wrap(err, "QueryRow")
 	}
 	info.ExecDuration = time.Duration(execDurationNs)
+	if results != nil {
+		if err := json.Unmarshal(results, &info.Results); err != nil {
+			return nil, errors.Wrap(err, "Unmarshal results")
+		}
+	}
 	return info, nil
 }
 
@@ -45,12 +56,21 @@ func (s *queryRunnerState) Get(ctx context.Context, query string) (*SavedQueryIn
 // It is not safe to call concurrently for the same info.Query, as it uses a
 // poor man's upsert implementation.
 func (s *queryRunnerState) Set(ctx context.Context, info *SavedQueryInfo) error {
+	var results []byte
+	if info.Results != nil {
+		var err error
+		if results, err = json.Marshal(info.Results); err != nil {
+			return errors.Wrap(err, "Marshal results")
+		}
+	}
+
 	res, err := dbconn.Global.ExecContext(
 		ctx,
-		"UPDATE query_runner_state SET last_executed=$1, latest_result=$2, exec_duration_ns=$3 WHERE query=$4",
+		"UPDATE query_runner_state SET last_executed=$1, latest_result=$2, exec_duration_ns=$3, results=$4 WHERE query=$5",
 		info.LastExecuted,
 		info.LatestResult,
 		int64(info.ExecDuration),
+		results,
 		info.Query,
 	)
 	if err != nil {
@@ -64,11 +84,12 @@ func (s *queryRunnerState) Set(ctx context.Context, info *SavedQueryInfo) error
 		// Didn't update any row, so insert a new one.
 		_, err := dbconn.Global.ExecContext(
 			ctx,
-			"INSERT INTO query_runner_state(query, last_executed, latest_result, exec_duration_ns) VALUES($1, $2, $3, $4)",
+			"INSERT INTO query_runner_state(query, last_executed, latest_result, exec_duration_ns, results) VALUES($1, $2, $3, $4, $5)",
 			info.Query,
 			info.LastExecuted,
 			info.LatestResult,
 			int64(info.ExecDuration),
+			results,
 		)
 		if err != nil {
 			return errors.Wrap(err, "INSERT")
@@ -85,3 +106,40 @@ func (s *queryRunnerState) Delete(ctx context.Context, query string) error {
 	)
 	return err
 }
+
+// Claim acquires the lease on running the given query for the runner, so that
+// multiple query runners don't run the same query at the same time. It reports
+// whether the lease was acquired, which is the case if no other runner holds
+// an unexpired lease on the query. A runner that already holds the lease
+// extends it.
+func (s *queryRunnerState) Claim(ctx context.Context, query, runner string, lease time.Duration) (bool, error) {
+	err := dbconn.Global.QueryRowContext(
+		ctx,
+		`INSERT INTO query_runner_leases(query, runner, expires_at) VALUES($1, $2, now() + $3 * interval '1 microsecond')
+		ON CONFLICT (query) DO UPDATE SET runner=excluded.runner, expires_at=excluded.expires_at
+		WHERE query_runner_leases.expires_at < now() OR query_runner_leases.runner=excluded.runner
+		RETURNING runner`,
+		query,
+		runner,
+		lease.Microseconds(),
+	).Scan(&runner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "INSERT")
+	}
+	return true, nil
+}
+
+// Release releases the runner's lease on running the given query, if it holds
+// one.
+func (s *queryRunnerState) Release(ctx context.Context, query, runner string) error {
+	_, err := dbconn.Global.ExecContext(
+		ctx,
+		"DELETE FROM query_runner_leases WHERE query=$1 AND runner=$2",
+		query,
+		runner,
+	)
+	return err
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// SavedSearchWebhookDeliveryListOptions specifies the options for listing saved search webhook
+// deliveries.
+type SavedSearchWebhookDeliveryListOptions struct {
+	// SavedSearchID, if nonzero, only lists the deliveries of this saved search.
+	SavedSearchID int32
+	// OnlyFailed only lists the deliveries that failed.
+	OnlyFailed bool
+
+	*LimitOffset
+}
+
+type savedSearchWebhookDeliveries struct{}
+
+// Create records a delivery of a saved search webhook payload.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure the user has
+// proper permissions to record deliveries.
+func (*savedSearchWebhookDeliveries) Create(ctx context.Context, d *types.SavedSearchWebhookDelivery) (*types.SavedSearchWebhookDelivery, error) {
+	created := *d
+	q := sqlf.Sprintf(`INSERT INTO saved_search_webhook_deliveries(
+			saved_search_id,
+			url,
+			payload,
+			attempts,
+			status_code,
+			error,
+			created_at,
+			delivered_at
+		) VALUES(%s, %s, %s, %s, %s, %s, %s, %s) RETURNING id`,
+		d.SavedSearchID,
+		d.URL,
+		d.Payload,
+		d.Attempts,
+		d.StatusCode,
+		d.Error,
+		d.CreatedAt,
+		d.DeliveredAt,
+	)
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&created.ID); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// List lists the saved search webhook deliveries matching the options, most recent first.
+//
+// 🚨 SECURITY: This method does NOT verify the user's identity or that the
+// user is an admin. It is the callers responsibility to ensure only users with
+// access to the saved searches can access the returned deliveries.
+func (*savedSearchWebhookDeliveries) List(ctx context.Context, opt SavedSearchWebhookDeliveryListOptions) ([]*types.SavedSearchWebhookDelivery, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opt.SavedSearchID != 0 {
+		conds = append(conds, sqlf.Sprintf("saved_search_id=%d", opt.SavedSearchID))
+	}
+	if opt.OnlyFailed {
+		conds = append(conds, sqlf.Sprintf("delivered_at IS NULL"))
+	}
+
+	q := sqlf.Sprintf(`SELECT
+		id,
+		saved_search_id,
+		url,
+		payload,
+		attempts,
+		status_code,
+		error,
+		created_at,
+		delivered_at
+		FROM saved_search_webhook_deliveries
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		%s`,
+		sqlf.Join(conds, "AND"),
+		opt.LimitOffset.SQL(),
+	)
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*types.SavedSearchWebhookDelivery
+	for rows.Next() {
+		var d types.SavedSearchWebhookDelivery
+		if err := rows.Scan(&d.ID, &d.SavedSearchID, &d.URL, &d.Payload, &d.Attempts, &d.StatusCode, &d.Error, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
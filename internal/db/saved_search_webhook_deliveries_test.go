@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestSavedSearchWebhookDeliveries(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+	user, err := Users.Create(ctx, NewUser{DisplayName: "test", Email: "test@test.com", Username: "test", Password: "test", EmailVerificationCode: "c2"})
+	if err != nil {
+		t.Fatal("can't create user", err)
+	}
+	webhookURL := "https://example.com/hook"
+	ss, err := SavedSearches.Create(ctx, &types.SavedSearch{
+		Query:         "test",
+		Description:   "test",
+		NotifyWebhook: true,
+		WebhookURL:    &webhookURL,
+		UserID:        &user.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	status, errMsg := int32(502), "unexpected response status"
+	failed := &types.SavedSearchWebhookDelivery{
+		SavedSearchID: ss.ID,
+		URL:           webhookURL,
+		Payload:       []byte(`{"event": "saved_search.results"}`),
+		Attempts:      5,
+		StatusCode:    &status,
+		Error:         &errMsg,
+		CreatedAt:     now.Add(-time.Minute),
+	}
+	ok := int32(200)
+	delivered := &types.SavedSearchWebhookDelivery{
+		SavedSearchID: ss.ID,
+		URL:           webhookURL,
+		Payload:       []byte(`{"event": "saved_search.results"}`),
+		Attempts:      1,
+		StatusCode:    &ok,
+		CreatedAt:     now,
+		DeliveredAt:   &now,
+	}
+	for _, d := range []*types.SavedSearchWebhookDelivery{failed, delivered} {
+		created, err := SavedSearchWebhookDeliveries.Create(ctx, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.ID = created.ID
+	}
+
+	normalize := func(ds []*types.SavedSearchWebhookDelivery) {
+		for _, d := range ds {
+			d.CreatedAt = d.CreatedAt.UTC()
+			if d.DeliveredAt != nil {
+				t := d.DeliveredAt.UTC()
+				d.DeliveredAt = &t
+			}
+		}
+	}
+
+	all, err := SavedSearchWebhookDeliveries.List(ctx, SavedSearchWebhookDeliveryListOptions{SavedSearchID: ss.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalize(all)
+	if want := []*types.SavedSearchWebhookDelivery{delivered, failed}; !reflect.DeepEqual(all, want) {
+		t.Errorf("got deliveries %+v, want %+v", all, want)
+	}
+
+	onlyFailed, err := SavedSearchWebhookDeliveries.List(ctx, SavedSearchWebhookDeliveryListOptions{SavedSearchID: ss.ID, OnlyFailed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalize(onlyFailed)
+	if want := []*types.SavedSearchWebhookDelivery{failed}; !reflect.DeepEqual(onlyFailed, want) {
+		t.Errorf("got failed deliveries %+v, want %+v", onlyFailed, want)
+	}
+
+	// Deliveries are deleted with their saved search.
+	if err := SavedSearches.Delete(ctx, ss.ID); err != nil {
+		t.Fatal(err)
+	}
+	all, err = SavedSearchWebhookDeliveries.List(ctx, SavedSearchWebhookDeliveryListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 0 {
+		t.Errorf("got %d deliveries after deleting the saved search, want 0", len(all))
+	}
+}
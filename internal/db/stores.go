@@ -17,6 +17,10 @@ var (
 
 	SurveyResponses = &surveyResponses{}
 
+	SavedSearchWebhookDeliveries = &savedSearchWebhookDeliveries{}
+
+	QueryRunnerRuns = &queryRunnerRuns{}
+
 	ExternalAccounts = &userExternalAccounts{}
 
 	OrgInvitations = &orgInvitations{}
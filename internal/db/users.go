@@ -515,6 +515,53 @@ func (u *users) HardDelete(ctx context.Context, id int32) error {
 	return nil
 }
 
+// Recover restores a soft-deleted user. The user's username is reserved again in the shared
+// users+orgs namespace, but their emails, access tokens and external accounts are not restored.
+func (u *users) Recover(ctx context.Context, id int32) (err error) {
+	if Mocks.Users.Recover != nil {
+		return Mocks.Users.Recover(ctx, id)
+	}
+
+	tx, err := dbconn.Global.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			rollErr := tx.Rollback()
+			if rollErr != nil {
+				err = multierror.Append(err, rollErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "users_username" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+
+	// Ensure the username is still available in shared users+orgs namespace.
+	if _, err = tx.ExecContext(ctx, "INSERT INTO names(name, user_id) SELECT username, id FROM users WHERE id=$1", id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "names_pkey" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	return nil
+}
+
 func (u *users) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error {
 	if Mocks.Users.SetIsSiteAdmin != nil {
 		return Mocks.Users.SetIsSiteAdmin(id, isSiteAdmin)
@@ -556,6 +603,15 @@ func (u *users) GetByID(ctx context.Context, id int32) (*types.User, error) {
 	return u.getOneBySQL(ctx, "WHERE id=$1 AND deleted_at IS NULL LIMIT 1", id)
 }
 
+// GetDeletedByID returns the soft-deleted user with the given ID. It returns a not found error
+// if the user does not exist or is not deleted.
+func (u *users) GetDeletedByID(ctx context.Context, id int32) (*types.User, error) {
+	if Mocks.Users.GetDeletedByID != nil {
+		return Mocks.Users.GetDeletedByID(ctx, id)
+	}
+	return u.getOneBySQL(ctx, "WHERE id=$1 AND deleted_at IS NOT NULL LIMIT 1", id)
+}
+
 // GetByVerifiedEmail returns the user (if any) with the specified verified email address. If a user
 // has a matching *unverified* email address, they will not be returned by this method. At most one
 // user may have any given verified email address.
@@ -566,6 +622,15 @@ func (u *users) GetByVerifiedEmail(ctx context.Context, email string) (*types.Us
 	return u.getOneBySQL(ctx, "WHERE id=(SELECT user_id FROM user_emails WHERE email=$1 AND verified_at IS NOT NULL) AND deleted_at IS NULL LIMIT 1", email)
 }
 
+// GetDeletedByUsername returns the most recently soft-deleted user with the given username. It
+// returns a not found error if no deleted user has the username.
+func (u *users) GetDeletedByUsername(ctx context.Context, username string) (*types.User, error) {
+	if Mocks.Users.GetDeletedByUsername != nil {
+		return Mocks.Users.GetDeletedByUsername(ctx, username)
+	}
+	return u.getOneBySQL(ctx, "WHERE username=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT 1", username)
+}
+
 func (u *users) GetByUsername(ctx context.Context, username string) (*types.User, error) {
 	if Mocks.Users.GetByUsername != nil {
 		return Mocks.Users.GetByUsername(ctx, username)
@@ -12,9 +12,12 @@ type MockUsers struct {
 	Update                       func(userID int32, update UserUpdate) error
 	Delete                       func(ctx context.Context, id int32) error
 	HardDelete                   func(ctx context.Context, id int32) error
+	Recover                      func(ctx context.Context, id int32) error
 	SetIsSiteAdmin               func(id int32, isSiteAdmin bool) error
 	CheckAndDecrementInviteQuota func(ctx context.Context, userID int32) (bool, error)
 	GetByID                      func(ctx context.Context, id int32) (*types.User, error)
+	GetDeletedByID               func(ctx context.Context, id int32) (*types.User, error)
+	GetDeletedByUsername         func(ctx context.Context, username string) (*types.User, error)
 	GetByUsername                func(ctx context.Context, username string) (*types.User, error)
 	GetByUsernames               func(ctx context.Context, usernames ...string) ([]*types.User, error)
 	GetByCurrentAuthUser         func(ctx context.Context) (*types.User, error)
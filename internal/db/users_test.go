@@ -597,6 +597,67 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_Recover(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, err := Users.Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Active user is not returned as deleted and can't be recovered.
+	if _, err := Users.GetDeletedByID(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+	if _, err := Users.GetDeletedByUsername(ctx, "u"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+	if err := Users.Recover(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+
+	if err := Users.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := Users.GetDeletedByID(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	} else if deleted.Username != "u" {
+		t.Errorf("got username %q, want %q", deleted.Username, "u")
+	}
+	if deleted, err := Users.GetDeletedByUsername(ctx, "u"); err != nil {
+		t.Fatal(err)
+	} else if deleted.ID != user.ID {
+		t.Errorf("got user %d, want %d", deleted.ID, user.ID)
+	}
+
+	if err := Users.Recover(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Users.GetByID(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Username is reserved again.
+	if _, err := Users.Create(ctx, NewUser{Username: "u"}); !IsUsernameExists(err) {
+		t.Errorf("got error %v, want username exists", err)
+	}
+
+	// Can't recover when the username has been taken in the meantime.
+	if err := Users.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Users.Create(ctx, NewUser{Username: "u"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Users.Recover(ctx, user.ID); !IsUsernameExists(err) {
+		t.Errorf("got error %v, want username exists", err)
+	}
+}
+
 func normalizeUsers(users []*types.User) []*types.User {
 	for _, u := range users {
 		u.CreatedAt = u.CreatedAt.Local().Round(time.Second)
@@ -0,0 +1,118 @@
+package awscodecommit
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+// IAMUser is an AWS IAM user.
+type IAMUser struct {
+	ARN  string // the ARN (Amazon Resource Name) of the user
+	ID   string // the stable and unique ID of the user
+	Name string // the friendly name of the user
+}
+
+// GitPullAction is the IAM action that allows reading an AWS CodeCommit repository.
+const GitPullAction = "codecommit:GitPull"
+
+// maxSimulatedResources is the number of resources to simulate IAM policies for in a single
+// request, to keep requests and responses reasonably small.
+const maxSimulatedResources = 100
+
+// IsIAMNotFound reports whether err is an AWS IAM API not-found error.
+func IsIAMNotFound(err error) bool {
+	if e, ok := err.(awserr.Error); ok {
+		return e.Code() == iam.ErrCodeNoSuchEntityException
+	}
+	return false
+}
+
+// GetIAMUser gets an IAM user by name.
+func (c *Client) GetIAMUser(ctx context.Context, name string) (*IAMUser, error) {
+	svc := iam.New(c.aws)
+	req := svc.GetUserRequest(&iam.GetUserInput{UserName: &name})
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fromIAMUser(result.User), nil
+}
+
+// ListIAMUsers calls the ListUsers API method of AWS IAM.
+func (c *Client) ListIAMUsers(ctx context.Context, marker string) (users []*IAMUser, nextMarker string, err error) {
+	svc := iam.New(c.aws)
+
+	input := iam.ListUsersInput{}
+	if marker != "" {
+		input.Marker = &marker
+	}
+	req := svc.ListUsersRequest(&input)
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if aws.BoolValue(result.IsTruncated) && result.Marker != nil {
+		nextMarker = *result.Marker
+	}
+
+	users = make([]*IAMUser, len(result.Users))
+	for i := range result.Users {
+		users[i] = fromIAMUser(&result.Users[i])
+	}
+	return users, nextMarker, nil
+}
+
+// CanGitPull returns the subset of the given repository ARNs that the IAM policies of the given
+// principal (e.g. an IAM user) allow to read, as determined by the IAM policy simulator.
+//
+// API docs: https://docs.aws.amazon.com/IAM/latest/APIReference/API_SimulatePrincipalPolicy.html
+func (c *Client) CanGitPull(ctx context.Context, principalARN string, repoARNs []string) (map[string]bool, error) {
+	svc := iam.New(c.aws)
+
+	allowed := make(map[string]bool)
+	for i := 0; i < len(repoARNs); i += maxSimulatedResources {
+		j := i + maxSimulatedResources
+		if j > len(repoARNs) {
+			j = len(repoARNs)
+		}
+
+		input := iam.SimulatePrincipalPolicyInput{
+			ActionNames:     []string{GitPullAction},
+			PolicySourceArn: &principalARN,
+			ResourceArns:    repoARNs[i:j],
+		}
+		for {
+			req := svc.SimulatePrincipalPolicyRequest(&input)
+			req.SetContext(ctx)
+			result, err := req.Send(ctx)
+			if err != nil {
+				return allowed, err
+			}
+
+			for _, r := range result.EvaluationResults {
+				if r.EvalDecision == iam.PolicyEvaluationDecisionTypeAllowed && r.EvalResourceName != nil {
+					allowed[*r.EvalResourceName] = true
+				}
+			}
+
+			if !aws.BoolValue(result.IsTruncated) || result.Marker == nil {
+				break
+			}
+			input.Marker = result.Marker
+		}
+	}
+	return allowed, nil
+}
+
+func fromIAMUser(u *iam.User) *IAMUser {
+	return &IAMUser{
+		ARN:  aws.StringValue(u.Arn),
+		ID:   aws.StringValue(u.UserId),
+		Name: aws.StringValue(u.UserName),
+	}
+}
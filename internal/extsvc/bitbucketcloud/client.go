@@ -103,6 +103,69 @@ func (c *Client) Repos(ctx context.Context, pageToken *PageToken, accountName st
 	return repos, next, err
 }
 
+// WorkspaceMembers returns a list of users who are members of the given workspace, paginated
+// the same way as Repos.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/members
+func (c *Client) WorkspaceMembers(ctx context.Context, pageToken *PageToken, workspace string) ([]*User, *PageToken, error) {
+	var members []*WorkspaceMembership
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &members)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/members", workspace), nil, pageToken, &members)
+	}
+
+	users := make([]*User, 0, len(members))
+	for _, m := range members {
+		if m.User != nil {
+			users = append(users, m.User)
+		}
+	}
+	return users, next, err
+}
+
+// WorkspaceRepoPermissions returns the effective permissions of users to the repositories of the
+// given workspace, paginated the same way as Repos. The permissions include the ones inherited from
+// the workspace, its projects and the groups of the users. If query is not empty, it is used to
+// filter the results, e.g. `user.uuid="{...}"`. The user of the app password must be an
+// administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories
+func (c *Client) WorkspaceRepoPermissions(ctx context.Context, pageToken *PageToken, workspace, query string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		qry := make(url.Values)
+		if query != "" {
+			qry.Set("q", query)
+		}
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace), qry, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
+// RepoPermissions returns the effective permissions of users to the given repository, paginated
+// the same way as Repos. Like WorkspaceRepoPermissions, the user of the app password must be an
+// administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (c *Client) RepoPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, repoSlug), nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
 func (c *Client) page(ctx context.Context, path string, qry url.Values, token *PageToken, results interface{}) (*PageToken, error) {
 	if qry == nil {
 		qry = make(url.Values)
@@ -235,6 +298,28 @@ type Repo struct {
 	Links       Links  `json:"links"`
 }
 
+// User is a Bitbucket Cloud account. Its UUID is stable, whereas its nickname can be changed by
+// the user.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+// WorkspaceMembership is the membership of a user in a workspace.
+type WorkspaceMembership struct {
+	User *User `json:"user"`
+}
+
+// RepoPermission is the effective permission of a user to a repository.
+type RepoPermission struct {
+	// Permission is one of "read", "write" and "admin".
+	Permission string `json:"permission"`
+	User       *User  `json:"user"`
+	Repository *Repo  `json:"repository"`
+}
+
 type Links struct {
 	Clone CloneLinks `json:"clone"`
 	HTML  Link       `json:"html"`
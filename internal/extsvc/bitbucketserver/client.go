@@ -550,6 +550,51 @@ func (c *Client) DeclinePullRequest(ctx context.Context, pr *PullRequest) error
 	return c.send(ctx, "POST", path, qry, nil, pr)
 }
 
+// MergePullRequest merges the given PullRequest, returning an error in case of failure.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	return c.send(ctx, "POST", path, qry, nil, pr)
+}
+
+// CreatePullRequestComment adds a general comment with the given text to
+// the PullRequest.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, text string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	payload := map[string]string{"text": text}
+
+	return c.send(ctx, "POST", path, nil, payload, nil)
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
@@ -649,6 +694,56 @@ func (c *Client) LoadPullRequestBuildStatuses(ctx context.Context, pr *PullReque
 	return nil
 }
 
+// MergeStatus is the result of the merge check of a pull request.
+type MergeStatus struct {
+	CanMerge   bool   `json:"canMerge"`
+	Conflicted bool   `json:"conflicted"`
+	Outcome    string `json:"outcome"`
+	Vetoes     []struct {
+		SummaryMessage  string `json:"summaryMessage"`
+		DetailedMessage string `json:"detailedMessage"`
+	} `json:"vetoes"`
+}
+
+// Merge check outcomes of a MergeStatus.
+const (
+	MergeOutcomeClean      = "CLEAN"
+	MergeOutcomeConflicted = "CONFLICTED"
+	MergeOutcomeUnknown    = "UNKNOWN"
+)
+
+// LoadPullRequestMergeStatus runs the merge check of the given open pull
+// request and sets its MergeStatus.
+func (c *Client) LoadPullRequestMergeStatus(ctx context.Context, pr *PullRequest) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	var status MergeStatus
+	if err := c.do(ctx, req, &status); err != nil {
+		return err
+	}
+
+	pr.MergeStatus = &status
+	return nil
+}
+
 func (c *Client) Repo(ctx context.Context, projectKey, repoSlug string) (*Repo, error) {
 	u := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s", projectKey, repoSlug)
 	req, err := http.NewRequest("GET", u, nil)
@@ -1086,6 +1181,7 @@ type PullRequest struct {
 	Activities   []*Activity     `json:"activities,omitempty"`
 	Commits      []*Commit       `json:"commits,omitempty"`
 	CommitStatus []*CommitStatus `json:"commit_status,omitempty"`
+	MergeStatus  *MergeStatus    `json:"merge_status,omitempty"`
 
 	// Deprecated, use CommitStatus instead. BuildStatus was not tied to individual commits
 	BuildStatuses []*BuildStatus `json:"buildstatuses,omitempty"`
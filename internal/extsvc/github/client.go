@@ -294,6 +294,9 @@ func (c *Client) requestGraphQL(ctx context.Context, query string, vars map[stri
 	// Enable Checks API
 	// https://developer.github.com/v4/previews/#checks
 	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+	// Enable draft pull requests
+	// https://developer.github.com/v4/previews/#draft-pull-requests-preview
+	req.Header.Add("Accept", "application/vnd.github.shadow-cat-preview+json")
 	var respBody struct {
 		Data   json.RawMessage `json:"data"`
 		Errors graphqlErrors   `json:"errors"`
@@ -420,6 +420,24 @@ func TestClient_ClosePullRequest(t *testing.T) {
 	}
 }
 
+func TestPickMergeMethod(t *testing.T) {
+	for _, tc := range []struct {
+		mergeCommit, squash, rebase bool
+		want                        MergeMethod
+		wantErr                     error
+	}{
+		{mergeCommit: true, squash: true, rebase: true, want: MergeMethodMerge},
+		{squash: true, rebase: true, want: MergeMethodSquash},
+		{rebase: true, want: MergeMethodRebase},
+		{wantErr: ErrNoMergeMethodAllowed},
+	} {
+		have, err := pickMergeMethod(tc.mergeCommit, tc.squash, tc.rebase)
+		if have != tc.want || err != tc.wantErr {
+			t.Errorf("pickMergeMethod(%t, %t, %t): have (%q, %v), want (%q, %v)", tc.mergeCommit, tc.squash, tc.rebase, have, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
 func TestClient_GetAuthenticatedUserOrgs(t *testing.T) {
 	cli, save := newClient(t, "GetAuthenticatedUserOrgs")
 	defer save()
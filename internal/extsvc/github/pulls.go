@@ -137,6 +137,8 @@ type PullRequest struct {
 	HeadRefName   string
 	BaseRefName   string
 	Number        int64
+	IsDraft       bool
+	Mergeable     string
 	Author        Actor
 	Participants  []Actor
 	Labels        struct{ Nodes []Label }
@@ -464,6 +466,8 @@ type CreatePullRequestInput struct {
 	Title string `json:"title"`
 	// The body of the pull request (optional).
 	Body string `json:"body"`
+	// Whether the pull request is created as a draft.
+	Draft bool `json:"draft"`
 }
 
 // CreatePullRequest creates a PullRequest on Github.
@@ -595,6 +599,176 @@ func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// MarkPullRequestReadyForReview marks the draft PullRequest on Github as
+// ready for review.
+func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, pr *PullRequest) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MarkPullRequestReadyForReview($input:MarkPullRequestReadyForReviewInput!) {
+  markPullRequestReadyForReview(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"markPullRequestReadyForReview"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MarkPullRequestReadyForReview.PullRequest.PullRequest
+	pr.TimelineItems = result.MarkPullRequestReadyForReview.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MarkPullRequestReadyForReview.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// CreatePullRequestComment adds a comment with the given body to the
+// PullRequest on GitHub.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, body string) error {
+	q := `mutation CreatePullRequestComment($input:AddCommentInput!) {
+  addComment(input:$input) {
+    subject { id }
+  }
+}`
+
+	var result struct {
+		AddComment struct {
+			Subject struct {
+				ID string
+			} `json:"subject"`
+		} `json:"addComment"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		SubjectID string `json:"subjectId"`
+		Body      string `json:"body"`
+	}{SubjectID: pr.ID, Body: body}}
+
+	return c.requestGraphQL(ctx, q, input, &result)
+}
+
+// MergeMethod is a method of merging a pull request on GitHub.
+type MergeMethod string
+
+// Merge methods of the mergePullRequest mutation.
+const (
+	MergeMethodMerge  MergeMethod = "MERGE"
+	MergeMethodSquash MergeMethod = "SQUASH"
+	MergeMethodRebase MergeMethod = "REBASE"
+)
+
+// ErrNoMergeMethodAllowed is returned by MergePullRequest if the repository
+// of the pull request allows none of the merge methods.
+var ErrNoMergeMethodAllowed = errors.New("repository allows no merge method")
+
+// pickMergeMethod returns the first merge method the repository allows, in
+// the order merge commit, squash, rebase.
+func pickMergeMethod(mergeCommitAllowed, squashMergeAllowed, rebaseMergeAllowed bool) (MergeMethod, error) {
+	switch {
+	case mergeCommitAllowed:
+		return MergeMethodMerge, nil
+	case squashMergeAllowed:
+		return MergeMethodSquash, nil
+	case rebaseMergeAllowed:
+		return MergeMethodRebase, nil
+	}
+	return "", ErrNoMergeMethodAllowed
+}
+
+// pullRequestMergeMethod returns the merge method to merge the PullRequest
+// with, based on the merge methods its repository allows.
+func (c *Client) pullRequestMergeMethod(ctx context.Context, pr *PullRequest) (MergeMethod, error) {
+	q := `query PullRequestMergeMethods($id:ID!) {
+  node(id:$id) {
+    ... on PullRequest {
+      repository {
+        mergeCommitAllowed
+        squashMergeAllowed
+        rebaseMergeAllowed
+      }
+    }
+  }
+}`
+
+	var result struct {
+		Node struct {
+			Repository struct {
+				MergeCommitAllowed bool
+				SquashMergeAllowed bool
+				RebaseMergeAllowed bool
+			}
+		}
+	}
+	if err := c.requestGraphQL(ctx, q, map[string]interface{}{"id": pr.ID}, &result); err != nil {
+		return "", err
+	}
+
+	r := result.Node.Repository
+	return pickMergeMethod(r.MergeCommitAllowed, r.SquashMergeAllowed, r.RebaseMergeAllowed)
+}
+
+// MergePullRequest merges the PullRequest on GitHub. The mergePullRequest
+// mutation always creates a merge commit unless told otherwise, which fails
+// in repositories that only allow squash or rebase merges, so the first merge
+// method that the repository allows is used, in the order merge commit,
+// squash, rebase.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	method, err := c.pullRequestMergeMethod(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MergePullRequest($input:MergePullRequestInput!) {
+  mergePullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MergePullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"mergePullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID          string      `json:"pullRequestId"`
+		MergeMethod MergeMethod `json:"mergeMethod"`
+	}{ID: pr.ID, MergeMethod: method}}
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MergePullRequest.PullRequest.PullRequest
+	pr.TimelineItems = result.MergePullRequest.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+
+	return nil
+}
+
 // LoadPullRequests loads a list of PullRequests from Github.
 func (c *Client) LoadPullRequests(ctx context.Context, prs ...*PullRequest) error {
 	const batchSize = 15
@@ -813,6 +987,8 @@ fragment pr on PullRequest {
   state
   url
   number
+  isDraft
+  mergeable
   createdAt
   updatedAt
   headRefOid
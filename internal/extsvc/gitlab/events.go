@@ -0,0 +1,249 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	eventTypeHeader = "X-Gitlab-Event"
+)
+
+// ErrUnknownWebhookEvent is returned by ParseWebhookEvent for event types that
+// are not supported.
+var ErrUnknownWebhookEvent = errors.New("unknown webhook event type")
+
+// WebhookEventType returns the type of the GitLab webhook event delivered in
+// the request, such as "Merge Request Hook".
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+// ParseWebhookEvent parses the payload of a GitLab webhook event of the given
+// type. It returns a *MergeRequestEvent, *NoteEvent or *PipelineEvent.
+func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err error) {
+	switch eventType {
+	case "Merge Request Hook":
+		e = &MergeRequestEvent{}
+		return e, json.Unmarshal(payload, e)
+	case "Note Hook":
+		e = &NoteEvent{}
+		return e, json.Unmarshal(payload, e)
+	case "Pipeline Hook":
+		e = &PipelineEvent{}
+		return e, json.Unmarshal(payload, e)
+	default:
+		return nil, errors.Wrapf(ErrUnknownWebhookEvent, "%q", eventType)
+	}
+}
+
+// WebhookProject is the project a webhook event was delivered for.
+type WebhookProject struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+// WebhookMergeRequest is the subset of a merge request included in webhook
+// events.
+type WebhookMergeRequest struct {
+	ID              ID                `json:"id"`
+	IID             ID                `json:"iid"`
+	TargetProjectID int               `json:"target_project_id"`
+	State           MergeRequestState `json:"state"`
+}
+
+// MergeRequestAction is the action that triggered a merge request webhook
+// event.
+type MergeRequestAction string
+
+const (
+	MergeRequestActionOpen       MergeRequestAction = "open"
+	MergeRequestActionUpdate     MergeRequestAction = "update"
+	MergeRequestActionClose      MergeRequestAction = "close"
+	MergeRequestActionReopen     MergeRequestAction = "reopen"
+	MergeRequestActionMerge      MergeRequestAction = "merge"
+	MergeRequestActionApproved   MergeRequestAction = "approved"
+	MergeRequestActionUnapproved MergeRequestAction = "unapproved"
+
+	// GitLab 13.2+ also delivers these actions for approvals that don't
+	// complete (or revoke) the approval requirements of a merge request.
+	MergeRequestActionApproval   MergeRequestAction = "approval"
+	MergeRequestActionUnapproval MergeRequestAction = "unapproval"
+)
+
+// MergeRequestEvent is delivered with the "Merge Request Hook" event type
+// when a merge request is opened, updated, closed, reopened, merged, approved
+// or unapproved.
+type MergeRequestEvent struct {
+	User             User           `json:"user"`
+	Project          WebhookProject `json:"project"`
+	ObjectAttributes struct {
+		WebhookMergeRequest
+		Action    MergeRequestAction `json:"action"`
+		UpdatedAt WebhookTime        `json:"updated_at"`
+	} `json:"object_attributes"`
+}
+
+// NoteEvent is delivered with the "Note Hook" event type when a comment is
+// made on a commit, merge request, issue or snippet.
+type NoteEvent struct {
+	User             User           `json:"user"`
+	Project          WebhookProject `json:"project"`
+	ObjectAttributes struct {
+		ID           ID          `json:"id"`
+		Note         string      `json:"note"`
+		NoteableType string      `json:"noteable_type"`
+		System       bool        `json:"system"`
+		CreatedAt    WebhookTime `json:"created_at"`
+	} `json:"object_attributes"`
+
+	// MergeRequest is only set if the note was made on a merge request.
+	MergeRequest *WebhookMergeRequest `json:"merge_request"`
+}
+
+// ToNote returns the note of the event, with the user of the event as its
+// author.
+func (e *NoteEvent) ToNote() *Note {
+	return &Note{
+		ID:        e.ObjectAttributes.ID,
+		Body:      e.ObjectAttributes.Note,
+		Author:    e.User,
+		CreatedAt: e.ObjectAttributes.CreatedAt.Time,
+		System:    e.ObjectAttributes.System,
+	}
+}
+
+// PipelineEvent is delivered with the "Pipeline Hook" event type when the
+// status of a pipeline changes.
+type PipelineEvent struct {
+	User             User           `json:"user"`
+	Project          WebhookProject `json:"project"`
+	ObjectAttributes struct {
+		ID         ID             `json:"id"`
+		Ref        string         `json:"ref"`
+		SHA        string         `json:"sha"`
+		Status     PipelineStatus `json:"status"`
+		CreatedAt  WebhookTime    `json:"created_at"`
+		FinishedAt WebhookTime    `json:"finished_at"`
+	} `json:"object_attributes"`
+
+	// MergeRequest is only set if the pipeline was run for a merge request.
+	MergeRequest *WebhookMergeRequest `json:"merge_request"`
+}
+
+// ToPipeline returns the pipeline of the event. Since webhook events don't
+// include the time the pipeline was last updated, it is set to receivedAt.
+func (e *PipelineEvent) ToPipeline(receivedAt time.Time) *Pipeline {
+	return &Pipeline{
+		ID:        e.ObjectAttributes.ID,
+		SHA:       e.ObjectAttributes.SHA,
+		Ref:       e.ObjectAttributes.Ref,
+		Status:    e.ObjectAttributes.Status,
+		CreatedAt: e.ObjectAttributes.CreatedAt.Time,
+		UpdatedAt: receivedAt,
+	}
+}
+
+// WebhookTime is a timestamp in a webhook payload. Depending on the event type
+// and version, GitLab formats them either as RFC 3339 timestamps or as
+// "2006-01-02 15:04:05 UTC".
+type WebhookTime struct{ time.Time }
+
+var webhookTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+func (t *WebhookTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" || s == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var err error
+	for _, layout := range webhookTimeLayouts {
+		var parsed time.Time
+		if parsed, err = time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			t.Time = parsed.UTC()
+			return nil
+		}
+	}
+	return err
+}
+
+// MergeRequestStateEvent is a change of the state of a merge request by a
+// user. These are only delivered by webhooks, since the REST API of our
+// minimum GitLab version has no equivalent to the events of other code hosts.
+type MergeRequestStateEvent struct {
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MergeRequestClosedEvent struct{ MergeRequestStateEvent }
+type MergeRequestReopenedEvent struct{ MergeRequestStateEvent }
+type MergeRequestMergedEvent struct{ MergeRequestStateEvent }
+
+func (e *MergeRequestClosedEvent) Key() string {
+	return fmt.Sprintf("Closed:%d:%d", e.User.ID, e.CreatedAt.Unix())
+}
+
+func (e *MergeRequestReopenedEvent) Key() string {
+	return fmt.Sprintf("Reopened:%d:%d", e.User.ID, e.CreatedAt.Unix())
+}
+
+func (e *MergeRequestMergedEvent) Key() string {
+	return fmt.Sprintf("Merged:%d:%d", e.User.ID, e.CreatedAt.Unix())
+}
+
+// ToStateEvent returns the MergeRequestClosedEvent, MergeRequestReopenedEvent
+// or MergeRequestMergedEvent of the event, or nil if it didn't change the
+// state of the merge request.
+func (e *MergeRequestEvent) ToStateEvent() interface{} {
+	state := MergeRequestStateEvent{
+		User:      e.User,
+		CreatedAt: e.ObjectAttributes.UpdatedAt.Time,
+	}
+	switch e.ObjectAttributes.Action {
+	case MergeRequestActionClose:
+		return &MergeRequestClosedEvent{state}
+	case MergeRequestActionReopen:
+		return &MergeRequestReopenedEvent{state}
+	case MergeRequestActionMerge:
+		return &MergeRequestMergedEvent{state}
+	}
+	return nil
+}
+
+// ToReview returns a pointer to a ReviewApproved or ReviewUnapproved struct
+// for approval events, or nil for all other events.
+//
+// Approval webhooks don't include the system note GitLab creates for the
+// approval, so the review is built from a note without an ID, and is keyed by
+// its author and time instead.
+func (e *MergeRequestEvent) ToReview() interface{} {
+	note := &Note{
+		Author:    e.User,
+		CreatedAt: e.ObjectAttributes.UpdatedAt.Time,
+		System:    true,
+	}
+	switch e.ObjectAttributes.Action {
+	case MergeRequestActionApproved, MergeRequestActionApproval:
+		note.Body = "approved this merge request"
+	case MergeRequestActionUnapproved, MergeRequestActionUnapproval:
+		note.Body = "unapproved this merge request"
+	default:
+		return nil
+	}
+	return note.ToReview()
+}
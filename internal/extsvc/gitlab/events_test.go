@@ -0,0 +1,109 @@
+package gitlab
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("merge request", func(t *testing.T) {
+		e, err := ParseWebhookEvent("Merge Request Hook", []byte(`{
+			"object_kind": "merge_request",
+			"user": {"id": 1, "name": "Administrator", "username": "root"},
+			"project": {"id": 14, "path_with_namespace": "sourcegraph/sourcegraph"},
+			"object_attributes": {
+				"id": 99,
+				"iid": 3,
+				"target_project_id": 14,
+				"state": "merged",
+				"action": "merge",
+				"updated_at": "2020-08-05 11:05:38 UTC"
+			}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mr, ok := e.(*MergeRequestEvent)
+		if !ok {
+			t.Fatalf("unexpected event type %T", e)
+		}
+
+		want := &MergeRequestMergedEvent{MergeRequestStateEvent{
+			User:      User{ID: 1, Name: "Administrator", Username: "root"},
+			CreatedAt: time.Date(2020, 8, 5, 11, 5, 38, 0, time.UTC),
+		}}
+		if diff := cmp.Diff(mr.ToStateEvent(), want); diff != "" {
+			t.Errorf("unexpected state event: %s", diff)
+		}
+		if have := mr.ToReview(); have != nil {
+			t.Errorf("unexpected review: %+v", have)
+		}
+		if have, want := want.Key(), "Merged:1:1596625538"; have != want {
+			t.Errorf("unexpected key: have %q, want %q", have, want)
+		}
+	})
+
+	t.Run("approval", func(t *testing.T) {
+		e, err := ParseWebhookEvent("Merge Request Hook", []byte(`{
+			"user": {"id": 2, "username": "alice"},
+			"object_attributes": {"iid": 3, "action": "approved", "updated_at": "2020-08-05T11:05:38Z"}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		review, ok := e.(*MergeRequestEvent).ToReview().(*ReviewApproved)
+		if !ok {
+			t.Fatalf("unexpected review type %T", review)
+		}
+		if have, want := review.Key(), "Note:2:1596625538"; have != want {
+			t.Errorf("unexpected key: have %q, want %q", have, want)
+		}
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		e, err := ParseWebhookEvent("Pipeline Hook", []byte(`{
+			"object_attributes": {
+				"id": 31,
+				"ref": "campaign-branch",
+				"sha": "bcbb5ec396a2c0f828686f14fac9b80b780504f2",
+				"status": "failed",
+				"created_at": "2020-08-05 11:05:38 UTC",
+				"finished_at": null
+			},
+			"merge_request": {"id": 99, "iid": 3, "target_project_id": 14, "state": "opened"}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pe := e.(*PipelineEvent)
+		if diff := cmp.Diff(pe.MergeRequest, &WebhookMergeRequest{ID: 99, IID: 3, TargetProjectID: 14, State: MergeRequestStateOpened}); diff != "" {
+			t.Errorf("unexpected merge request: %s", diff)
+		}
+
+		receivedAt := time.Date(2020, 8, 5, 12, 0, 0, 0, time.UTC)
+		want := &Pipeline{
+			ID:        31,
+			SHA:       "bcbb5ec396a2c0f828686f14fac9b80b780504f2",
+			Ref:       "campaign-branch",
+			Status:    PipelineStatusFailed,
+			CreatedAt: time.Date(2020, 8, 5, 11, 5, 38, 0, time.UTC),
+			UpdatedAt: receivedAt,
+		}
+		if diff := cmp.Diff(pe.ToPipeline(receivedAt), want); diff != "" {
+			t.Errorf("unexpected pipeline: %s", diff)
+		}
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		_, err := ParseWebhookEvent("Push Hook", []byte(`{}`))
+		if errors.Cause(err) != ErrUnknownWebhookEvent {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,21 +25,23 @@ const (
 )
 
 type MergeRequest struct {
-	ID           ID                `json:"id"`
-	IID          ID                `json:"iid"`
-	ProjectID    ID                `json:"project_id"`
-	Title        string            `json:"title"`
-	Description  string            `json:"description"`
-	State        MergeRequestState `json:"state"`
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    time.Time         `json:"updated_at"`
-	MergedAt     *time.Time        `json:"merged_at"`
-	ClosedAt     *time.Time        `json:"closed_at"`
-	HeadPipeline *Pipeline         `json:"head_pipeline"`
-	Labels       []string          `json:"labels"`
-	SourceBranch string            `json:"source_branch"`
-	TargetBranch string            `json:"target_branch"`
-	WebURL       string            `json:"web_url"`
+	ID             ID                `json:"id"`
+	IID            ID                `json:"iid"`
+	ProjectID      ID                `json:"project_id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	State          MergeRequestState `json:"state"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	MergedAt       *time.Time        `json:"merged_at"`
+	ClosedAt       *time.Time        `json:"closed_at"`
+	HeadPipeline   *Pipeline         `json:"head_pipeline"`
+	Labels         []string          `json:"labels"`
+	SourceBranch   string            `json:"source_branch"`
+	TargetBranch   string            `json:"target_branch"`
+	WebURL         string            `json:"web_url"`
+	WorkInProgress bool              `json:"work_in_progress"`
+	MergeStatus    MergeStatus       `json:"merge_status"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -50,6 +53,17 @@ type MergeRequest struct {
 	Pipelines []*Pipeline
 }
 
+// MergeStatus is whether a merge request can be merged into its target
+// branch, as computed by GitLab.
+type MergeStatus string
+
+const (
+	MergeStatusUnchecked      MergeStatus = "unchecked"
+	MergeStatusChecking       MergeStatus = "checking"
+	MergeStatusCanBeMerged    MergeStatus = "can_be_merged"
+	MergeStatusCannotBeMerged MergeStatus = "cannot_be_merged"
+)
+
 type DiffRefs struct {
 	BaseSHA  string `json:"base_sha"`
 	HeadSHA  string `json:"head_sha"`
@@ -60,6 +74,7 @@ var (
 	ErrMergeRequestAlreadyExists = errors.New("merge request already exists")
 	ErrMergeRequestNotFound      = errors.New("merge request not found")
 	ErrTooManyMergeRequests      = errors.New("retrieved too many merge requests")
+	ErrNotMergeable              = errors.New("merge request cannot be merged")
 )
 
 type CreateMergeRequestOpts struct {
@@ -202,3 +217,53 @@ func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *M
 
 	return resp, nil
 }
+
+// WIPTitlePrefix is the prefix of the title of a merge request that marks it
+// as a work in progress, which GitLab won't allow to be merged.
+const WIPTitlePrefix = "WIP: "
+
+// SetWIP returns the title with the WIPTitlePrefix.
+func SetWIP(title string) string {
+	if IsWIP(title) {
+		return title
+	}
+	return WIPTitlePrefix + title
+}
+
+// UnsetWIP returns the title without the WIPTitlePrefix.
+func UnsetWIP(title string) string {
+	return strings.TrimPrefix(title, WIPTitlePrefix)
+}
+
+// IsWIP returns whether the title has the WIPTitlePrefix.
+func IsWIP(title string) bool {
+	return strings.HasPrefix(title, WIPTitlePrefix)
+}
+
+// MergeMergeRequest merges the merge request on GitLab. ErrNotMergeable is
+// returned if GitLab refuses to merge it in its current state.
+func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error) {
+	if MockMergeMergeRequest != nil {
+		return MockMergeMergeRequest(c, ctx, project, mr)
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/merge", project.ID, mr.IID), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to merge a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, code, err := c.do(ctx, req, resp); err != nil {
+		// GitLab responds with 405 Method Not Allowed if the merge request
+		// can't be merged, e.g. because it's a work in progress or has
+		// unresolved discussions, and with 406 Not Acceptable if it has
+		// conflicts.
+		if code == http.StatusMethodNotAllowed || code == http.StatusNotAcceptable {
+			return nil, ErrNotMergeable
+		}
+
+		return nil, errors.Wrap(err, "sending request to merge a merge request")
+	}
+
+	return resp, nil
+}
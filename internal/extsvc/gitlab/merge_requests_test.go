@@ -339,3 +339,74 @@ func TestUpdateMergeRequest(t *testing.T) {
 	})
 
 }
+
+func TestMergeMergeRequest(t *testing.T) {
+	ctx := context.Background()
+	empty := &MergeRequest{}
+	project := &Project{}
+
+	t.Run("not mergeable", func(t *testing.T) {
+		for _, code := range []int{http.StatusMethodNotAllowed, http.StatusNotAcceptable} {
+			client := newTestClient(t)
+			client.httpClient = &mockHTTPEmptyResponse{code}
+
+			mr, err := client.MergeMergeRequest(ctx, project, empty)
+			if mr != nil {
+				t.Errorf("unexpected non-nil merge request: %+v", mr)
+			}
+			if err != ErrNotMergeable {
+				t.Errorf("unexpected error: have=%v want=%v", err, ErrNotMergeable)
+			}
+		}
+	})
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		mr, err := client.MergeMergeRequest(ctx, project, empty)
+		if mr != nil {
+			t.Errorf("unexpected non-nil merge request: %+v", mr)
+		}
+		if err == nil || err == ErrNotMergeable {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"state":"merged"}`,
+		}
+
+		mr, err := client.MergeMergeRequest(ctx, project, empty)
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(mr, &MergeRequest{IID: 42, State: MergeRequestStateMerged}); diff != "" {
+			t.Errorf("unexpected merge request: %s", diff)
+		}
+	})
+}
+
+func TestWIP(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		set   string
+		unset string
+		isWIP bool
+	}{
+		{title: "Add a feature", set: "WIP: Add a feature", unset: "Add a feature", isWIP: false},
+		{title: "WIP: Add a feature", set: "WIP: Add a feature", unset: "Add a feature", isWIP: true},
+	} {
+		if have, want := SetWIP(tc.title), tc.set; have != want {
+			t.Errorf("SetWIP(%q): have=%q want=%q", tc.title, have, want)
+		}
+		if have, want := UnsetWIP(tc.title), tc.unset; have != want {
+			t.Errorf("UnsetWIP(%q): have=%q want=%q", tc.title, have, want)
+		}
+		if have, want := IsWIP(tc.title), tc.isWIP; have != want {
+			t.Errorf("IsWIP(%q): have=%t want=%t", tc.title, have, want)
+		}
+	}
+}
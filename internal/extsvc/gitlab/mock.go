@@ -29,6 +29,10 @@ var MockGetMergeRequest func(c *Client, ctx context.Context, project *Project, i
 // Client.GetMergeRequestNotes
 var MockGetMergeRequestNotes func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*Note, error)
 
+// MockCreateMergeRequestNote, if non-nil, will be called instead of
+// Client.CreateMergeRequestNote
+var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) (*Note, error)
+
 // MockGetMergeRequestPipelines, if non-nil, will be called instead of
 // Client.GetMergeRequestPipelines
 var MockGetMergeRequestPipelines func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*Pipeline, error)
@@ -40,3 +44,7 @@ var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *
 // MockUpdateMergeRequest, if non-nil, will be called instead of
 // Client.UpdateMergeRequest
 var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error)
+
+// MockMergeMergeRequest, if non-nil, will be called instead of
+// Client.MergeMergeRequest
+var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error)
@@ -1,7 +1,9 @@
 package gitlab
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -47,6 +49,31 @@ func (c *Client) GetMergeRequestNotes(ctx context.Context, project *Project, iid
 	}
 }
 
+// CreateMergeRequestNote adds a note with the given body to the merge
+// request.
+func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, mr *MergeRequest, body string) (*Note, error) {
+	if MockCreateMergeRequestNote != nil {
+		return MockCreateMergeRequestNote(c, ctx, project, mr, body)
+	}
+
+	data, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling note")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/merge_requests/%d/notes", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create a note")
+	}
+
+	note := &Note{}
+	if _, _, err := c.do(ctx, req, note); err != nil {
+		return nil, errors.Wrap(err, "sending request to create a note")
+	}
+
+	return note, nil
+}
+
 type Note struct {
 	ID        ID        `json:"id"`
 	Body      string    `json:"body"`
@@ -56,6 +83,10 @@ type Note struct {
 }
 
 func (n *Note) Key() string {
+	// Notes built from approval webhook events don't have an ID.
+	if n.ID == 0 {
+		return fmt.Sprintf("Note:%d:%d", n.Author.ID, n.CreatedAt.Unix())
+	}
 	return fmt.Sprintf("Note:%d", n.ID)
 }
 
@@ -141,6 +141,40 @@ func TestGetMergeRequestNotes(t *testing.T) {
 	})
 }
 
+func TestCreateMergeRequestNote(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+	mr := &MergeRequest{IID: 42}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		note, err := client.CreateMergeRequestNote(ctx, project, mr, "ping")
+		if note != nil {
+			t.Errorf("unexpected non-nil note: %+v", note)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id":1,"body":"ping"}`,
+		}
+
+		note, err := client.CreateMergeRequestNote(ctx, project, mr, "ping")
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(note, &Note{ID: 1, Body: "ping"}); diff != "" {
+			t.Errorf("unexpected note: %s", diff)
+		}
+	})
+}
+
 func TestNoteKey(t *testing.T) {
 	note := &Note{ID: 42}
 	if have, want := note.Key(), "Note:42"; have != want {
@@ -215,6 +215,8 @@ func WebhookURL(kind string, externalServiceID int64, externalURL string) string
 	switch strings.ToUpper(kind) {
 	case KindGitHub:
 		path = "github-webhooks"
+	case KindGitLab:
+		path = "gitlab-webhooks"
 	case KindBitbucketServer:
 		path = "bitbucket-server-webhooks"
 	default:
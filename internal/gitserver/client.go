@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +23,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/neelance/parallel"
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
@@ -60,8 +62,10 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func(ctx context.Context) []string {
 			return conf.Get().ServiceConnections.GitServers
 		},
-		HTTPClient:  cli,
-		HTTPLimiter: parallel.NewRun(500),
+		ReplicationFactor: conf.GitServerReplicationFactor,
+		ConsistentHashing: conf.GitServerConsistentHashing,
+		HTTPClient:        cli,
+		HTTPLimiter:       parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -82,6 +86,17 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func(ctx context.Context) []string
 
+	// ReplicationFactor is a function which should return the number of
+	// gitservers each repository is cloned on. If nil, each repository is
+	// cloned on a single gitserver.
+	ReplicationFactor func() int
+
+	// ConsistentHashing is a function which should return true if
+	// repositories are assigned to gitservers with rendezvous hashing rather
+	// than by the hash of the repository name modulo the number of
+	// gitservers. If nil, rendezvous hashing is not used.
+	ConsistentHashing func() bool
+
 	// UserAgent is a string identifing who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
@@ -100,13 +115,92 @@ func (c *Client) addrForKey(ctx context.Context, key string) string {
 	if len(addrs) == 0 {
 		panic("unexpected state: no gitserver addresses")
 	}
-	return addrForKey(addrs, key)
+	return c.hashAddrs(addrs, key, 1)[0]
 }
 
-func addrForKey(addrs []string, key string) string {
+// AddrsForRepo returns the addresses of the gitservers the given repo is
+// cloned on. The first address is the repo's primary gitserver, which is the
+// same as AddrForRepo. Requests fail over to the remaining replicas in order if
+// the primary is unreachable.
+func (c *Client) AddrsForRepo(ctx context.Context, repo api.RepoName) []string {
+	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
+	addrs := c.Addrs(ctx)
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return c.hashAddrs(addrs, string(repo), c.replicationFactor())
+}
+
+// hashAddrs returns the n addresses to use for the given string key, using
+// rendezvous hashing if enabled.
+func (c *Client) hashAddrs(addrs []string, key string, n int) []string {
+	if c.ConsistentHashing != nil && c.ConsistentHashing() {
+		return rendezvousAddrsForKey(addrs, key, n)
+	}
+	return addrsForKey(addrs, key, n)
+}
+
+func (c *Client) replicationFactor() int {
+	if c.ReplicationFactor == nil {
+		return 1
+	}
+	return c.ReplicationFactor()
+}
+
+// addrsForKey returns the n addresses to use for the given string key. The
+// first address is chosen by the hash of key modulo the number of addresses,
+// followed by the addresses after it in addrs.
+func addrsForKey(addrs []string, key string, n int) []string {
+	n = clampReplicas(n, len(addrs))
+
 	sum := md5.Sum([]byte(key))
-	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+	idx := int(binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs)))
+	replicas := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		replicas = append(replicas, addrs[(idx+i)%len(addrs)])
+	}
+	return replicas
+}
+
+// rendezvousAddrsForKey returns the n addresses to use for the given string
+// key using rendezvous (highest random weight) hashing: every address is
+// weighted by the hash of the address and key, and the addresses with the
+// highest weights are returned. Unlike addrsForKey, adding or removing an
+// address only moves the keys assigned to that address.
+func rendezvousAddrsForKey(addrs []string, key string, n int) []string {
+	n = clampReplicas(n, len(addrs))
+
+	type weightedAddr struct {
+		addr   string
+		weight uint64
+	}
+	weighted := make([]weightedAddr, 0, len(addrs))
+	for _, addr := range addrs {
+		sum := md5.Sum([]byte(addr + "\x00" + key))
+		weighted = append(weighted, weightedAddr{addr: addr, weight: binary.BigEndian.Uint64(sum[:])})
+	}
+	sort.Slice(weighted, func(i, j int) bool {
+		if weighted[i].weight != weighted[j].weight {
+			return weighted[i].weight > weighted[j].weight
+		}
+		return weighted[i].addr < weighted[j].addr
+	})
+
+	replicas := make([]string, 0, n)
+	for _, w := range weighted[:n] {
+		replicas = append(replicas, w.addr)
+	}
+	return replicas
+}
+
+func clampReplicas(n, numAddrs int) int {
+	if n > numAddrs {
+		n = numAddrs
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
 }
 
 // ArchiveOptions contains options for the Archive func.
@@ -181,8 +275,10 @@ func (c *Client) Archive(ctx context.Context, repo Repo, opt ArchiveOptions) (_
 		return nil, err
 	}
 
+	// Use a relative op rather than the absolute ArchiveURL so that the
+	// request can fail over to a replica.
 	u := c.ArchiveURL(ctx, repo, opt)
-	resp, err := c.do(ctx, repo.Name, "GET", u.String(), nil)
+	resp, err := c.do(ctx, repo.Name, "GET", "archive?"+u.RawQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -278,8 +374,14 @@ var deadlineExceededCounter = prometheus.NewCounter(prometheus.CounterOpts{
 	Help: "Times that Client.sendExec() returned context.DeadlineExceeded",
 })
 
+var failoverCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_client_failover",
+	Help: "Times that a request to a gitserver failed over to a replica",
+})
+
 func init() {
 	prometheus.MustRegister(deadlineExceededCounter)
+	prometheus.MustRegister(failoverCounter)
 }
 
 // Cmd represents a command to be executed remotely.
@@ -495,7 +597,7 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 			if len(r) > 0 {
 				filtered := r[:0]
 				for _, repo := range r {
-					if addrForKey(addrs, repo) == addr {
+					if c.hashAddrs(addrs, repo, 1)[0] == addr {
 						filtered = append(filtered, repo)
 					}
 				}
@@ -559,13 +661,43 @@ func (c *Client) doListOne(ctx context.Context, urlSuffix, addr string) ([]strin
 // Repo updates are not guaranteed to occur. If a repo has been updated
 // recently (within the Since duration specified in the request), the
 // update won't happen.
+//
+// If the repo is replicated, the update is requested on every replica and the
+// response of the first replica that succeeded is returned.
 func (c *Client) RequestRepoUpdate(ctx context.Context, repo Repo, since time.Duration) (*protocol.RepoUpdateResponse, error) {
 	req := &protocol.RepoUpdateRequest{
 		Repo:  repo.Name,
 		URL:   repo.URL,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo.Name, "repo-update", req)
+
+	addrs := c.AddrsForRepo(ctx, repo.Name)
+	if len(addrs) == 1 {
+		return c.requestRepoUpdate(ctx, repo.Name, "repo-update", req)
+	}
+
+	infos := make([]*protocol.RepoUpdateResponse, len(addrs))
+	errs := make([]error, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			infos[i], errs[i] = c.requestRepoUpdate(ctx, repo.Name, "http://"+addr+"/repo-update", req)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			return infos[i], nil
+		}
+	}
+	return nil, errs[0]
+}
+
+func (c *Client) requestRepoUpdate(ctx context.Context, repo api.RepoName, op string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.httpPost(ctx, repo, op, req)
 	if err != nil {
 		return nil, err
 	}
@@ -657,17 +789,62 @@ func (c *Client) IsRepoCloned(ctx context.Context, repo api.RepoName) (bool, err
 	return cloned, nil
 }
 
+// ClonedAddrs returns the addresses of the gitservers that have repo cloned,
+// regardless of which gitservers the repo belongs on. Every gitserver is
+// queried, so this should only be used to locate a repo that moved between
+// gitservers. Gitservers that could not be queried are omitted from the
+// result, and their errors are returned as a *multierror.Error.
+func (c *Client) ClonedAddrs(ctx context.Context, repo api.RepoName) ([]string, error) {
+	repo = protocol.NormalizeRepo(repo)
+	addrs := c.Addrs(ctx)
+
+	type result struct {
+		addr   string
+		cloned bool
+		err    error
+	}
+	ch := make(chan result, len(addrs))
+	for _, addr := range addrs {
+		go func(addr string) {
+			resp, err := c.httpPost(ctx, repo, "http://"+addr+"/is-repo-cloned", &protocol.IsRepoClonedRequest{Repo: repo})
+			if err != nil {
+				ch <- result{addr: addr, err: err}
+				return
+			}
+			resp.Body.Close()
+			ch <- result{addr: addr, cloned: resp.StatusCode == http.StatusOK}
+		}(addr)
+	}
+
+	var cloned []string
+	err := new(multierror.Error)
+	for range addrs {
+		r := <-ch
+		if r.err != nil {
+			err = multierror.Append(err, r.err)
+			continue
+		}
+		if r.cloned {
+			cloned = append(cloned, r.addr)
+		}
+	}
+	sort.Strings(cloned)
+	return cloned, err.ErrorOrNil()
+}
+
 func (c *Client) RepoCloneProgress(ctx context.Context, repos ...api.RepoName) (*protocol.RepoCloneProgressResponse, error) {
 	numPossibleShards := len(c.Addrs(ctx))
 	shards := make(map[string]*protocol.RepoCloneProgressRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
 
 	for _, r := range repos {
-		addr := c.AddrForRepo(ctx, r)
-		shard := shards[addr]
+		// Shard by the full list of replicas, so that every repo in a shard
+		// fails over to the same gitserver.
+		addrs := strings.Join(c.AddrsForRepo(ctx, r), ",")
+		shard := shards[addrs]
 
 		if shard == nil {
 			shard = new(protocol.RepoCloneProgressRequest)
-			shards[addr] = shard
+			shards[addrs] = shard
 		}
 
 		shard.Repos = append(shard.Repos, r)
@@ -739,12 +916,14 @@ func (c *Client) RepoInfo(ctx context.Context, repos ...api.RepoName) (*protocol
 	shards := make(map[string]*protocol.RepoInfoRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
 
 	for _, r := range repos {
-		addr := c.AddrForRepo(ctx, r)
-		shard := shards[addr]
+		// Shard by the full list of replicas, so that every repo in a shard
+		// fails over to the same gitserver.
+		addrs := strings.Join(c.AddrsForRepo(ctx, r), ",")
+		shard := shards[addrs]
 
 		if shard == nil {
 			shard = new(protocol.RepoInfoRequest)
-			shards[addr] = shard
+			shards[addrs] = shard
 		}
 
 		shard.Repos = append(shard.Repos, r)
@@ -804,12 +983,35 @@ func (c *Client) RepoInfo(ctx context.Context, repos ...api.RepoName) (*protocol
 	return &res, err.ErrorOrNil()
 }
 
-// Remove removes the repository clone from gitserver.
+// Remove removes the repository clone from gitserver. If the repository is
+// replicated, it is removed from every replica.
 func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
+	addrs := c.AddrsForRepo(ctx, repo)
+	if len(addrs) == 1 {
+		return c.remove(ctx, repo, "delete")
+	}
+
+	errs := make(chan error, len(addrs))
+	for _, addr := range addrs {
+		go func(addr string) {
+			errs <- c.remove(ctx, repo, "http://"+addr+"/delete")
+		}(addr)
+	}
+
+	err := new(multierror.Error)
+	for range addrs {
+		if e := <-errs; e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err.ErrorOrNil()
+}
+
+func (c *Client) remove(ctx context.Context, repo api.RepoName, op string) error {
 	req := &protocol.RepoDeleteRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "delete", req)
+	resp, err := c.httpPost(ctx, repo, op, req)
 	if err != nil {
 		return err
 	}
@@ -828,6 +1030,11 @@ func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, pay
 
 // do performs a request to a gitserver, sharding based on the given
 // repo name (the repo name is otherwise not used).
+//
+// If op is not an absolute URL and the repo is replicated, the request fails
+// over to the next replica when a gitserver cannot be reached. Replicas keep
+// their refs in sync with the primary by comparing ref hashes, so reads served
+// by a replica are consistent with the primary.
 func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, payload interface{}) (resp *http.Response, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.do")
 	defer func() {
@@ -844,11 +1051,25 @@ func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, p
 		return nil, err
 	}
 
-	uri := op
-	if !strings.HasPrefix(op, "http") {
-		uri = "http://" + c.AddrForRepo(ctx, repo) + "/" + op
+	if strings.HasPrefix(op, "http") {
+		return c.doURL(ctx, span, method, op, reqBody)
 	}
 
+	addrs := c.AddrsForRepo(ctx, repo)
+	for i, addr := range addrs {
+		resp, err = c.doURL(ctx, span, method, "http://"+addr+"/"+op, reqBody)
+		if err == nil || ctx.Err() != nil || i == len(addrs)-1 {
+			break
+		}
+
+		log15.Warn("gitserver unreachable, failing over to replica", "repo", repo, "addr", addr, "replica", addrs[i+1], "error", err)
+		failoverCounter.Inc()
+	}
+	return resp, err
+}
+
+// doURL performs a single request to uri with the JSON encoded reqBody.
+func (c *Client) doURL(ctx context.Context, span opentracing.Span, method, uri string, reqBody []byte) (*http.Response, error) {
 	req, err := http.NewRequest(method, uri, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
@@ -57,6 +57,136 @@ func TestClient_ListCloned(t *testing.T) {
 	}
 }
 
+func TestClient_AddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2"}
+	cli := &gitserver.Client{
+		Addrs: func(ctx context.Context) []string { return addrs },
+	}
+
+	ctx := context.Background()
+	for _, factor := range []int{1, 2, 3, 5} {
+		cli.ReplicationFactor = func() int { return factor }
+
+		want := factor
+		if want > len(addrs) {
+			want = len(addrs)
+		}
+
+		for _, repo := range []api.RepoName{"github.com/foo/bar", "github.com/foo/baz", "github.com/sourcegraph/sourcegraph"} {
+			replicas := cli.AddrsForRepo(ctx, repo)
+			if len(replicas) != want {
+				t.Fatalf("factor %d: unexpected number of replicas for %s: %v", factor, repo, replicas)
+			}
+			if primary := cli.AddrForRepo(ctx, repo); replicas[0] != primary {
+				t.Errorf("factor %d: first replica of %s is %s, want primary %s", factor, repo, replicas[0], primary)
+			}
+
+			seen := map[string]bool{}
+			for _, addr := range replicas {
+				if seen[addr] {
+					t.Errorf("factor %d: duplicate replica %s for %s: %v", factor, addr, repo, replicas)
+				}
+				seen[addr] = true
+			}
+		}
+	}
+}
+
+func TestClient_ConsistentHashing(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}
+	cli := &gitserver.Client{
+		Addrs:             func(ctx context.Context) []string { return addrs },
+		ConsistentHashing: func() bool { return true },
+	}
+
+	ctx := context.Background()
+	repos := make([]api.RepoName, 0, 1000)
+	owners := map[api.RepoName]string{}
+	for i := 0; i < 1000; i++ {
+		repo := api.RepoName(fmt.Sprintf("github.com/foo/repo-%d", i))
+		repos = append(repos, repo)
+		owners[repo] = cli.AddrForRepo(ctx, repo)
+	}
+
+	// Removing a gitserver must only move the repos it owned.
+	addrs = []string{"gitserver-0", "gitserver-1", "gitserver-3", "gitserver-4"}
+	moved := 0
+	for _, repo := range repos {
+		owner := cli.AddrForRepo(ctx, repo)
+		if owner == owners[repo] {
+			continue
+		}
+		if owners[repo] != "gitserver-2" {
+			t.Fatalf("%s moved from %s to %s, but only repos on gitserver-2 should move", repo, owners[repo], owner)
+		}
+		moved++
+	}
+	if moved == 0 {
+		t.Fatal("expected the repos on gitserver-2 to move")
+	}
+
+	// Adding it back must move them back.
+	addrs = []string{"gitserver-0", "gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}
+	for _, repo := range repos {
+		if owner := cli.AddrForRepo(ctx, repo); owner != owners[repo] {
+			t.Fatalf("%s is on %s, want %s", repo, owner, owners[repo])
+		}
+	}
+
+	cli.ReplicationFactor = func() int { return 3 }
+	for _, repo := range repos[:10] {
+		replicas := cli.AddrsForRepo(ctx, repo)
+		if len(replicas) != 3 || replicas[0] != owners[repo] {
+			t.Fatalf("unexpected replicas for %s: %v", repo, replicas)
+		}
+	}
+}
+
+func TestClient_Failover(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1"}
+	cli := &gitserver.Client{
+		Addrs:             func(ctx context.Context) []string { return addrs },
+		ReplicationFactor: func() int { return 2 },
+	}
+
+	ctx := context.Background()
+	repo := api.RepoName("github.com/foo/bar")
+	replicas := cli.AddrsForRepo(ctx, repo)
+
+	var requested []string
+	cli.HTTPClient = httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.Host)
+		if r.URL.Host == replicas[0] {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	})
+
+	cloned, err := cli.IsRepoCloned(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cloned {
+		t.Error("expected repo to be cloned on replica")
+	}
+	if !cmp.Equal(replicas, requested) {
+		t.Errorf("unexpected requests (-want +got):\n%s", cmp.Diff(replicas, requested))
+	}
+
+	// Without replication there is nothing to fail over to.
+	cli.ReplicationFactor = nil
+	requested = nil
+	if _, err := cli.IsRepoCloned(ctx, repo); err == nil {
+		t.Error("expected error when the only gitserver is unreachable")
+	}
+	if want := replicas[:1]; !cmp.Equal(want, requested) {
+		t.Errorf("unexpected requests (-want +got):\n%s", cmp.Diff(want, requested))
+	}
+}
+
 func TestClient_Archive(t *testing.T) {
 	root, err := ioutil.TempDir("", t.Name())
 	if err != nil {
@@ -117,6 +117,7 @@ type RepoInfo struct {
 	Cloned          bool       // whether the repository has been cloned successfully
 	LastFetched     *time.Time // when the last `git remote update` or `git fetch` occurred
 	LastChanged     *time.Time // timestamp of the most recent ref in the git repository
+	RefHash         string     // hash of the refs in the git repository, see computeRefHash in gitserver
 
 	// CloneTime is the time the clone occurred. Note: Repositories may be
 	// recloned automatically, so this time is likely to move forward
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -276,6 +277,46 @@ func TestNewTimeoutOpt(t *testing.T) {
 	}
 }
 
+func TestIsPublicIP(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"::ffff:127.0.0.1": false,
+		"10.1.2.3":         false,
+		"172.20.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"0.0.0.0":          false,
+		"224.0.0.1":        false,
+	} {
+		if have := IsPublicIP(net.ParseIP(addr)); have != want {
+			t.Errorf("IsPublicIP(%s): have %v, want %v", addr, have, want)
+		}
+	}
+}
+
+func TestPublicOnlyTransportOpt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to loopback address should not be sent")
+	}))
+	defer srv.Close()
+
+	var cli http.Client
+	if err := PublicOnlyTransportOpt(&cli); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := cli.Get(srv.URL)
+	var e *ErrNonPublicAddress
+	if !errors.As(err, &e) {
+		t.Fatalf("have error %v, want ErrNonPublicAddress", err)
+	}
+}
+
 func newFakeClient(code int, body []byte, err error) Doer {
 	return DoerFunc(func(r *http.Request) (*http.Response, error) {
 		rr := httptest.NewRecorder()
@@ -0,0 +1,99 @@
+package httpcli
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// nonPublicNetworks are the networks of addresses that are not reachable on
+// the public internet: loopback, link-local (including cloud metadata
+// endpoints), private, shared and unspecified addresses.
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// IsPublicIP reports whether ip is a public unicast address, i.e. not a
+// loopback, link-local, private, multicast or unspecified address.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range nonPublicNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrNonPublicAddress is returned when dialing an address that is not public
+// with a client created with PublicOnlyTransportOpt.
+type ErrNonPublicAddress struct {
+	Address string
+}
+
+// Error doesn't include the address, so that the resolved addresses of
+// internal hostnames aren't revealed to users.
+func (e *ErrNonPublicAddress) Error() string {
+	return "refusing to connect to a loopback, link-local or private address"
+}
+
+// PublicOnlyTransportOpt modifies the transport of the given http.Client so
+// that it only connects to public addresses (see IsPublicIP). The check is
+// done on the resolved address right before connecting, so it can't be
+// circumvented with DNS records that point to internal addresses or change
+// after a URL was validated.
+//
+// It is meant for clients that send requests to user supplied URLs, such as
+// webhooks.
+func PublicOnlyTransportOpt(cli *http.Client) error {
+	tr, err := getTransportForMutation(cli)
+	if err != nil {
+		return err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicIP(net.ParseIP(host)) {
+				return &ErrNonPublicAddress{Address: host}
+			}
+			return nil
+		},
+	}
+	tr.DialContext = dialer.DialContext
+	// A proxy would be dialed instead of the destination, which defeats the
+	// check above.
+	tr.Proxy = nil
+
+	return nil
+}
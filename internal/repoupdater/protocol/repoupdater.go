@@ -125,6 +125,10 @@ func (r *RepoInfo) String() string {
 // VCSInfo describes how to access an external repository's Git data (to clone or update it).
 type VCSInfo struct {
 	URL string // the Git remote URL
+
+	// ExternalServiceID is the ID of the code host connection that URL was
+	// obtained from, or zero if it is not known.
+	ExternalServiceID int64 `json:",omitempty"`
 }
 
 // RepoLinks contains URLs and URL patterns for objects in this repository.
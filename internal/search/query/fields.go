@@ -16,6 +16,7 @@ var allFields = map[string]struct{}{
 	"l":                     empty,
 	"language":              empty,
 	FieldType:               empty,
+	FieldKind:               empty,
 	FieldPatternType:        empty,
 	FieldContent:            empty,
 	FieldRepoHasFile:        empty,
@@ -227,6 +227,7 @@ func (q AndOrQuery) valueToTypedValue(field, value string, label labels) []*type
 		FieldArchived,
 		FieldLang, "l", "language",
 		FieldType,
+		FieldKind,
 		FieldPatternType,
 		FieldContent:
 		return []*types.Value{{String: &value}}
@@ -269,7 +269,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldLang:
 		return satisfies(isLanguage)
 	case
-		FieldType:
+		FieldType,
+		FieldKind:
 		return satisfies(isNotNegated)
 	case
 		FieldPatternType,
@@ -64,6 +64,10 @@ type SymbolsParameters struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// Kinds, if non-empty, is the list of symbol kinds (such as "function" or
+	// "class") to include in the result. Kinds are compared case-insensitively.
+	Kinds []string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 }
@@ -89,6 +93,11 @@ type TextParameters struct {
 	// to true if the user requests a specific timeout or maximum result size.
 	UseFullDeadline bool
 
+	// Continuable requests paginated results from searcher, so that the
+	// search of an unindexed repository revision can later be continued
+	// from searcher's cached results where it stopped.
+	Continuable bool
+
 	Zoekt        *searchbackend.Zoekt
 	SearcherURLs *endpoint.Map
 }
@@ -128,6 +137,9 @@ type TextPatternInfo struct {
 	PatternMatchesPath    bool
 
 	Languages []string
+
+	// SymbolKinds restricts symbol results to the given symbol kinds.
+	SymbolKinds []string
 }
 
 func (p *TextPatternInfo) String() string {
@@ -160,6 +172,9 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, kind := range p.SymbolKinds {
+		args = append(args, fmt.Sprintf("kind:%s", kind))
+	}
 
 	for _, inc := range p.FilePatternsReposMustInclude {
 		args = append(args, fmt.Sprintf("repositoryPathPattern:%s", inc))
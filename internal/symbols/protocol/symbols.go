@@ -33,6 +33,12 @@ type SearchArgs struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// Kinds, if non-empty, is the list of ctags symbol kinds (such as "func" or
+	// "class") to include in the result. Kinds are compared case-insensitively.
+	// The frontend expands the kinds of kind: filters to all ctags kinds with
+	// the same LSP symbol kind, so the symbols service doesn't have to.
+	Kinds []string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 }
@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP VIEW lsif_indexes_with_repository_name;
+
+ALTER TABLE lsif_indexes DROP COLUMN indexer;
+ALTER TABLE lsif_indexes DROP COLUMN indexer_args;
+ALTER TABLE lsif_indexable_repositories DROP COLUMN indexer;
+ALTER TABLE lsif_indexable_repositories DROP COLUMN indexer_args;
+
+-- Recreate view with original columns
+CREATE VIEW lsif_indexes_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_indexes u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE lsif_indexes ADD COLUMN indexer text NOT NULL DEFAULT 'lsif-go';
+ALTER TABLE lsif_indexes ADD COLUMN indexer_args text[];
+
+-- Allow per-repository overrides of the inferred indexer
+ALTER TABLE lsif_indexable_repositories ADD COLUMN indexer text;
+ALTER TABLE lsif_indexable_repositories ADD COLUMN indexer_args text[];
+
+-- Recreate view with new columns
+DROP VIEW lsif_indexes_with_repository_name;
+CREATE VIEW lsif_indexes_with_repository_name AS
+    SELECT u.*, r.name as repository_name FROM lsif_indexes u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sub_repo_permissions (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    path_includes text[] NOT NULL DEFAULT '{}',
+    path_excludes text[] NOT NULL DEFAULT '{}',
+    updated_at timestamp with time zone NOT NULL,
+    PRIMARY KEY (user_id, repo_id)
+);
+
+CREATE INDEX IF NOT EXISTS sub_repo_permissions_repo_id_idx ON sub_repo_permissions (repo_id);
+
+COMMIT;
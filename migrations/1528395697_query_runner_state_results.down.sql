@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE query_runner_state DROP COLUMN IF EXISTS results;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS query_runner_runs;
+DROP TABLE IF EXISTS query_runner_leases;
+
+COMMIT;
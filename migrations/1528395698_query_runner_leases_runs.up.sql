@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS query_runner_leases (
+    query text PRIMARY KEY,
+    runner text NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS query_runner_runs (
+    id bigserial PRIMARY KEY,
+    query text NOT NULL,
+    runner text NOT NULL,
+    scheduled_at timestamp with time zone NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone NOT NULL,
+    result_count integer,
+    error text
+);
+
+CREATE INDEX IF NOT EXISTS query_runner_runs_query_started_at_idx ON query_runner_runs (query, started_at DESC);
+CREATE INDEX IF NOT EXISTS query_runner_runs_started_at_idx ON query_runner_runs (started_at);
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_spec_jobs (
+    id bigserial PRIMARY KEY,
+    campaign_spec_id bigint NOT NULL REFERENCES campaign_specs(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+
+    state text NOT NULL DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+
+    log text NOT NULL DEFAULT '',
+    changeset_spec_id bigint REFERENCES changeset_specs(id) ON DELETE SET NULL DEFERRABLE,
+
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_spec_jobs_campaign_spec_id ON campaign_spec_jobs(campaign_spec_id);
+CREATE INDEX IF NOT EXISTS campaign_spec_jobs_state ON campaign_spec_jobs(state);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS changeset_bulk_operation_jobs;
+DROP TABLE IF EXISTS changeset_bulk_operations;
+
+COMMIT;
@@ -0,0 +1,37 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS changeset_bulk_operations (
+    id bigserial PRIMARY KEY,
+    campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+
+    operation text NOT NULL,
+    comment text NOT NULL DEFAULT '',
+
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_bulk_operations_campaign_id ON changeset_bulk_operations(campaign_id);
+
+CREATE TABLE IF NOT EXISTS changeset_bulk_operation_jobs (
+    id bigserial PRIMARY KEY,
+    bulk_operation_id bigint NOT NULL REFERENCES changeset_bulk_operations(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+
+    state text NOT NULL DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_bulk_operation_jobs_bulk_operation_id ON changeset_bulk_operation_jobs(bulk_operation_id);
+CREATE INDEX IF NOT EXISTS changeset_bulk_operation_jobs_state ON changeset_bulk_operation_jobs(state);
+
+COMMIT;
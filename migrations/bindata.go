@@ -88,6 +88,22 @@
 // 1528395692_add_campaign_specs_and_changeset_specs.up.sql (1.67kB)
 // 1528395693_remove_old_campaigns_workflow_tables.down.sql (2.293kB)
 // 1528395693_remove_old_campaigns_workflow_tables.up.sql (208B)
+// 1528395694_lsif_indexes_indexer.down.sql (512B)
+// 1528395694_lsif_indexes_indexer.up.sql (612B)
+// 1528395695_sub_repo_permissions.down.sql (60B)
+// 1528395695_sub_repo_permissions.up.sql (484B)
+// 1528395696_saved_search_webhooks.down.sql (264B)
+// 1528395696_saved_search_webhooks.up.sql (831B)
+// 1528395697_query_runner_state_results.down.sql (79B)
+// 1528395697_query_runner_state_results.up.sql (88B)
+// 1528395698_query_runner_leases_runs.down.sql (99B)
+// 1528395698_query_runner_leases_runs.up.sql (707B)
+// 1528395699_campaign_spec_jobs.down.sql (58B)
+// 1528395699_campaign_spec_jobs.up.sql (999B)
+// 1528395700_changesets_external_mergeable_state.down.sql (88B)
+// 1528395700_changesets_external_mergeable_state.up.sql (96B)
+// 1528395701_changeset_bulk_operations.down.sql (117B)
+// 1528395701_changeset_bulk_operations.up.sql (1.513kB)
 
 package migrations
 
@@ -1916,6 +1932,326 @@ func _1528395693_remove_old_campaigns_workflow_tablesUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395694_lsif_indexes_indexerDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x41\x4b\xc3\x40\x10\x85\xef\xfb\x2b\xde\x59\x6c\xfe\x40\xf0\x90\xc6\x55\x23\x9b\xac\x6c\x52\x7b\x5c\xd6\x66\xac\x03\x69\x22\xb3\x89\xd5\x7f\x2f\x8d\x82\x56\x10\xda\xeb\xf0\x7d\xef\x0d\x6f\xa9\x6f\x8b\x2a\x55\xea\xda\xd9\x07\x3c\x16\x7a\x8d\x2e\xf2\xb3\xe7\xbe\xa5\x77\x8a\x7e\xcf\xe3\x8b\x17\x7a\x1d\x22\x8f\x83\x7c\xf8\x3e\xec\x28\x55\x2a\x33\x8d\x76\x68\xb2\xa5\xd1\x47\x3c\xe6\x98\xdc\x9a\x55\x59\xe1\xeb\x26\xe9\x59\xb4\x0f\xb2\x8d\xff\x29\xe1\xa9\xa3\x9f\x6f\xf8\xbc\xbe\x93\xe4\xef\x7a\xb5\x58\xc0\xd1\x46\x28\x8c\x84\x37\xa6\x3d\x0e\x43\x60\x10\xde\x72\x1f\x3a\x6c\x86\x6e\xda\xf5\x51\xe5\x4e\x67\x8d\x3e\x71\x37\x64\xb5\x02\x80\x5a\x1b\x9d\x37\x98\x92\x8b\x4b\x48\x72\x58\x14\x21\xe2\x2f\x7c\xe3\x6c\x79\x94\x89\x69\xb6\xef\x6d\x51\xcd\x30\x04\xb6\x82\x24\xdc\xe2\x0a\x53\xf2\xcb\xe7\x76\x26\xd7\x77\xda\x69\x48\xd2\x52\x47\x23\xb5\x3e\x8c\x28\x6a\x54\x2b\x63\x52\xa5\x72\x5b\x96\x45\x93\xaa\xcf\x01\x00\x22\xcf\x1a\x4a\x00\x02\x00\x00")
+
+func _1528395694_lsif_indexes_indexerDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395694_lsif_indexes_indexerDownSql,
+		"1528395694_lsif_indexes_indexer.down.sql",
+	)
+}
+
+func _1528395694_lsif_indexes_indexerDownSql() (*asset, error) {
+	bytes, err := _1528395694_lsif_indexes_indexerDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395694_lsif_indexes_indexer.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5d, 0x97, 0xf0, 0x3e, 0xaa, 0x30, 0x39, 0x78, 0xc, 0xb2, 0x94, 0xcd, 0xb8, 0xf7, 0xda, 0xb4, 0xdc, 0x0, 0x48, 0x38, 0x5b, 0xc0, 0xe7, 0xa8, 0xf7, 0xb3, 0xe4, 0x96, 0x2a, 0x9e, 0x46, 0x68}}
+	return a, nil
+}
+
+var __1528395694_lsif_indexes_indexerUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\xdd\x4a\xeb\x40\x14\x85\xef\xe7\x29\xd6\x5d\xe1\x70\x92\x17\x08\xe7\x22\x4d\xa6\xc7\x48\x7e\x24\x4d\xed\x85\x48\x88\x9d\xdd\x76\x20\xcd\x94\x3d\x93\xa6\x7d\x7b\x69\x44\xad\x4a\x41\xf1\x76\xf3\xad\x6f\xb1\x60\x4f\xe5\xff\x24\x0f\x84\x08\xd3\x4a\x96\xa8\xc2\x69\x2a\xd1\x5a\xbd\xae\x75\xa7\xe8\x48\x16\x61\x1c\x23\x2a\xd2\x45\x96\xe3\xe5\xc4\x70\x74\x74\xc8\x8b\x0a\xf9\x22\x4d\x11\xcb\x59\xb8\x48\x2b\x4c\xce\x31\x6f\x63\x26\xc1\x4f\x64\x75\xc3\x1b\x3b\x1a\x1f\x1e\x03\x21\x3c\x0f\x61\xdb\x9a\x01\x7b\x62\x8f\x69\x6f\xac\x76\x86\x4f\x30\x07\x62\xd6\x8a\x2c\xcc\x1a\x6e\x4b\xd0\xdd\x9a\x98\x49\xbd\x7a\xae\x74\x36\x4f\x2d\xd5\x6f\x1e\x7d\x7d\x4f\xf0\x0b\xc1\xd7\x0d\x25\xad\x98\x1a\x47\x38\x68\x1a\x30\x68\xb7\x45\x47\x03\x56\xa6\xed\x77\x9d\x15\x71\x59\xdc\xe1\x3e\x91\xcb\x8b\x22\xb2\xf5\x99\x7b\xef\x3a\xd5\x5d\xb3\xa3\x40\x44\xa5\x0c\x2b\xf9\x4d\x1c\xe1\x5c\x00\xc0\x5c\xa6\x32\xaa\xd0\xfb\x7f\xfe\x82\xfd\xb3\x08\x8d\xc5\x67\x78\x56\x16\xd9\x07\x27\xfa\x31\x7d\x5b\x24\xf9\x08\x83\x51\xe4\x60\x5f\x2b\xfc\x43\xef\x5f\xe4\xb5\x1a\xc9\xe5\x8d\x2c\x25\xd8\x57\xd4\x92\x23\x55\x37\x0e\xc9\x7c\xfc\x8b\x40\x88\xa8\xc8\xb2\xa4\x0a\xc4\xf3\x00\x27\x8e\xdc\x0d\x64\x02\x00\x00")
+
+func _1528395694_lsif_indexes_indexerUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395694_lsif_indexes_indexerUpSql,
+		"1528395694_lsif_indexes_indexer.up.sql",
+	)
+}
+
+func _1528395694_lsif_indexes_indexerUpSql() (*asset, error) {
+	bytes, err := _1528395694_lsif_indexes_indexerUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395694_lsif_indexes_indexer.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf0, 0x53, 0x5b, 0x60, 0xa8, 0x5c, 0x84, 0x9d, 0x30, 0x9, 0x6e, 0x14, 0xd5, 0x45, 0x16, 0x3f, 0x30, 0x86, 0xc8, 0xe3, 0x78, 0xdd, 0x2e, 0x41, 0x9c, 0x1f, 0x8c, 0xb2, 0xfb, 0x4e, 0x36, 0xc5}}
+	return a, nil
+}
+
+var __1528395695_sub_repo_permissionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x75\x62\x5f\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x18\x3d\x94\xd1\x3c\x00\x00\x00")
+
+func _1528395695_sub_repo_permissionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395695_sub_repo_permissionsDownSql,
+		"1528395695_sub_repo_permissions.down.sql",
+	)
+}
+
+func _1528395695_sub_repo_permissionsDownSql() (*asset, error) {
+	bytes, err := _1528395695_sub_repo_permissionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395695_sub_repo_permissions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x41, 0x58, 0x33, 0x7d, 0xc6, 0xd8, 0x2, 0xa8, 0x6f, 0x3b, 0x7e, 0xc1, 0xe2, 0x1c, 0xaa, 0xe7, 0x84, 0xda, 0x2, 0x4f, 0x53, 0x75, 0x3f, 0xaf, 0xb2, 0xf9, 0xe6, 0x5b, 0x49, 0xc6, 0xac, 0xeb}}
+	return a, nil
+}
+
+var __1528395695_sub_repo_permissionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xdf\x4a\xc3\x30\x18\xc5\xef\xf3\x14\xe7\x6e\x1b\xec\x0d\x7a\x95\xb5\x5f\x25\xd8\xa6\xd2\x66\xb0\x21\x12\xaa\x09\x2e\x60\xff\xd0\xa4\x58\x14\xdf\x5d\x56\x37\xe7\x85\xe8\x2e\x03\xe7\xfc\x0e\xf9\x7d\x1b\xba\x11\x32\x62\x2c\x2e\x89\x2b\x82\xe2\x9b\x8c\x20\x52\xc8\x42\x81\x76\xa2\x52\x15\xfc\xf8\xa8\x07\xdb\x77\xba\xb7\x43\xe3\xbc\x77\x5d\xeb\xb1\x64\x00\x30\x7a\x3b\x68\x67\xe0\xda\x60\x9f\xed\x30\xb7\xe4\x36\xcb\x50\x52\x4a\x25\xc9\x98\xaa\x39\xe3\x97\xce\xac\x50\x48\x24\x94\x91\x22\xc4\xbc\x8a\x79\x42\xeb\x19\x32\xb3\xff\x81\x1c\x33\x7f\x31\xfa\x3a\x1c\xb4\x6b\x9f\x5e\x46\x63\x3d\x82\x9d\xc2\xfd\xc3\x05\x94\x50\xca\xb7\x99\xc2\xe2\xfd\x63\xf1\x23\x6f\xa7\xab\xf3\x63\x6f\xea\x60\x8d\xae\x03\x82\x6b\xac\x0f\x75\xd3\xe3\xd5\x85\xc3\xfc\xc4\x5b\xd7\xda\xef\xfa\xd7\xc2\x5d\x29\x72\x5e\xee\x71\x4b\x7b\x2c\x4f\x9e\xd6\xe7\xbf\xae\xd8\xea\xe2\x5c\xc8\x84\x76\x57\x38\xd7\xa7\xb2\x76\x66\x3a\x7a\xf8\xfd\x2e\xe7\x85\x88\xb1\xb8\xc8\x73\xa1\x22\xf6\x39\x00\x78\xe0\x86\xc6\xe4\x01\x00\x00")
+
+func _1528395695_sub_repo_permissionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395695_sub_repo_permissionsUpSql,
+		"1528395695_sub_repo_permissions.up.sql",
+	)
+}
+
+func _1528395695_sub_repo_permissionsUpSql() (*asset, error) {
+	bytes, err := _1528395695_sub_repo_permissionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395695_sub_repo_permissions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2, 0x86, 0xd4, 0xaf, 0x98, 0x76, 0x3e, 0x86, 0x8e, 0xaf, 0x66, 0xa8, 0xcd, 0x81, 0x7f, 0xfe, 0x8b, 0xd8, 0x96, 0x77, 0xff, 0xa4, 0xbf, 0xab, 0xa3, 0xb9, 0x90, 0xf8, 0x2f, 0xe6, 0x54, 0x72}}
+	return a, nil
+}
+
+var __1528395696_saved_search_webhooksDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4e\x2c\x4b\x4d\x89\x2f\x4e\x4d\x2c\x4a\xce\x88\x2f\x4f\x4d\xca\xc8\xcf\xcf\x8e\x4f\x49\xcd\xc9\x2c\x4b\x2d\xca\x4c\x2d\xb6\xe6\xe2\x72\xf4\x09\x71\x0d\x82\xea\x43\x56\x9d\x5a\xac\x00\x36\xd1\xd9\xdf\x27\xd4\xd7\x0f\xc9\xc8\xbc\xfc\x92\xcc\xb4\x4a\x98\x61\xd6\xa4\x1b\x00\x73\x46\x69\x51\x0e\x05\xba\x8b\x53\x93\x8b\x52\x4b\xac\xb9\xb8\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x03\x00\xc9\x29\xf8\xd3\x08\x01\x00\x00")
+
+func _1528395696_saved_search_webhooksDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395696_saved_search_webhooksDownSql,
+		"1528395696_saved_search_webhooks.down.sql",
+	)
+}
+
+func _1528395696_saved_search_webhooksDownSql() (*asset, error) {
+	bytes, err := _1528395696_saved_search_webhooksDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395696_saved_search_webhooks.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe7, 0x30, 0x20, 0xe0, 0xb3, 0xa8, 0x5b, 0x59, 0xb6, 0x6b, 0x97, 0xf8, 0xb3, 0x8e, 0xc4, 0x6d, 0x94, 0xd, 0xf4, 0x5, 0xe6, 0x61, 0x86, 0xe2, 0xd2, 0x33, 0xac, 0x44, 0xa1, 0x59, 0x66, 0xcd}}
+	return a, nil
+}
+
+var __1528395696_saved_search_webhooksUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x91\xcb\xce\x9b\x30\x10\x85\xf7\x3c\xc5\x2c\x89\xf4\xbf\x41\x56\xfe\x61\x52\xa1\x72\xa9\xc0\x91\x92\x95\x65\xf0\x24\x71\x4b\x70\x64\x3b\xb7\x3e\x7d\x55\x20\x17\x52\xa9\x91\xda\xa5\x7d\xec\x6f\xce\x9c\xf3\x89\x5f\x92\x7c\x1e\x04\x2c\xe5\x58\x02\x67\x9f\x29\x82\x93\x27\x52\xc2\x91\xb4\xcd\x8e\x1c\xb0\x38\x86\xa8\x48\x97\x59\x0e\xc9\x02\xf2\x82\x03\xae\x92\x8a\x57\xd0\x19\xaf\x37\x57\x71\xa6\x7a\x67\xcc\x0f\xa8\x8d\x69\x49\x76\xfd\x8b\x7c\x99\xa6\x10\xe3\x82\x2d\x53\x0e\x1b\xd9\x3a\x9a\xff\xd3\x88\x91\x2d\x8e\xb6\x05\x4f\x17\xff\x7f\x14\x47\x8d\x25\x3f\x82\x82\xa8\x44\xc6\x71\x44\x4d\x3f\x3c\x83\x6f\xfb\x09\x45\xad\x3e\x91\xd5\xe4\x20\x0c\x00\x00\xb4\x82\x5a\x6f\x1d\x59\x2d\x5b\xf8\x56\x26\x19\x2b\xd7\xf0\x15\xd7\x1f\xbd\x3a\x61\x68\x05\xba\xf3\xb4\x25\xfb\x88\xa7\xc4\x05\x96\x98\x47\x38\x9d\x47\x2e\xd4\x6a\x06\x45\x0e\x31\xa6\xc8\x11\x22\x56\x45\x2c\xc6\x81\x7a\x0b\xe2\x8e\x19\xae\x0f\xf2\xda\x1a\xa9\xe0\xbb\x33\x5d\xfd\xa2\x49\xef\x69\x7f\xf0\xee\x0f\x07\xa3\x4f\x2f\xfd\xd1\x89\xc6\x28\xba\xbd\x18\x04\xb2\xd6\xd8\x3e\xac\xe1\xdc\x58\x92\x9e\x94\x90\x1e\xbc\xde\x93\xf3\x72\x7f\x80\xb3\xf6\xbb\xfe\x08\x3f\x4d\x47\x77\xf4\xbd\xfb\xce\x9c\xc3\xd9\xf0\x7f\xcc\xef\xef\x84\x60\xf6\x68\x26\xc9\x63\x5c\xbd\x54\xf9\xa6\x19\x31\xd1\xb5\x12\x0f\xd3\x42\xab\xcb\xef\x54\xdf\x10\x20\x7c\x41\x7c\x3c\x2f\x1e\x63\x15\xf5\x0e\x8b\x2c\x4b\xf8\x3c\xf8\x35\x00\xcb\x9f\xe2\xff\x3f\x03\x00\x00")
+
+func _1528395696_saved_search_webhooksUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395696_saved_search_webhooksUpSql,
+		"1528395696_saved_search_webhooks.up.sql",
+	)
+}
+
+func _1528395696_saved_search_webhooksUpSql() (*asset, error) {
+	bytes, err := _1528395696_saved_search_webhooksUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395696_saved_search_webhooks.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xae, 0x73, 0xc3, 0x83, 0x73, 0x2c, 0x2, 0x64, 0x2d, 0xaf, 0x3c, 0xf9, 0x1a, 0x60, 0x48, 0x84, 0xe9, 0x38, 0x6b, 0x72, 0x26, 0x37, 0x3e, 0x4d, 0x63, 0xc9, 0x1, 0xff, 0xe8, 0x0, 0x9e, 0xad}}
+	return a, nil
+}
+
+var __1528395697_query_runner_state_resultsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4f\x00\xb0\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x71\x75\x65\x72\x79\x5f\x72\x75\x6e\x6e\x65\x72\x5f\x73\x74\x61\x74\x65\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x73\x75\x6c\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x63\x4d\xf1\xd4\x4f\x00\x00\x00")
+
+func _1528395697_query_runner_state_resultsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395697_query_runner_state_resultsDownSql,
+		"1528395697_query_runner_state_results.down.sql",
+	)
+}
+
+func _1528395697_query_runner_state_resultsDownSql() (*asset, error) {
+	bytes, err := _1528395697_query_runner_state_resultsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395697_query_runner_state_results.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa8, 0xc2, 0x54, 0x74, 0x49, 0xf6, 0x2b, 0xa2, 0x5c, 0xf5, 0xba, 0x54, 0x75, 0x5c, 0xee, 0x9c, 0xd5, 0x8f, 0x1a, 0xef, 0xea, 0xb8, 0xdb, 0x69, 0xb8, 0x77, 0x50, 0xd1, 0xf6, 0x83, 0xd9, 0xee}}
+	return a, nil
+}
+
+var __1528395697_query_runner_state_resultsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x58\x00\xa7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x71\x75\x65\x72\x79\x5f\x72\x75\x6e\x6e\x65\x72\x5f\x73\x74\x61\x74\x65\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x73\x75\x6c\x74\x73\x20\x6a\x73\x6f\x6e\x62\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x69\xe8\x1c\x34\x58\x00\x00\x00")
+
+func _1528395697_query_runner_state_resultsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395697_query_runner_state_resultsUpSql,
+		"1528395697_query_runner_state_results.up.sql",
+	)
+}
+
+func _1528395697_query_runner_state_resultsUpSql() (*asset, error) {
+	bytes, err := _1528395697_query_runner_state_resultsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395697_query_runner_state_results.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5c, 0xae, 0xd3, 0x88, 0x7a, 0x71, 0xfe, 0x1d, 0x10, 0x9f, 0x6e, 0xb5, 0xe6, 0x4a, 0xad, 0x30, 0x70, 0x5d, 0x9, 0xe1, 0x6, 0x8e, 0x3c, 0x3e, 0x1, 0x68, 0x54, 0x9d, 0xa1, 0x1f, 0x9f, 0xd5}}
+	return a, nil
+}
+
+var __1528395698_query_runner_leases_runsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x63\x00\x9c\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x71\x75\x65\x72\x79\x5f\x72\x75\x6e\x6e\x65\x72\x5f\x72\x75\x6e\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x71\x75\x65\x72\x79\x5f\x72\x75\x6e\x6e\x65\x72\x5f\x6c\x65\x61\x73\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x0a\xc5\x52\xdb\x63\x00\x00\x00")
+
+func _1528395698_query_runner_leases_runsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395698_query_runner_leases_runsDownSql,
+		"1528395698_query_runner_leases_runs.down.sql",
+	)
+}
+
+func _1528395698_query_runner_leases_runsDownSql() (*asset, error) {
+	bytes, err := _1528395698_query_runner_leases_runsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395698_query_runner_leases_runs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x73, 0xd6, 0xfb, 0x5f, 0x4f, 0x87, 0xbc, 0x8c, 0x9c, 0xb4, 0x61, 0x48, 0x60, 0x54, 0xbc, 0x6c, 0xd9, 0x2, 0x95, 0x5b, 0xb7, 0x91, 0x24, 0xd9, 0x23, 0xa1, 0x7c, 0x47, 0x28, 0xc6, 0x69, 0x80}}
+	return a, nil
+}
+
+var __1528395698_query_runner_leases_runsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\xdf\x4e\xb3\x40\x10\xc5\xef\x79\x8a\xb9\xfc\x9a\xf4\x0d\xb8\xa2\xed\x7e\x66\x23\x7f\x0c\x60\xd2\x5e\x6d\xb0\x8c\x65\x12\xba\xd4\xd9\xd9\x88\x3e\xbd\x11\xaa\xc5\xa8\xb1\x5c\xee\x9e\x39\x33\xbf\x73\x56\xea\x46\xa7\x61\x10\xac\x73\x15\x95\x0a\xca\x68\x15\x2b\xd0\xff\x21\xcd\x4a\x50\x5b\x5d\x94\x05\x3c\x79\xe4\x17\xc3\xde\x5a\x64\xd3\x62\xe5\xd0\xc1\xbf\x00\x00\x46\x05\x04\x7b\x81\xbb\x5c\x27\x51\xbe\x83\x5b\xb5\x5b\x0e\xda\x38\x3f\x8a\xef\xcb\xd2\xfb\x38\x1e\x15\xec\x4f\xc4\xe8\x4c\x25\x20\x74\x44\x27\xd5\xf1\x04\xcf\x24\xcd\xf0\x84\xd7\xce\xe2\xa7\x23\x58\xcc\x60\x63\x6f\x3f\xc8\xa8\x86\x07\x3a\x38\x64\xaa\xda\xef\x6c\x13\xee\xaf\x68\xbf\x43\xbb\x7d\x83\xb5\x6f\xb1\xbe\x0a\xfb\xec\x91\x8a\x65\x96\xe3\x91\x2c\xb9\x66\x96\x85\xd1\xf9\x56\xcc\xbe\xf3\x56\x80\xac\xe0\x01\xf9\xdc\x33\x73\x37\x66\x99\xb6\xa8\xd3\x8d\xda\xfe\xd5\xa2\x19\x1a\x32\x97\x00\x86\xea\x1e\xb2\xf4\xa7\xbe\x87\xaf\xe5\x34\xec\x46\x15\xeb\x45\x38\xef\xe0\x55\xa7\x2e\x43\x43\xa0\x2c\x49\x74\x19\x06\x6f\x03\x00\xef\xcd\x72\xc3\xc3\x02\x00\x00")
+
+func _1528395698_query_runner_leases_runsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395698_query_runner_leases_runsUpSql,
+		"1528395698_query_runner_leases_runs.up.sql",
+	)
+}
+
+func _1528395698_query_runner_leases_runsUpSql() (*asset, error) {
+	bytes, err := _1528395698_query_runner_leases_runsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395698_query_runner_leases_runs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x68, 0xf4, 0xf4, 0x7, 0xdf, 0x9a, 0xf6, 0xb1, 0x9d, 0x3, 0x45, 0xc5, 0xca, 0x89, 0x28, 0xe0, 0x9f, 0xa1, 0xe0, 0x1b, 0xd7, 0x1a, 0x4c, 0x6c, 0xd8, 0x2b, 0xec, 0x6f, 0xa6, 0xee, 0xa}}
+	return a, nil
+}
+
+var __1528395699_campaign_spec_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x5f\x6a\x6f\x62\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xba\x4d\x1a\x06\x3a\x00\x00\x00")
+
+func _1528395699_campaign_spec_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395699_campaign_spec_jobsDownSql,
+		"1528395699_campaign_spec_jobs.down.sql",
+	)
+}
+
+func _1528395699_campaign_spec_jobsDownSql() (*asset, error) {
+	bytes, err := _1528395699_campaign_spec_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395699_campaign_spec_jobs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaa, 0x85, 0x4f, 0xc1, 0xe7, 0x4d, 0xda, 0x30, 0x34, 0xa, 0x5f, 0x48, 0xaf, 0x90, 0x71, 0xb7, 0xa, 0xda, 0xc5, 0xe7, 0xb, 0xde, 0xee, 0xb3, 0xff, 0xec, 0x65, 0x80, 0x10, 0xfe, 0x46, 0x53}}
+	return a, nil
+}
+
+var __1528395699_campaign_spec_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x41\x8f\xa2\x40\x10\x85\xef\xfc\x8a\xba\x09\x89\x87\xbd\x7b\x42\x28\x37\x64\x11\x37\x80\x89\x9e\x3a\x2d\x94\xd8\x1b\x69\xd8\xee\x26\x4e\xe6\xd7\x4f\x00\x8d\x82\xce\xe8\xcc\x11\xfa\xab\xf7\x5e\x57\x75\xcd\xf1\x77\x10\xcd\x2c\xcb\x8b\xd1\x4d\x11\x52\x77\x1e\x22\x04\x0b\x88\x56\x29\xe0\x26\x48\xd2\x04\x32\x5e\xd6\x5c\x14\x92\xe9\x9a\x32\xf6\xaf\xda\x69\xb0\x2d\x00\x00\x91\xc3\x4e\x14\x9a\x94\xe0\x47\xf8\x1b\x07\x4b\x37\xde\xc2\x1f\xdc\x4e\xbb\xd3\x61\x59\xcf\x0a\x69\x3a\xe5\x68\x1d\x86\x10\xe3\x02\x63\x8c\x3c\x1c\x59\x68\x5b\xe4\x0e\xac\x22\xf0\x31\xc4\x14\xc1\x73\x13\xcf\xf5\x11\xfc\x96\x8f\xdb\x80\xbd\x81\xa2\xba\x62\x22\x07\x21\x0d\x15\xa4\x1e\x0a\xb7\xcc\x0b\x72\x9d\x9e\x36\xdc\x10\x18\x7a\xbb\xc9\xe8\xe3\xc2\x5d\x87\x29\x4c\xfe\x37\xd4\x50\x3e\xe9\x9d\xf7\x5c\x1c\x1b\x45\xac\x24\xad\x79\xd1\xd7\x4c\x2f\x1a\xca\x50\xce\xb8\x01\x23\x4a\xd2\x86\x97\x35\x9c\x84\x39\x74\x9f\xf0\x5e\x49\x3a\x4b\x08\x29\xf4\xe1\x15\xb2\x56\x55\x46\x5a\x33\xbe\x37\xa4\x9e\xb0\xb2\x29\x99\x22\x4d\x46\xdf\x77\xe5\x72\x95\x5f\x57\xf4\x7c\x8f\xaf\xe1\x8e\x3e\x56\xc5\x67\x9d\x39\xf7\x24\x3b\x70\x59\xb4\xd6\xe3\x79\xdf\x8e\x79\xc0\x8c\xe7\x9c\xe0\x55\x7a\x38\x99\x4c\x11\x7f\xd2\xd5\xfb\x60\xb2\x3a\xd9\x4e\x9f\xad\xa9\xf3\x1f\xd6\x5b\xce\x75\x37\x82\xc8\xc7\xcd\xd3\xdd\x60\xc3\x5f\x22\x6f\x9f\xde\x3d\x66\x8f\x31\x67\xf6\x4d\x9f\xfe\xb9\x3e\x16\xef\xce\xba\xe8\xab\xe5\x32\x48\x67\xd6\xc7\x00\xe7\x25\x09\x13\xe7\x03\x00\x00")
+
+func _1528395699_campaign_spec_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395699_campaign_spec_jobsUpSql,
+		"1528395699_campaign_spec_jobs.up.sql",
+	)
+}
+
+func _1528395699_campaign_spec_jobsUpSql() (*asset, error) {
+	bytes, err := _1528395699_campaign_spec_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395699_campaign_spec_jobs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x56, 0x37, 0x3b, 0xb2, 0xc1, 0x4d, 0x64, 0x5b, 0xbf, 0x8c, 0xbe, 0xeb, 0x5f, 0x13, 0x2, 0x8d, 0xfe, 0x42, 0x0, 0xc2, 0x5d, 0xfb, 0xe, 0xa2, 0xa7, 0xc3, 0x23, 0x4e, 0x18, 0xdf, 0x89, 0x89}}
+	return a, nil
+}
+
+var __1528395700_changesets_external_mergeable_stateDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x58\x00\xa7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x6d\x65\x72\x67\x65\x61\x62\x6c\x65\x5f\x73\x74\x61\x74\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x11\x61\xf1\xa0\x58\x00\x00\x00")
+
+func _1528395700_changesets_external_mergeable_stateDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395700_changesets_external_mergeable_stateDownSql,
+		"1528395700_changesets_external_mergeable_state.down.sql",
+	)
+}
+
+func _1528395700_changesets_external_mergeable_stateDownSql() (*asset, error) {
+	bytes, err := _1528395700_changesets_external_mergeable_stateDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395700_changesets_external_mergeable_state.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4, 0x68, 0x9, 0xf, 0xf8, 0x2e, 0x36, 0x2a, 0xe3, 0xec, 0x36, 0x67, 0x1c, 0xa0, 0x51, 0x3a, 0x71, 0x4b, 0xae, 0x9b, 0x6b, 0xed, 0x14, 0x37, 0x88, 0x64, 0x2d, 0x7f, 0xd1, 0x2b, 0x37, 0xd4}}
+	return a, nil
+}
+
+var __1528395700_changesets_external_mergeable_stateUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x60\x00\x9f\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x6d\x65\x72\x67\x65\x61\x62\x6c\x65\x5f\x73\x74\x61\x74\x65\x20\x74\x65\x78\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x08\x2e\xc8\xa4\x60\x00\x00\x00")
+
+func _1528395700_changesets_external_mergeable_stateUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395700_changesets_external_mergeable_stateUpSql,
+		"1528395700_changesets_external_mergeable_state.up.sql",
+	)
+}
+
+func _1528395700_changesets_external_mergeable_stateUpSql() (*asset, error) {
+	bytes, err := _1528395700_changesets_external_mergeable_stateUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395700_changesets_external_mergeable_state.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x36, 0x3c, 0xa2, 0xb1, 0x8c, 0xc3, 0x7f, 0x3, 0xab, 0xa7, 0x74, 0x5a, 0x23, 0x3f, 0x31, 0x7c, 0x56, 0xfb, 0x18, 0x90, 0x9e, 0xed, 0xef, 0x8a, 0xc1, 0x23, 0x7e, 0x31, 0xc3, 0x26, 0x2b, 0x12}}
+	return a, nil
+}
+
+var __1528395701_changeset_bulk_operationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x75\x00\x8a\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x62\x75\x6c\x6b\x5f\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x5f\x6a\x6f\x62\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x62\x75\x6c\x6b\x5f\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xe4\x25\x21\x4b\x75\x00\x00\x00")
+
+func _1528395701_changeset_bulk_operationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_changeset_bulk_operationsDownSql,
+		"1528395701_changeset_bulk_operations.down.sql",
+	)
+}
+
+func _1528395701_changeset_bulk_operationsDownSql() (*asset, error) {
+	bytes, err := _1528395701_changeset_bulk_operationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_changeset_bulk_operations.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x14, 0xf7, 0x41, 0x3e, 0x7a, 0xbc, 0x2d, 0x67, 0xb, 0xd0, 0x4c, 0xdb, 0xd4, 0x6d, 0x23, 0x0, 0xa8, 0x8e, 0x72, 0xa, 0x66, 0xe2, 0x60, 0x96, 0xaa, 0x5c, 0x2f, 0x95, 0xb0, 0x2d, 0x2e, 0xb7}}
+	return a, nil
+}
+
+var __1528395701_changeset_bulk_operationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x53\x41\xcf\x9a\x40\x14\xbc\xf3\x2b\xde\x4d\x48\xbe\x43\xef\x9e\xf8\x60\xbf\x86\x14\xa1\x01\x4c\xf4\xb4\x59\xe1\x89\xdb\xca\x42\x77\x97\xd8\xf4\xd7\x37\x2c\x28\x56\xab\x10\x6f\xdf\x51\x77\x66\xde\x0c\x6f\xde\x3b\xf9\x1a\x44\x4b\xcb\xf2\x12\xe2\x66\x04\x32\xf7\x3d\x24\x10\x7c\x40\x14\x67\x40\x36\x41\x9a\xa5\x90\x1f\x98\x28\x51\xa1\xa6\xbb\xf6\xf8\x93\xd6\x0d\x4a\xa6\x79\x2d\x14\xd8\x16\x00\x00\x2f\x60\xc7\x4b\x85\x92\xb3\x23\x7c\x4f\x82\x95\x9b\x6c\xe1\x1b\xd9\xbe\x99\xd7\x9c\x55\x0d\xe3\xa5\xa0\x3d\x8c\x0b\x6d\xb4\xa3\x75\x18\x42\x42\x3e\x48\x42\x22\x8f\xa4\x17\x98\xb2\x79\xe1\x40\x1c\x81\x4f\x42\x92\x11\xf0\xdc\xd4\x73\x7d\x02\x7e\x07\x4d\x3a\x77\xbd\x6c\xab\x50\x76\x92\x5c\x68\x2c\x51\xfe\x57\xb3\xc3\xcc\xd1\x33\x82\x97\x58\xa0\xf1\xf7\xe8\x71\x08\x51\x57\x15\x0a\xfd\xef\x53\xe7\xc9\x5d\x87\x19\x2c\x16\x83\x46\x2e\x91\x69\x2c\x28\xd3\xa0\x79\x85\x4a\xb3\xaa\x81\x13\xd7\x07\xf3\x13\xfe\xd4\x02\xef\xd9\xa2\x3e\xd9\xce\x10\xaa\x29\x5e\xe4\x5b\xce\xb8\xc3\x20\xf2\xc9\x66\xee\x0e\xe9\xf5\x7e\xe2\xe8\x31\xd0\xbe\x02\x3a\xaf\x15\x86\xfe\xa8\x77\xf3\x4a\x73\xc3\x9b\xa8\xce\x43\xcb\xf3\xaa\x34\xda\x9d\x39\x67\x76\xa7\x94\x66\x1a\x1f\x95\xe6\x57\x8b\x2d\x16\x8b\xde\xc3\x9e\xf1\x63\x2b\x91\x56\xa8\x14\x2b\x7b\xce\xdb\x59\x43\x4e\x74\x62\x90\xe0\x82\xab\xc3\x1c\x64\x23\xeb\x1c\x95\xa2\x6c\xaf\x51\x4e\x60\x45\x5b\x51\xd9\x7d\x5c\x75\x7f\x6a\xe7\x28\x5f\x46\xe8\x90\xe3\x39\xf8\x93\xde\x8a\xa9\xef\xed\x7f\xcf\xaf\xc6\x50\xec\x3b\x8a\xb3\x7c\x7d\x7e\xdf\xa9\xc9\x99\x06\x66\x82\xc6\xab\x55\x90\x2d\xad\xbf\x03\x00\x2b\x10\x7c\x22\xe9\x05\x00\x00")
+
+func _1528395701_changeset_bulk_operationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_changeset_bulk_operationsUpSql,
+		"1528395701_changeset_bulk_operations.up.sql",
+	)
+}
+
+func _1528395701_changeset_bulk_operationsUpSql() (*asset, error) {
+	bytes, err := _1528395701_changeset_bulk_operationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_changeset_bulk_operations.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf8, 0x18, 0x8d, 0x33, 0xbf, 0xdb, 0x76, 0x7b, 0x6c, 0xe, 0xe6, 0x20, 0x8b, 0x59, 0x78, 0x9c, 0xda, 0x7f, 0x3e, 0x2c, 0x98, 0xd4, 0x80, 0x1, 0xbc, 0xac, 0x2d, 0x8d, 0xa5, 0xd5, 0xbc, 0x9d}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -2095,6 +2431,22 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395692_add_campaign_specs_and_changeset_specs.up.sql":                _1528395692_add_campaign_specs_and_changeset_specsUpSql,
 	"1528395693_remove_old_campaigns_workflow_tables.down.sql":                _1528395693_remove_old_campaigns_workflow_tablesDownSql,
 	"1528395693_remove_old_campaigns_workflow_tables.up.sql":                  _1528395693_remove_old_campaigns_workflow_tablesUpSql,
+	"1528395694_lsif_indexes_indexer.down.sql":                                _1528395694_lsif_indexes_indexerDownSql,
+	"1528395694_lsif_indexes_indexer.up.sql":                                  _1528395694_lsif_indexes_indexerUpSql,
+	"1528395695_sub_repo_permissions.down.sql":                                _1528395695_sub_repo_permissionsDownSql,
+	"1528395695_sub_repo_permissions.up.sql":                                  _1528395695_sub_repo_permissionsUpSql,
+	"1528395696_saved_search_webhooks.down.sql":                               _1528395696_saved_search_webhooksDownSql,
+	"1528395696_saved_search_webhooks.up.sql":                                 _1528395696_saved_search_webhooksUpSql,
+	"1528395697_query_runner_state_results.down.sql":                          _1528395697_query_runner_state_resultsDownSql,
+	"1528395697_query_runner_state_results.up.sql":                            _1528395697_query_runner_state_resultsUpSql,
+	"1528395698_query_runner_leases_runs.down.sql":                            _1528395698_query_runner_leases_runsDownSql,
+	"1528395698_query_runner_leases_runs.up.sql":                              _1528395698_query_runner_leases_runsUpSql,
+	"1528395699_campaign_spec_jobs.down.sql":                                  _1528395699_campaign_spec_jobsDownSql,
+	"1528395699_campaign_spec_jobs.up.sql":                                    _1528395699_campaign_spec_jobsUpSql,
+	"1528395700_changesets_external_mergeable_state.down.sql":                 _1528395700_changesets_external_mergeable_stateDownSql,
+	"1528395700_changesets_external_mergeable_state.up.sql":                   _1528395700_changesets_external_mergeable_stateUpSql,
+	"1528395701_changeset_bulk_operations.down.sql":                           _1528395701_changeset_bulk_operationsDownSql,
+	"1528395701_changeset_bulk_operations.up.sql":                             _1528395701_changeset_bulk_operationsUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -2229,6 +2581,22 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395692_add_campaign_specs_and_changeset_specs.up.sql":                {_1528395692_add_campaign_specs_and_changeset_specsUpSql, map[string]*bintree{}},
 	"1528395693_remove_old_campaigns_workflow_tables.down.sql":                {_1528395693_remove_old_campaigns_workflow_tablesDownSql, map[string]*bintree{}},
 	"1528395693_remove_old_campaigns_workflow_tables.up.sql":                  {_1528395693_remove_old_campaigns_workflow_tablesUpSql, map[string]*bintree{}},
+	"1528395694_lsif_indexes_indexer.down.sql":                                {_1528395694_lsif_indexes_indexerDownSql, map[string]*bintree{}},
+	"1528395694_lsif_indexes_indexer.up.sql":                                  {_1528395694_lsif_indexes_indexerUpSql, map[string]*bintree{}},
+	"1528395695_sub_repo_permissions.down.sql":                                {_1528395695_sub_repo_permissionsDownSql, map[string]*bintree{}},
+	"1528395695_sub_repo_permissions.up.sql":                                  {_1528395695_sub_repo_permissionsUpSql, map[string]*bintree{}},
+	"1528395696_saved_search_webhooks.down.sql":                               {_1528395696_saved_search_webhooksDownSql, map[string]*bintree{}},
+	"1528395696_saved_search_webhooks.up.sql":                                 {_1528395696_saved_search_webhooksUpSql, map[string]*bintree{}},
+	"1528395697_query_runner_state_results.down.sql":                          {_1528395697_query_runner_state_resultsDownSql, map[string]*bintree{}},
+	"1528395697_query_runner_state_results.up.sql":                            {_1528395697_query_runner_state_resultsUpSql, map[string]*bintree{}},
+	"1528395698_query_runner_leases_runs.down.sql":                            {_1528395698_query_runner_leases_runsDownSql, map[string]*bintree{}},
+	"1528395698_query_runner_leases_runs.up.sql":                              {_1528395698_query_runner_leases_runsUpSql, map[string]*bintree{}},
+	"1528395699_campaign_spec_jobs.down.sql":                                  {_1528395699_campaign_spec_jobsDownSql, map[string]*bintree{}},
+	"1528395699_campaign_spec_jobs.up.sql":                                    {_1528395699_campaign_spec_jobsUpSql, map[string]*bintree{}},
+	"1528395700_changesets_external_mergeable_state.down.sql":                 {_1528395700_changesets_external_mergeable_stateDownSql, map[string]*bintree{}},
+	"1528395700_changesets_external_mergeable_state.up.sql":                   {_1528395700_changesets_external_mergeable_stateUpSql, map[string]*bintree{}},
+	"1528395701_changeset_bulk_operations.down.sql":                           {_1528395701_changeset_bulk_operationsDownSql, map[string]*bintree{}},
+	"1528395701_changeset_bulk_operations.up.sql":                             {_1528395701_changeset_bulk_operationsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
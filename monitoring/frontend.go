@@ -400,6 +400,43 @@ func Frontend() *Container {
 					},
 				},
 			},
+			{
+				Title: "Service level objectives",
+				Rows: []Row{
+					{
+						{
+							Name:        "http_availability",
+							Description: "requests without server errors",
+							SLO: &SLO{
+								Kind:      Availability,
+								Objective: 0.995,
+								Total:     `src_http_request_duration_seconds_count{job="frontend"}`,
+								Bad:       `src_http_request_duration_seconds_count{job="frontend",code=~"5.."}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Check the logs of the frontend** for errors, e.g. with 'kubectl logs sourcegraph-frontend' or 'docker logs sourcegraph-frontend'.
+								- **Check the other alerts of the frontend** for services or databases that it can't reach.
+							`,
+						},
+						{
+							Name:        "search_latency",
+							Description: "browser search requests completing within 5s",
+							SLO: &SLO{
+								Kind:      Latency,
+								Objective: 0.95,
+								Total:     `src_graphql_field_seconds_count{type="Search",field="results",error="false",source="browser",request_name!="CodeIntelSearch"}`,
+								Good:      `src_graphql_field_seconds_bucket{type="Search",field="results",error="false",source="browser",request_name!="CodeIntelSearch",le="5"}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Get details on the exact queries that are slow** by configuring '"observability.logSlowSearches": 5,' in the site configuration and looking for 'frontend' warning logs prefixed with 'slow search request' for additional details.
+								- **Check that most repositories are indexed** by visiting https://sourcegraph.example.com/site-admin/repositories?filter=needs-index (it should show few or no results.)
+							`,
+						},
+					},
+				},
+			},
 			{
 				Title:  "Container monitoring (not available on server)",
 				Hidden: true,
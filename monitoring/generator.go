@@ -138,7 +138,8 @@ type Observable struct {
 	//
 	Description string
 
-	// Query is the actual Prometheus query that should be observed.
+	// Query is the actual Prometheus query that should be observed. It must be empty for
+	// observables that define an SLO, which observe the ratio of bad events instead.
 	Query string
 
 	// DataMayNotExist indicates if the query may not return data until some event occurs in the
@@ -157,12 +158,22 @@ type Observable struct {
 	// When false, alerts will fire if the query returns NaN.
 	DataMayBeNaN bool
 
-	// Warning and Critical alert definitions. At least a Warning alert must be present.
+	// Warning and Critical alert definitions. At least a Warning alert must be present, unless
+	// the observable defines an SLO.
 	//
 	// See README.md for why it is intentionally impossible to create a dashboard to monitor
 	// something without at least a warning alert being defined.
 	Warning, Critical Alert
 
+	// SLO, if non-nil, defines a service level objective for the container. The observable then
+	// observes the ratio of bad events, and instead of Warning and Critical threshold alerts,
+	// error budget burn rate alerts are generated for it (see SLO). The SLOs of all containers
+	// are also shown on a generated SLO dashboard.
+	//
+	// The Description of the observable should describe the good events, e.g. "search requests
+	// without server errors".
+	SLO *SLO
+
 	// PossibleSolutions is Markdown describing possible solutions in the event that the alert is
 	// firing. If there is no clear potential resolution, "none" must be explicitly stated.
 	//
@@ -201,7 +212,17 @@ func (o Observable) validate() error {
 	if v := string([]rune(o.Description)[0]); v != strings.ToLower(v) {
 		return fmt.Errorf("Observable.Description must be lowercase; found \"%s\"", o.Description)
 	}
-	if o.Warning.isEmpty() && o.Critical.isEmpty() {
+	if o.SLO != nil {
+		if err := o.SLO.validate(); err != nil {
+			return err
+		}
+		if o.Query != "" {
+			return errors.New("Observable.Query must be empty for SLOs, it is derived from the SLO")
+		}
+		if !o.Warning.isEmpty() || !o.Critical.isEmpty() {
+			return errors.New("Warning and Critical alerts must not be defined for SLOs, burn rate alerts are generated instead")
+		}
+	} else if o.Warning.isEmpty() && o.Critical.isEmpty() {
 		return fmt.Errorf("%s: a Warning or Critical alert MUST be defined", o.Name)
 	}
 	if err := o.Warning.validate(); err != nil && !o.Warning.isEmpty() {
@@ -223,6 +244,14 @@ func (o Observable) validate() error {
 	return nil
 }
 
+// query returns the Prometheus query that is observed.
+func (o Observable) query() string {
+	if o.SLO != nil {
+		return o.SLO.errorRatio("5m")
+	}
+	return o.Query
+}
+
 // Alert defines when an alert would be considered firing.
 type Alert struct {
 	// GreaterOrEqual, when non-zero, indicates the alert should fire when
@@ -510,6 +539,19 @@ func (c *Container) dashboard() *sdk.Board {
 					Show:     true,
 				}
 
+				if o.SLO != nil {
+					// Error budget threshold, with the error ratio shown as a percentage.
+					leftAxis.Format = "percentunit"
+					panel.GraphPanel.Thresholds = append(panel.GraphPanel.Thresholds, sdk.Threshold{
+						Value:     float32(o.SLO.errorBudget()),
+						Op:        "gt",
+						ColorMode: "custom",
+						Fill:      true,
+						Line:      false,
+						FillColor: "rgba(255, 73, 53, 0.8)",
+					})
+				}
+
 				if o.Warning.GreaterOrEqual != 0 {
 					// Warning threshold
 					panel.GraphPanel.Thresholds = append(panel.GraphPanel.Thresholds, sdk.Threshold{
@@ -570,7 +612,7 @@ func (c *Container) dashboard() *sdk.Board {
 					},
 				}
 				panel.AddTarget(&sdk.Target{
-					Expr:         o.Query,
+					Expr:         o.query(),
 					LegendFormat: opt.legendFormat,
 					Interval:     opt.interval,
 				})
@@ -615,6 +657,18 @@ func (c *Container) promAlertsFile() *promRulesFile {
 	for _, g := range c.Groups {
 		for _, r := range g.Rows {
 			for _, o := range r {
+				if o.SLO != nil {
+					for _, alert := range sloBurnRateAlerts {
+						group.AppendRow(c.sloAlertQuery(o, alert.conditions), map[string]string{
+							"name":         o.Name,
+							"level":        alert.level,
+							"service_name": c.Name,
+							"description":  c.sloAlertDescription(o, alert.conditions),
+						})
+					}
+					continue
+				}
+
 				for level, alert := range map[string]Alert{
 					"warning":  o.Warning,
 					"critical": o.Critical,
@@ -773,6 +827,13 @@ for assistance.
 					fmt.Fprintf(&b, "# %s: %s\n\n", c.Name, o.Name)
 
 					fmt.Fprintf(&b, "**Descriptions:**\n")
+					if o.SLO != nil {
+						for _, alert := range sloBurnRateAlerts {
+							fmt.Fprintf(&b, "\n- _%s_ (`%s`)\n\n",
+								c.sloAlertDescription(o, alert.conditions),
+								prometheusAlertName(alert.level, c.Name, o.Name))
+						}
+					}
 					for _, alert := range []struct {
 						level     string
 						threshold Alert
@@ -903,6 +964,39 @@ func main() {
 			if err != nil {
 				log.Fatal(err)
 			}
+
+			if promSLOFile := container.promSLOFile(); promSLOFile != nil {
+				data, err := yaml.Marshal(promSLOFile)
+				if err != nil {
+					log.Fatal(err)
+				}
+				fileName := strings.Replace(container.Name, "-", "_", -1) + "_slo_rules.yml"
+				// #nosec G306  prometheus runs as nobody
+				err = ioutil.WriteFile(filepath.Join(prometheusDir, fileName), data, 0666)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+	}
+
+	if board := sloDashboard(containers); board != nil && grafanaDir != "" {
+		data, err := json.MarshalIndent(board, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		// #nosec G306  grafana runs as UID 472
+		err = ioutil.WriteFile(filepath.Join(grafanaDir, board.UID+".json"), data, 0666)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if reload {
+			ctx := context.Background()
+			client := sdk.NewClient("http://127.0.0.1:3370", "admin:admin", sdk.DefaultHTTPClient)
+			_, err := client.SetDashboard(ctx, *board, sdk.SetDashboardParams{Overwrite: true})
+			if err != nil {
+				log.Fatal("updating dashboard:", err)
+			}
 		}
 	}
 
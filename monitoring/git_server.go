@@ -81,6 +81,43 @@ func GitServer() *Container {
 					},
 				},
 			},
+			{
+				Title: "Service level objectives",
+				Rows: []Row{
+					{
+						{
+							Name:        "exec_availability",
+							Description: "git commands that gitserver was able to run",
+							SLO: &SLO{
+								Kind:      Availability,
+								Objective: 0.995,
+								Total:     `src_gitserver_exec_duration_seconds_count`,
+								Bad:       `src_gitserver_exec_duration_seconds_count{status="-10810"}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Check the logs of gitserver** for errors running git commands.
+								- **Check that gitserver has enough disk space and memory** on the Git Server dashboard.
+							`,
+						},
+						{
+							Name:        "exec_latency",
+							Description: "git commands completing within 5s",
+							SLO: &SLO{
+								Kind:      Latency,
+								Objective: 0.95,
+								Total:     `src_gitserver_exec_duration_seconds_count`,
+								Good:      `src_gitserver_exec_duration_seconds_bucket{le="5"}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Check the number of concurrently running git commands** on the Git Server dashboard, and consider adding gitserver replicas if it is regularly high.
+								- **Check for slow git commands** in the gitserver logs, which are prefixed with 'Long exec request'.
+							`,
+						},
+					},
+				},
+			},
 			{
 				Title:  "Container monitoring (not available on server)",
 				Hidden: true,
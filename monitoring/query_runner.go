@@ -10,6 +10,18 @@ func QueryRunner() *Container {
 				Title: "General",
 				Rows: []Row{
 					{
+						{
+							Name:            "saved_query_run_lag",
+							Description:     "90th percentile time saved queries wait to run after they are due",
+							Query:           `histogram_quantile(0.90, sum by (le)(rate(src_query_runner_run_lag_seconds_bucket[5m])))`,
+							DataMayNotExist: true,
+							Warning:         Alert{GreaterOrEqual: 600},
+							PanelOptions:    PanelOptions().LegendFormat("lag").Unit(Seconds),
+							PossibleSolutions: `
+								- **Scale the query runner horizontally** by increasing the number of query-runner replicas, which share the saved searches between them.
+								- **Inspect the runs of individual saved searches** with the 'runs' field of saved searches in the GraphQL API to find slow or failing queries.
+							`,
+						},
 						sharedFrontendInternalAPIErrorResponses("query-runner"),
 					},
 				},
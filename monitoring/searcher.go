@@ -23,6 +23,43 @@ func Searcher() *Container {
 					},
 				},
 			},
+			{
+				Title: "Service level objectives",
+				Rows: []Row{
+					{
+						{
+							Name:        "search_availability",
+							Description: "unindexed search requests without server errors",
+							SLO: &SLO{
+								Kind:      Availability,
+								Objective: 0.99,
+								Total:     `searcher_service_request_total{code!="canceled"}`,
+								Bad:       `searcher_service_request_total{code=~"5.."}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Check the logs of searcher** for errors, e.g. failures to fetch archives of repositories from gitserver.
+							`,
+						},
+						{
+							Name:        "search_latency",
+							Description: "unindexed search requests completing within 10s",
+							SLO: &SLO{
+								Kind:      Latency,
+								Objective: 0.95,
+								Total:     `searcher_service_request_duration_seconds_count{code!="canceled"}`,
+								Good:      `searcher_service_request_duration_seconds_bucket{code!="canceled",le="10"}`,
+							},
+							PanelOptions: PanelOptions().LegendFormat("error ratio"),
+							PossibleSolutions: `
+								- **Check that most repositories are indexed** by visiting https://sourcegraph.example.com/site-admin/repositories?filter=needs-index (it should show few or no results.)
+								- **Kubernetes:** Consider increasing the number of searcher replicas, or the CPU limits in 'searcher.Deployment.yaml'.
+								- **Docker Compose:** Consider increasing 'cpus:' of the searcher container in 'docker-compose.yml'.
+							`,
+						},
+					},
+				},
+			},
 			{
 				Title:  "Container monitoring (not available on server)",
 				Hidden: true,
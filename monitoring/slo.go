@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/grafana-tools/sdk"
+)
+
+// SLOKind is the kind of service level objective.
+type SLOKind string
+
+const (
+	// Availability objectives are about the fraction of events (e.g. requests) that succeed.
+	Availability SLOKind = "availability"
+
+	// Latency objectives are about the fraction of events that complete within some duration.
+	Latency SLOKind = "latency"
+)
+
+// SLO describes a service level objective: the fraction of events (e.g. requests) that should be
+// good over a period of 30 days. The remaining fraction is the error budget.
+//
+// For every SLO, the generator records the ratio of bad events over several windows and alerts
+// when the error budget is burning too fast, using the multi-window, multi-burn-rate alerts
+// described in https://sre.google/workbook/alerting-on-slos/:
+//
+//   - critical: 2% of the budget spent in 1h (14.4x burn rate) or 5% in 6h (6x burn rate)
+//   - warning: 10% of the budget spent in 1d (3x burn rate) or 10% in 3d (1x burn rate)
+//
+// Each condition must hold over both a long and a short window, so that alerts fire quickly
+// and resolve quickly once the error ratio is back to normal.
+type SLO struct {
+	// Kind is the kind of the objective.
+	Kind SLOKind
+
+	// Objective is the fraction of events that should be good, e.g. 0.995 for 99.5%.
+	Objective float64
+
+	// Total is a selector of the counter (or counters) of all events, e.g.
+	// `searcher_service_request_total{code!="canceled"}`.
+	Total string
+
+	// Bad is a selector of the counter of bad events, e.g.
+	// `searcher_service_request_total{code=~"5.."}`. Exactly one of Bad and Good must be set.
+	Bad string
+
+	// Good is a selector of the counter of good events, e.g. the bucket of a histogram for
+	// latency objectives: `searcher_service_request_duration_seconds_bucket{le="5"}`.
+	Good string
+}
+
+func (s *SLO) validate() error {
+	if s.Kind != Availability && s.Kind != Latency {
+		return fmt.Errorf("SLO.Kind must be %q or %q; found %q", Availability, Latency, s.Kind)
+	}
+	if s.Objective <= 0 || s.Objective >= 1 {
+		return fmt.Errorf("SLO.Objective must be between 0 and 1 (exclusive); found %v", s.Objective)
+	}
+	if s.Total == "" {
+		return errors.New("SLO.Total must be set")
+	}
+	if (s.Bad == "") == (s.Good == "") {
+		return errors.New("exactly one of SLO.Bad and SLO.Good must be set")
+	}
+	return nil
+}
+
+// errorBudget returns the fraction of events that may be bad.
+func (s *SLO) errorBudget() float64 {
+	return 1 - s.Objective
+}
+
+// errorRatio returns the query for the ratio of bad events over the window.
+func (s *SLO) errorRatio(window string) string {
+	if s.Bad != "" {
+		return fmt.Sprintf("sum(rate(%s[%s])) / sum(rate(%s[%s]))", s.Bad, window, s.Total, window)
+	}
+	return fmt.Sprintf("1 - (sum(rate(%s[%s])) / sum(rate(%s[%s])))", s.Good, window, s.Total, window)
+}
+
+// sloWindows are the windows over which the error ratio of SLOs is recorded.
+var sloWindows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+// sloBurnRateAlerts are the multi-window, multi-burn-rate alerts generated for every SLO. An
+// alert fires if the error ratio exceeds burnRate times the error budget over both the long
+// and the short window of any of its conditions.
+var sloBurnRateAlerts = []struct {
+	level      string
+	conditions []sloBurnRateCondition
+}{
+	{level: "warning", conditions: []sloBurnRateCondition{{"1d", "2h", 3}, {"3d", "6h", 1}}},
+	{level: "critical", conditions: []sloBurnRateCondition{{"1h", "5m", 14.4}, {"6h", "30m", 6}}},
+}
+
+type sloBurnRateCondition struct {
+	long, short string
+	burnRate    float64
+}
+
+// sloRecordingRule returns the name of the recording rule for the error ratio of SLOs over the
+// window.
+func sloRecordingRule(window string) string {
+	return "slo:error_ratio:rate" + window
+}
+
+// sloSelector returns the label selector for the recorded error ratios of the SLO observable.
+func (c *Container) sloSelector(o Observable) string {
+	return fmt.Sprintf(`{service_name=%q,slo=%q}`, c.Name, o.Name)
+}
+
+// sloAlertDescription generates an alert description for the specified container's SLO burn
+// rate alert.
+func (c *Container) sloAlertDescription(o Observable, conditions []sloBurnRateCondition) string {
+	var parts []string
+	for _, cond := range conditions {
+		parts = append(parts, fmt.Sprintf("%vx+ over %s and %s", cond.burnRate, cond.long, cond.short))
+	}
+	// e.g. "searcher: error budget burn rate of 14.4x+ over 1h and 5m or 6x+ over 6h and 30m (SLO: 99% of search requests without server errors)"
+	return fmt.Sprintf("%s: error budget burn rate of %s (SLO: %v%% of %s)", c.Name, strings.Join(parts, " or "), o.SLO.Objective*100, o.Description)
+}
+
+// sloAlertQuery returns the query for an SLO burn rate alert, which returns the number of
+// conditions that hold (i.e. >= 1 when it is firing), or 0 when none hold or there is no data.
+func (c *Container) sloAlertQuery(o Observable, conditions []sloBurnRateCondition) string {
+	selector := c.sloSelector(o)
+	var conds []string
+	for _, cond := range conditions {
+		threshold := fmt.Sprintf("%.6g", cond.burnRate*o.SLO.errorBudget())
+		conds = append(conds, fmt.Sprintf("(%s%s > %s and %s%s > %s)",
+			sloRecordingRule(cond.long), selector, threshold,
+			sloRecordingRule(cond.short), selector, threshold,
+		))
+	}
+	return fmt.Sprintf("count(%s) OR on() vector(0)", strings.Join(conds, " or "))
+}
+
+// promSLOFile generates the Prometheus recording rules file which records the error ratios of
+// the container's SLOs over every window in sloWindows. It returns nil if the container has no
+// SLOs.
+func (c *Container) promSLOFile() *promRulesFile {
+	group := promGroup{Name: c.Name + "-slo"}
+	for _, o := range c.sloObservables() {
+		for _, window := range sloWindows {
+			group.Rules = append(group.Rules, promRule{
+				Record: sloRecordingRule(window),
+				Labels: map[string]string{
+					"service_name": c.Name,
+					"slo":          o.Name,
+					"kind":         string(o.SLO.Kind),
+				},
+				Expr: o.SLO.errorRatio(window),
+			})
+		}
+	}
+	if len(group.Rules) == 0 {
+		return nil
+	}
+	return &promRulesFile{Groups: []promGroup{group}}
+}
+
+// sloObservables returns the observables of the container that define an SLO.
+func (c *Container) sloObservables() []Observable {
+	var observables []Observable
+	for _, g := range c.Groups {
+		for _, r := range g.Rows {
+			for _, o := range r {
+				if o.SLO != nil {
+					observables = append(observables, o)
+				}
+			}
+		}
+	}
+	return observables
+}
+
+// sloDashboard generates the Grafana dashboard for the SLOs of all containers, showing their
+// service level indicator, remaining error budget and burn rate.
+func sloDashboard(containers []*Container) *sdk.Board {
+	board := sdk.NewBoard("Service Level Objectives")
+	board.Version = uint(rand.Uint32())
+	board.UID = "slos"
+	board.ID = 0
+	board.Timezone = "utc"
+	board.Timepicker.RefreshIntervals = []string{"5s", "10s", "30s", "1m", "5m", "15m", "30m", "1h", "2h", "1d"}
+	board.Time.From = "now-7d"
+	board.Time.To = "now"
+	board.SharedCrosshair = true
+	board.AddTags("builtin")
+
+	offsetY := 0
+	for _, c := range containers {
+		observables := c.sloObservables()
+		if len(observables) == 0 {
+			continue
+		}
+
+		rowPanel := &sdk.Panel{RowPanel: &sdk.RowPanel{}}
+		rowPanel.OfType = sdk.RowType
+		rowPanel.Type = "row"
+		rowPanel.Title = c.Title
+		rowPanel.Panels = []sdk.Panel{} // cannot be null
+		setPanelPos(rowPanel, 0, offsetY)
+		board.Panels = append(board.Panels, rowPanel)
+		offsetY++
+
+		for _, o := range observables {
+			selector := c.sloSelector(o)
+			budget := fmt.Sprintf("%.6g", o.SLO.errorBudget())
+			title := upperFirst(o.Description)
+
+			sli := sloGraph(fmt.Sprintf("%s (objective: %v%%)", title, o.SLO.Objective*100), "percentunit")
+			sli.GraphPanel.Thresholds = []sdk.Threshold{sloThreshold(o.SLO.Objective, "lt", "rgba(255, 73, 53, 0.8)")}
+			for _, window := range []string{"1h", "1d"} {
+				sli.AddTarget(&sdk.Target{
+					Expr:         fmt.Sprintf("1 - %s%s", sloRecordingRule(window), selector),
+					LegendFormat: window,
+				})
+			}
+
+			remaining := sloGraph(title+": error budget remaining over 30d", "percentunit")
+			remaining.GraphPanel.Thresholds = []sdk.Threshold{sloThreshold(0, "lt", "rgba(255, 17, 36, 0.8)")}
+			remaining.AddTarget(&sdk.Target{
+				Expr:         fmt.Sprintf("1 - (avg_over_time(%s%s[30d]) / %s)", sloRecordingRule("1h"), selector, budget),
+				LegendFormat: "remaining",
+			})
+
+			burnRate := sloGraph(title+": error budget burn rate", "short")
+			burnRate.GraphPanel.Thresholds = []sdk.Threshold{
+				sloThreshold(3, "gt", "rgba(255, 73, 53, 0.8)"),
+				sloThreshold(14.4, "gt", "rgba(255, 17, 36, 0.8)"),
+			}
+			for _, window := range []string{"5m", "1h", "6h", "1d", "3d"} {
+				burnRate.AddTarget(&sdk.Target{
+					Expr:         fmt.Sprintf("%s%s / %s", sloRecordingRule(window), selector, budget),
+					LegendFormat: window,
+				})
+			}
+
+			for i, panel := range []*sdk.Panel{sli, remaining, burnRate} {
+				setPanelSize(panel, 8, 6)
+				setPanelPos(panel, i*8, offsetY)
+				board.Panels = append(board.Panels, panel)
+			}
+			offsetY += 6
+		}
+	}
+	if len(board.Panels) == 0 {
+		return nil
+	}
+	return board
+}
+
+func sloGraph(title, format string) *sdk.Panel {
+	panel := sdk.NewGraph(title)
+	panel.GraphPanel.Legend.Show = true
+	panel.GraphPanel.Fill = 1
+	panel.GraphPanel.Lines = true
+	panel.GraphPanel.Linewidth = 1
+	panel.GraphPanel.NullPointMode = "connected"
+	panel.GraphPanel.Pointradius = 2
+	panel.GraphPanel.AliasColors = map[string]string{}
+	panel.GraphPanel.Xaxis = sdk.Axis{Show: true}
+	panel.GraphPanel.Yaxes = []sdk.Axis{
+		{Format: format, LogBase: 1, Show: true},
+		{Format: "short", LogBase: 1, Show: true},
+	}
+	return panel
+}
+
+func sloThreshold(value float64, op, color string) sdk.Threshold {
+	return sdk.Threshold{
+		Value:     float32(value),
+		Op:        op,
+		ColorMode: "custom",
+		Line:      true,
+		LineColor: color,
+	}
+}
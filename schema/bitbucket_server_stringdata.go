@@ -81,6 +81,11 @@ const BitbucketServerSchemaJSON = `{
       "default": "http",
       "examples": ["ssh"]
     },
+    "partialClone": {
+      "description": "EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.",
+      "type": "boolean",
+      "default": false
+    },
     "certificate": {
       "description": "TLS certificate of the Bitbucket Server instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run ` + "`" + `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM` + "`" + `. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.",
       "type": "string",
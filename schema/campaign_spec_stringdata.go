@@ -116,9 +116,14 @@ const CampaignSpecSchemaJSON = `{
           }
         },
         "published": {
-          "type": "boolean",
-          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.",
+          "oneOf": [{ "type": "boolean" }, { "type": "string", "enum": ["draft"] }],
+          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If set to \"draft\", the pull request is created as a draft (on GitHub) or a work in progress merge request (on GitLab), which can later be marked as ready for review.",
           "$comment": "TODO(sqs): Come up with a way to specify that only a subset of changesets should be published. For example, making ` + "`" + `published` + "`" + ` an array with some include/exclude syntax items."
+        },
+        "autoMerge": {
+          "type": "boolean",
+          "description": "Whether to merge the changesets on the code host as soon as their checks have passed and they have been approved. Changesets are merged with the default merge method of their repository.",
+          "default": false
         }
       }
     }
@@ -78,8 +78,8 @@ const ChangesetSpecSchemaJSON = `{
           }
         },
         "published": {
-          "type": "boolean",
-          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+          "oneOf": [{ "type": "boolean" }, { "type": "string", "enum": ["draft"] }],
+          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If set to \"draft\", the changeset is published as a draft, which is only supported on GitHub and GitLab."
         }
       },
       "required": [
@@ -30,6 +30,11 @@ const GitHubSchemaJSON = `{
       "enum": ["http", "ssh"],
       "default": "http"
     },
+    "partialClone": {
+      "description": "EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.",
+      "type": "boolean",
+      "default": false
+    },
     "token": {
       "description": "A GitHub personal access token. Create one for GitHub.com at https://github.com/settings/tokens/new?description=Sourcegraph (for GitHub Enterprise, replace github.com with your instance's hostname). See https://docs.sourcegraph.com/admin/external_service/github#github-api-token-and-access for which scopes are required for which use cases.",
       "type": "string",
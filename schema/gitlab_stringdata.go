@@ -58,6 +58,11 @@ const GitLabSchemaJSON = `{
       "enum": ["http", "ssh"],
       "default": "http"
     },
+    "partialClone": {
+      "description": "EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.",
+      "type": "boolean",
+      "default": false
+    },
     "certificate": {
       "description": "TLS certificate of the GitLab instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run ` + "`" + `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM` + "`" + `. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.",
       "type": "string",
@@ -154,6 +159,29 @@ const GitLabSchemaJSON = `{
       "description": "Defines whether repositories from this GitLab instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable GitLab repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by GitLab); site admins can still disable them explicitly, and they'll remain disabled.",
       "type": "boolean"
     },
+    "webhooks": {
+      "description": "An array of configurations defining existing GitLab webhooks that send updates back to Sourcegraph.",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "title": "GitLabWebhook",
+        "required": ["secret"],
+        "properties": {
+          "secret": {
+            "description": "The secret token used when creating the webhook",
+            "type": "string",
+            "minLength": 1
+          }
+        }
+      },
+      "examples": [
+        [
+          {
+            "secret": "webhook-secret"
+          }
+        ]
+      ]
+    },
     "authorization": {
       "title": "GitLabAuthorization",
       "description": "If non-null, enforces GitLab repository permissions. This requires that there be an item in the ` + "`" + `auth.providers` + "`" + ` field of type \"gitlab\" with the same ` + "`" + `url` + "`" + ` field as specified in this ` + "`" + `GitLabConnection` + "`" + `.",
@@ -24,6 +24,11 @@ const OtherExternalServiceSchemaJSON = `{
       },
       "examples": ["https://github.com/?access_token=secret", "ssh://user@host.xz:2333/", "git://host.xz:2333/"]
     },
+    "partialClone": {
+      "description": "EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.",
+      "type": "boolean",
+      "default": false
+    },
     "repos": {
       "title": "List of repository clone URLs to be discovered.",
       "type": "array",
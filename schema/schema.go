@@ -8,10 +8,20 @@ import (
 	"fmt"
 )
 
+// AWSCodeCommitAuthorization description: If non-null, enforces AWS CodeCommit repository permissions. A user can read a repository if the IAM policies of the user allow the "codecommit:GitPull" action on it, as determined by the IAM policy simulator. The access key must therefore have the "iam:GetUser", "iam:ListUsers" and "iam:SimulatePrincipalPolicy" permissions.
+type AWSCodeCommitAuthorization struct {
+	// AccountID description: The ID of the AWS account that owns the repositories.
+	AccountID string `json:"accountID"`
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the IAM user to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical to IAM user names and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider AWSCodeCommitIdentityProvider `json:"identityProvider"`
+}
+
 // AWSCodeCommitConnection description: Configuration for a connection to AWS CodeCommit.
 type AWSCodeCommitConnection struct {
 	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy.
 	AccessKeyID string `json:"accessKeyID"`
+	// Authorization description: If non-null, enforces AWS CodeCommit repository permissions. A user can read a repository if the IAM policies of the user allow the "codecommit:GitPull" action on it, as determined by the IAM policy simulator. The access key must therefore have the "iam:GetUser", "iam:ListUsers" and "iam:SimulatePrincipalPolicy" permissions.
+	Authorization *AWSCodeCommitAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from AWS CodeCommit.
 	//
 	// Supports excluding by name ({"name": "git-codecommit.us-west-1.amazonaws.com/repo-name"}) or by ARN ({"id": "arn:aws:codecommit:us-west-1:999999999999:name"}).
@@ -46,6 +56,35 @@ type AWSCodeCommitGitCredentials struct {
 	Username string `json:"username"`
 }
 
+// AWSCodeCommitIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the IAM user to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical to IAM user names and `auth.enableUsernameChanges` must be set to false for security reasons.
+type AWSCodeCommitIdentityProvider struct {
+	Username *AWSCodeCommitUsernameIdentity
+}
+
+func (v AWSCodeCommitIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *AWSCodeCommitIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
+}
+
+type AWSCodeCommitUsernameIdentity struct {
+	Type string `json:"type"`
+}
+
 // AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
 type AuthAccessTokens struct {
 	// Allow description: Allow or restrict the use of access tokens. The default is "all-users-create", which enables all users to create access tokens. Use "none" to disable access tokens entirely. Use "site-admin-create" to restrict creation of new tokens to admin users (existing tokens will still work until revoked).
@@ -64,6 +103,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -85,6 +125,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -103,12 +146,20 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspace of "username" and the workspaces listed in "teams", so the user of the app password must be an administrator of these workspaces and the app password must have the "Account: Read" and "Workspace membership: Read" permissions.
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical to the nicknames of Bitbucket Cloud accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
 }
 
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
@@ -117,6 +168,8 @@ type BitbucketCloudConnection struct {
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspace of "username" and the workspaces listed in "teams", so the user of the app password must be an administrator of these workspaces and the app password must have the "Account: Read" and "Workspace membership: Read" permissions.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -145,6 +198,31 @@ type BitbucketCloudConnection struct {
 	Username string `json:"username"`
 }
 
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical to the nicknames of Bitbucket Cloud accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Username *BitbucketCloudUsernameIdentity
+}
+
+func (v BitbucketCloudIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *BitbucketCloudIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -152,6 +230,9 @@ type BitbucketCloudRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type BitbucketCloudUsernameIdentity struct {
+	Type string `json:"type"`
+}
 
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions.
 type BitbucketServerAuthorization struct {
@@ -189,6 +270,8 @@ type BitbucketServerConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Defines whether repositories from this Bitbucket Server instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Bitbucket Server repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by Bitbucket Server); site admins can still disable them explicitly, and they'll remain disabled.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// PartialClone description: EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.
+	PartialClone bool `json:"partialClone,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server instance. Also set the corresponding "username" field.
 	//
 	// For Bitbucket Server instances that support personal access tokens (Bitbucket Server version 5.5 and newer), it is recommended to provide a token instead (in the "token" field).
@@ -482,6 +565,8 @@ type GitHubConnection struct {
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
 	Orgs []string `json:"orgs,omitempty"`
+	// PartialClone description: EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.
+	PartialClone bool `json:"partialClone,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to GitHub.
 	RateLimit *GitHubRateLimit `json:"rateLimit,omitempty"`
 	// Repos description: An array of repository "owner/name" strings specifying which GitHub or GitHub Enterprise repositories to mirror on Sourcegraph.
@@ -575,6 +660,8 @@ type GitLabConnection struct {
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
 	NameTransformations []*GitLabNameTransformation `json:"nameTransformations,omitempty"`
+	// PartialClone description: EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.
+	PartialClone bool `json:"partialClone,omitempty"`
 	// ProjectQuery description: An array of strings specifying which GitLab projects to mirror on Sourcegraph. Each string is a URL path and query that targets a GitLab API endpoint returning a list of projects. If the string only contains a query, then "projects" is used as the path. Examples: "?membership=true&search=foo", "groups/mygroup/projects".
 	//
 	// The special string "none" can be used as the only element to disable this feature. Projects matched by multiple query strings are only imported once. Here are a few endpoints that return a list of projects: https://docs.gitlab.com/ee/api/projects.html#list-all-projects, https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects, https://docs.gitlab.com/ee/api/search.html#scope-projects.
@@ -593,6 +680,8 @@ type GitLabConnection struct {
 	Token string `json:"token"`
 	// Url description: URL of a GitLab instance, such as https://gitlab.example.com or (for GitLab.com) https://gitlab.com.
 	Url string `json:"url"`
+	// Webhooks description: An array of configurations defining existing GitLab webhooks that send updates back to Sourcegraph.
+	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
@@ -614,6 +703,10 @@ type GitLabRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+type GitLabWebhook struct {
+	// Secret description: The secret token used when creating the webhook
+	Secret string `json:"secret"`
+}
 
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
@@ -677,6 +770,61 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"oauth", "username", "external"})
 }
 
+// LDAPAttributes description: The LDAP attributes of user entries that are mapped to Sourcegraph user properties.
+type LDAPAttributes struct {
+	// DisplayName description: The attribute of the display name of a user.
+	DisplayName string `json:"displayName,omitempty"`
+	// Email description: The attribute of the email address of a user.
+	Email string `json:"email,omitempty"`
+	// Username description: The attribute that users sign in with, which is also used as their Sourcegraph username (after normalization). Use `sAMAccountName` for Active Directory.
+	Username string `json:"username,omitempty"`
+}
+
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which signs in users with the username and password of their account on an LDAP server (such as OpenLDAP or Active Directory). Users are looked up with a search request, then authenticated by binding as the found user with the given password.
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool           `json:"allowSignup,omitempty"`
+	Attributes  *LDAPAttributes `json:"attributes,omitempty"`
+	// BindDN description: The DN to bind as to search for users and groups. Leave empty to search anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of `bindDN`.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:636 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`.
+	Certificate string           `json:"certificate,omitempty"`
+	DisplayName string           `json:"displayName,omitempty"`
+	GroupSearch *LDAPGroupSearch `json:"groupSearch,omitempty"`
+	// InsecureSkipVerify description: Disables the verification of the TLS certificate of the LDAP server. This is insecure and should only be used for testing.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// StartTLS description: Upgrades the connection to TLS with the StartTLS operation before binding. Only applies to ldap:// URLs.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: URL of the LDAP server. Use the ldaps:// scheme for LDAP over TLS, or set `startTLS` to upgrade an ldap:// connection to TLS.
+	Url        string         `json:"url"`
+	UserSearch LDAPUserSearch `json:"userSearch"`
+}
+
+// LDAPGroupSearch description: If set, the LDAP groups of users are looked up when they sign in, and users are added to or removed from the Sourcegraph organizations mapped to their groups in `orgs`.
+type LDAPGroupSearch struct {
+	// BaseDN description: The DN of the subtree to search for groups.
+	BaseDN string `json:"baseDN"`
+	// Filter description: An additional LDAP filter that group entries must match.
+	Filter string `json:"filter,omitempty"`
+	// MemberAttribute description: The attribute of group entries that contains the DNs of the members.
+	MemberAttribute string `json:"memberAttribute,omitempty"`
+	// NameAttribute description: The attribute of group entries that contains the name of the group, which is matched against the keys of `orgs`.
+	NameAttribute string `json:"nameAttribute,omitempty"`
+	// Orgs description: Maps LDAP group names to the names of Sourcegraph organizations. Members of a group are added to its organization on sign-in, and users that are no longer members of a group are removed from its organization. Organizations must already exist.
+	Orgs map[string]string `json:"orgs"`
+}
+
+// LDAPUserSearch description: How to find the LDAP entry of a user signing in.
+type LDAPUserSearch struct {
+	// BaseDN description: The DN of the subtree to search for users.
+	BaseDN string `json:"baseDN"`
+	// Filter description: An additional LDAP filter that user entries must match, for example to only allow members of a group to sign in. It is combined with an equality filter on the username attribute.
+	Filter string `json:"filter,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
 	// Sentry description: Configuration for Sentry
@@ -835,7 +983,9 @@ type OpenIDConnectAuthProvider struct {
 
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
-	Repos []string `json:"repos"`
+	// PartialClone description: EXPERIMENTAL: Clone repositories from this code host as blob-less partial clones (git clone --filter=blob:none) over Git protocol version 2. Commits and trees are cloned up front, and file contents are fetched on demand the first time they are read. This reduces clone time and disk usage for very large repositories, but requires the code host to support partial clone and makes the first reads of files slower.
+	PartialClone bool     `json:"partialClone,omitempty"`
+	Repos        []string `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1112,6 +1262,10 @@ type SiteConfiguration struct {
 	GitCloneURLToRepositoryName []*CloneURLToRepositoryName `json:"git.cloneURLToRepositoryName,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitServerConsistentHashing description: EXPERIMENTAL: Assign repositories to gitservers with consistent (rendezvous) hashing, so that adding or removing a gitserver only moves the repositories of that gitserver. Changing this option moves most repositories once. Repositories that move are fetched from the gitserver that previously had them rather than from the code host.
+	GitServerConsistentHashing bool `json:"gitServerConsistentHashing,omitempty"`
+	// GitServerReplicationFactor description: EXPERIMENTAL: The number of gitservers each repository is cloned on. When greater than 1, requests for a repository fail over to another replica if its primary gitserver is unreachable. Values larger than the number of gitservers are capped at the number of gitservers.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
 	GithubClientID string `json:"githubClientID,omitempty"`
 	// GithubClientSecret description: Client secret for GitHub. (DEPRECATED)
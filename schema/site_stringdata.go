@@ -333,6 +333,19 @@ const SiteSchemaJSON = `{
       "default": 5,
       "group": "External services"
     },
+    "gitServerReplicationFactor": {
+      "description": "EXPERIMENTAL: The number of gitservers each repository is cloned on. When greater than 1, requests for a repository fail over to another replica if its primary gitserver is unreachable. Values larger than the number of gitservers are capped at the number of gitservers.",
+      "type": "integer",
+      "minimum": 1,
+      "default": 1,
+      "group": "External services"
+    },
+    "gitServerConsistentHashing": {
+      "description": "EXPERIMENTAL: Assign repositories to gitservers with consistent (rendezvous) hashing, so that adding or removing a gitserver only moves the repositories of that gitserver. Changing this option moves most repositories once. Repositories that move are fetched from the gitserver that previously had them rather than from the code host.",
+      "type": "boolean",
+      "default": false,
+      "group": "External services"
+    },
     "repoListUpdateInterval": {
       "description": "Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.",
       "type": "integer",
@@ -735,7 +748,7 @@ const SiteSchemaJSON = `{
         "properties": {
           "type": {
             "type": "string",
-            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab"]
+            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"]
           }
         },
         "oneOf": [
@@ -744,7 +757,8 @@ const SiteSchemaJSON = `{
           { "$ref": "#/definitions/OpenIDConnectAuthProvider" },
           { "$ref": "#/definitions/HTTPHeaderAuthProvider" },
           { "$ref": "#/definitions/GitHubAuthProvider" },
-          { "$ref": "#/definitions/GitLabAuthProvider" }
+          { "$ref": "#/definitions/GitLabAuthProvider" },
+          { "$ref": "#/definitions/LDAPAuthProvider" }
         ],
         "!go": {
           "taggedUnionType": true
@@ -1021,6 +1035,144 @@ const SiteSchemaJSON = `{
         "displayName": { "$ref": "#/definitions/AuthProviderCommon/properties/displayName" }
       }
     },
+    "LDAPAuthProvider": {
+      "description": "Configures the LDAP authentication provider, which signs in users with the username and password of their account on an LDAP server (such as OpenLDAP or Active Directory). Users are looked up with a search request, then authenticated by binding as the found user with the given password.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["type", "url", "userSearch"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "const": "ldap"
+        },
+        "url": {
+          "description": "URL of the LDAP server. Use the ldaps:// scheme for LDAP over TLS, or set ` + "`" + `startTLS` + "`" + ` to upgrade an ldap:// connection to TLS.",
+          "type": "string",
+          "pattern": "^ldaps?://",
+          "examples": ["ldaps://ldap.example.com", "ldap://ad.example.com:389"]
+        },
+        "displayName": { "$ref": "#/definitions/AuthProviderCommon/properties/displayName" },
+        "startTLS": {
+          "description": "Upgrades the connection to TLS with the StartTLS operation before binding. Only applies to ldap:// URLs.",
+          "type": "boolean",
+          "default": false
+        },
+        "certificate": {
+          "description": "TLS certificate of the LDAP server. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run ` + "`" + `openssl s_client -connect HOST:636 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM` + "`" + `.",
+          "type": "string",
+          "pattern": "^-----BEGIN CERTIFICATE-----\n",
+          "examples": ["-----BEGIN CERTIFICATE-----\n..."]
+        },
+        "insecureSkipVerify": {
+          "description": "Disables the verification of the TLS certificate of the LDAP server. This is insecure and should only be used for testing.",
+          "type": "boolean",
+          "default": false
+        },
+        "bindDN": {
+          "description": "The DN to bind as to search for users and groups. Leave empty to search anonymously.",
+          "type": "string",
+          "examples": ["cn=sourcegraph,ou=services,dc=example,dc=com"]
+        },
+        "bindPassword": {
+          "description": "The password of ` + "`" + `bindDN` + "`" + `.",
+          "type": "string"
+        },
+        "userSearch": {
+          "$ref": "#/definitions/LDAPUserSearch"
+        },
+        "attributes": {
+          "$ref": "#/definitions/LDAPAttributes"
+        },
+        "groupSearch": {
+          "$ref": "#/definitions/LDAPGroupSearch"
+        },
+        "allowSignup": {
+          "description": "Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.",
+          "type": "boolean",
+          "default": true,
+          "!go": { "pointer": true }
+        }
+      }
+    },
+    "LDAPUserSearch": {
+      "description": "How to find the LDAP entry of a user signing in.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["baseDN"],
+      "properties": {
+        "baseDN": {
+          "description": "The DN of the subtree to search for users.",
+          "type": "string",
+          "examples": ["ou=people,dc=example,dc=com"]
+        },
+        "filter": {
+          "description": "An additional LDAP filter that user entries must match, for example to only allow members of a group to sign in. It is combined with an equality filter on the username attribute.",
+          "type": "string",
+          "examples": ["(objectClass=person)", "(memberOf=cn=sourcegraph-users,ou=groups,dc=example,dc=com)"]
+        }
+      }
+    },
+    "LDAPAttributes": {
+      "description": "The LDAP attributes of user entries that are mapped to Sourcegraph user properties.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "username": {
+          "description": "The attribute that users sign in with, which is also used as their Sourcegraph username (after normalization). Use ` + "`" + `sAMAccountName` + "`" + ` for Active Directory.",
+          "type": "string",
+          "default": "uid",
+          "examples": ["sAMAccountName"]
+        },
+        "email": {
+          "description": "The attribute of the email address of a user.",
+          "type": "string",
+          "default": "mail"
+        },
+        "displayName": {
+          "description": "The attribute of the display name of a user.",
+          "type": "string",
+          "default": "cn",
+          "examples": ["displayName"]
+        }
+      }
+    },
+    "LDAPGroupSearch": {
+      "description": "If set, the LDAP groups of users are looked up when they sign in, and users are added to or removed from the Sourcegraph organizations mapped to their groups in ` + "`" + `orgs` + "`" + `.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["baseDN", "orgs"],
+      "properties": {
+        "baseDN": {
+          "description": "The DN of the subtree to search for groups.",
+          "type": "string",
+          "examples": ["ou=groups,dc=example,dc=com"]
+        },
+        "filter": {
+          "description": "An additional LDAP filter that group entries must match.",
+          "type": "string",
+          "default": "(objectClass=groupOfNames)",
+          "examples": ["(objectClass=group)"]
+        },
+        "memberAttribute": {
+          "description": "The attribute of group entries that contains the DNs of the members.",
+          "type": "string",
+          "default": "member"
+        },
+        "nameAttribute": {
+          "description": "The attribute of group entries that contains the name of the group, which is matched against the keys of ` + "`" + `orgs` + "`" + `.",
+          "type": "string",
+          "default": "cn"
+        },
+        "orgs": {
+          "description": "Maps LDAP group names to the names of Sourcegraph organizations. Members of a group are added to its organization on sign-in, and users that are no longer members of a group are removed from its organization. Organizations must already exist.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "examples": [{ "engineering": "eng", "platform-team": "platform" }]
+        }
+      }
+    },
     "AuthProviderCommon": {
       "$comment": "This schema is not used directly. The *AuthProvider schemas refer to its properties directly.",
       "description": "Common properties for authentication providers.",
@@ -25,6 +25,7 @@ export enum FilterType {
     repohascommitafter = 'repohascommitafter',
     file = 'file',
     type = 'type',
+    kind = 'kind',
     case = 'case',
     lang = 'lang',
     fork = 'fork',
@@ -43,6 +43,7 @@ describe('getCompletionItems()', () => {
             '-file',
             'fork',
             'index',
+            'kind',
             'lang',
             '-lang',
             'message',
@@ -97,6 +98,7 @@ describe('getCompletionItems()', () => {
             '-file',
             'fork',
             'index',
+            'kind',
             'lang',
             '-lang',
             'message',
@@ -132,6 +134,7 @@ describe('getCompletionItems()', () => {
             '-file',
             'fork',
             'index',
+            'kind',
             'lang',
             '-lang',
             'message',
@@ -174,6 +177,7 @@ describe('getCompletionItems()', () => {
             '-file',
             'fork',
             'index',
+            'kind',
             'lang',
             '-lang',
             'message',
@@ -212,6 +216,7 @@ describe('getCompletionItems()', () => {
             '-file',
             'fork',
             'index',
+            'kind',
             'lang',
             '-lang',
             'message',
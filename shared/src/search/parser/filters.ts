@@ -51,6 +51,22 @@ const LANGUAGES: string[] = [
     'typescript',
 ]
 
+const SYMBOL_KINDS: string[] = [
+    'class',
+    'constant',
+    'enum',
+    'field',
+    'function',
+    'interface',
+    'method',
+    'module',
+    'namespace',
+    'package',
+    'struct',
+    'type',
+    'variable',
+]
+
 export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
     Record<Exclude<FilterType, NegatableFilter>, BaseFilterDefinition> = {
     [FilterType.after]: {
@@ -98,6 +114,10 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: 'Include results from indexed repositories',
         singular: true,
     },
+    [FilterType.kind]: {
+        description: 'Include only symbols of the given kind (for type:symbol searches)',
+        suggestions: SYMBOL_KINDS,
+    },
     [FilterType.lang]: {
         negatable: true,
         description: negated => `${negated ? 'Exclude' : 'Include only'} results from the given language`,
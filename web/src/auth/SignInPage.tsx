@@ -35,6 +35,8 @@ export const SignInPage: React.FunctionComponent<SignInPageProps> = props => {
                             {window.context.authProviders.map((provider, index) =>
                                 provider.isBuiltin ? (
                                     <UsernamePasswordSignInForm key={index} {...props} />
+                                ) : provider.serviceType === 'ldap' ? (
+                                    <UsernamePasswordSignInForm key={index} {...props} provider={provider} />
                                 ) : (
                                     <div className="mb-2">
                                         <a key={index} href={provider.authenticationURL} className="btn btn-secondary">
@@ -7,10 +7,17 @@ import { eventLogger } from '../tracking/eventLogger'
 import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
 import { ErrorAlert } from '../components/alerts'
 import { asError } from '../../../shared/src/util/errors'
+import { AuthProvider } from '../jscontext'
 
 interface Props {
     location: H.Location
     history: H.History
+
+    /**
+     * The non-builtin auth provider (such as LDAP) that verifies the username and password. If not set,
+     * the builtin auth provider is used.
+     */
+    provider?: AuthProvider
 }
 
 interface State {
@@ -36,7 +43,9 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
     public render(): JSX.Element | null {
         return (
             <Form className="signin-signup-form signin-form test-signin-form" onSubmit={this.handleSubmit}>
-                {window.context.allowSignup ? (
+                {this.props.provider ? (
+                    <p>Sign in with your {this.props.provider.displayName} account.</p>
+                ) : window.context.allowSignup ? (
                     <p>
                         <Link to={`/sign-up${this.props.location.search}`}>Don't have an account? Sign up.</Link>
                     </p>
@@ -50,7 +59,7 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                     <input
                         className="form-control signin-signup-form__input"
                         type="text"
-                        placeholder="Username or email"
+                        placeholder={this.props.provider ? 'Username' : 'Username or email'}
                         onChange={this.onEmailFieldChange}
                         required={true}
                         value={this.state.email}
@@ -74,7 +83,7 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                     <button className="btn btn-primary btn-block" type="submit" disabled={this.state.loading}>
                         Sign in
                     </button>
-                    {window.context.resetPasswordEnabled && (
+                    {!this.props.provider && window.context.resetPasswordEnabled && (
                         <small className="form-text text-muted">
                             <Link to="/password-reset">Forgot password?</Link>
                         </small>
@@ -105,7 +114,8 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
 
         this.setState({ loading: true })
         eventLogger.log('InitiateSignIn')
-        fetch('/-/sign-in', {
+        const { provider } = this.props
+        fetch(provider?.authenticationURL ?? '/-/sign-in', {
             credentials: 'same-origin',
             method: 'POST',
             headers: {
@@ -113,10 +123,11 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                 Accept: 'application/json',
                 'Content-Type': 'application/json',
             },
-            body: JSON.stringify({
-                email: this.state.email,
-                password: this.state.password,
-            }),
+            body: JSON.stringify(
+                provider
+                    ? { username: this.state.email, password: this.state.password }
+                    : { email: this.state.email, password: this.state.password }
+            ),
         })
             .then(response => {
                 if (response.status === 200) {
@@ -16,6 +16,7 @@ import CheckboxBlankCircleIcon from 'mdi-react/CheckboxBlankCircleIcon'
 
 export const changesetExternalStateColorClasses: Record<ChangesetExternalState, string> = {
     [ChangesetExternalState.OPEN]: 'success',
+    [ChangesetExternalState.DRAFT]: 'secondary',
     [ChangesetExternalState.CLOSED]: 'danger',
     [ChangesetExternalState.DELETED]: 'muted',
     [ChangesetExternalState.MERGED]: 'merged',
@@ -39,6 +40,7 @@ export const changesetReviewStateIcons: Record<ChangesetReviewState, MdiReactIco
 
 export const changesetStateLabels: Record<ChangesetReviewState | ChangesetExternalState, string> = {
     [ChangesetExternalState.OPEN]: 'open',
+    [ChangesetExternalState.DRAFT]: 'draft',
     [ChangesetExternalState.CLOSED]: 'closed',
     [ChangesetExternalState.MERGED]: 'merged',
     [ChangesetExternalState.DELETED]: 'deleted',
@@ -53,6 +55,7 @@ export const changesetExternalStateIcons: Record<ChangesetExternalState, MdiReac
     [ChangesetExternalState.CLOSED]: SourcePullIcon,
     [ChangesetExternalState.MERGED]: SourceMergeIcon,
     [ChangesetExternalState.OPEN]: SourcePullIcon,
+    [ChangesetExternalState.DRAFT]: SourcePullIcon,
     [ChangesetExternalState.DELETED]: DeleteIcon,
 }
 
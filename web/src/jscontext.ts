@@ -84,11 +84,7 @@ export interface SourcegraphContext extends Pick<Required<SiteConfiguration>, 'e
     allowSignup: boolean
 
     /** Authentication provider instances in site config. */
-    authProviders?: {
-        displayName: string
-        isBuiltin: boolean
-        authenticationURL?: string
-    }[]
+    authProviders?: AuthProvider[]
 
     /** Custom branding for the homepage and search icon. */
     branding?: {
@@ -116,3 +112,12 @@ export interface BrandAssets {
     /** The URL to the symbol used as the search icon */
     symbol?: string
 }
+
+/** An auth provider that users can sign in with. */
+export interface AuthProvider {
+    /** The type of the auth provider, e.g. "builtin" or "ldap". */
+    serviceType: string
+    displayName: string
+    isBuiltin: boolean
+    authenticationURL?: string
+}
@@ -42,6 +42,11 @@ export class SavedSearchCreateForm extends React.Component<Props, State> {
                                 fields.query,
                                 fields.notify,
                                 fields.notifySlack,
+                                {
+                                    notifyWebhook: fields.notifyWebhook,
+                                    webhookURL: fields.webhookURL,
+                                    webhookSecret: fields.webhookSecret || null,
+                                },
                                 this.props.namespace.__typename === 'User' ? this.props.namespace.id : null,
                                 this.props.namespace.__typename === 'Org' ? this.props.namespace.id : null
                             ).pipe(
@@ -14,6 +14,11 @@ export interface SavedQueryFields {
     notify: boolean
     notifySlack: boolean
     slackWebhookURL: string | null
+    notifyWebhook: boolean
+    webhookURL: string | null
+    /** The new webhook secret. It is never returned by the API, so it is empty unless changed. */
+    webhookSecret: string
+    hasWebhookSecret: boolean
 }
 
 interface Props extends RouteComponentProps<{}>, NamespaceProps {
@@ -36,8 +41,16 @@ export class SavedSearchForm extends React.Component<Props, State> {
     constructor(props: Props) {
         super(props)
 
-        const { description = '', query = '', notify = false, notifySlack = false, slackWebhookURL = '' } =
-            props.defaultValues || {}
+        const {
+            description = '',
+            query = '',
+            notify = false,
+            notifySlack = false,
+            slackWebhookURL = '',
+            notifyWebhook = false,
+            webhookURL = '',
+            hasWebhookSecret = false,
+        } = props.defaultValues || {}
 
         this.state = {
             values: {
@@ -46,6 +59,10 @@ export class SavedSearchForm extends React.Component<Props, State> {
                 notify,
                 notifySlack,
                 slackWebhookURL,
+                notifyWebhook,
+                webhookURL,
+                webhookSecret: '',
+                hasWebhookSecret,
             },
         }
     }
@@ -74,7 +91,17 @@ export class SavedSearchForm extends React.Component<Props, State> {
 
     public render(): JSX.Element | null {
         const {
-            values: { query, description, notify, notifySlack, slackWebhookURL },
+            values: {
+                query,
+                description,
+                notify,
+                notifySlack,
+                slackWebhookURL,
+                notifyWebhook,
+                webhookURL,
+                webhookSecret,
+                hasWebhookSecret,
+            },
         } = this.state
 
         return (
@@ -129,6 +156,51 @@ export class SavedSearchForm extends React.Component<Props, State> {
                             </label>
                         </div>
                     </div>
+                    <div className="saved-search-form__input">
+                        <label className="saved-search-form__label">Webhook notifications:</label>
+                        <div>
+                            <label>
+                                <input
+                                    type="checkbox"
+                                    name="Notify webhook"
+                                    className="saved-search-form__checkbox"
+                                    defaultChecked={notifyWebhook}
+                                    onChange={this.createInputChangeHandler('notifyWebhook')}
+                                />{' '}
+                                <span>Post new results as JSON to a webhook URL</span>
+                            </label>
+                        </div>
+                        {notifyWebhook && (
+                            <>
+                                <input
+                                    type="url"
+                                    name="Webhook URL"
+                                    className="form-control mb-2"
+                                    placeholder="https://example.com/webhook"
+                                    required={true}
+                                    value={webhookURL || ''}
+                                    onChange={this.createInputChangeHandler('webhookURL')}
+                                />
+                                <input
+                                    type="password"
+                                    name="Webhook secret"
+                                    className="form-control"
+                                    placeholder={
+                                        hasWebhookSecret
+                                            ? 'Secret (unchanged if left empty)'
+                                            : 'Secret used to sign payloads (optional)'
+                                    }
+                                    autoComplete="new-password"
+                                    value={webhookSecret}
+                                    onChange={this.createInputChangeHandler('webhookSecret')}
+                                />
+                                <small className="form-text text-muted">
+                                    If a secret is set, each payload is signed with HMAC-SHA256 and the signature is
+                                    sent in the <code>X-Sourcegraph-Signature</code> header.
+                                </small>
+                            </>
+                        )}
+                    </div>
                     {notifySlack && slackWebhookURL && (
                         <div className="saved-search-form__input">
                             <label className="saved-search-form__label">Slack notifications:</label>
@@ -147,13 +219,7 @@ export class SavedSearchForm extends React.Component<Props, State> {
                             </label>
                         </div>
                     )}
-                    {this.isUnsupportedNotifyQuery(this.state.values) && (
-                        <div className="alert alert-warning mb-3">
-                            <strong>Warning:</strong> non-commit searches do not currently support notifications.
-                            Consider adding <code>type:diff</code> or <code>type:commit</code> to your query.
-                        </div>
-                    )}
-                    {notify && !window.context.emailEnabled && !this.isUnsupportedNotifyQuery(this.state.values) && (
+                    {notify && !window.context.emailEnabled && (
                         <div className="alert alert-warning mb-3">
                             <strong>Warning:</strong> Sending emails is not currently configured on this Sourcegraph
                             server.{' '}
@@ -176,11 +242,4 @@ export class SavedSearchForm extends React.Component<Props, State> {
             </div>
         )
     }
-    /**
-     * Tells if the query is unsupported for sending notifications.
-     */
-    private isUnsupportedNotifyQuery(savedQuery: Omit<SavedQueryFields, 'id'>): boolean {
-        const notifying = savedQuery.notify || savedQuery.notifySlack
-        return notifying && !savedQuery.query.includes('type:diff') && !savedQuery.query.includes('type:commit')
-    }
 }
@@ -73,6 +73,12 @@ export class SavedSearchUpdateForm extends React.Component<Props, State> {
                                 input.query,
                                 input.notify,
                                 input.notifySlack,
+                                {
+                                    notifyWebhook: input.notifyWebhook,
+                                    webhookURL: input.webhookURL,
+                                    // An empty secret keeps the existing secret.
+                                    webhookSecret: input.webhookSecret || null,
+                                },
                                 this.props.namespace.__typename === 'User' ? this.props.namespace.id : null,
                                 this.props.namespace.__typename === 'Org' ? this.props.namespace.id : null
                             ).pipe(
@@ -122,6 +128,9 @@ export class SavedSearchUpdateForm extends React.Component<Props, State> {
                             notify: savedSearch.notify,
                             notifySlack: savedSearch.notifySlack,
                             slackWebhookURL: savedSearch.slackWebhookURL,
+                            notifyWebhook: savedSearch.notifyWebhook,
+                            webhookURL: savedSearch.webhookURL,
+                            hasWebhookSecret: savedSearch.hasWebhookSecret,
                         }}
                         loading={this.state.updatedOrError === LOADING}
                         onSubmit={(fields: Pick<SavedQueryFields, Exclude<keyof SavedQueryFields, 'id'>>): void =>
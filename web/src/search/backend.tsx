@@ -268,6 +268,9 @@ const savedSearchFragment = gql`
             id
         }
         slackWebhookURL
+        notifyWebhook
+        webhookURL
+        hasWebhookSecret
     }
 `
 
@@ -301,6 +304,9 @@ export function fetchSavedSearch(id: GQL.ID): Observable<GQL.ISavedSearch> {
                         notify
                         notifySlack
                         slackWebhookURL
+                        notifyWebhook
+                        webhookURL
+                        hasWebhookSecret
                         namespace {
                             id
                         }
@@ -315,11 +321,22 @@ export function fetchSavedSearch(id: GQL.ID): Observable<GQL.ISavedSearch> {
     )
 }
 
+/**
+ * The webhook settings of a saved search. A null webhookSecret keeps the existing secret when
+ * updating a saved search.
+ */
+export interface SavedSearchWebhookFields {
+    notifyWebhook: boolean
+    webhookURL: string | null
+    webhookSecret: string | null
+}
+
 export function createSavedSearch(
     description: string,
     query: string,
     notify: boolean,
     notifySlack: boolean,
+    webhook: SavedSearchWebhookFields,
     userId: GQL.ID | null,
     orgId: GQL.ID | null
 ): Observable<void> {
@@ -330,6 +347,9 @@ export function createSavedSearch(
                 $query: String!
                 $notifyOwner: Boolean!
                 $notifySlack: Boolean!
+                $notifyWebhook: Boolean
+                $webhookURL: String
+                $webhookSecret: String
                 $userID: ID
                 $orgID: ID
             ) {
@@ -338,6 +358,9 @@ export function createSavedSearch(
                     query: $query
                     notifyOwner: $notifyOwner
                     notifySlack: $notifySlack
+                    notifyWebhook: $notifyWebhook
+                    webhookURL: $webhookURL
+                    webhookSecret: $webhookSecret
                     userID: $userID
                     orgID: $orgID
                 ) {
@@ -351,6 +374,7 @@ export function createSavedSearch(
             query,
             notifyOwner: notify,
             notifySlack,
+            ...webhook,
             userID: userId,
             orgID: orgId,
         }
@@ -366,6 +390,7 @@ export function updateSavedSearch(
     query: string,
     notify: boolean,
     notifySlack: boolean,
+    webhook: SavedSearchWebhookFields,
     userId: GQL.ID | null,
     orgId: GQL.ID | null
 ): Observable<void> {
@@ -377,6 +402,9 @@ export function updateSavedSearch(
                 $query: String!
                 $notifyOwner: Boolean!
                 $notifySlack: Boolean!
+                $notifyWebhook: Boolean
+                $webhookURL: String
+                $webhookSecret: String
                 $userID: ID
                 $orgID: ID
             ) {
@@ -386,6 +414,9 @@ export function updateSavedSearch(
                     query: $query
                     notifyOwner: $notifyOwner
                     notifySlack: $notifySlack
+                    notifyWebhook: $notifyWebhook
+                    webhookURL: $webhookURL
+                    webhookSecret: $webhookSecret
                     userID: $userID
                     orgID: $orgID
                 ) {
@@ -400,6 +431,7 @@ export function updateSavedSearch(
             query,
             notifyOwner: notify,
             notifySlack,
+            ...webhook,
             userID: userId,
             orgID: orgId,
         }
@@ -90,6 +90,7 @@ export const FilterTypeToProseNames: Record<FilterType, string> = {
     message: 'Commit message contains',
     author: 'Commit author',
     type: 'Type',
+    kind: 'Symbol kind',
     content: 'Content',
     patterntype: 'Pattern type',
     index: 'Indexed repos',